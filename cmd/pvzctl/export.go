@@ -0,0 +1,83 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/senyabanana/pvz-service/internal/entity"
+	"github.com/senyabanana/pvz-service/internal/infrastructure/export"
+	"github.com/senyabanana/pvz-service/internal/service"
+)
+
+func runExport(ctx context.Context, a *app, args []string) error {
+	flags := flag.NewFlagSet("export", flag.ContinueOnError)
+	dataset := flags.String("dataset", string(entity.ExportPVZ), "dataset: pvz, receptions or products")
+	format := flags.String("format", string(entity.ExportFormatCSV), "file format: csv or xlsx")
+	output := flags.String("o", "", "output file; stdout when omitted")
+	start := flags.String("start", "", "start date, RFC3339")
+	end := flags.String("end", "", "end date, RFC3339")
+	rawPVZID := flags.String("pvz", "", "export a single PVZ")
+	includeClosed := flags.Bool("include-closed", false, "include closed PVZs")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if *output == "" && entity.ExportFormat(*format) == entity.ExportFormatXLSX {
+		return errors.New("xlsx export requires -o")
+	}
+
+	filter := entity.ExportFilter{IncludeClosed: *includeClosed}
+	for _, date := range []struct {
+		raw    string
+		target **time.Time
+	}{{*start, &filter.StartDate}, {*end, &filter.EndDate}} {
+		if date.raw == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, date.raw)
+		if err != nil {
+			return fmt.Errorf("invalid date %q: %w", date.raw, err)
+		}
+		*date.target = &t
+	}
+	if *rawPVZID != "" {
+		pvzID, err := uuid.Parse(*rawPVZID)
+		if err != nil {
+			return fmt.Errorf("invalid -pvz: %w", err)
+		}
+		filter.PVZID = &pvzID
+	}
+
+	if err := a.connect(ctx); err != nil {
+		return err
+	}
+
+	var out io.Writer = os.Stdout
+	if *output != "" {
+		file, err := os.Create(*output)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		out = file
+	}
+
+	w, _, err := export.NewWriter(entity.ExportFormat(*format), out, *dataset)
+	if err != nil {
+		return err
+	}
+
+	exporter := service.NewExportService(a.repos, a.log)
+	if err := exporter.Export(ctx, entity.ExportDataset(*dataset), filter, w); err != nil {
+		return err
+	}
+
+	return w.Close()
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/senyabanana/pvz-service/internal/entity"
+	"github.com/senyabanana/pvz-service/internal/service"
+)
+
+func runImport(ctx context.Context, a *app, args []string) error {
+	flags := flag.NewFlagSet("import", flag.ContinueOnError)
+	format := flags.String("format", "", "file format: csv or json (by default taken from the file extension)")
+	dryRun := flags.Bool("dry-run", false, "only validate the file")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: pvzctl import [-format csv|json] [-dry-run] FILE")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return errors.New("exactly one file is required")
+	}
+
+	path := flags.Arg(0)
+	if *format == "" {
+		*format = strings.TrimPrefix(strings.ToLower(filepath.Ext(path)), ".")
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if err := a.connect(ctx); err != nil {
+		return err
+	}
+
+	importer := service.NewImportService(a.repos, a.repos, a.repos, a.trManager, a.log)
+	report, err := importer.ImportData(ctx, entity.ImportFormat(*format), file, *dryRun)
+	if report != nil {
+		printImportReport(report)
+	}
+	if err == nil && len(report.Errors) > 0 {
+		return entity.ErrImportRejected
+	}
+
+	return err
+}
+
+func printImportReport(report *entity.ImportReport) {
+	for _, rowErr := range report.Errors {
+		if rowErr.Field != "" {
+			fmt.Printf("row %d: %s: %s\n", rowErr.Row, rowErr.Field, rowErr.Message)
+		} else {
+			fmt.Printf("row %d: %s\n", rowErr.Row, rowErr.Message)
+		}
+	}
+
+	verb := "imported"
+	if report.DryRun {
+		verb = "checked"
+	}
+	if len(report.Errors) > 0 {
+		verb = "rejected"
+	}
+	fmt.Printf("%s: %d pvz, %d receptions, %d products, %d errors\n",
+		verb, report.PVZ, report.Receptions, report.Products, len(report.Errors))
+}
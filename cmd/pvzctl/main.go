@@ -0,0 +1,120 @@
+// Command pvzctl is an administrative tool that works with the service database directly.
+// It reads the same .env as the service.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	trmsqlx "github.com/avito-tech/go-transaction-manager/drivers/sqlx/v2"
+	"github.com/avito-tech/go-transaction-manager/trm/v2/manager"
+	"github.com/jmoiron/sqlx"
+	"github.com/sirupsen/logrus"
+
+	"github.com/senyabanana/pvz-service/internal/infrastructure/config"
+	"github.com/senyabanana/pvz-service/internal/infrastructure/database"
+	"github.com/senyabanana/pvz-service/internal/infrastructure/logger"
+	"github.com/senyabanana/pvz-service/internal/repository"
+)
+
+const usage = `Usage: pvzctl <command> [flags]
+
+Commands:
+  migrate     apply or roll back database migrations, show the schema version
+  user        create a user with the given role, e.g. the first moderator
+  pvz         list PVZs
+  reception   open or close the reception of a PVZ
+  export      export PVZs, receptions or products to CSV or XLSX
+  import      validate and import PVZs with historical receptions from a CSV or JSON file
+
+Run "pvzctl <command> -h" for the flags of a command.
+`
+
+// app holds what every command needs; commands call connect after parsing their flags
+// and build the services they use from it.
+type app struct {
+	cfg       *config.Config
+	db        *sqlx.DB
+	repos     *repository.Repository
+	trManager *manager.Manager
+	log       *logrus.Logger
+}
+
+type command func(ctx context.Context, a *app, args []string) error
+
+var commands = map[string]command{
+	"migrate":   runMigrate,
+	"user":      runUser,
+	"pvz":       runPVZ,
+	"reception": runReception,
+	"export":    runExport,
+	"import":    runImport,
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	run, ok := commands[os.Args[1]]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n%s", os.Args[1], usage)
+		os.Exit(2)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM, syscall.SIGINT)
+	defer stop()
+
+	a, err := newApp()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "pvzctl: %v\n", err)
+		os.Exit(1)
+	}
+	defer a.close()
+
+	err = run(ctx, a, os.Args[2:])
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "pvzctl %s: %v\n", os.Args[1], err)
+		os.Exit(1)
+	}
+}
+
+func newApp() (*app, error) {
+	log := logger.NewLogger()
+	log.SetOutput(os.Stderr)
+	log.SetLevel(logrus.WarnLevel)
+
+	cfg, err := config.LoadConfig(".")
+	if err != nil {
+		return nil, fmt.Errorf("error initializing configs: %w", err)
+	}
+
+	return &app{cfg: cfg, log: log}, nil
+}
+
+func (a *app) connect(ctx context.Context) error {
+	db, err := database.NewPostgresDB(ctx, a.cfg)
+	if err != nil {
+		return fmt.Errorf("failed to initialize db: %w", err)
+	}
+
+	a.db = db
+	a.repos = repository.NewRepository(db)
+	a.trManager = manager.Must(trmsqlx.NewDefaultFactory(db))
+	return nil
+}
+
+func (a *app) close() {
+	if a.db != nil {
+		a.db.Close()
+	}
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"strconv"
+
+	"github.com/golang-migrate/migrate/v4"
+
+	"github.com/senyabanana/pvz-service/internal/infrastructure/database"
+	"github.com/senyabanana/pvz-service/migrations"
+)
+
+func runMigrate(ctx context.Context, a *app, args []string) error {
+	flags := flag.NewFlagSet("migrate", flag.ContinueOnError)
+	path := flags.String("path", "", "directory with migration files; the migrations built into pvzctl by default")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: pvzctl migrate [-path DIR] up | down N | force VERSION | version")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if err := a.connect(ctx); err != nil {
+		return err
+	}
+
+	var source fs.FS = migrations.FS
+	if *path != "" {
+		source = os.DirFS(*path)
+	}
+
+	m, err := database.NewMigrator(ctx, a.db, source)
+	if err != nil {
+		return err
+	}
+	defer m.Close()
+
+	switch flags.Arg(0) {
+	case "up":
+		err = m.Up()
+	case "down":
+		// Rolling back everything by accident is too easy, so the number of steps is required.
+		steps, convErr := strconv.Atoi(flags.Arg(1))
+		if convErr != nil || steps < 1 {
+			return errors.New("down requires a positive number of steps")
+		}
+		err = m.Steps(-steps)
+	case "force":
+		// Records the version without running anything: used to clear a dirty state after a manual fix
+		// or to adopt a database created by the Postgres init scripts.
+		version, convErr := strconv.Atoi(flags.Arg(1))
+		if convErr != nil || version < 1 {
+			return errors.New("force requires a migration version")
+		}
+		err = m.Force(version)
+	case "version":
+	default:
+		flags.Usage()
+		return errors.New("unknown migrate action")
+	}
+	if err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return err
+	}
+
+	return printSchemaVersion(m)
+}
+
+func printSchemaVersion(m *migrate.Migrate) error {
+	version, dirty, err := m.Version()
+	if errors.Is(err, migrate.ErrNilVersion) {
+		fmt.Println("schema version: none")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if dirty {
+		fmt.Printf("schema version: %d (dirty)\n", version)
+	} else {
+		fmt.Printf("schema version: %d\n", version)
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/senyabanana/pvz-service/internal/service"
+)
+
+func runPVZ(ctx context.Context, a *app, args []string) error {
+	if len(args) == 0 || args[0] != "list" {
+		return errors.New("usage: pvzctl pvz list [-include-closed]")
+	}
+
+	flags := flag.NewFlagSet("pvz list", flag.ContinueOnError)
+	includeClosed := flags.Bool("include-closed", false, "include closed PVZs")
+	if err := flags.Parse(args[1:]); err != nil {
+		return err
+	}
+
+	if err := a.connect(ctx); err != nil {
+		return err
+	}
+
+	pvzService := service.NewPVZService(a.repos, a.repos, a.repos, a.trManager, a.log)
+	allPVZ, err := pvzService.GetAllPVZ(ctx, *includeClosed)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tCITY\tSTATUS\tREGISTERED\tADDRESS")
+	for _, pvz := range allPVZ {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
+			pvz.ID, pvz.City, pvz.Status, pvz.RegistrationDate.Format(time.RFC3339), pvz.Address)
+	}
+
+	return w.Flush()
+}
+
+func runReception(ctx context.Context, a *app, args []string) error {
+	const usage = "usage: pvzctl reception open|close -pvz ID"
+	if len(args) == 0 {
+		return errors.New(usage)
+	}
+
+	flags := flag.NewFlagSet("reception "+args[0], flag.ContinueOnError)
+	rawPVZID := flags.String("pvz", "", "PVZ ID")
+	if err := flags.Parse(args[1:]); err != nil {
+		return err
+	}
+
+	pvzID, err := uuid.Parse(*rawPVZID)
+	if err != nil {
+		return fmt.Errorf("invalid -pvz: %w", err)
+	}
+
+	if err := a.connect(ctx); err != nil {
+		return err
+	}
+
+	receptions := service.NewReceptionService(a.repos, a.repos, a.trManager, a.log)
+	switch args[0] {
+	case "open":
+		reception, err := receptions.CreateReception(ctx, pvzID)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("reception opened: id=%s, pvz=%s\n", reception.ID, pvzID)
+	case "close":
+		reception, err := receptions.CloseLastReception(ctx, pvzID)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("reception closed: id=%s, pvz=%s\n", reception.ID, pvzID)
+	default:
+		return errors.New(usage)
+	}
+
+	return nil
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/senyabanana/pvz-service/internal/entity"
+	"github.com/senyabanana/pvz-service/internal/infrastructure/security"
+	"github.com/senyabanana/pvz-service/internal/service"
+)
+
+func runUser(ctx context.Context, a *app, args []string) error {
+	if len(args) == 0 || args[0] != "create" {
+		return errors.New(`usage: pvzctl user create -email EMAIL -role client|employee|moderator [-password PASSWORD]`)
+	}
+
+	flags := flag.NewFlagSet("user create", flag.ContinueOnError)
+	email := flags.String("email", "", "user email")
+	role := flags.String("role", string(entity.RoleEmployee), "user role: client, employee or moderator")
+	password := flags.String("password", "", "user password; read from stdin when omitted")
+	if err := flags.Parse(args[1:]); err != nil {
+		return err
+	}
+	if *email == "" {
+		return errors.New("-email is required")
+	}
+
+	if *password == "" {
+		fmt.Fprint(os.Stderr, "password: ")
+		line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil && line == "" {
+			return fmt.Errorf("failed to read password: %w", err)
+		}
+		*password = strings.TrimRight(line, "\r\n")
+	}
+
+	if err := a.connect(ctx); err != nil {
+		return err
+	}
+
+	hasher, err := security.NewPasswordHasher(
+		a.cfg.PasswordHashAlgorithm, a.cfg.BcryptCost, a.cfg.Argon2Memory, a.cfg.Argon2Iterations, a.cfg.Argon2Parallelism,
+	)
+	if err != nil {
+		return fmt.Errorf("invalid password hashing config: %w", err)
+	}
+	policy := security.PasswordPolicy{
+		MinLength:      a.cfg.PasswordMinLength,
+		RequireUpper:   a.cfg.PasswordRequireUpper,
+		RequireLower:   a.cfg.PasswordRequireLower,
+		RequireDigit:   a.cfg.PasswordRequireDigit,
+		RequireSpecial: a.cfg.PasswordRequireSpecial,
+		RejectCommon:   a.cfg.PasswordRejectCommon,
+	}
+
+	users := service.NewUserService(a.repos, hasher, policy, a.trManager, a.cfg.JWTSecretKey, a.log)
+	user := &entity.User{Email: *email, Password: *password, Role: entity.UserRole(*role)}
+	if err := users.RegisterUser(ctx, user); err != nil {
+		return err
+	}
+
+	fmt.Printf("user created: id=%s, email=%s, role=%s\n", user.ID, user.Email, user.Role)
+	return nil
+}
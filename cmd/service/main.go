@@ -4,24 +4,37 @@ import (
 	"context"
 	"errors"
 	"net/http"
+	"os"
 	"os/signal"
 	"syscall"
 	"time"
 
 	trmsqlx "github.com/avito-tech/go-transaction-manager/drivers/sqlx/v2"
 	"github.com/avito-tech/go-transaction-manager/trm/v2/manager"
+	"github.com/sirupsen/logrus"
 
 	_ "github.com/senyabanana/pvz-service/docs"
+	"github.com/senyabanana/pvz-service/internal/entity"
 	"github.com/senyabanana/pvz-service/internal/handler"
 	"github.com/senyabanana/pvz-service/internal/infrastructure/config"
 	"github.com/senyabanana/pvz-service/internal/infrastructure/database"
+	"github.com/senyabanana/pvz-service/internal/infrastructure/events"
 	"github.com/senyabanana/pvz-service/internal/infrastructure/logger"
+	"github.com/senyabanana/pvz-service/internal/infrastructure/notifier"
+	"github.com/senyabanana/pvz-service/internal/infrastructure/oidc"
+	"github.com/senyabanana/pvz-service/internal/infrastructure/security"
+	"github.com/senyabanana/pvz-service/internal/infrastructure/tracing"
 	"github.com/senyabanana/pvz-service/internal/repository"
+	"github.com/senyabanana/pvz-service/internal/scheduler"
 	"github.com/senyabanana/pvz-service/internal/service"
 	grpcServer "github.com/senyabanana/pvz-service/internal/transport/grpc"
 	httpServer "github.com/senyabanana/pvz-service/internal/transport/http"
+	"github.com/senyabanana/pvz-service/migrations"
 )
 
+// version is set at build time with -ldflags "-X main.version=...".
+var version = "dev"
+
 // @title PVZ Service API
 // @version 1.0
 // @description API для управления пунктами выдачи заказов (ПВЗ), приёмками и товарами.
@@ -32,6 +45,10 @@ import (
 // @securityDefinitions.apikey BearerAuth
 // @in header
 // @name Authorization
+
+// @securityDefinitions.apikey APIKeyAuth
+// @in header
+// @name X-API-Key
 func main() {
 	log := logger.NewLogger()
 
@@ -43,6 +60,31 @@ func main() {
 		log.Fatalf("error initializing configs: %s", err.Error())
 	}
 
+	logOutput, err := logger.Configure(log, logger.Options{
+		Level:  cfg.LogLevel,
+		Format: cfg.LogFormat,
+		Output: cfg.LogOutput,
+	})
+	if err != nil {
+		log.Fatalf("invalid logging config: %s", err.Error())
+	}
+	if logOutput != nil {
+		defer logOutput.Close()
+	}
+	go reloadLogLevelOnSIGHUP(ctx, log)
+
+	shutdownTracing, err := tracing.Setup(ctx, tracing.Config{
+		Exporter:     cfg.TracingExporter,
+		OTLPEndpoint: cfg.TracingOTLPEndpoint,
+		OTLPInsecure: cfg.TracingOTLPInsecure,
+		SampleRatio:  cfg.TracingSampleRatio,
+		Version:      version,
+	})
+	if err != nil {
+		log.Fatalf("failed to initialize tracing: %s", err.Error())
+	}
+	log.AddHook(tracing.NewLogrusHook())
+
 	db, err := database.NewPostgresDB(ctx, cfg)
 	if err != nil {
 		log.Fatalf("failed to initialize db: %s", err.Error())
@@ -50,11 +92,99 @@ func main() {
 
 	defer db.Close()
 
+	if cfg.AutoMigrate {
+		if err := database.ApplyMigrations(ctx, db, migrations.FS, log); err != nil {
+			log.Fatalf("failed to migrate db: %s", err.Error())
+		}
+	}
+
+	latestSchema, err := database.LatestMigration(migrations.FS)
+	if err != nil {
+		log.Fatalf("failed to read embedded migrations: %s", err.Error())
+	}
+
 	trManager := manager.Must(trmsqlx.NewDefaultFactory(db))
 	repos := repository.NewRepository(db)
-	services := service.NewService(repos, trManager, cfg.JWTSecretKey, log)
+	roleMapping, err := oidc.ParseRoleMapping(cfg.OIDCRoleMapping)
+	if err != nil {
+		log.Fatalf("invalid oidc role mapping: %s", err.Error())
+	}
+
+	var identityProvider service.IdentityProvider
+	if cfg.OIDCIssuerURL != "" {
+		provider, err := oidc.NewProvider(ctx, cfg)
+		if err != nil {
+			log.Fatalf("failed to initialize oidc provider: %s", err.Error())
+		}
+		identityProvider = provider
+	}
+
+	passwordHasher, err := security.NewPasswordHasher(
+		cfg.PasswordHashAlgorithm, cfg.BcryptCost, cfg.Argon2Memory, cfg.Argon2Iterations, cfg.Argon2Parallelism,
+	)
+	if err != nil {
+		log.Fatalf("invalid password hashing config: %s", err.Error())
+	}
+
+	var resetNotifier service.Notifier
+	switch cfg.NotifierType {
+	case notifier.TypeLog:
+		resetNotifier = notifier.NewLogNotifier(log)
+	case notifier.TypeFile:
+		resetNotifier = notifier.NewFileNotifier(cfg.NotifierFilePath)
+	default:
+		log.Fatalf("unsupported notifier type: %s", cfg.NotifierType)
+	}
+
+	if !entity.IsValidCapacityPolicy(cfg.CapacityPolicy) {
+		log.Fatalf("unsupported capacity policy: %s", cfg.CapacityPolicy)
+	}
+
+	storagePolicy, err := entity.ParseStoragePolicy(cfg.StoragePeriod, cfg.StoragePeriodsByType)
+	if err != nil {
+		log.Fatalf("invalid storage period config: %s", err.Error())
+	}
+
+	var eventPublisher service.EventPublisher
+	switch cfg.EventsPublisher {
+	case events.TypeLog:
+		eventPublisher = events.NewLogPublisher(log)
+	case events.TypeFile:
+		eventPublisher = events.NewFilePublisher(cfg.EventsFilePath)
+	default:
+		log.Fatalf("unsupported events publisher: %s", cfg.EventsPublisher)
+	}
+
+	services := service.NewService(service.Dependencies{
+		Repos:            repos,
+		TrManager:        trManager,
+		JWTSecret:        cfg.JWTSecretKey,
+		IdentityProvider: identityProvider,
+		OIDCRoleMapping:  roleMapping,
+		PasswordHasher:   passwordHasher,
+		PasswordPolicy: security.PasswordPolicy{
+			MinLength:      cfg.PasswordMinLength,
+			RequireUpper:   cfg.PasswordRequireUpper,
+			RequireLower:   cfg.PasswordRequireLower,
+			RequireDigit:   cfg.PasswordRequireDigit,
+			RequireSpecial: cfg.PasswordRequireSpecial,
+			RejectCommon:   cfg.PasswordRejectCommon,
+		},
+		Notifier:         resetNotifier,
+		PasswordResetTTL: cfg.PasswordResetTTL,
+		CapacityPolicy:   entity.CapacityPolicy(cfg.CapacityPolicy),
+		StoragePolicy:    storagePolicy,
+		Events:           eventPublisher,
+		ReportJobTimeout: cfg.ReportJobTimeout,
+		BuildVersion:     version,
+		LatestSchema:     int64(latestSchema),
+		ReadyTimeout:     cfg.ReadinessTimeout,
+		Log:              log,
+	})
+	logVersion(ctx, services.VersionOperations, log)
+
 	handlers := handler.NewHandler(services, cfg.JWTSecretKey, log)
-	routes := httpServer.SetupRouter(handlers, cfg.JWTSecretKey, log)
+	routes := httpServer.SetupRouter(handlers, cfg.JWTSecretKey, services.APIKeyOperations, log)
 	httpSrv := httpServer.NewServer(routes, cfg.ServerPort, log)
 	grpcSrv, err := grpcServer.NewGRPCServer(cfg.GRPCPort, services, log)
 	if err != nil {
@@ -73,9 +203,14 @@ func main() {
 		}
 	}()
 
+	go scheduler.NewOverdueScheduler(services.StorageOperations, cfg.StorageCheckInterval, log).Run(ctx)
+	go scheduler.NewReadinessWatcher(services.HealthOperations, grpcSrv, cfg.ReadinessCheckInterval, log).Run(ctx)
+	go scheduler.NewReportWorkerPool(services.ReportJobOperations, cfg.ReportWorkers, cfg.ReportPollInterval, log).Run(ctx)
+
 	<-ctx.Done()
 
-	shutdownCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	// ctx is already cancelled here, so shutdown deadlines start from a fresh context.
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
 	if err := httpSrv.Shutdown(shutdownCtx); err != nil {
@@ -84,5 +219,63 @@ func main() {
 
 	grpcSrv.Shutdown(shutdownCtx)
 
+	// Spans of the requests drained above are flushed with their own deadline.
+	flushCtx, cancelFlush := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancelFlush()
+
+	if err := shutdownTracing(flushCtx); err != nil {
+		log.Errorf("failed to flush traces: %s", err.Error())
+	}
+
 	log.Info("Service stopped gracefully")
 }
+
+func logVersion(ctx context.Context, versions service.VersionOperations, log *logrus.Logger) {
+	info, err := versions.GetVersion(ctx)
+	if err != nil {
+		log.Fatalf("failed to get schema version: %s", err.Error())
+	}
+
+	if info.Schema == nil {
+		log.Warnf("starting pvz-service %s: schema version unknown, expected %d", info.Version, info.LatestSchemaVersion)
+		return
+	}
+
+	log.Infof("starting pvz-service %s: schema version %d, latest %d",
+		info.Version, info.Schema.Version, info.LatestSchemaVersion)
+	if info.Schema.Dirty {
+		log.Warnf("schema version %d is dirty, a migration failed halfway", info.Schema.Version)
+	} else if info.Schema.Version < info.LatestSchemaVersion {
+		log.Warnf("database schema is behind, run pvzctl migrate up or set AUTO_MIGRATE=true")
+	}
+}
+
+// reloadLogLevelOnSIGHUP re-reads LOG_LEVEL from the config on SIGHUP, undoing changes made through PUT /log-level.
+func reloadLogLevelOnSIGHUP(ctx context.Context, log *logrus.Logger) {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-hup:
+		}
+
+		cfg, err := config.LoadConfig(".")
+		if err != nil {
+			log.Errorf("failed to reload config: %s", err.Error())
+			continue
+		}
+
+		level, err := logger.ParseLevel(cfg.LogLevel)
+		if err != nil {
+			log.Errorf("failed to reload log level: %s", err.Error())
+			continue
+		}
+
+		log.SetLevel(level)
+		log.Warnf("log level reloaded: %s", level)
+	}
+}
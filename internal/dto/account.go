@@ -0,0 +1,32 @@
+package dto
+
+type MeResponse struct {
+	ID             string   `json:"id"`
+	Email          string   `json:"email,omitempty"`
+	Role           string   `json:"role"`
+	AuthProvider   string   `json:"authProvider,omitempty"`
+	CreatedAt      string   `json:"createdAt,omitempty"`
+	PVZIDs         []string `json:"pvzIds"`
+	Dummy          bool     `json:"dummy"`
+	TokenExpiresAt string   `json:"tokenExpiresAt"`
+}
+
+type AssignPVZRequest struct {
+	PVZIDs []string `json:"pvzIds" binding:"required,dive,uuid"`
+}
+
+type IntrospectionRequest struct {
+	Token         string `form:"token" binding:"required"`
+	TokenTypeHint string `form:"token_type_hint"`
+}
+
+// IntrospectionResponse follows RFC 7662; only "active" is set for inactive tokens.
+type IntrospectionResponse struct {
+	Active    bool   `json:"active"`
+	Sub       string `json:"sub,omitempty"`
+	Role      string `json:"role,omitempty"`
+	TokenType string `json:"token_type,omitempty"`
+	Exp       int64  `json:"exp,omitempty"`
+	Iat       int64  `json:"iat,omitempty"`
+	Dummy     bool   `json:"dummy,omitempty"`
+}
@@ -0,0 +1,30 @@
+package dto
+
+type AnalyticsQueryParams struct {
+	StartDate string `form:"startDate" binding:"required,datetime=2006-01-02T15:04:05Z07:00"`
+	EndDate   string `form:"endDate" binding:"required,datetime=2006-01-02T15:04:05Z07:00"`
+	GroupBy   string `form:"groupBy" binding:"omitempty,oneof=pvz city"`
+	Period    string `form:"period" binding:"omitempty,oneof=day week month"`
+	City      string `form:"city"`
+	PVZID     string `form:"pvzId" binding:"omitempty,uuid"`
+}
+
+type ReceptionThroughputResponse struct {
+	PVZID                   string   `json:"pvzId,omitempty"`
+	City                    string   `json:"city"`
+	PeriodStart             string   `json:"periodStart"`
+	ReceptionsOpened        int      `json:"receptionsOpened"`
+	ReceptionsClosed        int      `json:"receptionsClosed"`
+	ItemsReceived           int      `json:"itemsReceived"`
+	AvgItemsPerReception    float64  `json:"avgItemsPerReception"`
+	AvgReceptionDurationSec *float64 `json:"avgReceptionDurationSec,omitempty"`
+}
+
+type ProductMixResponse struct {
+	PVZID       string  `json:"pvzId,omitempty"`
+	City        string  `json:"city"`
+	PeriodStart string  `json:"periodStart"`
+	Type        string  `json:"type"`
+	Items       int     `json:"items"`
+	Share       float64 `json:"share"`
+}
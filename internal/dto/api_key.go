@@ -0,0 +1,25 @@
+package dto
+
+type APIKeyRequest struct {
+	Name      string   `json:"name" binding:"required"`
+	Scopes    []string `json:"scopes" binding:"required,min=1"`
+	PVZID     string   `json:"pvzId" binding:"omitempty,uuid"`
+	ExpiresAt string   `json:"expiresAt" binding:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+}
+
+type APIKeyResponse struct {
+	ID         string   `json:"id"`
+	Name       string   `json:"name"`
+	Prefix     string   `json:"prefix"`
+	Scopes     []string `json:"scopes"`
+	PVZID      string   `json:"pvzId,omitempty"`
+	ExpiresAt  string   `json:"expiresAt,omitempty"`
+	LastUsedAt string   `json:"lastUsedAt,omitempty"`
+	RevokedAt  string   `json:"revokedAt,omitempty"`
+	CreatedAt  string   `json:"createdAt"`
+}
+
+type CreatedAPIKeyResponse struct {
+	APIKey APIKeyResponse `json:"apiKey"`
+	Key    string         `json:"key"`
+}
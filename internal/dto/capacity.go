@@ -0,0 +1,19 @@
+package dto
+
+type CapacityRequest struct {
+	Total  *int           `json:"total" binding:"omitempty,min=0"`
+	ByType map[string]int `json:"byType" binding:"omitempty,dive,min=0"`
+}
+
+type CapacityUsage struct {
+	Type      string `json:"type,omitempty"`
+	Capacity  *int   `json:"capacity"`
+	Occupied  int    `json:"occupied"`
+	Available *int   `json:"available"`
+}
+
+type CapacityResponse struct {
+	PVZID  string          `json:"pvzId"`
+	Total  CapacityUsage   `json:"total"`
+	ByType []CapacityUsage `json:"byType"`
+}
@@ -0,0 +1,15 @@
+package dto
+
+type HealthResponse struct {
+	Status string `json:"status"`
+}
+
+type HealthCheckResponse struct {
+	Status  string `json:"status"`
+	Message string `json:"message,omitempty"`
+}
+
+type ReadinessResponse struct {
+	Status string                         `json:"status"`
+	Checks map[string]HealthCheckResponse `json:"checks"`
+}
@@ -0,0 +1,20 @@
+package dto
+
+type ImportQueryParams struct {
+	Format string `form:"format" binding:"omitempty,oneof=csv json"`
+	DryRun bool   `form:"dryRun"`
+}
+
+type ImportRowErrorResponse struct {
+	Row     int    `json:"row"`
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+}
+
+type ImportReportResponse struct {
+	DryRun     bool                     `json:"dryRun"`
+	PVZ        int                      `json:"pvz"`
+	Receptions int                      `json:"receptions"`
+	Products   int                      `json:"products"`
+	Errors     []ImportRowErrorResponse `json:"errors,omitempty"`
+}
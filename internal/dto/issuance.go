@@ -0,0 +1,38 @@
+package dto
+
+type AssignOwnerRequest struct {
+	OwnerID string `json:"ownerId" binding:"required,uuid"`
+}
+
+type AssignOwnerResponse struct {
+	ProductID  string `json:"productId"`
+	OwnerID    string `json:"ownerId"`
+	PickupCode string `json:"pickupCode"`
+}
+
+type IssueProductRequest struct {
+	PickupCode string `json:"pickupCode" binding:"required,len=6,numeric"`
+}
+
+type IssuedProductResponse struct {
+	ID          string `json:"id"`
+	Type        string `json:"type"`
+	ReceptionID string `json:"receptionId"`
+	Status      string `json:"status"`
+	OwnerID     string `json:"ownerId"`
+	IssuedAt    string `json:"issuedAt"`
+	IssuedBy    string `json:"issuedBy,omitempty"`
+}
+
+type PickupCodeResponse struct {
+	ProductID  string `json:"productId"`
+	PickupCode string `json:"pickupCode"`
+}
+
+type ParcelResponse struct {
+	ProductID        string      `json:"productId"`
+	Type             string      `json:"type"`
+	ReceivedAt       string      `json:"receivedAt"`
+	PickupCodeLocked bool        `json:"pickupCodeLocked"`
+	PVZ              PVZResponse `json:"pvz"`
+}
@@ -0,0 +1,9 @@
+package dto
+
+type LogLevelRequest struct {
+	Level string `json:"level" binding:"required"`
+}
+
+type LogLevelResponse struct {
+	Level string `json:"level"`
+}
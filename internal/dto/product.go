@@ -5,6 +5,15 @@ type ProductRequest struct {
 	PVZID string `json:"pvzId" binding:"required,uuid"`
 }
 
+type ProductStatusChangeResponse struct {
+	FromStatus string `json:"fromStatus,omitempty"`
+	ToStatus   string `json:"toStatus"`
+	PVZID      string `json:"pvzId,omitempty"`
+	ChangedAt  string `json:"changedAt"`
+	ChangedBy  string `json:"changedBy,omitempty"`
+	Comment    string `json:"comment,omitempty"`
+}
+
 type ProductResponse struct {
 	ID          string `json:"id"`
 	DateTime    string `json:"dateTime"`
@@ -5,14 +5,55 @@ type PVZRequest struct {
 }
 
 type PVZResponse struct {
-	ID               string `json:"id"`
-	RegistrationDate string `json:"registrationDate"`
-	City             string `json:"city"`
+	ID               string        `json:"id"`
+	RegistrationDate string        `json:"registrationDate"`
+	City             string        `json:"city"`
+	Address          string        `json:"address,omitempty"`
+	Latitude         *float64      `json:"latitude,omitempty"`
+	Longitude        *float64      `json:"longitude,omitempty"`
+	Phone            string        `json:"phone,omitempty"`
+	WorkingHours     *WorkingHours `json:"workingHours,omitempty"`
+	Status           string        `json:"status,omitempty"`
+	StatusReason     string        `json:"statusReason,omitempty"`
+	StatusChangedAt  string        `json:"statusChangedAt,omitempty"`
+}
+
+type PVZStatusRequest struct {
+	Status string `json:"status" binding:"required,oneof=active suspended closed"`
+	Reason string `json:"reason" binding:"max=500"`
+}
+
+type PVZUpdateRequest struct {
+	Address      *string       `json:"address" binding:"omitempty,max=500"`
+	Latitude     *float64      `json:"latitude" binding:"omitempty,min=-90,max=90"`
+	Longitude    *float64      `json:"longitude" binding:"omitempty,min=-180,max=180"`
+	Phone        *string       `json:"phone" binding:"omitempty,max=20"`
+	WorkingHours *WorkingHours `json:"workingHours" binding:"omitempty"`
+}
+
+type WorkingHours struct {
+	Weekly     []DailyHours     `json:"weekly" binding:"max=7,dive"`
+	Exceptions []HoursException `json:"exceptions,omitempty" binding:"omitempty,dive"`
+}
+
+type DailyHours struct {
+	Weekday string `json:"weekday" binding:"required" example:"monday"`
+	Open    string `json:"open" binding:"required" example:"09:00"`
+	Close   string `json:"close" binding:"required" example:"21:00"`
+}
+
+type HoursException struct {
+	Date   string `json:"date" binding:"required" example:"2025-01-01"`
+	Closed bool   `json:"closed"`
+	Open   string `json:"open,omitempty" example:"10:00"`
+	Close  string `json:"close,omitempty" example:"18:00"`
+	Note   string `json:"note,omitempty"`
 }
 
 type FullPVZResponse struct {
 	PVZ        PVZResponse             `json:"pvz"`
 	Receptions []ReceptionWithProducts `json:"receptions"`
+	Returns    []ReturnResponse        `json:"returns"`
 }
 
 type ReceptionWithProducts struct {
@@ -25,4 +66,25 @@ type FullPVZQueryParams struct {
 	EndDate   string `form:"endDate" binding:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
 	Page      int    `form:"page" binding:"omitempty,min=1"`
 	Limit     int    `form:"limit" binding:"omitempty,min=1,max=30"`
+
+	IncludeClosed bool `form:"includeClosed"`
+}
+
+type NearbyPVZQueryParams struct {
+	Lat    *float64 `form:"lat" binding:"required,min=-90,max=90"`
+	Lon    *float64 `form:"lon" binding:"required,min=-180,max=180"`
+	Radius float64  `form:"radius" binding:"omitempty,gt=0,max=50000"`
+	Limit  int      `form:"limit" binding:"omitempty,min=1,max=50"`
+}
+
+type NearbyPVZResponse struct {
+	PVZ            PVZResponse `json:"pvz"`
+	DistanceMeters float64     `json:"distanceMeters"`
+}
+
+type ExportQueryParams struct {
+	Format        string `form:"format" binding:"omitempty,oneof=csv xlsx"`
+	StartDate     string `form:"startDate" binding:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+	EndDate       string `form:"endDate" binding:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+	IncludeClosed bool   `form:"includeClosed"`
 }
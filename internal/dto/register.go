@@ -2,7 +2,7 @@ package dto
 
 type RegisterRequest struct {
 	Email    string `json:"email" binding:"required,email"`
-	Password string `json:"password" binding:"required,min=6"`
+	Password string `json:"password" binding:"required"`
 	Role     string `json:"role" binding:"required"`
 }
 
@@ -0,0 +1,28 @@
+package dto
+
+type CreateReportJobRequest struct {
+	Kind          string  `json:"kind" binding:"required,oneof=export_pvz export_receptions export_products analytics_receptions analytics_products"`
+	Format        string  `json:"format" binding:"omitempty,oneof=csv xlsx"`
+	StartDate     *string `json:"startDate" binding:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+	EndDate       *string `json:"endDate" binding:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+	PVZID         *string `json:"pvzId" binding:"omitempty,uuid"`
+	IncludeClosed bool    `json:"includeClosed"`
+	GroupBy       string  `json:"groupBy" binding:"omitempty,oneof=pvz city"`
+	Period        string  `json:"period" binding:"omitempty,oneof=day week month"`
+	City          string  `json:"city"`
+}
+
+type ReportJobResponse struct {
+	ID           string `json:"id"`
+	Kind         string `json:"kind"`
+	Format       string `json:"format"`
+	Status       string `json:"status"`
+	Progress     int    `json:"progress"`
+	Attempts     int    `json:"attempts"`
+	Error        string `json:"error,omitempty"`
+	CreatedAt    string `json:"createdAt"`
+	StartedAt    string `json:"startedAt,omitempty"`
+	FinishedAt   string `json:"finishedAt,omitempty"`
+	ArtifactName string `json:"artifactName,omitempty"`
+	ArtifactSize int64  `json:"artifactSize,omitempty"`
+}
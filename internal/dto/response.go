@@ -41,3 +41,7 @@ func Forbidden(c *gin.Context, message ...string) {
 func NotFound(c *gin.Context, message ...string) {
 	RespondWithError(c, http.StatusNotFound, message...)
 }
+
+func Conflict(c *gin.Context, message ...string) {
+	RespondWithError(c, http.StatusConflict, message...)
+}
@@ -0,0 +1,27 @@
+package dto
+
+type ReturnRequest struct {
+	ProductID string `json:"productId" binding:"required,uuid"`
+	Reason    string `json:"reason" binding:"required,oneof=defective wrong_item not_as_described damaged_in_transit changed_mind other"`
+	Condition string `json:"condition" binding:"required,oneof=new opened used damaged"`
+	Comment   string `json:"comment" binding:"max=500"`
+}
+
+type ReturnStatusRequest struct {
+	Status string `json:"status" binding:"required,oneof=ready_to_ship shipped_to_warehouse"`
+}
+
+type ReturnResponse struct {
+	ID              string `json:"id"`
+	PVZID           string `json:"pvzId"`
+	ProductID       string `json:"productId"`
+	ClientID        string `json:"clientId,omitempty"`
+	Reason          string `json:"reason"`
+	Condition       string `json:"condition"`
+	Comment         string `json:"comment,omitempty"`
+	Status          string `json:"status"`
+	AcceptedAt      string `json:"acceptedAt"`
+	AcceptedBy      string `json:"acceptedBy,omitempty"`
+	StatusChangedAt string `json:"statusChangedAt"`
+	ShippedAt       string `json:"shippedAt,omitempty"`
+}
@@ -0,0 +1,31 @@
+package dto
+
+type StocktakeScanRequest struct {
+	ProductIDs []string `json:"productIds" binding:"required,min=1,max=500,dive,uuid"`
+}
+
+type StocktakeCompleteRequest struct {
+	Comment string `json:"comment" binding:"max=500"`
+}
+
+type StocktakeItemResponse struct {
+	ProductID string `json:"productId"`
+	Result    string `json:"result"`
+}
+
+type StocktakeResponse struct {
+	ID              string                  `json:"id"`
+	PVZID           string                  `json:"pvzId"`
+	Status          string                  `json:"status"`
+	StartedAt       string                  `json:"startedAt"`
+	StartedBy       string                  `json:"startedBy,omitempty"`
+	CompletedAt     string                  `json:"completedAt,omitempty"`
+	CompletedBy     string                  `json:"completedBy,omitempty"`
+	Comment         string                  `json:"comment,omitempty"`
+	ScannedCount    int                     `json:"scannedCount"`
+	ExpectedCount   int                     `json:"expectedCount"`
+	MatchedCount    int                     `json:"matchedCount"`
+	MissingCount    int                     `json:"missingCount"`
+	UnexpectedCount int                     `json:"unexpectedCount"`
+	Items           []StocktakeItemResponse `json:"items,omitempty"`
+}
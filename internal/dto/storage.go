@@ -0,0 +1,22 @@
+package dto
+
+type OverdueProductResponse struct {
+	ProductID   string `json:"productId"`
+	ReceptionID string `json:"receptionId"`
+	Type        string `json:"type"`
+	OwnerID     string `json:"ownerId,omitempty"`
+	ReadyAt     string `json:"readyAt"`
+	Deadline    string `json:"deadline"`
+	QueuedAt    string `json:"queuedAt"`
+}
+
+type SenderShipmentRequest struct {
+	ProductIDs []string `json:"productIds" binding:"required,min=1,max=500,dive,uuid"`
+}
+
+type SenderShipmentResponse struct {
+	PVZID      string   `json:"pvzId"`
+	ProductIDs []string `json:"productIds"`
+	ShippedAt  string   `json:"shippedAt"`
+	ShippedBy  string   `json:"shippedBy,omitempty"`
+}
@@ -0,0 +1,28 @@
+package dto
+
+type TransferRequest struct {
+	DestinationPVZID string   `json:"destinationPvzId" binding:"required,uuid"`
+	ProductIDs       []string `json:"productIds" binding:"required,min=1,max=500,dive,uuid"`
+}
+
+type TransferResponse struct {
+	ID                     string   `json:"id"`
+	SourcePVZID            string   `json:"sourcePvzId"`
+	DestinationPVZID       string   `json:"destinationPvzId"`
+	Status                 string   `json:"status"`
+	CreatedAt              string   `json:"createdAt"`
+	CreatedBy              string   `json:"createdBy,omitempty"`
+	ReceivedAt             string   `json:"receivedAt,omitempty"`
+	ReceivedBy             string   `json:"receivedBy,omitempty"`
+	DestinationReceptionID string   `json:"destinationReceptionId,omitempty"`
+	ProductIDs             []string `json:"productIds"`
+}
+
+type CustodyEventResponse struct {
+	Kind        string `json:"kind"`
+	PVZID       string `json:"pvzId"`
+	ReceptionID string `json:"receptionId,omitempty"`
+	TransferID  string `json:"transferId,omitempty"`
+	At          string `json:"at"`
+	By          string `json:"by,omitempty"`
+}
@@ -0,0 +1,8 @@
+package dto
+
+type VersionResponse struct {
+	Version             string `json:"version"`
+	SchemaVersion       *int64 `json:"schemaVersion"`
+	SchemaDirty         bool   `json:"schemaDirty"`
+	LatestSchemaVersion int64  `json:"latestSchemaVersion"`
+}
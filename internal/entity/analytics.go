@@ -0,0 +1,59 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AnalyticsGroupBy string
+
+const (
+	AnalyticsGroupByPVZ  AnalyticsGroupBy = "pvz"
+	AnalyticsGroupByCity AnalyticsGroupBy = "city"
+)
+
+type AnalyticsPeriod string
+
+const (
+	AnalyticsPeriodDay   AnalyticsPeriod = "day"
+	AnalyticsPeriodWeek  AnalyticsPeriod = "week"
+	AnalyticsPeriodMonth AnalyticsPeriod = "month"
+)
+
+// MaxAnalyticsRange bounds a single analytics request so the aggregation stays cheap.
+const MaxAnalyticsRange = 366 * 24 * time.Hour
+
+// AnalyticsFilter selects the data for an aggregate report. The range is half-open: [StartDate, EndDate).
+type AnalyticsFilter struct {
+	StartDate time.Time
+	EndDate   time.Time
+	GroupBy   AnalyticsGroupBy
+	Period    AnalyticsPeriod
+	City      string
+	PVZID     *uuid.UUID
+}
+
+// ReceptionThroughput aggregates the receptions opened in a period. Closed receptions and durations
+// are counted for the same receptions, so a reception closed the next day stays in its opening period.
+type ReceptionThroughput struct {
+	PVZID                   *uuid.UUID `db:"pvz_id"`
+	City                    string     `db:"city"`
+	PeriodStart             time.Time  `db:"period_start"`
+	ReceptionsOpened        int        `db:"receptions_opened"`
+	ReceptionsClosed        int        `db:"receptions_closed"`
+	ItemsReceived           int        `db:"items_received"`
+	AvgItemsPerReception    float64    `db:"avg_items_per_reception"`
+	AvgReceptionDurationSec *float64   `db:"avg_reception_duration_sec"`
+}
+
+// ProductMix is the number of products of one type received in a period and their share of all
+// products received by the same group in that period.
+type ProductMix struct {
+	PVZID       *uuid.UUID  `db:"pvz_id"`
+	City        string      `db:"city"`
+	PeriodStart time.Time   `db:"period_start"`
+	ProductType ProductType `db:"product_type"`
+	Items       int         `db:"items"`
+	Share       float64     `db:"share"`
+}
@@ -0,0 +1,57 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type APIKeyScope string
+
+const (
+	ScopePVZManage        APIKeyScope = "pvz:manage"
+	ScopeReceptionsManage APIKeyScope = "receptions:manage"
+	ScopePVZRead          APIKeyScope = "pvz:read"
+	ScopeTokensIntrospect APIKeyScope = "tokens:introspect"
+)
+
+type APIKey struct {
+	ID         uuid.UUID     `json:"id" db:"id"`
+	Name       string        `json:"name" db:"name"`
+	Prefix     string        `json:"prefix" db:"prefix"`
+	KeyHash    string        `json:"-" db:"key_hash"`
+	Scopes     []APIKeyScope `json:"scopes" db:"-"`
+	PVZID      *uuid.UUID    `json:"pvzId,omitempty" db:"pvz_id"`
+	CreatedBy  *uuid.UUID    `json:"createdBy,omitempty" db:"created_by"`
+	ExpiresAt  *time.Time    `json:"expiresAt,omitempty" db:"expires_at"`
+	LastUsedAt *time.Time    `json:"lastUsedAt,omitempty" db:"last_used_at"`
+	RevokedAt  *time.Time    `json:"revokedAt,omitempty" db:"revoked_at"`
+	CreatedAt  time.Time     `json:"createdAt" db:"created_at"`
+}
+
+func (k *APIKey) HasScope(scope APIKeyScope) bool {
+	for _, s := range k.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (k *APIKey) IsActive(now time.Time) bool {
+	if k.RevokedAt != nil {
+		return false
+	}
+
+	return k.ExpiresAt == nil || now.Before(*k.ExpiresAt)
+}
+
+func IsValidAPIKeyScope(scope APIKeyScope) bool {
+	switch scope {
+	case ScopePVZManage, ScopeReceptionsManage, ScopePVZRead, ScopeTokensIntrospect:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,86 @@
+package entity
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+type CapacityPolicy string
+
+const (
+	CapacityPolicyReject CapacityPolicy = "reject"
+	CapacityPolicyWarn   CapacityPolicy = "warn"
+)
+
+func IsValidCapacityPolicy(policy string) bool {
+	switch CapacityPolicy(policy) {
+	case CapacityPolicyReject, CapacityPolicyWarn:
+		return true
+	default:
+		return false
+	}
+}
+
+// PVZCapacity is the storage limit of a PVZ. A nil Total and product types missing
+// from ByType are unlimited.
+type PVZCapacity struct {
+	PVZID  uuid.UUID
+	Total  *int
+	ByType map[ProductType]int
+}
+
+func (c PVZCapacity) Validate() error {
+	if c.Total != nil && *c.Total < 0 {
+		return fmt.Errorf("%w: total capacity must not be negative", ErrInvalidCapacity)
+	}
+
+	for productType, limit := range c.ByType {
+		if !IsValidProductType(productType) {
+			return fmt.Errorf("%w: unknown product type %q", ErrInvalidCapacity, productType)
+		}
+		if limit < 0 {
+			return fmt.Errorf("%w: capacity for %q must not be negative", ErrInvalidCapacity, productType)
+		}
+	}
+
+	return nil
+}
+
+// PVZOccupancy pairs the capacity of a PVZ with the number of products that were
+// received there and not yet issued.
+type PVZOccupancy struct {
+	Capacity PVZCapacity
+	Occupied map[ProductType]int
+}
+
+func (o PVZOccupancy) TotalOccupied() int {
+	total := 0
+	for _, count := range o.Occupied {
+		total += count
+	}
+
+	return total
+}
+
+// CanAccept reports whether one more product of the given type fits into the PVZ.
+func (o PVZOccupancy) CanAccept(productType ProductType) bool {
+	return o.CanAcceptAll(map[ProductType]int{productType: 1})
+}
+
+// CanAcceptAll reports whether all incoming products, counted by type, fit into the PVZ together.
+func (o PVZOccupancy) CanAcceptAll(incoming map[ProductType]int) bool {
+	total := 0
+	for productType, count := range incoming {
+		if limit, ok := o.Capacity.ByType[productType]; ok && o.Occupied[productType]+count > limit {
+			return false
+		}
+		total += count
+	}
+
+	if o.Capacity.Total != nil && o.TotalOccupied()+total > *o.Capacity.Total {
+		return false
+	}
+
+	return true
+}
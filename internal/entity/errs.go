@@ -3,15 +3,82 @@ package entity
 import "errors"
 
 var (
-	ErrInvalidCredentials     = errors.New("invalid credentials")
-	ErrEmailTaken             = errors.New("email already taken")
-	ErrInvalidCity            = errors.New("invalid city")
-	ErrPVZNotFound            = errors.New("pvz not found")
-	ErrReceptionAlreadyExists = errors.New("open reception already exists")
-	ErrNoActiveReception      = errors.New("no active reception for this PVZ")
-	ErrInvalidProductType     = errors.New("invalid product type")
-	ErrInvalidUserRole        = errors.New("invalid user role")
-	ErrNoOpenReception        = errors.New("no open receptions")
-	ErrNoProductsToDelete     = errors.New("no product to delete")
-	ErrReceptionAlreadyClosed = errors.New("reception already closed")
+	ErrInvalidCredentials      = errors.New("invalid credentials")
+	ErrEmailTaken              = errors.New("email already taken")
+	ErrInvalidCity             = errors.New("invalid city")
+	ErrPVZNotFound             = errors.New("pvz not found")
+	ErrReceptionAlreadyExists  = errors.New("open reception already exists")
+	ErrNoActiveReception       = errors.New("no active reception for this PVZ")
+	ErrInvalidProductType      = errors.New("invalid product type")
+	ErrInvalidUserRole         = errors.New("invalid user role")
+	ErrNoOpenReception         = errors.New("no open receptions")
+	ErrNoProductsToDelete      = errors.New("no product to delete")
+	ErrReceptionAlreadyClosed  = errors.New("reception already closed")
+	ErrInvalidAPIKey           = errors.New("invalid api key")
+	ErrInvalidAPIKeyScope      = errors.New("invalid api key scope")
+	ErrInvalidAPIKeyExpiry     = errors.New("api key expiry must be in the future")
+	ErrAPIKeyNotFound          = errors.New("api key not found")
+	ErrOIDCDisabled            = errors.New("oidc login is not configured")
+	ErrOIDCAuthFailed          = errors.New("oidc authentication failed")
+	ErrOIDCNoRole              = errors.New("no role is mapped to identity provider groups")
+	ErrOIDCEmailNotVerified    = errors.New("email is not verified by identity provider")
+	ErrOIDCAccountConflict     = errors.New("email belongs to an account not linked to this identity")
+	ErrWeakPassword            = errors.New("password does not satisfy policy")
+	ErrUserNotFound            = errors.New("user not found")
+	ErrPasswordNotManaged      = errors.New("password is managed by external identity provider")
+	ErrSamePassword            = errors.New("new password must differ from the current one")
+	ErrInvalidResetToken       = errors.New("invalid or expired password reset token")
+	ErrInvalidToken            = errors.New("invalid token")
+	ErrInvalidPVZDetails       = errors.New("invalid pvz details")
+	ErrInvalidCoordinates      = errors.New("invalid coordinates")
+	ErrInvalidWorkingHours     = errors.New("invalid working hours")
+	ErrInvalidSearchRadius     = errors.New("invalid search radius")
+	ErrInvalidPVZStatus        = errors.New("invalid pvz status")
+	ErrPVZStatusTransition     = errors.New("pvz status transition is not allowed")
+	ErrPVZStatusReason         = errors.New("reason is required to suspend or close pvz")
+	ErrPVZNotActive            = errors.New("pvz is not active")
+	ErrPVZHasOpenReception     = errors.New("pvz has an open reception")
+	ErrInvalidCapacity         = errors.New("invalid pvz capacity")
+	ErrCapacityExceeded        = errors.New("pvz capacity exceeded")
+	ErrProductNotFound         = errors.New("product not found")
+	ErrOwnerNotClient          = errors.New("product owner must be a client")
+	ErrProductNotReady         = errors.New("product reception is still in progress")
+	ErrProductAlreadyIssued    = errors.New("product already issued")
+	ErrProductHasNoOwner       = errors.New("product has no owner")
+	ErrInvalidPickupCode       = errors.New("invalid pickup code")
+	ErrPickupCodeLocked        = errors.New("pickup code is locked after too many failed attempts, request a new one")
+	ErrInvalidReturnReason     = errors.New("invalid return reason")
+	ErrInvalidItemCondition    = errors.New("invalid item condition")
+	ErrInvalidReturnStatus     = errors.New("invalid return status")
+	ErrReturnStatusTransition  = errors.New("return status transition is not allowed")
+	ErrProductNotIssued        = errors.New("only issued products can be returned")
+	ErrReturnNotFound          = errors.New("return not found")
+	ErrReturnAlreadyExists     = errors.New("product already has a return")
+	ErrInvalidStoragePeriod    = errors.New("invalid storage period")
+	ErrProductOverdue          = errors.New("product storage period expired")
+	ErrProductNotOverdue       = errors.New("product is not waiting for return to sender at this pvz")
+	ErrInvalidSenderShipment   = errors.New("invalid shipment to sender")
+	ErrInvalidTransfer         = errors.New("invalid transfer")
+	ErrTransferNotFound        = errors.New("transfer not found")
+	ErrTransferAlreadyClosed   = errors.New("transfer already received")
+	ErrProductNotAtPVZ         = errors.New("product is not stored at the source pvz")
+	ErrProductInTransit        = errors.New("product is in transit")
+	ErrProductNotInTransit     = errors.New("transferred product is no longer in transit")
+	ErrProductReferenced       = errors.New("product was transferred or returned and cannot be deleted")
+	ErrProductStatusTransition = errors.New("product status transition is not allowed")
+	ErrStocktakeNotFound       = errors.New("stocktake not found")
+	ErrStocktakeInProgress     = errors.New("pvz already has an open stocktake")
+	ErrStocktakeCompleted      = errors.New("stocktake already completed")
+	ErrStocktakeSignOff        = errors.New("stocktake must be signed off by an employee")
+	ErrInvalidStocktakeScan    = errors.New("invalid stocktake scan")
+	ErrInvalidAnalyticsFilter  = errors.New("invalid analytics filter")
+	ErrUnknownExport           = errors.New("unknown export dataset")
+	ErrInvalidReportJob        = errors.New("invalid report job")
+	ErrReportJobNotFound       = errors.New("report job not found")
+	ErrReportNotReady          = errors.New("report is not ready")
+	ErrReportJobLost           = errors.New("report job was requeued and belongs to another attempt")
+	ErrReportTooLarge          = errors.New("report exceeds the size limit")
+	ErrInvalidImportFile       = errors.New("invalid import file")
+	ErrImportRejected          = errors.New("import file has invalid rows")
+	ErrInvalidLogLevel         = errors.New("invalid log level")
 )
@@ -0,0 +1,62 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ExportFormat string
+
+const (
+	ExportFormatCSV  ExportFormat = "csv"
+	ExportFormatXLSX ExportFormat = "xlsx"
+)
+
+type ExportDataset string
+
+const (
+	ExportPVZ        ExportDataset = "pvz"
+	ExportReceptions ExportDataset = "receptions"
+	ExportProducts   ExportDataset = "products"
+)
+
+// ExportFilter mirrors the GET /pvz filters without pagination: the date range applies to
+// reception dates, inclusive on both ends.
+type ExportFilter struct {
+	StartDate     *time.Time
+	EndDate       *time.Time
+	PVZID         *uuid.UUID
+	IncludeClosed bool
+}
+
+type PVZExportRow struct {
+	ID               uuid.UUID `db:"id"`
+	City             PVZCity   `db:"city"`
+	Address          string    `db:"address"`
+	Status           PVZStatus `db:"status"`
+	RegistrationDate time.Time `db:"registration_date"`
+	Receptions       int       `db:"receptions"`
+	Products         int       `db:"products"`
+}
+
+type ReceptionExportRow struct {
+	ID       uuid.UUID       `db:"id"`
+	PVZID    uuid.UUID       `db:"pvz_id"`
+	City     PVZCity         `db:"city"`
+	DateTime time.Time       `db:"date_time"`
+	Status   ReceptionStatus `db:"status"`
+	ClosedAt *time.Time      `db:"closed_at"`
+	Products int             `db:"products"`
+}
+
+type ProductExportRow struct {
+	ID                uuid.UUID     `db:"id"`
+	DateTime          time.Time     `db:"date_time"`
+	Type              ProductType   `db:"type"`
+	Status            ProductStatus `db:"status"`
+	ReceptionID       uuid.UUID     `db:"reception_id"`
+	ReceptionDateTime time.Time     `db:"reception_date_time"`
+	PVZID             uuid.UUID     `db:"pvz_id"`
+	City              PVZCity       `db:"city"`
+}
@@ -0,0 +1,29 @@
+package entity
+
+type HealthStatus string
+
+const (
+	HealthStatusUp   HealthStatus = "up"
+	HealthStatusDown HealthStatus = "down"
+	// HealthStatusUnknown marks a check that could not reach a verdict; it doesn't fail readiness.
+	HealthStatusUnknown HealthStatus = "unknown"
+)
+
+const (
+	HealthCheckDatabase   = "database"
+	HealthCheckMigrations = "migrations"
+)
+
+type HealthCheck struct {
+	Status  HealthStatus
+	Message string
+}
+
+type Readiness struct {
+	Status HealthStatus
+	Checks map[string]HealthCheck
+}
+
+func (r Readiness) IsReady() bool {
+	return r.Status == HealthStatusUp
+}
@@ -0,0 +1,60 @@
+package entity
+
+import (
+	"time"
+)
+
+type ImportFormat string
+
+const (
+	ImportFormatCSV  ImportFormat = "csv"
+	ImportFormatJSON ImportFormat = "json"
+)
+
+const (
+	// MaxImportRows caps the number of PVZs plus receptions in one file; larger networks are imported in parts.
+	MaxImportRows = 10000
+	// MaxImportedProducts caps the products of a single imported reception.
+	MaxImportedProducts = 10000
+	// MaxImportFileProducts caps the products of the whole file, which is imported in one transaction.
+	MaxImportFileProducts = 100000
+)
+
+// ImportBatch is a parsed import file: new PVZs, each with its historical receptions.
+type ImportBatch struct {
+	PVZ []ImportPVZ
+}
+
+// ImportPVZ is a PVZ to be created. Ref is the partner's own identifier, used to attach receptions
+// to the PVZ in the file and to report errors; it is not stored.
+type ImportPVZ struct {
+	Row        int
+	Ref        string
+	PVZ        PVZ
+	Receptions []ImportReception
+}
+
+// ImportReception is a historical reception; a reception without ClosedAt is still open and
+// must be the latest one of its PVZ. Products are counted by type and get the reception date.
+// Products of a closed reception are history, not stock: they are imported as issued at ClosedAt.
+type ImportReception struct {
+	Row      int
+	DateTime time.Time
+	ClosedAt *time.Time
+	Products map[ProductType]int
+}
+
+// ImportRowError points at a line of a CSV file or a PVZ entry of a JSON file.
+type ImportRowError struct {
+	Row     int
+	Field   string
+	Message string
+}
+
+type ImportReport struct {
+	DryRun     bool
+	PVZ        int
+	Receptions int
+	Products   int
+	Errors     []ImportRowError
+}
@@ -14,11 +14,90 @@ const (
 	ProductShoes       ProductType = "обувь"
 )
 
+type ProductStatus string
+
+const (
+	ProductStatusReceived ProductStatus = "received"
+	ProductStatusIssued   ProductStatus = "issued"
+	ProductStatusReturned ProductStatus = "returned"
+	// ProductStatusReturnToSender marks a product whose storage period expired before pickup.
+	ProductStatusReturnToSender ProductStatus = "return_to_sender"
+	// ProductStatusShippedToSender marks an overdue product handed to the courier for the sender.
+	ProductStatusShippedToSender ProductStatus = "shipped_to_sender"
+	ProductStatusInTransit       ProductStatus = "in_transit"
+)
+
+// productTransitions lists the statuses a product may move to from each status. Returned products
+// and products shipped back to the sender have left the PVZ for good.
+var productTransitions = map[ProductStatus][]ProductStatus{
+	ProductStatusReceived:       {ProductStatusIssued, ProductStatusInTransit, ProductStatusReturnToSender},
+	ProductStatusIssued:         {ProductStatusReturned},
+	ProductStatusInTransit:      {ProductStatusReceived},
+	ProductStatusReturnToSender: {ProductStatusShippedToSender},
+}
+
+func (s ProductStatus) CanTransitionTo(next ProductStatus) bool {
+	for _, allowed := range productTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+
+	return false
+}
+
+// PickupCodeLength is the number of digits in the code a client shows to collect a parcel.
+const PickupCodeLength = 6
+
+// MaxPickupCodeAttempts is how many wrong codes lock the pickup code until a new one is issued.
+const MaxPickupCodeAttempts = 5
+
 type Product struct {
-	ID          uuid.UUID   `json:"id" db:"id"`
-	DateTime    time.Time   `json:"dateTime" db:"date_time"`
-	Type        ProductType `json:"type" db:"type"`
-	ReceptionID uuid.UUID   `json:"receptionId" db:"reception_id"`
+	ID                 uuid.UUID     `json:"id" db:"id"`
+	DateTime           time.Time     `json:"dateTime" db:"date_time"`
+	Type               ProductType   `json:"type" db:"type"`
+	ReceptionID        uuid.UUID     `json:"receptionId" db:"reception_id"`
+	Status             ProductStatus `json:"status" db:"status"`
+	OwnerID            *uuid.UUID    `json:"ownerId,omitempty" db:"owner_id"`
+	PickupCodeHash     *string       `json:"-" db:"pickup_code_hash"`
+	PickupCodeAttempts int           `json:"-" db:"pickup_code_attempts"`
+	IssuedAt           *time.Time    `json:"issuedAt,omitempty" db:"issued_at"`
+	IssuedBy           *uuid.UUID    `json:"issuedBy,omitempty" db:"issued_by"`
+}
+
+// ProductStatusChange is one entry of a product's append-only status history.
+// From is nil for the entry written when the product is first received.
+type ProductStatusChange struct {
+	ID        int64          `db:"id"`
+	ProductID uuid.UUID      `db:"product_id"`
+	From      *ProductStatus `db:"from_status"`
+	To        ProductStatus  `db:"to_status"`
+	PVZID     *uuid.UUID     `db:"pvz_id"`
+	ChangedAt time.Time      `db:"changed_at"`
+	ChangedBy *uuid.UUID     `db:"changed_by"`
+	Comment   string         `db:"comment"`
+}
+
+// Parcel is a received product as seen by its owner: where it waits and how to collect it.
+type Parcel struct {
+	ProductID    uuid.UUID     `db:"product_id"`
+	Type         ProductType   `db:"type"`
+	ReceivedAt   time.Time     `db:"received_at"`
+	CodeAttempts int           `db:"pickup_code_attempts"`
+	PVZID        uuid.UUID     `db:"pvz_id"`
+	City         PVZCity       `db:"city"`
+	Address      string        `db:"address"`
+	Phone        string        `db:"phone"`
+	WorkingHours *WorkingHours `db:"working_hours"`
+}
+
+// PickupCodeLocked reports whether the owner has to request a new pickup code.
+func (p Parcel) PickupCodeLocked() bool {
+	return p.CodeAttempts >= MaxPickupCodeAttempts
+}
+
+func ProductTypes() []ProductType {
+	return []ProductType{ProductElectronics, ProductClothing, ProductShoes}
 }
 
 func IsValidProductType(t ProductType) bool {
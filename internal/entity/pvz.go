@@ -14,10 +14,52 @@ const (
 	CityKazan  PVZCity = "Казань"
 )
 
+type PVZStatus string
+
+const (
+	PVZStatusActive    PVZStatus = "active"
+	PVZStatusSuspended PVZStatus = "suspended"
+	PVZStatusClosed    PVZStatus = "closed"
+)
+
+// pvzTransitions lists the statuses a PVZ may move to from each status. Closing is final:
+// a closed PVZ stays in the database only to keep its receptions and products queryable.
+var pvzTransitions = map[PVZStatus][]PVZStatus{
+	PVZStatusActive:    {PVZStatusSuspended, PVZStatusClosed},
+	PVZStatusSuspended: {PVZStatusActive, PVZStatusClosed},
+}
+
+func IsValidPVZStatus(status string) bool {
+	switch PVZStatus(status) {
+	case PVZStatusActive, PVZStatusSuspended, PVZStatusClosed:
+		return true
+	default:
+		return false
+	}
+}
+
+func (s PVZStatus) CanTransitionTo(next PVZStatus) bool {
+	for _, allowed := range pvzTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+
+	return false
+}
+
 type PVZ struct {
-	ID               uuid.UUID `json:"id" db:"id"`
-	RegistrationDate time.Time `json:"registrationDate" db:"registration_date"`
-	City             PVZCity   `json:"city" db:"city"`
+	ID               uuid.UUID     `json:"id" db:"id"`
+	RegistrationDate time.Time     `json:"registrationDate" db:"registration_date"`
+	City             PVZCity       `json:"city" db:"city"`
+	Address          string        `json:"address" db:"address"`
+	Latitude         *float64      `json:"latitude,omitempty" db:"latitude"`
+	Longitude        *float64      `json:"longitude,omitempty" db:"longitude"`
+	Phone            string        `json:"phone" db:"phone"`
+	WorkingHours     *WorkingHours `json:"workingHours,omitempty" db:"working_hours"`
+	Status           PVZStatus     `json:"status" db:"status"`
+	StatusReason     string        `json:"statusReason,omitempty" db:"status_reason"`
+	StatusChangedAt  *time.Time    `json:"statusChangedAt,omitempty" db:"status_changed_at"`
 }
 
 func IsValidCity(city string) bool {
@@ -29,9 +71,40 @@ func IsValidCity(city string) bool {
 	}
 }
 
+type PVZFilter struct {
+	StartDate     *time.Time
+	EndDate       *time.Time
+	Page          int
+	Limit         int
+	PVZID         *uuid.UUID
+	IncludeClosed bool
+}
+
+const (
+	DefaultNearbyRadius = 5000
+	MaxNearbyRadius     = 50000
+	DefaultNearbyLimit  = 10
+	MaxNearbyLimit      = 50
+)
+
+// NearbyFilter describes a search for PVZs within RadiusMeters of a point.
+type NearbyFilter struct {
+	Latitude     float64
+	Longitude    float64
+	RadiusMeters float64
+	Limit        int
+	PVZID        *uuid.UUID
+}
+
+type NearbyPVZ struct {
+	PVZ
+	DistanceMeters float64 `json:"distanceMeters" db:"distance"`
+}
+
 type FullPVZInfo struct {
 	PVZ        PVZ                     `json:"pvz"`
 	Receptions []ReceptionWithProducts `json:"receptions"`
+	Returns    []CustomerReturn        `json:"returns"`
 }
 
 type ReceptionWithProducts struct {
@@ -0,0 +1,172 @@
+package entity
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+)
+
+const (
+	hoursLayout     = "15:04"
+	dateLayout      = "2006-01-02"
+	endOfDay        = "24:00"
+	maxAddressRunes = 500
+)
+
+var phonePattern = regexp.MustCompile(`^\+?[0-9][0-9 ()\-]{4,19}$`)
+
+var weekdays = map[string]struct{}{
+	"monday": {}, "tuesday": {}, "wednesday": {}, "thursday": {}, "friday": {}, "saturday": {}, "sunday": {},
+}
+
+// WorkingHours is a weekly opening schedule with date-specific exceptions such as holidays.
+// Weekdays missing from Weekly are days off.
+type WorkingHours struct {
+	Weekly     []DailyHours     `json:"weekly"`
+	Exceptions []HoursException `json:"exceptions,omitempty"`
+}
+
+type DailyHours struct {
+	Weekday string `json:"weekday"`
+	Open    string `json:"open"`
+	Close   string `json:"close"`
+}
+
+type HoursException struct {
+	Date   string `json:"date"`
+	Closed bool   `json:"closed"`
+	Open   string `json:"open,omitempty"`
+	Close  string `json:"close,omitempty"`
+	Note   string `json:"note,omitempty"`
+}
+
+// PVZDetailsUpdate holds the fields of a partial PVZ update; nil fields are left unchanged.
+type PVZDetailsUpdate struct {
+	Address      *string
+	Latitude     *float64
+	Longitude    *float64
+	Phone        *string
+	WorkingHours *WorkingHours
+}
+
+func (u PVZDetailsUpdate) Apply(pvz *PVZ) {
+	if u.Address != nil {
+		pvz.Address = *u.Address
+	}
+	if u.Latitude != nil {
+		pvz.Latitude = u.Latitude
+	}
+	if u.Longitude != nil {
+		pvz.Longitude = u.Longitude
+	}
+	if u.Phone != nil {
+		pvz.Phone = *u.Phone
+	}
+	if u.WorkingHours != nil {
+		pvz.WorkingHours = u.WorkingHours
+	}
+}
+
+func (p *PVZ) ValidateDetails() error {
+	if len([]rune(p.Address)) > maxAddressRunes {
+		return fmt.Errorf("%w: address is too long", ErrInvalidPVZDetails)
+	}
+
+	if (p.Latitude == nil) != (p.Longitude == nil) {
+		return fmt.Errorf("%w: latitude and longitude must be set together", ErrInvalidCoordinates)
+	}
+	if p.Latitude != nil && !IsValidCoordinates(*p.Latitude, *p.Longitude) {
+		return ErrInvalidCoordinates
+	}
+
+	if p.Phone != "" && !phonePattern.MatchString(p.Phone) {
+		return fmt.Errorf("%w: invalid phone number", ErrInvalidPVZDetails)
+	}
+
+	if p.WorkingHours != nil {
+		return p.WorkingHours.Validate()
+	}
+
+	return nil
+}
+
+func IsValidCoordinates(lat, lon float64) bool {
+	return lat >= -90 && lat <= 90 && lon >= -180 && lon <= 180
+}
+
+func (w WorkingHours) Validate() error {
+	seenDays := make(map[string]struct{}, len(w.Weekly))
+	for _, day := range w.Weekly {
+		if _, ok := weekdays[day.Weekday]; !ok {
+			return fmt.Errorf("%w: unknown weekday %q", ErrInvalidWorkingHours, day.Weekday)
+		}
+		if _, ok := seenDays[day.Weekday]; ok {
+			return fmt.Errorf("%w: duplicate weekday %q", ErrInvalidWorkingHours, day.Weekday)
+		}
+		seenDays[day.Weekday] = struct{}{}
+
+		if err := validateInterval(day.Open, day.Close); err != nil {
+			return fmt.Errorf("%w: %s: %v", ErrInvalidWorkingHours, day.Weekday, err)
+		}
+	}
+
+	seenDates := make(map[string]struct{}, len(w.Exceptions))
+	for _, exception := range w.Exceptions {
+		if _, err := time.Parse(dateLayout, exception.Date); err != nil {
+			return fmt.Errorf("%w: invalid exception date %q", ErrInvalidWorkingHours, exception.Date)
+		}
+		if _, ok := seenDates[exception.Date]; ok {
+			return fmt.Errorf("%w: duplicate exception date %q", ErrInvalidWorkingHours, exception.Date)
+		}
+		seenDates[exception.Date] = struct{}{}
+
+		if exception.Closed {
+			continue
+		}
+		if err := validateInterval(exception.Open, exception.Close); err != nil {
+			return fmt.Errorf("%w: %s: %v", ErrInvalidWorkingHours, exception.Date, err)
+		}
+	}
+
+	return nil
+}
+
+func (w WorkingHours) Value() (driver.Value, error) {
+	return json.Marshal(w)
+}
+
+func (w *WorkingHours) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case []byte:
+		return json.Unmarshal(v, w)
+	case string:
+		return json.Unmarshal([]byte(v), w)
+	default:
+		return fmt.Errorf("unsupported working hours type %T", src)
+	}
+}
+
+func validateInterval(open, close string) error {
+	openAt, err := time.Parse(hoursLayout, open)
+	if err != nil {
+		return fmt.Errorf("invalid open time %q", open)
+	}
+
+	if close == endOfDay {
+		return nil
+	}
+
+	closeAt, err := time.Parse(hoursLayout, close)
+	if err != nil {
+		return fmt.Errorf("invalid close time %q", close)
+	}
+
+	if !closeAt.After(openAt) {
+		return errors.New("close time must be after open time")
+	}
+
+	return nil
+}
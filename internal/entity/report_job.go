@@ -0,0 +1,138 @@
+package entity
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ReportKind string
+
+const (
+	ReportExportPVZ           ReportKind = "export_pvz"
+	ReportExportReceptions    ReportKind = "export_receptions"
+	ReportExportProducts      ReportKind = "export_products"
+	ReportAnalyticsReceptions ReportKind = "analytics_receptions"
+	ReportAnalyticsProducts   ReportKind = "analytics_products"
+)
+
+func IsValidReportKind(kind ReportKind) bool {
+	switch kind {
+	case ReportExportPVZ, ReportExportReceptions, ReportExportProducts,
+		ReportAnalyticsReceptions, ReportAnalyticsProducts:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsAnalytics reports whether the report aggregates data across PVZs; such reports are moderator-only.
+func (k ReportKind) IsAnalytics() bool {
+	return k == ReportAnalyticsReceptions || k == ReportAnalyticsProducts
+}
+
+type ReportJobStatus string
+
+const (
+	ReportJobQueued    ReportJobStatus = "queued"
+	ReportJobRunning   ReportJobStatus = "running"
+	ReportJobSucceeded ReportJobStatus = "succeeded"
+	ReportJobFailed    ReportJobStatus = "failed"
+)
+
+// ReportHeartbeatInterval is how often a running job saves its progress.
+const ReportHeartbeatInterval = 2 * time.Second
+
+// MinReportJobHeartbeats is how many heartbeats the job timeout has to cover at least, so that a slow
+// progress save does not get a healthy job taken for a crashed one.
+const MinReportJobHeartbeats = 5
+
+// MaxReportJobAttempts is how many times a job is picked up before a worker crash marks it failed.
+const MaxReportJobAttempts = 3
+
+// MaxReportArtifactSize caps a report: it is built in memory and stored in the database.
+const MaxReportArtifactSize = 64 << 20
+
+// ReportParams holds the filters of a report. Exports use the date range, PVZ and IncludeClosed;
+// analytics use the date range, grouping, city and PVZ.
+type ReportParams struct {
+	Format        ExportFormat     `json:"format"`
+	StartDate     *time.Time       `json:"startDate,omitempty"`
+	EndDate       *time.Time       `json:"endDate,omitempty"`
+	PVZID         *uuid.UUID       `json:"pvzId,omitempty"`
+	IncludeClosed bool             `json:"includeClosed,omitempty"`
+	GroupBy       AnalyticsGroupBy `json:"groupBy,omitempty"`
+	Period        AnalyticsPeriod  `json:"period,omitempty"`
+	City          string           `json:"city,omitempty"`
+}
+
+func (p ReportParams) Value() (driver.Value, error) {
+	return json.Marshal(p)
+}
+
+func (p *ReportParams) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case []byte:
+		return json.Unmarshal(v, p)
+	case string:
+		return json.Unmarshal([]byte(v), p)
+	default:
+		return fmt.Errorf("unsupported report params type %T", src)
+	}
+}
+
+func (p ReportParams) ExportFilter() ExportFilter {
+	return ExportFilter{
+		StartDate:     p.StartDate,
+		EndDate:       p.EndDate,
+		PVZID:         p.PVZID,
+		IncludeClosed: p.IncludeClosed,
+	}
+}
+
+func (p ReportParams) AnalyticsFilter() AnalyticsFilter {
+	filter := AnalyticsFilter{
+		GroupBy: p.GroupBy,
+		Period:  p.Period,
+		City:    p.City,
+		PVZID:   p.PVZID,
+	}
+	if p.StartDate != nil {
+		filter.StartDate = *p.StartDate
+	}
+	if p.EndDate != nil {
+		filter.EndDate = *p.EndDate
+	}
+
+	return filter
+}
+
+// ReportJob is a report generated in the background. Progress counts the rows written so far;
+// it is also the worker heartbeat, so a running job that stops progressing is picked up again.
+type ReportJob struct {
+	ID          uuid.UUID       `db:"id"`
+	Kind        ReportKind      `db:"kind"`
+	Params      ReportParams    `db:"params"`
+	Status      ReportJobStatus `db:"status"`
+	Progress    int             `db:"progress"`
+	Attempts    int             `db:"attempts"`
+	Error       string          `db:"error"`
+	CreatedBy   *uuid.UUID      `db:"created_by"`
+	APIKeyID    *uuid.UUID      `db:"api_key_id"`
+	CreatedAt   time.Time       `db:"created_at"`
+	StartedAt   *time.Time      `db:"started_at"`
+	HeartbeatAt *time.Time      `db:"heartbeat_at"`
+	FinishedAt  *time.Time      `db:"finished_at"`
+	// ArtifactName is empty until the job succeeds; the artifact itself is loaded separately.
+	ArtifactName string `db:"artifact_name"`
+	ArtifactSize int64  `db:"artifact_size"`
+}
+
+type ReportArtifact struct {
+	Name        string `db:"artifact_name"`
+	ContentType string `db:"artifact_content_type"`
+	Data        []byte `db:"artifact"`
+}
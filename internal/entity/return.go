@@ -0,0 +1,90 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ReturnReason string
+
+const (
+	ReturnReasonDefective        ReturnReason = "defective"
+	ReturnReasonWrongItem        ReturnReason = "wrong_item"
+	ReturnReasonNotAsDescribed   ReturnReason = "not_as_described"
+	ReturnReasonDamagedInTransit ReturnReason = "damaged_in_transit"
+	ReturnReasonChangedMind      ReturnReason = "changed_mind"
+	ReturnReasonOther            ReturnReason = "other"
+)
+
+type ItemCondition string
+
+const (
+	ConditionNew     ItemCondition = "new"
+	ConditionOpened  ItemCondition = "opened"
+	ConditionUsed    ItemCondition = "used"
+	ConditionDamaged ItemCondition = "damaged"
+)
+
+type ReturnStatus string
+
+const (
+	ReturnStatusAccepted    ReturnStatus = "accepted"
+	ReturnStatusReadyToShip ReturnStatus = "ready_to_ship"
+	ReturnStatusShipped     ReturnStatus = "shipped_to_warehouse"
+)
+
+// returnTransitions lists the next state for each return state; returns only move forward
+// and a shipped return is final.
+var returnTransitions = map[ReturnStatus]ReturnStatus{
+	ReturnStatusAccepted:    ReturnStatusReadyToShip,
+	ReturnStatusReadyToShip: ReturnStatusShipped,
+}
+
+// CustomerReturn is a previously issued product brought back to a PVZ by a client.
+type CustomerReturn struct {
+	ID              uuid.UUID     `json:"id" db:"id"`
+	PVZID           uuid.UUID     `json:"pvzId" db:"pvz_id"`
+	ProductID       uuid.UUID     `json:"productId" db:"product_id"`
+	ClientID        *uuid.UUID    `json:"clientId,omitempty" db:"client_id"`
+	Reason          ReturnReason  `json:"reason" db:"reason"`
+	Condition       ItemCondition `json:"condition" db:"condition"`
+	Comment         string        `json:"comment,omitempty" db:"comment"`
+	Status          ReturnStatus  `json:"status" db:"status"`
+	AcceptedAt      time.Time     `json:"acceptedAt" db:"accepted_at"`
+	AcceptedBy      *uuid.UUID    `json:"acceptedBy,omitempty" db:"accepted_by"`
+	StatusChangedAt time.Time     `json:"statusChangedAt" db:"status_changed_at"`
+	ShippedAt       *time.Time    `json:"shippedAt,omitempty" db:"shipped_at"`
+}
+
+func IsValidReturnReason(reason ReturnReason) bool {
+	switch reason {
+	case ReturnReasonDefective, ReturnReasonWrongItem, ReturnReasonNotAsDescribed,
+		ReturnReasonDamagedInTransit, ReturnReasonChangedMind, ReturnReasonOther:
+		return true
+	default:
+		return false
+	}
+}
+
+func IsValidItemCondition(condition ItemCondition) bool {
+	switch condition {
+	case ConditionNew, ConditionOpened, ConditionUsed, ConditionDamaged:
+		return true
+	default:
+		return false
+	}
+}
+
+func IsValidReturnStatus(status ReturnStatus) bool {
+	switch status {
+	case ReturnStatusAccepted, ReturnStatusReadyToShip, ReturnStatusShipped:
+		return true
+	default:
+		return false
+	}
+}
+
+func (s ReturnStatus) CanTransitionTo(next ReturnStatus) bool {
+	return returnTransitions[s] == next
+}
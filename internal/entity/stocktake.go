@@ -0,0 +1,79 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type StocktakeStatus string
+
+const (
+	StocktakeStatusOpen      StocktakeStatus = "open"
+	StocktakeStatusCompleted StocktakeStatus = "completed"
+)
+
+// MaxStocktakeScanBatch caps the number of products recorded by a single scan request.
+const MaxStocktakeScanBatch = 500
+
+type StocktakeResult string
+
+const (
+	StocktakeMatched    StocktakeResult = "matched"
+	StocktakeMissing    StocktakeResult = "missing"
+	StocktakeUnexpected StocktakeResult = "unexpected"
+)
+
+// Stocktake is a recount of the products physically present at a PVZ. While open it collects scans;
+// completing it compares the scans with the expected stock and stores the signed-off report.
+type Stocktake struct {
+	ID              uuid.UUID       `db:"id"`
+	PVZID           uuid.UUID       `db:"pvz_id"`
+	Status          StocktakeStatus `db:"status"`
+	StartedAt       time.Time       `db:"started_at"`
+	StartedBy       *uuid.UUID      `db:"started_by"`
+	CompletedAt     *time.Time      `db:"completed_at"`
+	CompletedBy     *uuid.UUID      `db:"completed_by"`
+	Comment         string          `db:"comment"`
+	ScannedCount    int             `db:"scanned_count"`
+	ExpectedCount   int             `db:"expected_count"`
+	MatchedCount    int             `db:"matched_count"`
+	MissingCount    int             `db:"missing_count"`
+	UnexpectedCount int             `db:"unexpected_count"`
+	Items           []StocktakeItem `db:"-"`
+}
+
+// StocktakeItem is one line of a completed stocktake report.
+type StocktakeItem struct {
+	ProductID uuid.UUID       `db:"product_id"`
+	Result    StocktakeResult `db:"result"`
+}
+
+// CompareStock matches the scanned products against the expected stock. Matched and missing items
+// follow the order of expected, unexpected ones the order of scanned.
+func CompareStock(expected, scanned []uuid.UUID) []StocktakeItem {
+	scannedSet := make(map[uuid.UUID]struct{}, len(scanned))
+	for _, id := range scanned {
+		scannedSet[id] = struct{}{}
+	}
+
+	expectedSet := make(map[uuid.UUID]struct{}, len(expected))
+	items := make([]StocktakeItem, 0, len(expected)+len(scanned))
+	for _, id := range expected {
+		expectedSet[id] = struct{}{}
+
+		result := StocktakeMissing
+		if _, ok := scannedSet[id]; ok {
+			result = StocktakeMatched
+		}
+		items = append(items, StocktakeItem{ProductID: id, Result: result})
+	}
+
+	for _, id := range scanned {
+		if _, ok := expectedSet[id]; !ok {
+			items = append(items, StocktakeItem{ProductID: id, Result: StocktakeUnexpected})
+		}
+	}
+
+	return items
+}
@@ -0,0 +1,92 @@
+package entity
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// StoragePolicy defines how long a product waits for its owner once its reception is closed.
+// Product types missing from ByType use Default.
+type StoragePolicy struct {
+	Default time.Duration
+	ByType  map[ProductType]time.Duration
+}
+
+func (p StoragePolicy) Period(productType ProductType) time.Duration {
+	if period, ok := p.ByType[productType]; ok {
+		return period
+	}
+
+	return p.Default
+}
+
+// ParseStoragePolicy builds a policy from a default period and a "type=duration,..." list of overrides,
+// e.g. "электроника=336h,одежда=168h".
+func ParseStoragePolicy(defaultPeriod time.Duration, overrides string) (StoragePolicy, error) {
+	policy := StoragePolicy{Default: defaultPeriod, ByType: make(map[ProductType]time.Duration)}
+	if defaultPeriod <= 0 {
+		return policy, fmt.Errorf("%w: default period must be positive", ErrInvalidStoragePeriod)
+	}
+
+	for _, pair := range strings.Split(overrides, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+
+		rawType, rawPeriod, ok := strings.Cut(pair, "=")
+		if !ok {
+			return policy, fmt.Errorf("%w: invalid entry %q", ErrInvalidStoragePeriod, pair)
+		}
+
+		productType := ProductType(strings.TrimSpace(rawType))
+		if !IsValidProductType(productType) {
+			return policy, fmt.Errorf("%w: unknown product type %q", ErrInvalidStoragePeriod, productType)
+		}
+
+		period, err := time.ParseDuration(strings.TrimSpace(rawPeriod))
+		if err != nil || period <= 0 {
+			return policy, fmt.Errorf("%w: invalid period in entry %q", ErrInvalidStoragePeriod, pair)
+		}
+
+		policy.ByType[productType] = period
+	}
+
+	return policy, nil
+}
+
+// OverdueProduct is a product whose storage period has expired and which is queued for return to the sender.
+type OverdueProduct struct {
+	ProductID   uuid.UUID   `json:"productId" db:"product_id"`
+	PVZID       uuid.UUID   `json:"pvzId" db:"pvz_id"`
+	ReceptionID uuid.UUID   `json:"receptionId" db:"reception_id"`
+	Type        ProductType `json:"type" db:"type"`
+	OwnerID     *uuid.UUID  `json:"ownerId,omitempty" db:"owner_id"`
+	ReadyAt     time.Time   `json:"readyAt" db:"ready_at"`
+	Deadline    time.Time   `json:"deadline" db:"deadline"`
+	QueuedAt    time.Time   `json:"queuedAt" db:"queued_at"`
+}
+
+// MaxSenderShipmentProducts caps the number of overdue products handed to the courier at once.
+const MaxSenderShipmentProducts = 500
+
+// SenderShipment is a batch of overdue products handed over to be returned to the sender.
+// ShippedBy is nil when the shipment is recorded with an API key.
+type SenderShipment struct {
+	PVZID      uuid.UUID
+	ProductIDs []uuid.UUID
+	ShippedAt  time.Time
+	ShippedBy  *uuid.UUID
+}
+
+const EventProductOverdue = "product.overdue"
+
+// Event is a domain event published to external consumers.
+type Event struct {
+	Type       string    `json:"type"`
+	OccurredAt time.Time `json:"occurredAt"`
+	Payload    any       `json:"payload"`
+}
@@ -0,0 +1,24 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TokenClaims is the verified content of a service JWT.
+type TokenClaims struct {
+	UserID    string
+	Role      UserRole
+	Dummy     bool
+	IssuedAt  time.Time
+	ExpiresAt time.Time
+}
+
+// UserProfile describes the caller: a stored user, or a synthetic one for tokens from /dummyLogin.
+type UserProfile struct {
+	User
+	PVZIDs         []uuid.UUID
+	Dummy          bool
+	TokenExpiresAt time.Time
+}
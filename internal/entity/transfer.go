@@ -0,0 +1,81 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type TransferStatus string
+
+const (
+	TransferStatusInTransit TransferStatus = "in_transit"
+	TransferStatusReceived  TransferStatus = "received"
+)
+
+// MaxTransferProducts caps the number of products moved by a single transfer order.
+const MaxTransferProducts = 500
+
+// TransferOrder moves products from one PVZ to another. The products leave their source reception
+// when the order is created and join an open reception at the destination when it is received.
+type TransferOrder struct {
+	ID                     uuid.UUID      `json:"id" db:"id"`
+	SourcePVZID            uuid.UUID      `json:"sourcePvzId" db:"source_pvz_id"`
+	DestinationPVZID       uuid.UUID      `json:"destinationPvzId" db:"destination_pvz_id"`
+	Status                 TransferStatus `json:"status" db:"status"`
+	CreatedAt              time.Time      `json:"createdAt" db:"created_at"`
+	CreatedBy              *uuid.UUID     `json:"createdBy,omitempty" db:"created_by"`
+	ReceivedAt             *time.Time     `json:"receivedAt,omitempty" db:"received_at"`
+	ReceivedBy             *uuid.UUID     `json:"receivedBy,omitempty" db:"received_by"`
+	DestinationReceptionID *uuid.UUID     `json:"destinationReceptionId,omitempty" db:"destination_reception_id"`
+	ProductIDs             []uuid.UUID    `json:"productIds" db:"-"`
+}
+
+// TransferItem records which reception a product left when it was put on a transfer.
+type TransferItem struct {
+	TransferID        uuid.UUID `db:"transfer_id"`
+	ProductID         uuid.UUID `db:"product_id"`
+	SourceReceptionID uuid.UUID `db:"source_reception_id"`
+}
+
+type CustodyEventKind string
+
+const (
+	CustodyReceived   CustodyEventKind = "received"
+	CustodyDispatched CustodyEventKind = "dispatched"
+	CustodyArrived    CustodyEventKind = "arrived"
+)
+
+// CustodyEvent is one hop in a product's chain of custody.
+type CustodyEvent struct {
+	Kind        CustodyEventKind `json:"kind"`
+	PVZID       uuid.UUID        `json:"pvzId"`
+	ReceptionID *uuid.UUID       `json:"receptionId,omitempty"`
+	TransferID  *uuid.UUID       `json:"transferId,omitempty"`
+	At          time.Time        `json:"at"`
+	By          *uuid.UUID       `json:"by,omitempty"`
+}
+
+// ProductTransferHop joins a transfer item with its order, as needed to rebuild the chain of custody.
+type ProductTransferHop struct {
+	TransferID             uuid.UUID      `db:"transfer_id"`
+	SourcePVZID            uuid.UUID      `db:"source_pvz_id"`
+	SourceReceptionID      uuid.UUID      `db:"source_reception_id"`
+	DestinationPVZID       uuid.UUID      `db:"destination_pvz_id"`
+	DestinationReceptionID *uuid.UUID     `db:"destination_reception_id"`
+	Status                 TransferStatus `db:"status"`
+	CreatedAt              time.Time      `db:"created_at"`
+	CreatedBy              *uuid.UUID     `db:"created_by"`
+	ReceivedAt             *time.Time     `db:"received_at"`
+	ReceivedBy             *uuid.UUID     `db:"received_by"`
+}
+
+// ProductLocation is where a product currently sits: its reception and that reception's PVZ.
+type ProductLocation struct {
+	ProductID       uuid.UUID       `db:"product_id"`
+	Type            ProductType     `db:"type"`
+	Status          ProductStatus   `db:"status"`
+	ReceptionID     uuid.UUID       `db:"reception_id"`
+	ReceptionStatus ReceptionStatus `db:"reception_status"`
+	PVZID           uuid.UUID       `db:"pvz_id"`
+}
@@ -1,6 +1,7 @@
 package entity
 
 import (
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -14,12 +15,53 @@ const (
 	RoleModerator UserRole = "moderator"
 )
 
+type AuthProvider string
+
+const (
+	AuthProviderLocal AuthProvider = "local"
+	AuthProviderOIDC  AuthProvider = "oidc"
+)
+
 type User struct {
-	ID        uuid.UUID `json:"id" db:"id"`
-	Email     string    `json:"email" db:"email"`
-	Password  string    `json:"-" db:"password_hash"`
-	Role      UserRole  `json:"role" db:"role"`
-	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	ID           uuid.UUID    `json:"id" db:"id"`
+	Email        string       `json:"email" db:"email"`
+	Password     string       `json:"-" db:"password_hash"`
+	Role         UserRole     `json:"role" db:"role"`
+	AuthProvider AuthProvider `json:"auth_provider" db:"auth_provider"`
+	CreatedAt    time.Time    `json:"created_at" db:"created_at"`
+	// OIDCIssuer and OIDCSubject link an OIDC account to its identity at the provider; nil for local accounts.
+	OIDCIssuer  *string `json:"-" db:"oidc_issuer"`
+	OIDCSubject *string `json:"-" db:"oidc_subject"`
+}
+
+// ExternalIdentity is a user identity asserted by an external identity provider.
+// Issuer and Subject identify it; Email is informational unless EmailVerified is set.
+type ExternalIdentity struct {
+	Issuer        string
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Groups        []string
+}
+
+// NormalizeEmail brings an email to the form it is stored and looked up in. Emails are case-insensitive,
+// so "Ivan@Mail.ru" and "ivan@mail.ru" name the same account.
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// RolePriority orders roles by privilege so that the strongest role wins when several apply.
+func RolePriority(role UserRole) int {
+	switch role {
+	case RoleModerator:
+		return 3
+	case RoleEmployee:
+		return 2
+	case RoleClient:
+		return 1
+	default:
+		return 0
+	}
 }
 
 func IsValidUserRole(role UserRole) bool {
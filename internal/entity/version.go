@@ -0,0 +1,16 @@
+package entity
+
+// SchemaVersion is the database schema state recorded by golang-migrate. A dirty schema means
+// a migration failed halfway and has to be fixed by hand.
+type SchemaVersion struct {
+	Version int64 `db:"version"`
+	Dirty   bool  `db:"dirty"`
+}
+
+// VersionInfo describes the running build. Schema is nil when the database has no migration history,
+// e.g. when it was created by the Postgres init scripts instead of migrate.
+type VersionInfo struct {
+	Version             string
+	Schema              *SchemaVersion
+	LatestSchemaVersion int64
+}
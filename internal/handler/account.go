@@ -0,0 +1,185 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+
+	"github.com/senyabanana/pvz-service/internal/dto"
+	"github.com/senyabanana/pvz-service/internal/entity"
+	"github.com/senyabanana/pvz-service/internal/infrastructure/logger"
+	"github.com/senyabanana/pvz-service/internal/middleware"
+	"github.com/senyabanana/pvz-service/internal/service"
+)
+
+type AccountHandler struct {
+	service service.AccountOperations
+	log     *logrus.Logger
+}
+
+func NewAccountHandler(service service.AccountOperations, log *logrus.Logger) *AccountHandler {
+	return &AccountHandler{
+		service: service,
+		log:     log,
+	}
+}
+
+// GetMe godoc
+// @Summary Current User
+// @Tags auth
+// @Security BearerAuth
+// @Description Информация о текущем пользователе: роль, назначенные ПВЗ и срок действия токена
+// @Produce json
+// @Success 200 {object} dto.MeResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /me [get]
+func (h *AccountHandler) GetMe(c *gin.Context) {
+	claims, ok := middleware.GetTokenClaims(c)
+	if !ok {
+		dto.Unauthorized(c, "bearer token required")
+		return
+	}
+
+	profile, err := h.service.GetCurrentUser(c.Request.Context(), claims)
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrInvalidToken):
+			dto.Unauthorized(c, "invalid token")
+		case errors.Is(err, entity.ErrUserNotFound):
+			dto.NotFound(c, "user not found")
+		default:
+			dto.InternalError(c, "failed to get current user")
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, convertProfileToResponse(profile))
+}
+
+// AssignUserPVZ godoc
+// @Summary Assign PVZ To User
+// @Tags auth
+// @Security BearerAuth
+// @Description Замена списка ПВЗ, закреплённых за пользователем (только для модераторов)
+// @Accept json
+// @Produce json
+// @Param userId path string true "User ID"
+// @Param input body dto.AssignPVZRequest true "PVZ IDs"
+// @Success 204
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /users/{userId}/pvz [put]
+func (h *AccountHandler) AssignUserPVZ(c *gin.Context) {
+	log := logger.FromContext(c.Request.Context(), h.log)
+
+	userID, err := uuid.Parse(c.Param("userId"))
+	if err != nil {
+		log.Warnf("invalid userId: %s", c.Param("userId"))
+		dto.BadRequest(c, "invalid userId")
+		return
+	}
+
+	var req dto.AssignPVZRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Warnf("invalid assign pvz input: %v", err)
+		dto.BadRequest(c, "pvzIds must be a list of UUIDs")
+		return
+	}
+
+	pvzIDs := make([]uuid.UUID, 0, len(req.PVZIDs))
+	for _, id := range req.PVZIDs {
+		pvzIDs = append(pvzIDs, uuid.MustParse(id))
+	}
+
+	if err := h.service.AssignUserPVZ(c.Request.Context(), userID, pvzIDs); err != nil {
+		switch {
+		case errors.Is(err, entity.ErrUserNotFound):
+			dto.NotFound(c, "user not found")
+		case errors.Is(err, entity.ErrPVZNotFound):
+			dto.NotFound(c, "pvz not found")
+		default:
+			dto.InternalError(c, "failed to assign pvz")
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// IntrospectToken godoc
+// @Summary Token Introspection
+// @Tags auth
+// @Security APIKeyAuth
+// @Security BearerAuth
+// @Description Проверка токена другими сервисами в стиле RFC 7662. Требуется ключ API со скоупом tokens:introspect или JWT модератора
+// @Accept x-www-form-urlencoded
+// @Produce json
+// @Param token formData string true "Token"
+// @Param token_type_hint formData string false "Token type hint"
+// @Success 200 {object} dto.IntrospectionResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Router /token/introspect [post]
+func (h *AccountHandler) IntrospectToken(c *gin.Context) {
+	log := logger.FromContext(c.Request.Context(), h.log)
+
+	var req dto.IntrospectionRequest
+	if err := c.ShouldBind(&req); err != nil {
+		log.Warnf("invalid introspection input: %v", err)
+		dto.BadRequest(c, "token is required")
+		return
+	}
+
+	c.Header("Cache-Control", "no-store")
+
+	claims, err := h.service.IntrospectToken(c.Request.Context(), req.Token)
+	if err != nil {
+		c.JSON(http.StatusOK, dto.IntrospectionResponse{Active: false})
+		return
+	}
+
+	resp := dto.IntrospectionResponse{
+		Active:    true,
+		Sub:       claims.UserID,
+		Role:      string(claims.Role),
+		TokenType: "Bearer",
+		Dummy:     claims.Dummy,
+	}
+	if !claims.ExpiresAt.IsZero() {
+		resp.Exp = claims.ExpiresAt.Unix()
+	}
+	if !claims.IssuedAt.IsZero() {
+		resp.Iat = claims.IssuedAt.Unix()
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func convertProfileToResponse(profile *entity.UserProfile) dto.MeResponse {
+	resp := dto.MeResponse{
+		ID:             profile.ID.String(),
+		Email:          profile.Email,
+		Role:           string(profile.Role),
+		AuthProvider:   string(profile.AuthProvider),
+		PVZIDs:         make([]string, 0, len(profile.PVZIDs)),
+		Dummy:          profile.Dummy,
+		TokenExpiresAt: profile.TokenExpiresAt.Format(time.RFC3339),
+	}
+	if !profile.CreatedAt.IsZero() {
+		resp.CreatedAt = profile.CreatedAt.Format(time.RFC3339)
+	}
+	for _, id := range profile.PVZIDs {
+		resp.PVZIDs = append(resp.PVZIDs, id.String())
+	}
+
+	return resp
+}
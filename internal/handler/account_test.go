@@ -0,0 +1,243 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/senyabanana/pvz-service/internal/dto"
+	"github.com/senyabanana/pvz-service/internal/entity"
+	mocks "github.com/senyabanana/pvz-service/internal/service/mocks"
+)
+
+func TestAccountHandler_GetMe(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockAccountOperations(ctrl)
+	mockLog := logrus.New()
+	h := NewAccountHandler(mockService, mockLog)
+
+	gin.SetMode(gin.TestMode)
+
+	userID := uuid.New()
+	claims := entity.TokenClaims{UserID: userID.String(), Role: entity.RoleEmployee, ExpiresAt: time.Now().Add(time.Hour)}
+
+	tests := []struct {
+		name       string
+		withClaims bool
+		mock       func()
+		wantStatus int
+	}{
+		{
+			name:       "success",
+			withClaims: true,
+			mock: func() {
+				mockService.EXPECT().GetCurrentUser(gomock.Any(), claims).Return(&entity.UserProfile{
+					User:           entity.User{ID: userID, Email: "e@example.com", Role: entity.RoleEmployee},
+					PVZIDs:         []uuid.UUID{uuid.New()},
+					TokenExpiresAt: claims.ExpiresAt,
+				}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "no token claims",
+			withClaims: false,
+			mock:       func() {},
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "user not found",
+			withClaims: true,
+			mock: func() {
+				mockService.EXPECT().GetCurrentUser(gomock.Any(), claims).Return(nil, entity.ErrUserNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "internal error",
+			withClaims: true,
+			mock: func() {
+				mockService.EXPECT().GetCurrentUser(gomock.Any(), claims).Return(nil, assert.AnError)
+			},
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(rr)
+			ctx.Request, _ = http.NewRequest(http.MethodGet, "/me", nil)
+			if tt.withClaims {
+				ctx.Set("token_claims", claims)
+			}
+
+			tt.mock()
+			h.GetMe(ctx)
+
+			assert.Equal(t, tt.wantStatus, rr.Code)
+			if tt.wantStatus == http.StatusOK {
+				var resp dto.MeResponse
+				assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+				assert.Equal(t, userID.String(), resp.ID)
+				assert.Len(t, resp.PVZIDs, 1)
+			}
+		})
+	}
+}
+
+func TestAccountHandler_AssignUserPVZ(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockAccountOperations(ctrl)
+	mockLog := logrus.New()
+	h := NewAccountHandler(mockService, mockLog)
+
+	gin.SetMode(gin.TestMode)
+
+	userID := uuid.New()
+	pvzID := uuid.New()
+
+	tests := []struct {
+		name       string
+		param      string
+		input      string
+		mock       func()
+		wantStatus int
+	}{
+		{
+			name:  "success",
+			param: userID.String(),
+			input: `{"pvzIds":["` + pvzID.String() + `"]}`,
+			mock: func() {
+				mockService.EXPECT().AssignUserPVZ(gomock.Any(), userID, []uuid.UUID{pvzID}).Return(nil)
+			},
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name:       "invalid user id",
+			param:      "bad",
+			input:      `{"pvzIds":[]}`,
+			mock:       func() {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "invalid pvz id",
+			param:      userID.String(),
+			input:      `{"pvzIds":["bad"]}`,
+			mock:       func() {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:  "pvz not found",
+			param: userID.String(),
+			input: `{"pvzIds":["` + pvzID.String() + `"]}`,
+			mock: func() {
+				mockService.EXPECT().AssignUserPVZ(gomock.Any(), userID, []uuid.UUID{pvzID}).Return(entity.ErrPVZNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(rr)
+			ctx.Request, _ = http.NewRequest(http.MethodPut, "/users/"+tt.param+"/pvz", bytes.NewBufferString(tt.input))
+			ctx.Request.Header.Set("Content-Type", "application/json")
+			ctx.Params = []gin.Param{{Key: "userId", Value: tt.param}}
+
+			tt.mock()
+			h.AssignUserPVZ(ctx)
+			ctx.Writer.WriteHeaderNow()
+
+			assert.Equal(t, tt.wantStatus, rr.Code)
+		})
+	}
+}
+
+func TestAccountHandler_IntrospectToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockAccountOperations(ctrl)
+	mockLog := logrus.New()
+	h := NewAccountHandler(mockService, mockLog)
+
+	gin.SetMode(gin.TestMode)
+
+	now := time.Now().Truncate(time.Second)
+
+	tests := []struct {
+		name       string
+		form       url.Values
+		mock       func()
+		wantStatus int
+		wantActive bool
+	}{
+		{
+			name: "active token",
+			form: url.Values{"token": {"good"}},
+			mock: func() {
+				mockService.EXPECT().IntrospectToken(gomock.Any(), "good").Return(&entity.TokenClaims{
+					UserID: "user-1", Role: entity.RoleEmployee, IssuedAt: now, ExpiresAt: now.Add(time.Hour),
+				}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantActive: true,
+		},
+		{
+			name: "inactive token",
+			form: url.Values{"token": {"bad"}},
+			mock: func() {
+				mockService.EXPECT().IntrospectToken(gomock.Any(), "bad").Return(nil, entity.ErrInvalidToken)
+			},
+			wantStatus: http.StatusOK,
+			wantActive: false,
+		},
+		{
+			name:       "missing token",
+			form:       url.Values{},
+			mock:       func() {},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(rr)
+			ctx.Request, _ = http.NewRequest(http.MethodPost, "/token/introspect", strings.NewReader(tt.form.Encode()))
+			ctx.Request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+			tt.mock()
+			h.IntrospectToken(ctx)
+
+			assert.Equal(t, tt.wantStatus, rr.Code)
+			if tt.wantStatus == http.StatusOK {
+				var resp map[string]interface{}
+				assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+				assert.Equal(t, tt.wantActive, resp["active"])
+				if !tt.wantActive {
+					assert.Len(t, resp, 1)
+				} else {
+					assert.Equal(t, float64(now.Add(time.Hour).Unix()), resp["exp"])
+				}
+			}
+		})
+	}
+}
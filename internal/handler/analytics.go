@@ -0,0 +1,163 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+
+	"github.com/senyabanana/pvz-service/internal/dto"
+	"github.com/senyabanana/pvz-service/internal/entity"
+	"github.com/senyabanana/pvz-service/internal/infrastructure/logger"
+	"github.com/senyabanana/pvz-service/internal/service"
+)
+
+type AnalyticsHandler struct {
+	service service.AnalyticsOperations
+	log     *logrus.Logger
+}
+
+func NewAnalyticsHandler(service service.AnalyticsOperations, log *logrus.Logger) *AnalyticsHandler {
+	return &AnalyticsHandler{
+		service: service,
+		log:     log,
+	}
+}
+
+// GetReceptionThroughput godoc
+// @Summary Get reception throughput
+// @Tags analytics
+// @Description Число открытых и закрытых приёмок, принятых товаров, среднее число товаров в приёмке и средняя длительность приёмки по ПВЗ или городу за день, неделю или месяц
+// @Security BearerAuth
+// @Produce json
+// @Param startDate query string true "Начало периода (RFC3339, включительно)"
+// @Param endDate query string true "Конец периода (RFC3339, не включительно)"
+// @Param groupBy query string false "Группировка: pvz или city (по умолчанию pvz)"
+// @Param period query string false "Интервал: day, week или month (по умолчанию day)"
+// @Param city query string false "Фильтр по городу"
+// @Param pvzId query string false "Фильтр по ПВЗ"
+// @Success 200 {array} dto.ReceptionThroughputResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /analytics/receptions [get]
+func (h *AnalyticsHandler) GetReceptionThroughput(c *gin.Context) {
+	filter, ok := h.parseAnalyticsQuery(c)
+	if !ok {
+		return
+	}
+
+	rows, err := h.service.GetReceptionThroughput(c.Request.Context(), filter)
+	if err != nil {
+		if errors.Is(err, entity.ErrInvalidAnalyticsFilter) {
+			dto.BadRequest(c, err.Error())
+			return
+		}
+
+		dto.InternalError(c, "failed to get reception throughput")
+		return
+	}
+
+	resp := make([]dto.ReceptionThroughputResponse, 0, len(rows))
+	for _, row := range rows {
+		item := dto.ReceptionThroughputResponse{
+			City:                    row.City,
+			PeriodStart:             row.PeriodStart.Format(time.RFC3339),
+			ReceptionsOpened:        row.ReceptionsOpened,
+			ReceptionsClosed:        row.ReceptionsClosed,
+			ItemsReceived:           row.ItemsReceived,
+			AvgItemsPerReception:    row.AvgItemsPerReception,
+			AvgReceptionDurationSec: row.AvgReceptionDurationSec,
+		}
+		if row.PVZID != nil {
+			item.PVZID = row.PVZID.String()
+		}
+		resp = append(resp, item)
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// GetProductMix godoc
+// @Summary Get product type mix
+// @Tags analytics
+// @Description Число принятых товаров каждого типа и их доля по ПВЗ или городу за день, неделю или месяц
+// @Security BearerAuth
+// @Produce json
+// @Param startDate query string true "Начало периода (RFC3339, включительно)"
+// @Param endDate query string true "Конец периода (RFC3339, не включительно)"
+// @Param groupBy query string false "Группировка: pvz или city (по умолчанию pvz)"
+// @Param period query string false "Интервал: day, week или month (по умолчанию day)"
+// @Param city query string false "Фильтр по городу"
+// @Param pvzId query string false "Фильтр по ПВЗ"
+// @Success 200 {array} dto.ProductMixResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /analytics/products [get]
+func (h *AnalyticsHandler) GetProductMix(c *gin.Context) {
+	filter, ok := h.parseAnalyticsQuery(c)
+	if !ok {
+		return
+	}
+
+	rows, err := h.service.GetProductMix(c.Request.Context(), filter)
+	if err != nil {
+		if errors.Is(err, entity.ErrInvalidAnalyticsFilter) {
+			dto.BadRequest(c, err.Error())
+			return
+		}
+
+		dto.InternalError(c, "failed to get product mix")
+		return
+	}
+
+	resp := make([]dto.ProductMixResponse, 0, len(rows))
+	for _, row := range rows {
+		item := dto.ProductMixResponse{
+			City:        row.City,
+			PeriodStart: row.PeriodStart.Format(time.RFC3339),
+			Type:        string(row.ProductType),
+			Items:       row.Items,
+			Share:       row.Share,
+		}
+		if row.PVZID != nil {
+			item.PVZID = row.PVZID.String()
+		}
+		resp = append(resp, item)
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *AnalyticsHandler) parseAnalyticsQuery(c *gin.Context) (entity.AnalyticsFilter, bool) {
+	log := logger.FromContext(c.Request.Context(), h.log)
+
+	var query dto.AnalyticsQueryParams
+	if err := c.ShouldBindQuery(&query); err != nil {
+		log.Warnf("invalid analytics query: %v", err)
+		dto.BadRequest(c, "invalid query parameters")
+		return entity.AnalyticsFilter{}, false
+	}
+
+	// Both dates are validated by the binding, so parsing cannot fail here.
+	startDate, _ := time.Parse(time.RFC3339, query.StartDate)
+	endDate, _ := time.Parse(time.RFC3339, query.EndDate)
+
+	filter := entity.AnalyticsFilter{
+		StartDate: startDate,
+		EndDate:   endDate,
+		GroupBy:   entity.AnalyticsGroupBy(query.GroupBy),
+		Period:    entity.AnalyticsPeriod(query.Period),
+		City:      query.City,
+	}
+	if query.PVZID != "" {
+		pvzID := uuid.MustParse(query.PVZID)
+		filter.PVZID = &pvzID
+	}
+
+	return filter, true
+}
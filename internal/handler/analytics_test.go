@@ -0,0 +1,167 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/senyabanana/pvz-service/internal/entity"
+	mocks "github.com/senyabanana/pvz-service/internal/service/mocks"
+)
+
+func TestAnalyticsHandler_GetReceptionThroughput(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockAnalyticsOperations(ctrl)
+	mockLog := logrus.New()
+	h := NewAnalyticsHandler(mockService, mockLog)
+	gin.SetMode(gin.TestMode)
+
+	start := time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 0, 7)
+	pvzID := uuid.New()
+	duration := 1800.0
+
+	tests := []struct {
+		name       string
+		query      string
+		mock       func()
+		wantStatus int
+	}{
+		{
+			name:  "success",
+			query: "?startDate=2025-04-01T00:00:00Z&endDate=2025-04-08T00:00:00Z&groupBy=city&period=week&city=Казань",
+			mock: func() {
+				mockService.EXPECT().GetReceptionThroughput(gomock.Any(), entity.AnalyticsFilter{
+					StartDate: start, EndDate: end, GroupBy: entity.AnalyticsGroupByCity,
+					Period: entity.AnalyticsPeriodWeek, City: "Казань",
+				}).Return([]entity.ReceptionThroughput{{
+					City: "Казань", PeriodStart: start, ReceptionsOpened: 2, ReceptionsClosed: 2,
+					ItemsReceived: 7, AvgItemsPerReception: 3.5, AvgReceptionDurationSec: &duration,
+				}}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:  "filtered by pvz",
+			query: "?startDate=2025-04-01T00:00:00Z&endDate=2025-04-08T00:00:00Z&pvzId=" + pvzID.String(),
+			mock: func() {
+				mockService.EXPECT().GetReceptionThroughput(gomock.Any(), entity.AnalyticsFilter{
+					StartDate: start, EndDate: end, PVZID: &pvzID,
+				}).Return([]entity.ReceptionThroughput{{PVZID: &pvzID, City: "Москва", PeriodStart: start}}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "missing dates",
+			query:      "?groupBy=pvz",
+			mock:       func() {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "invalid period",
+			query:      "?startDate=2025-04-01T00:00:00Z&endDate=2025-04-08T00:00:00Z&period=year",
+			mock:       func() {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:  "invalid range",
+			query: "?startDate=2025-04-08T00:00:00Z&endDate=2025-04-01T00:00:00Z",
+			mock: func() {
+				mockService.EXPECT().GetReceptionThroughput(gomock.Any(), gomock.Any()).Return(nil, entity.ErrInvalidAnalyticsFilter)
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:  "internal error",
+			query: "?startDate=2025-04-01T00:00:00Z&endDate=2025-04-08T00:00:00Z",
+			mock: func() {
+				mockService.EXPECT().GetReceptionThroughput(gomock.Any(), gomock.Any()).Return(nil, errors.New("db down"))
+			},
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest(http.MethodGet, "/analytics/receptions"+tt.query, nil)
+
+			tt.mock()
+			h.GetReceptionThroughput(c)
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
+
+func TestAnalyticsHandler_GetProductMix(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockAnalyticsOperations(ctrl)
+	mockLog := logrus.New()
+	h := NewAnalyticsHandler(mockService, mockLog)
+	gin.SetMode(gin.TestMode)
+
+	start := time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)
+	pvzID := uuid.New()
+
+	tests := []struct {
+		name       string
+		query      string
+		mock       func()
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:  "success",
+			query: "?startDate=2025-04-01T00:00:00Z&endDate=2025-05-01T00:00:00Z&period=month",
+			mock: func() {
+				mockService.EXPECT().GetProductMix(gomock.Any(), gomock.Any()).Return([]entity.ProductMix{
+					{PVZID: &pvzID, City: "Казань", PeriodStart: start, ProductType: entity.ProductElectronics, Items: 3, Share: 0.75},
+				}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   `"type":"электроника","items":3,"share":0.75`,
+		},
+		{
+			name:       "invalid pvz id",
+			query:      "?startDate=2025-04-01T00:00:00Z&endDate=2025-05-01T00:00:00Z&pvzId=abc",
+			mock:       func() {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:  "internal error",
+			query: "?startDate=2025-04-01T00:00:00Z&endDate=2025-05-01T00:00:00Z",
+			mock: func() {
+				mockService.EXPECT().GetProductMix(gomock.Any(), gomock.Any()).Return(nil, errors.New("db down"))
+			},
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest(http.MethodGet, "/analytics/products"+tt.query, nil)
+
+			tt.mock()
+			h.GetProductMix(c)
+			assert.Equal(t, tt.wantStatus, w.Code)
+			if tt.wantBody != "" {
+				assert.Contains(t, w.Body.String(), tt.wantBody)
+			}
+		})
+	}
+}
@@ -0,0 +1,199 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+
+	"github.com/senyabanana/pvz-service/internal/dto"
+	"github.com/senyabanana/pvz-service/internal/entity"
+	"github.com/senyabanana/pvz-service/internal/infrastructure/logger"
+	"github.com/senyabanana/pvz-service/internal/middleware"
+	"github.com/senyabanana/pvz-service/internal/service"
+)
+
+type APIKeyHandler struct {
+	service service.APIKeyOperations
+	log     *logrus.Logger
+}
+
+func NewAPIKeyHandler(service service.APIKeyOperations, log *logrus.Logger) *APIKeyHandler {
+	return &APIKeyHandler{
+		service: service,
+		log:     log,
+	}
+}
+
+// CreateAPIKey godoc
+// @Summary Create API Key
+// @Tags api-keys
+// @Description Выпуск ключа для межсервисного доступа. Ключ возвращается только один раз
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param input body dto.APIKeyRequest true "Параметры ключа"
+// @Success 201 {object} dto.CreatedAPIKeyResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api-keys [post]
+func (h *APIKeyHandler) CreateAPIKey(c *gin.Context) {
+	log := logger.FromContext(c.Request.Context(), h.log)
+
+	var req dto.APIKeyRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Warnf("invalid api key input: %v", err)
+		dto.BadRequest(c, "invalid name, scopes, pvzId or expiresAt")
+		return
+	}
+
+	key := &entity.APIKey{
+		Name:   req.Name,
+		Scopes: make([]entity.APIKeyScope, 0, len(req.Scopes)),
+	}
+	for _, scope := range req.Scopes {
+		key.Scopes = append(key.Scopes, entity.APIKeyScope(scope))
+	}
+
+	if req.PVZID != "" {
+		pvzID, err := uuid.Parse(req.PVZID)
+		if err != nil {
+			log.Warnf("invalid UUID format: %v", err)
+			dto.BadRequest(c, "invalid UUID format")
+			return
+		}
+		key.PVZID = &pvzID
+	}
+
+	key.ExpiresAt = parseQueryTime(req.ExpiresAt, "expiresAt", c, log)
+	if c.IsAborted() {
+		return
+	}
+
+	if createdBy, err := uuid.Parse(middleware.GetUserID(c)); err == nil {
+		key.CreatedBy = &createdBy
+	}
+
+	rawKey, err := h.service.CreateAPIKey(c.Request.Context(), key)
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrInvalidAPIKeyScope):
+			dto.BadRequest(c, "scopes must be: pvz:manage, receptions:manage, pvz:read or tokens:introspect")
+			return
+		case errors.Is(err, entity.ErrInvalidAPIKeyExpiry):
+			dto.BadRequest(c, "expiresAt must be in the future")
+			return
+		case errors.Is(err, entity.ErrPVZNotFound):
+			dto.NotFound(c, "pvz not found")
+			return
+		default:
+			dto.InternalError(c, "failed to create api key")
+			return
+		}
+	}
+
+	c.JSON(http.StatusCreated, dto.CreatedAPIKeyResponse{
+		APIKey: convertAPIKeyToResponse(*key),
+		Key:    rawKey,
+	})
+}
+
+// GetAllAPIKeys godoc
+// @Summary Get API Keys
+// @Tags api-keys
+// @Description Получение списка выпущенных ключей (без секретов)
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {array} dto.APIKeyResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api-keys [get]
+func (h *APIKeyHandler) GetAllAPIKeys(c *gin.Context) {
+	log := logger.FromContext(c.Request.Context(), h.log)
+
+	keys, err := h.service.GetAllAPIKeys(c.Request.Context())
+	if err != nil {
+		log.Errorf("failed to get api keys: %v", err)
+		dto.InternalError(c, "failed to get api keys")
+		return
+	}
+
+	result := make([]dto.APIKeyResponse, 0, len(keys))
+	for _, key := range keys {
+		result = append(result, convertAPIKeyToResponse(key))
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// RevokeAPIKey godoc
+// @Summary Revoke API Key
+// @Tags api-keys
+// @Description Отзыв ключа
+// @Security BearerAuth
+// @Produce json
+// @Param keyId path string true "API key ID"
+// @Success 204
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /api-keys/{keyId} [delete]
+func (h *APIKeyHandler) RevokeAPIKey(c *gin.Context) {
+	log := logger.FromContext(c.Request.Context(), h.log)
+
+	keyIDParam := c.Param("keyId")
+	keyID, err := uuid.Parse(keyIDParam)
+	if err != nil {
+		log.Warnf("invalid keyId: %s", keyIDParam)
+		dto.BadRequest(c, "invalid keyId")
+		return
+	}
+
+	if err := h.service.RevokeAPIKey(c.Request.Context(), keyID); err != nil {
+		if errors.Is(err, entity.ErrAPIKeyNotFound) {
+			dto.NotFound(c, "api key not found or already revoked")
+			return
+		}
+
+		dto.InternalError(c, "failed to revoke api key")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func convertAPIKeyToResponse(key entity.APIKey) dto.APIKeyResponse {
+	resp := dto.APIKeyResponse{
+		ID:        key.ID.String(),
+		Name:      key.Name,
+		Prefix:    key.Prefix,
+		Scopes:    make([]string, 0, len(key.Scopes)),
+		CreatedAt: key.CreatedAt.Format(time.RFC3339),
+	}
+
+	for _, scope := range key.Scopes {
+		resp.Scopes = append(resp.Scopes, string(scope))
+	}
+
+	if key.PVZID != nil {
+		resp.PVZID = key.PVZID.String()
+	}
+
+	if key.ExpiresAt != nil {
+		resp.ExpiresAt = key.ExpiresAt.Format(time.RFC3339)
+	}
+
+	if key.LastUsedAt != nil {
+		resp.LastUsedAt = key.LastUsedAt.Format(time.RFC3339)
+	}
+
+	if key.RevokedAt != nil {
+		resp.RevokedAt = key.RevokedAt.Format(time.RFC3339)
+	}
+
+	return resp
+}
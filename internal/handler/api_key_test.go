@@ -0,0 +1,157 @@
+package handler
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/senyabanana/pvz-service/internal/entity"
+	mocks "github.com/senyabanana/pvz-service/internal/service/mocks"
+)
+
+func TestAPIKeyHandler_CreateAPIKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockAPIKeyOperations(ctrl)
+	mockLog := logrus.New()
+	h := NewAPIKeyHandler(mockService, mockLog)
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name       string
+		inputBody  string
+		mock       func()
+		wantStatus int
+	}{
+		{
+			name:      "success",
+			inputBody: `{"name":"sorting-center","scopes":["receptions:manage"],"pvzId":"` + uuid.New().String() + `"}`,
+			mock: func() {
+				mockService.EXPECT().CreateAPIKey(gomock.Any(), gomock.Any()).Return("pvz_abcd1234_secret", nil)
+			},
+			wantStatus: http.StatusCreated,
+		},
+		{
+			name:       "missing scopes",
+			inputBody:  `{"name":"sorting-center","scopes":[]}`,
+			mock:       func() {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "invalid expiresAt",
+			inputBody:  `{"name":"sorting-center","scopes":["pvz:read"],"expiresAt":"tomorrow"}`,
+			mock:       func() {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:      "invalid scope",
+			inputBody: `{"name":"sorting-center","scopes":["admin"]}`,
+			mock: func() {
+				mockService.EXPECT().CreateAPIKey(gomock.Any(), gomock.Any()).Return("", entity.ErrInvalidAPIKeyScope)
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:      "pvz not found",
+			inputBody: `{"name":"sorting-center","scopes":["pvz:read"],"pvzId":"` + uuid.New().String() + `"}`,
+			mock: func() {
+				mockService.EXPECT().CreateAPIKey(gomock.Any(), gomock.Any()).Return("", entity.ErrPVZNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:      "internal error",
+			inputBody: `{"name":"sorting-center","scopes":["pvz:read"]}`,
+			mock: func() {
+				mockService.EXPECT().CreateAPIKey(gomock.Any(), gomock.Any()).Return("", errors.New("db down"))
+			},
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest(http.MethodPost, "/api-keys", bytes.NewBufferString(tt.inputBody))
+			c.Request.Header.Set("Content-Type", "application/json")
+
+			tt.mock()
+			h.CreateAPIKey(c)
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
+
+func TestAPIKeyHandler_RevokeAPIKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockAPIKeyOperations(ctrl)
+	mockLog := logrus.New()
+	h := NewAPIKeyHandler(mockService, mockLog)
+	gin.SetMode(gin.TestMode)
+
+	validID := uuid.New().String()
+
+	tests := []struct {
+		name       string
+		param      string
+		mock       func()
+		wantStatus int
+	}{
+		{
+			name:  "success",
+			param: validID,
+			mock: func() {
+				mockService.EXPECT().RevokeAPIKey(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name:       "invalid UUID",
+			param:      "not-a-uuid",
+			mock:       func() {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:  "not found",
+			param: validID,
+			mock: func() {
+				mockService.EXPECT().RevokeAPIKey(gomock.Any(), gomock.Any()).Return(entity.ErrAPIKeyNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:  "internal error",
+			param: validID,
+			mock: func() {
+				mockService.EXPECT().RevokeAPIKey(gomock.Any(), gomock.Any()).Return(errors.New("db down"))
+			},
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest(http.MethodDelete, "/api-keys/"+tt.param, nil)
+			c.Params = []gin.Param{{Key: "keyId", Value: tt.param}}
+
+			tt.mock()
+			h.RevokeAPIKey(c)
+			c.Writer.WriteHeaderNow()
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/senyabanana/pvz-service/internal/dto"
 	"github.com/senyabanana/pvz-service/internal/entity"
 	"github.com/senyabanana/pvz-service/internal/infrastructure/jwtutil"
+	"github.com/senyabanana/pvz-service/internal/infrastructure/logger"
 	"github.com/senyabanana/pvz-service/internal/service"
 )
 
@@ -41,25 +42,27 @@ func NewAuthHandler(service service.Authorization, secretKey string, log *logrus
 // @Failure 500 {object} dto.ErrorResponse
 // @Router /dummyLogin [post]
 func (h *AuthHandler) DummyLogin(c *gin.Context) {
+	log := logger.FromContext(c.Request.Context(), h.log)
+
 	var req dto.DummyLoginRequest
 
 	if err := c.ShouldBindJSON(&req); err != nil {
-		h.log.Warnf("invalid input: %v", err)
+		log.Warnf("invalid input: %v", err)
 		dto.BadRequest(c, "role must be: client, employee, or moderator")
 		return
 	}
 
 	role := entity.UserRole(req.Role)
 	if !entity.IsValidUserRole(role) {
-		h.log.Warnf("invalid dummy login role: %s", req.Role)
+		log.Warnf("invalid dummy login role: %s", req.Role)
 		dto.BadRequest(c, "invalid role")
 		return
 	}
 
 	userID := uuid.New().String()
-	token, err := jwtutil.GenerateToken(userID, req.Role, h.JWTSecret, 2*time.Hour)
+	token, err := jwtutil.GenerateDummyToken(userID, req.Role, h.JWTSecret, 2*time.Hour)
 	if err != nil {
-		h.log.Errorf("failed to generate JWT: %v", err)
+		log.Errorf("failed to generate JWT: %v", err)
 		dto.InternalError(c, "token generation error")
 		return
 	}
@@ -79,10 +82,12 @@ func (h *AuthHandler) DummyLogin(c *gin.Context) {
 // @Failure 500 {object} dto.ErrorResponse
 // @Router /register [post]
 func (h *AuthHandler) Register(c *gin.Context) {
+	log := logger.FromContext(c.Request.Context(), h.log)
+
 	var req dto.RegisterRequest
 
 	if err := c.ShouldBindJSON(&req); err != nil {
-		h.log.Warnf("invalid register input: %v", err)
+		log.Warnf("invalid register input: %v", err)
 		dto.BadRequest(c, "invalid email, password or role")
 		return
 	}
@@ -101,6 +106,9 @@ func (h *AuthHandler) Register(c *gin.Context) {
 		case errors.Is(err, entity.ErrInvalidUserRole):
 			dto.BadRequest(c, "invalid user role")
 			return
+		case errors.Is(err, entity.ErrWeakPassword):
+			dto.BadRequest(c, err.Error())
+			return
 		default:
 			dto.InternalError(c, "failed to register user")
 			return
@@ -127,10 +135,12 @@ func (h *AuthHandler) Register(c *gin.Context) {
 // @Failure 500 {object} dto.ErrorResponse
 // @Router /login [post]
 func (h *AuthHandler) Login(c *gin.Context) {
+	log := logger.FromContext(c.Request.Context(), h.log)
+
 	var req dto.LoginRequest
 
 	if err := c.ShouldBindJSON(&req); err != nil {
-		h.log.Warnf("invalid login input: %v", err)
+		log.Warnf("invalid login input: %v", err)
 		dto.BadRequest(c, "invalid email or password format")
 		return
 	}
@@ -138,12 +148,12 @@ func (h *AuthHandler) Login(c *gin.Context) {
 	token, err := h.service.LoginUser(c.Request.Context(), req.Email, req.Password)
 	if err != nil {
 		if errors.Is(err, entity.ErrInvalidCredentials) {
-			h.log.Infof("login failed: invalid credentials for email=%s", req.Email)
+			log.Infof("login failed: invalid credentials for email=%s", req.Email)
 			dto.Unauthorized(c, "invalid email or password")
 			return
 		}
 
-		h.log.Errorf("login error: %v", err)
+		log.Errorf("login error: %v", err)
 		dto.InternalError(c, "login failed due to internal error")
 		return
 	}
@@ -0,0 +1,150 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+
+	"github.com/senyabanana/pvz-service/internal/dto"
+	"github.com/senyabanana/pvz-service/internal/entity"
+	"github.com/senyabanana/pvz-service/internal/infrastructure/logger"
+	"github.com/senyabanana/pvz-service/internal/service"
+)
+
+type CapacityHandler struct {
+	service service.CapacityOperations
+	log     *logrus.Logger
+}
+
+func NewCapacityHandler(service service.CapacityOperations, log *logrus.Logger) *CapacityHandler {
+	return &CapacityHandler{
+		service: service,
+		log:     log,
+	}
+}
+
+// GetPVZCapacity godoc
+// @Summary Get PVZ capacity
+// @Tags capacity
+// @Description Вместимость ПВЗ и текущая загрузка: товары, принятые и ещё не выданные
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Produce json
+// @Param pvzId path string true "PVZ ID"
+// @Success 200 {object} dto.CapacityResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /pvz/{pvzId}/capacity [get]
+func (h *CapacityHandler) GetPVZCapacity(c *gin.Context) {
+	log := logger.FromContext(c.Request.Context(), h.log)
+
+	pvzID, ok := parsePVZParam(c, log)
+	if !ok {
+		return
+	}
+
+	occupancy, err := h.service.GetPVZOccupancy(c.Request.Context(), pvzID)
+	if err != nil {
+		if errors.Is(err, entity.ErrPVZNotFound) {
+			dto.NotFound(c, "pvz not found")
+			return
+		}
+
+		dto.InternalError(c, "failed to get pvz capacity")
+		return
+	}
+
+	c.JSON(http.StatusOK, toCapacityResponse(occupancy))
+}
+
+// SetPVZCapacity godoc
+// @Summary Set PVZ capacity
+// @Tags capacity
+// @Description Установка вместимости ПВЗ: общей и по типам товаров. Не указанный лимит означает отсутствие ограничения
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Accept json
+// @Produce json
+// @Param pvzId path string true "PVZ ID"
+// @Param request body dto.CapacityRequest true "Вместимость"
+// @Success 200 {object} dto.CapacityResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /pvz/{pvzId}/capacity [put]
+func (h *CapacityHandler) SetPVZCapacity(c *gin.Context) {
+	log := logger.FromContext(c.Request.Context(), h.log)
+
+	pvzID, ok := parsePVZParam(c, log)
+	if !ok {
+		return
+	}
+
+	var req dto.CapacityRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Warnf("invalid capacity input: %v", err)
+		dto.BadRequest(c, "invalid request body")
+		return
+	}
+
+	capacity := entity.PVZCapacity{
+		PVZID:  pvzID,
+		Total:  req.Total,
+		ByType: make(map[entity.ProductType]int, len(req.ByType)),
+	}
+	for productType, limit := range req.ByType {
+		capacity.ByType[entity.ProductType(productType)] = limit
+	}
+
+	occupancy, err := h.service.SetPVZCapacity(c.Request.Context(), capacity)
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrInvalidCapacity):
+			dto.BadRequest(c, err.Error())
+		case errors.Is(err, entity.ErrPVZNotFound):
+			dto.NotFound(c, "pvz not found")
+		default:
+			dto.InternalError(c, "failed to set pvz capacity")
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, toCapacityResponse(occupancy))
+}
+
+func toCapacityResponse(occupancy *entity.PVZOccupancy) dto.CapacityResponse {
+	resp := dto.CapacityResponse{
+		PVZID: occupancy.Capacity.PVZID.String(),
+		Total: capacityUsage("", occupancy.Capacity.Total, occupancy.TotalOccupied()),
+	}
+
+	for _, productType := range entity.ProductTypes() {
+		var limit *int
+		if value, ok := occupancy.Capacity.ByType[productType]; ok {
+			limit = &value
+		}
+		resp.ByType = append(resp.ByType, capacityUsage(productType, limit, occupancy.Occupied[productType]))
+	}
+
+	return resp
+}
+
+func capacityUsage(productType entity.ProductType, limit *int, occupied int) dto.CapacityUsage {
+	usage := dto.CapacityUsage{
+		Type:     string(productType),
+		Capacity: limit,
+		Occupied: occupied,
+	}
+
+	if limit != nil {
+		available := max(*limit-occupied, 0)
+		usage.Available = &available
+	}
+
+	return usage
+}
@@ -0,0 +1,179 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/senyabanana/pvz-service/internal/dto"
+	"github.com/senyabanana/pvz-service/internal/entity"
+	mocks "github.com/senyabanana/pvz-service/internal/service/mocks"
+)
+
+func TestCapacityHandler_GetPVZCapacity(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockCapacityOperations(ctrl)
+	mockLog := logrus.New()
+	h := NewCapacityHandler(mockService, mockLog)
+	gin.SetMode(gin.TestMode)
+
+	pvzID := uuid.New()
+	total := 10
+
+	tests := []struct {
+		name       string
+		param      string
+		mock       func()
+		wantStatus int
+		check      func(t *testing.T, body []byte)
+	}{
+		{
+			name:  "success",
+			param: pvzID.String(),
+			mock: func() {
+				mockService.EXPECT().GetPVZOccupancy(gomock.Any(), pvzID).Return(&entity.PVZOccupancy{
+					Capacity: entity.PVZCapacity{
+						PVZID:  pvzID,
+						Total:  &total,
+						ByType: map[entity.ProductType]int{entity.ProductShoes: 3},
+					},
+					Occupied: map[entity.ProductType]int{entity.ProductShoes: 4, entity.ProductClothing: 2},
+				}, nil)
+			},
+			wantStatus: http.StatusOK,
+			check: func(t *testing.T, body []byte) {
+				var resp dto.CapacityResponse
+				assert.NoError(t, json.Unmarshal(body, &resp))
+				assert.Equal(t, 6, resp.Total.Occupied)
+				assert.Equal(t, 4, *resp.Total.Available)
+				assert.Len(t, resp.ByType, 3)
+				for _, usage := range resp.ByType {
+					switch entity.ProductType(usage.Type) {
+					case entity.ProductShoes:
+						assert.Equal(t, 0, *usage.Available)
+					case entity.ProductElectronics:
+						assert.Nil(t, usage.Capacity)
+					}
+				}
+			},
+		},
+		{
+			name:       "invalid pvzId",
+			param:      "abc",
+			mock:       func() {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:  "pvz not found",
+			param: pvzID.String(),
+			mock: func() {
+				mockService.EXPECT().GetPVZOccupancy(gomock.Any(), pvzID).Return(nil, entity.ErrPVZNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:  "internal error",
+			param: pvzID.String(),
+			mock: func() {
+				mockService.EXPECT().GetPVZOccupancy(gomock.Any(), pvzID).Return(nil, errors.New("db down"))
+			},
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest(http.MethodGet, "/pvz/"+tt.param+"/capacity", nil)
+			c.Params = []gin.Param{{Key: "pvzId", Value: tt.param}}
+
+			tt.mock()
+			h.GetPVZCapacity(c)
+			assert.Equal(t, tt.wantStatus, w.Code)
+			if tt.check != nil {
+				tt.check(t, w.Body.Bytes())
+			}
+		})
+	}
+}
+
+func TestCapacityHandler_SetPVZCapacity(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockCapacityOperations(ctrl)
+	mockLog := logrus.New()
+	h := NewCapacityHandler(mockService, mockLog)
+	gin.SetMode(gin.TestMode)
+
+	pvzID := uuid.New()
+
+	tests := []struct {
+		name       string
+		inputBody  string
+		mock       func()
+		wantStatus int
+	}{
+		{
+			name:      "success",
+			inputBody: `{"total":100,"byType":{"электроника":20}}`,
+			mock: func() {
+				mockService.EXPECT().SetPVZCapacity(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ interface{}, capacity entity.PVZCapacity) (*entity.PVZOccupancy, error) {
+						assert.Equal(t, 100, *capacity.Total)
+						assert.Equal(t, 20, capacity.ByType[entity.ProductElectronics])
+						return &entity.PVZOccupancy{Capacity: capacity}, nil
+					})
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "negative total",
+			inputBody:  `{"total":-1}`,
+			mock:       func() {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:      "unknown type",
+			inputBody: `{"byType":{"мебель":5}}`,
+			mock: func() {
+				mockService.EXPECT().SetPVZCapacity(gomock.Any(), gomock.Any()).Return(nil, entity.ErrInvalidCapacity)
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:      "pvz not found",
+			inputBody: `{"total":10}`,
+			mock: func() {
+				mockService.EXPECT().SetPVZCapacity(gomock.Any(), gomock.Any()).Return(nil, entity.ErrPVZNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest(http.MethodPut, "/pvz/"+pvzID.String()+"/capacity", bytes.NewBufferString(tt.inputBody))
+			c.Request.Header.Set("Content-Type", "application/json")
+			c.Params = []gin.Param{{Key: "pvzId", Value: pvzID.String()}}
+
+			tt.mock()
+			h.SetPVZCapacity(c)
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
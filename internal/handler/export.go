@@ -0,0 +1,152 @@
+package handler
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+
+	"github.com/senyabanana/pvz-service/internal/dto"
+	"github.com/senyabanana/pvz-service/internal/entity"
+	"github.com/senyabanana/pvz-service/internal/infrastructure/export"
+	"github.com/senyabanana/pvz-service/internal/infrastructure/logger"
+	"github.com/senyabanana/pvz-service/internal/middleware"
+	"github.com/senyabanana/pvz-service/internal/service"
+)
+
+type ExportHandler struct {
+	service service.ExportOperations
+	log     *logrus.Logger
+}
+
+func NewExportHandler(service service.ExportOperations, log *logrus.Logger) *ExportHandler {
+	return &ExportHandler{
+		service: service,
+		log:     log,
+	}
+}
+
+// ExportPVZ godoc
+// @Summary Export PVZ
+// @Tags export
+// @Description Выгрузка ПВЗ с числом приёмок и товаров за период в CSV или XLSX
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Produce text/csv
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param format query string false "Формат: csv или xlsx (по умолчанию csv)"
+// @Param startDate query string false "Фильтрация по дате начала (RFC3339)"
+// @Param endDate query string false "Фильтрация по дате окончания (RFC3339)"
+// @Param includeClosed query bool false "Включить закрытые ПВЗ (по умолчанию false)"
+// @Success 200 {file} file
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /export/pvz [get]
+func (h *ExportHandler) ExportPVZ(c *gin.Context) {
+	h.export(c, entity.ExportPVZ)
+}
+
+// ExportReceptions godoc
+// @Summary Export receptions
+// @Tags export
+// @Description Выгрузка приёмок с числом товаров в CSV или XLSX
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Produce text/csv
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param format query string false "Формат: csv или xlsx (по умолчанию csv)"
+// @Param startDate query string false "Фильтрация по дате начала (RFC3339)"
+// @Param endDate query string false "Фильтрация по дате окончания (RFC3339)"
+// @Param includeClosed query bool false "Включить закрытые ПВЗ (по умолчанию false)"
+// @Success 200 {file} file
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /export/receptions [get]
+func (h *ExportHandler) ExportReceptions(c *gin.Context) {
+	h.export(c, entity.ExportReceptions)
+}
+
+// ExportProducts godoc
+// @Summary Export products
+// @Tags export
+// @Description Выгрузка товаров из приёмок за период в CSV или XLSX
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Produce text/csv
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param format query string false "Формат: csv или xlsx (по умолчанию csv)"
+// @Param startDate query string false "Фильтрация по дате начала (RFC3339)"
+// @Param endDate query string false "Фильтрация по дате окончания (RFC3339)"
+// @Param includeClosed query bool false "Включить закрытые ПВЗ (по умолчанию false)"
+// @Success 200 {file} file
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /export/products [get]
+func (h *ExportHandler) ExportProducts(c *gin.Context) {
+	h.export(c, entity.ExportProducts)
+}
+
+// export streams the dataset into the response. Once the first bytes are sent the status can no longer
+// change, so a failure mid-stream only aborts the request and leaves a truncated file.
+func (h *ExportHandler) export(c *gin.Context, dataset entity.ExportDataset) {
+	log := logger.FromContext(c.Request.Context(), h.log)
+
+	var query dto.ExportQueryParams
+	if err := c.ShouldBindQuery(&query); err != nil {
+		log.Warnf("invalid export query: %v", err)
+		dto.BadRequest(c, "invalid query parameters")
+		return
+	}
+
+	startDate := parseQueryTime(query.StartDate, "startDate", c, log)
+	if c.IsAborted() {
+		return
+	}
+	endDate := parseQueryTime(query.EndDate, "endDate", c, log)
+	if c.IsAborted() {
+		return
+	}
+
+	filter := entity.ExportFilter{
+		StartDate:     startDate,
+		EndDate:       endDate,
+		IncludeClosed: query.IncludeClosed,
+	}
+	if pvzID, ok := middleware.RestrictedPVZ(c); ok {
+		filter.PVZID = &pvzID
+	}
+
+	format := entity.ExportFormat(query.Format)
+	if format == "" {
+		format = entity.ExportFormatCSV
+	}
+
+	w, contentType, err := export.NewWriter(format, c.Writer, string(dataset))
+	if err != nil {
+		log.Errorf("failed to create %s writer: %v", format, err)
+		dto.InternalError(c, "failed to export")
+		return
+	}
+	defer w.Discard()
+
+	filename := fmt.Sprintf("%s_%s.%s", dataset, time.Now().Format("20060102_150405"), format)
+	c.Header("Content-Type", contentType)
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+
+	err = h.service.Export(c.Request.Context(), dataset, filter, w)
+	if err == nil {
+		err = w.Close()
+	}
+	if err != nil {
+		if c.Writer.Written() {
+			log.Errorf("export of %s interrupted: %v", dataset, err)
+			c.Abort()
+			return
+		}
+
+		c.Writer.Header().Del("Content-Disposition")
+		c.Writer.Header().Del("Content-Type")
+		dto.InternalError(c, "failed to export")
+	}
+}
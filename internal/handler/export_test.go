@@ -0,0 +1,151 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/xuri/excelize/v2"
+
+	"github.com/senyabanana/pvz-service/internal/entity"
+	"github.com/senyabanana/pvz-service/internal/service"
+	mocks "github.com/senyabanana/pvz-service/internal/service/mocks"
+)
+
+func TestExportHandler_ExportReceptions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockExportOperations(ctrl)
+	mockLog := logrus.New()
+	h := NewExportHandler(mockService, mockLog)
+	gin.SetMode(gin.TestMode)
+
+	restrictedPVZ := uuid.New()
+	writeRows := func(_ context.Context, _ entity.ExportDataset, _ entity.ExportFilter, w service.RowWriter) error {
+		if err := w.WriteRow([]interface{}{"id", "products"}); err != nil {
+			return err
+		}
+		return w.WriteRow([]interface{}{"r1", 3})
+	}
+
+	tests := []struct {
+		name            string
+		query           string
+		restricted      *uuid.UUID
+		mock            func()
+		wantStatus      int
+		wantContentType string
+		check           func(t *testing.T, body []byte)
+	}{
+		{
+			name:  "csv by default",
+			query: "?startDate=2025-04-01T00:00:00Z&includeClosed=true",
+			mock: func() {
+				mockService.EXPECT().Export(gomock.Any(), entity.ExportReceptions, gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, d entity.ExportDataset, f entity.ExportFilter, w service.RowWriter) error {
+						assert.NotNil(t, f.StartDate)
+						assert.Nil(t, f.EndDate)
+						assert.True(t, f.IncludeClosed)
+						return writeRows(ctx, d, f, w)
+					})
+			},
+			wantStatus:      http.StatusOK,
+			wantContentType: "text/csv; charset=utf-8",
+			check: func(t *testing.T, body []byte) {
+				assert.Equal(t, "id,products\nr1,3\n", string(body))
+			},
+		},
+		{
+			name:  "xlsx",
+			query: "?format=xlsx",
+			mock: func() {
+				mockService.EXPECT().Export(gomock.Any(), entity.ExportReceptions, gomock.Any(), gomock.Any()).DoAndReturn(writeRows)
+			},
+			wantStatus:      http.StatusOK,
+			wantContentType: "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+			check: func(t *testing.T, body []byte) {
+				file, err := excelize.OpenReader(bytes.NewReader(body))
+				assert.NoError(t, err)
+				rows, err := file.GetRows("receptions")
+				assert.NoError(t, err)
+				assert.Equal(t, [][]string{{"id", "products"}, {"r1", "3"}}, rows)
+			},
+		},
+		{
+			name:       "api key limited to its pvz",
+			restricted: &restrictedPVZ,
+			mock: func() {
+				mockService.EXPECT().Export(gomock.Any(), entity.ExportReceptions, entity.ExportFilter{PVZID: &restrictedPVZ}, gomock.Any()).
+					Return(nil)
+			},
+			wantStatus:      http.StatusOK,
+			wantContentType: "text/csv; charset=utf-8",
+		},
+		{
+			name:       "unknown format",
+			query:      "?format=pdf",
+			mock:       func() {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "invalid date",
+			query:      "?endDate=yesterday",
+			mock:       func() {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:  "error before streaming",
+			query: "?format=csv",
+			mock: func() {
+				mockService.EXPECT().Export(gomock.Any(), entity.ExportReceptions, gomock.Any(), gomock.Any()).Return(errors.New("db down"))
+			},
+			wantStatus:      http.StatusInternalServerError,
+			wantContentType: "application/json; charset=utf-8",
+		},
+		{
+			name:  "xlsx error after rows",
+			query: "?format=xlsx",
+			mock: func() {
+				mockService.EXPECT().Export(gomock.Any(), entity.ExportReceptions, gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, dataset entity.ExportDataset, filter entity.ExportFilter, w service.RowWriter) error {
+						if err := writeRows(ctx, dataset, filter, w); err != nil {
+							return err
+						}
+						return errors.New("db down")
+					})
+			},
+			wantStatus:      http.StatusInternalServerError,
+			wantContentType: "application/json; charset=utf-8",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest(http.MethodGet, "/export/receptions"+tt.query, nil)
+			if tt.restricted != nil {
+				c.Set("restricted_pvz_id", *tt.restricted)
+			}
+
+			tt.mock()
+			h.ExportReceptions(c)
+			assert.Equal(t, tt.wantStatus, w.Code)
+			if tt.wantContentType != "" {
+				assert.Equal(t, tt.wantContentType, w.Header().Get("Content-Type"))
+			}
+			if tt.check != nil {
+				tt.check(t, w.Body.Bytes())
+			}
+		})
+	}
+}
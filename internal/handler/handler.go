@@ -13,9 +13,35 @@ type Authorization interface {
 	Login(c *gin.Context)
 }
 
+type OIDCAuthorization interface {
+	OIDCLogin(c *gin.Context)
+	OIDCCallback(c *gin.Context)
+}
+
+type PasswordOperations interface {
+	ChangePassword(c *gin.Context)
+	RequestPasswordReset(c *gin.Context)
+	ResetPassword(c *gin.Context)
+}
+
+type AccountOperations interface {
+	GetMe(c *gin.Context)
+	AssignUserPVZ(c *gin.Context)
+	IntrospectToken(c *gin.Context)
+}
+
 type PVZOperations interface {
 	CreatePVZ(c *gin.Context)
 	GetFullInfoPVZ(c *gin.Context)
+	UpdatePVZ(c *gin.Context)
+	GetNearbyPVZ(c *gin.Context)
+	ChangePVZStatus(c *gin.Context)
+	ClosePVZ(c *gin.Context)
+}
+
+type CapacityOperations interface {
+	GetPVZCapacity(c *gin.Context)
+	SetPVZCapacity(c *gin.Context)
 }
 
 type ReceptionOperations interface {
@@ -26,20 +52,126 @@ type ReceptionOperations interface {
 type ProductOperations interface {
 	AddProduct(c *gin.Context)
 	DeleteLastProduct(c *gin.Context)
+	GetProductHistory(c *gin.Context)
+}
+
+type IssuanceOperations interface {
+	AssignProductOwner(c *gin.Context)
+	IssueProduct(c *gin.Context)
+	GetMyParcels(c *gin.Context)
+	RenewPickupCode(c *gin.Context)
+}
+
+type ReturnOperations interface {
+	AcceptReturn(c *gin.Context)
+	ChangeReturnStatus(c *gin.Context)
+}
+
+type StorageOperations interface {
+	GetOverdueProducts(c *gin.Context)
+	ShipOverdueProducts(c *gin.Context)
+}
+
+type TransferOperations interface {
+	CreateTransfer(c *gin.Context)
+	ReceiveTransfer(c *gin.Context)
+	GetProductCustody(c *gin.Context)
+}
+
+type StocktakeOperations interface {
+	StartStocktake(c *gin.Context)
+	ScanProducts(c *gin.Context)
+	CompleteStocktake(c *gin.Context)
+	GetStocktake(c *gin.Context)
+}
+
+type AnalyticsOperations interface {
+	GetReceptionThroughput(c *gin.Context)
+	GetProductMix(c *gin.Context)
+}
+
+type ExportOperations interface {
+	ExportPVZ(c *gin.Context)
+	ExportReceptions(c *gin.Context)
+	ExportProducts(c *gin.Context)
+}
+
+type ImportOperations interface {
+	ImportData(c *gin.Context)
+}
+
+type ReportJobOperations interface {
+	CreateReportJob(c *gin.Context)
+	GetReportJob(c *gin.Context)
+	GetReportArtifact(c *gin.Context)
+}
+
+type VersionOperations interface {
+	GetVersion(c *gin.Context)
+}
+
+type HealthOperations interface {
+	Healthz(c *gin.Context)
+	Readyz(c *gin.Context)
+}
+
+type LogLevelOperations interface {
+	GetLogLevel(c *gin.Context)
+	SetLogLevel(c *gin.Context)
+}
+
+type APIKeyOperations interface {
+	CreateAPIKey(c *gin.Context)
+	GetAllAPIKeys(c *gin.Context)
+	RevokeAPIKey(c *gin.Context)
 }
 
 type Handler struct {
 	Authorization
+	OIDCAuthorization
+	PasswordOperations
+	AccountOperations
 	PVZOperations
+	CapacityOperations
 	ReceptionOperations
 	ProductOperations
+	IssuanceOperations
+	ReturnOperations
+	StorageOperations
+	TransferOperations
+	StocktakeOperations
+	AnalyticsOperations
+	ExportOperations
+	ImportOperations
+	ReportJobOperations
+	VersionOperations
+	HealthOperations
+	LogLevelOperations
+	APIKeyOperations
 }
 
 func NewHandler(services *service.Service, secretKey string, log *logrus.Logger) *Handler {
 	return &Handler{
 		Authorization:       NewAuthHandler(services, secretKey, log),
+		OIDCAuthorization:   NewOIDCHandler(services, log),
+		PasswordOperations:  NewPasswordHandler(services, log),
+		AccountOperations:   NewAccountHandler(services, log),
 		PVZOperations:       NewPVZHandler(services, log),
+		CapacityOperations:  NewCapacityHandler(services, log),
 		ReceptionOperations: NewReceptionHandler(services, log),
 		ProductOperations:   NewProductHandler(services, log),
+		IssuanceOperations:  NewIssuanceHandler(services, log),
+		ReturnOperations:    NewReturnHandler(services, log),
+		StorageOperations:   NewStorageHandler(services, log),
+		TransferOperations:  NewTransferHandler(services, log),
+		StocktakeOperations: NewStocktakeHandler(services, log),
+		AnalyticsOperations: NewAnalyticsHandler(services, log),
+		ExportOperations:    NewExportHandler(services, log),
+		ImportOperations:    NewImportHandler(services, log),
+		ReportJobOperations: NewReportJobHandler(services, log),
+		VersionOperations:   NewVersionHandler(services, log),
+		HealthOperations:    NewHealthHandler(services, log),
+		LogLevelOperations:  NewLogLevelHandler(services, log),
+		APIKeyOperations:    NewAPIKeyHandler(services, log),
 	}
 }
@@ -0,0 +1,63 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+
+	"github.com/senyabanana/pvz-service/internal/dto"
+	"github.com/senyabanana/pvz-service/internal/entity"
+	"github.com/senyabanana/pvz-service/internal/service"
+)
+
+type HealthHandler struct {
+	service service.HealthOperations
+	log     *logrus.Logger
+}
+
+func NewHealthHandler(service service.HealthOperations, log *logrus.Logger) *HealthHandler {
+	return &HealthHandler{
+		service: service,
+		log:     log,
+	}
+}
+
+// Healthz godoc
+// @Summary Liveness probe
+// @Tags system
+// @Description Процесс жив и обрабатывает запросы. Зависимости не проверяются
+// @Produce json
+// @Success 200 {object} dto.HealthResponse
+// @Router /healthz [get]
+func (h *HealthHandler) Healthz(c *gin.Context) {
+	c.JSON(http.StatusOK, dto.HealthResponse{Status: string(entity.HealthStatusUp)})
+}
+
+// Readyz godoc
+// @Summary Readiness probe
+// @Tags system
+// @Description Проверяет доступность БД и соответствие версии схемы встроенным миграциям.
+// @Description Статус unknown у проверки не делает сервис неготовым
+// @Produce json
+// @Success 200 {object} dto.ReadinessResponse
+// @Failure 503 {object} dto.ReadinessResponse
+// @Router /readyz [get]
+func (h *HealthHandler) Readyz(c *gin.Context) {
+	readiness := h.service.CheckReadiness(c.Request.Context())
+
+	resp := dto.ReadinessResponse{
+		Status: string(readiness.Status),
+		Checks: make(map[string]dto.HealthCheckResponse, len(readiness.Checks)),
+	}
+	for name, check := range readiness.Checks {
+		resp.Checks[name] = dto.HealthCheckResponse{Status: string(check.Status), Message: check.Message}
+	}
+
+	status := http.StatusOK
+	if !readiness.IsReady() {
+		status = http.StatusServiceUnavailable
+	}
+
+	c.JSON(status, resp)
+}
@@ -0,0 +1,88 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/senyabanana/pvz-service/internal/entity"
+	mocks "github.com/senyabanana/pvz-service/internal/service/mocks"
+)
+
+func TestHealthHandler_Healthz(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	h := NewHealthHandler(mocks.NewMockHealthOperations(ctrl), logrus.New())
+	gin.SetMode(gin.TestMode)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/healthz", nil)
+
+	h.Healthz(c)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"status":"up"}`, w.Body.String())
+}
+
+func TestHealthHandler_Readyz(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockHealthOperations(ctrl)
+	mockLog := logrus.New()
+	h := NewHealthHandler(mockService, mockLog)
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name       string
+		readiness  entity.Readiness
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name: "ready",
+			readiness: entity.Readiness{
+				Status: entity.HealthStatusUp,
+				Checks: map[string]entity.HealthCheck{
+					entity.HealthCheckDatabase:   {Status: entity.HealthStatusUp},
+					entity.HealthCheckMigrations: {Status: entity.HealthStatusUnknown, Message: "schema is not managed by migrate"},
+				},
+			},
+			wantStatus: http.StatusOK,
+			wantBody: `{"status":"up","checks":{"database":{"status":"up"},` +
+				`"migrations":{"status":"unknown","message":"schema is not managed by migrate"}}}`,
+		},
+		{
+			name: "database down",
+			readiness: entity.Readiness{
+				Status: entity.HealthStatusDown,
+				Checks: map[string]entity.HealthCheck{
+					entity.HealthCheckDatabase:   {Status: entity.HealthStatusDown, Message: "connection refused"},
+					entity.HealthCheckMigrations: {Status: entity.HealthStatusDown, Message: "connection refused"},
+				},
+			},
+			wantStatus: http.StatusServiceUnavailable,
+			wantBody: `{"status":"down","checks":{"database":{"status":"down","message":"connection refused"},` +
+				`"migrations":{"status":"down","message":"connection refused"}}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest(http.MethodGet, "/readyz", nil)
+
+			mockService.EXPECT().CheckReadiness(gomock.Any()).Return(tt.readiness)
+			h.Readyz(c)
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.JSONEq(t, tt.wantBody, w.Body.String())
+		})
+	}
+}
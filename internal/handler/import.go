@@ -0,0 +1,102 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+
+	"github.com/senyabanana/pvz-service/internal/dto"
+	"github.com/senyabanana/pvz-service/internal/entity"
+	"github.com/senyabanana/pvz-service/internal/infrastructure/logger"
+	"github.com/senyabanana/pvz-service/internal/service"
+)
+
+const maxImportFileSize = 32 << 20
+
+type ImportHandler struct {
+	service service.ImportOperations
+	log     *logrus.Logger
+}
+
+func NewImportHandler(service service.ImportOperations, log *logrus.Logger) *ImportHandler {
+	return &ImportHandler{
+		service: service,
+		log:     log,
+	}
+}
+
+// ImportData godoc
+// @Summary Import PVZ and receptions
+// @Tags import
+// @Description Массовая загрузка ПВЗ с историческими приёмками из CSV или JSON. Файл передаётся телом запроса
+// @Description и загружается целиком в одной транзакции; при ошибках в строках не загружается ничего.
+// @Description В режиме dryRun файл только проверяется
+// @Security BearerAuth
+// @Accept text/csv
+// @Accept json
+// @Produce json
+// @Param format query string false "Формат: csv или json (по умолчанию определяется по Content-Type)"
+// @Param dryRun query bool false "Только проверить файл"
+// @Success 200 {object} dto.ImportReportResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 422 {object} dto.ImportReportResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /import [post]
+func (h *ImportHandler) ImportData(c *gin.Context) {
+	log := logger.FromContext(c.Request.Context(), h.log)
+
+	var query dto.ImportQueryParams
+	if err := c.ShouldBindQuery(&query); err != nil {
+		log.Warnf("invalid import query: %v", err)
+		dto.BadRequest(c, "invalid query parameters")
+		return
+	}
+
+	format := entity.ImportFormat(query.Format)
+	if format == "" {
+		format = entity.ImportFormatCSV
+		if strings.HasPrefix(c.ContentType(), "application/json") {
+			format = entity.ImportFormatJSON
+		}
+	}
+
+	body := http.MaxBytesReader(c.Writer, c.Request.Body, maxImportFileSize)
+	report, err := h.service.ImportData(c.Request.Context(), format, body, query.DryRun)
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		switch {
+		case errors.Is(err, entity.ErrImportRejected):
+			c.JSON(http.StatusUnprocessableEntity, toImportReportResponse(report))
+		case errors.As(err, &tooLarge):
+			dto.BadRequest(c, "import file is too large")
+		case errors.Is(err, entity.ErrInvalidImportFile):
+			dto.BadRequest(c, err.Error())
+		default:
+			dto.InternalError(c, "failed to import data")
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, toImportReportResponse(report))
+}
+
+func toImportReportResponse(report *entity.ImportReport) dto.ImportReportResponse {
+	resp := dto.ImportReportResponse{
+		DryRun:     report.DryRun,
+		PVZ:        report.PVZ,
+		Receptions: report.Receptions,
+		Products:   report.Products,
+	}
+	for _, rowErr := range report.Errors {
+		resp.Errors = append(resp.Errors, dto.ImportRowErrorResponse{
+			Row:     rowErr.Row,
+			Field:   rowErr.Field,
+			Message: rowErr.Message,
+		})
+	}
+
+	return resp
+}
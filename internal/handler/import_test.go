@@ -0,0 +1,100 @@
+package handler
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/senyabanana/pvz-service/internal/entity"
+	mocks "github.com/senyabanana/pvz-service/internal/service/mocks"
+)
+
+func TestImportHandler_ImportData(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockImportOperations(ctrl)
+	mockLog := logrus.New()
+	h := NewImportHandler(mockService, mockLog)
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name        string
+		query       string
+		contentType string
+		mock        func()
+		wantStatus  int
+	}{
+		{
+			name:        "csv import",
+			contentType: "text/csv",
+			mock: func() {
+				mockService.EXPECT().ImportData(gomock.Any(), entity.ImportFormatCSV, gomock.Any(), false).
+					Return(&entity.ImportReport{PVZ: 2, Receptions: 3, Products: 10}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:        "json dry run detected by content type",
+			query:       "?dryRun=true",
+			contentType: "application/json",
+			mock: func() {
+				mockService.EXPECT().ImportData(gomock.Any(), entity.ImportFormatJSON, gomock.Any(), true).
+					Return(&entity.ImportReport{DryRun: true, Errors: []entity.ImportRowError{{Row: 1, Message: "bad"}}}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:  "rejected rows",
+			query: "?format=json",
+			mock: func() {
+				mockService.EXPECT().ImportData(gomock.Any(), entity.ImportFormatJSON, gomock.Any(), false).
+					Return(&entity.ImportReport{Errors: []entity.ImportRowError{{Row: 1, Message: "bad"}}}, entity.ErrImportRejected)
+			},
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "malformed file",
+			mock: func() {
+				mockService.EXPECT().ImportData(gomock.Any(), entity.ImportFormatCSV, gomock.Any(), false).
+					Return(nil, entity.ErrInvalidImportFile)
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "unsupported format",
+			query:      "?format=xml",
+			mock:       func() {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "internal error",
+			mock: func() {
+				mockService.EXPECT().ImportData(gomock.Any(), gomock.Any(), gomock.Any(), false).Return(nil, errors.New("db down"))
+			},
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest(http.MethodPost, "/import"+tt.query, bytes.NewBufferString("pvz_ref,city\n"))
+			if tt.contentType != "" {
+				c.Request.Header.Set("Content-Type", tt.contentType)
+			}
+
+			tt.mock()
+			h.ImportData(c)
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
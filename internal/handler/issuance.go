@@ -0,0 +1,283 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+
+	"github.com/senyabanana/pvz-service/internal/dto"
+	"github.com/senyabanana/pvz-service/internal/entity"
+	"github.com/senyabanana/pvz-service/internal/infrastructure/logger"
+	"github.com/senyabanana/pvz-service/internal/middleware"
+	"github.com/senyabanana/pvz-service/internal/service"
+)
+
+type IssuanceHandler struct {
+	service service.IssuanceOperations
+	log     *logrus.Logger
+}
+
+func NewIssuanceHandler(service service.IssuanceOperations, log *logrus.Logger) *IssuanceHandler {
+	return &IssuanceHandler{
+		service: service,
+		log:     log,
+	}
+}
+
+// AssignProductOwner godoc
+// @Summary Assign product owner
+// @Tags issuance
+// @Description Закрепление принятого товара за клиентом. Возвращает новый код получения; прежний код перестаёт действовать
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Accept json
+// @Produce json
+// @Param pvzId path string true "PVZ ID"
+// @Param productId path string true "Product ID"
+// @Param request body dto.AssignOwnerRequest true "Получатель"
+// @Success 200 {object} dto.AssignOwnerResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /pvz/{pvzId}/products/{productId}/owner [post]
+func (h *IssuanceHandler) AssignProductOwner(c *gin.Context) {
+	log := logger.FromContext(c.Request.Context(), h.log)
+
+	pvzID, ok := parsePVZParam(c, log)
+	if !ok {
+		return
+	}
+
+	productID, err := uuid.Parse(c.Param("productId"))
+	if err != nil {
+		log.Warnf("invalid productId: %v", err)
+		dto.BadRequest(c, "invalid productId")
+		return
+	}
+
+	var req dto.AssignOwnerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Warnf("invalid assign owner input: %v", err)
+		dto.BadRequest(c, "ownerId is required")
+		return
+	}
+	ownerID := uuid.MustParse(req.OwnerID)
+
+	code, err := h.service.AssignProductOwner(c.Request.Context(), pvzID, productID, ownerID)
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrProductNotFound):
+			dto.NotFound(c, "product not found")
+		case errors.Is(err, entity.ErrUserNotFound):
+			dto.NotFound(c, "owner not found")
+		case errors.Is(err, entity.ErrOwnerNotClient),
+			errors.Is(err, entity.ErrProductAlreadyIssued),
+			errors.Is(err, entity.ErrProductOverdue),
+			errors.Is(err, entity.ErrProductInTransit):
+			dto.BadRequest(c, err.Error())
+		default:
+			dto.InternalError(c, "failed to assign product owner")
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.AssignOwnerResponse{
+		ProductID:  productID.String(),
+		OwnerID:    ownerID.String(),
+		PickupCode: code,
+	})
+}
+
+// IssueProduct godoc
+// @Summary Issue product to client
+// @Tags issuance
+// @Description Выдача товара клиенту по коду получения. Товар должен быть из закрытой приёмки этого ПВЗ
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Accept json
+// @Produce json
+// @Param pvzId path string true "PVZ ID"
+// @Param productId path string true "Product ID"
+// @Param request body dto.IssueProductRequest true "Код получения"
+// @Success 200 {object} dto.IssuedProductResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /pvz/{pvzId}/products/{productId}/issue [post]
+func (h *IssuanceHandler) IssueProduct(c *gin.Context) {
+	log := logger.FromContext(c.Request.Context(), h.log)
+
+	pvzID, ok := parsePVZParam(c, log)
+	if !ok {
+		return
+	}
+
+	productID, err := uuid.Parse(c.Param("productId"))
+	if err != nil {
+		log.Warnf("invalid productId: %v", err)
+		dto.BadRequest(c, "invalid productId")
+		return
+	}
+
+	var req dto.IssueProductRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Warnf("invalid issue product input: %v", err)
+		dto.BadRequest(c, "pickupCode must be 6 digits")
+		return
+	}
+
+	var issuedBy *uuid.UUID
+	if employeeID, err := uuid.Parse(middleware.GetUserID(c)); err == nil {
+		issuedBy = &employeeID
+	}
+
+	product, err := h.service.IssueProduct(c.Request.Context(), pvzID, productID, req.PickupCode, issuedBy)
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrProductNotFound):
+			dto.NotFound(c, "product not found")
+		case errors.Is(err, entity.ErrProductNotReady),
+			errors.Is(err, entity.ErrProductHasNoOwner),
+			errors.Is(err, entity.ErrProductAlreadyIssued),
+			errors.Is(err, entity.ErrProductOverdue),
+			errors.Is(err, entity.ErrProductInTransit),
+			errors.Is(err, entity.ErrProductStatusTransition),
+			errors.Is(err, entity.ErrInvalidPickupCode):
+			dto.BadRequest(c, err.Error())
+		case errors.Is(err, entity.ErrPickupCodeLocked):
+			dto.Conflict(c, err.Error())
+		default:
+			dto.InternalError(c, "failed to issue product")
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, toIssuedProductResponse(product))
+}
+
+// GetMyParcels godoc
+// @Summary Get my parcels
+// @Tags issuance
+// @Description Товары текущего клиента, ожидающие выдачи, с адресами ПВЗ. pickupCodeLocked означает, что нужен новый код получения
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {array} dto.ParcelResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /me/parcels [get]
+func (h *IssuanceHandler) GetMyParcels(c *gin.Context) {
+	log := logger.FromContext(c.Request.Context(), h.log)
+
+	ownerID, err := uuid.Parse(middleware.GetUserID(c))
+	if err != nil {
+		log.Warnf("invalid user id in token: %v", err)
+		dto.Unauthorized(c, "invalid token")
+		return
+	}
+
+	parcels, err := h.service.GetMyParcels(c.Request.Context(), ownerID)
+	if err != nil {
+		dto.InternalError(c, "failed to get parcels")
+		return
+	}
+
+	resp := make([]dto.ParcelResponse, 0, len(parcels))
+	for _, parcel := range parcels {
+		resp = append(resp, dto.ParcelResponse{
+			ProductID:        parcel.ProductID.String(),
+			Type:             string(parcel.Type),
+			ReceivedAt:       parcel.ReceivedAt.Format(time.RFC3339),
+			PickupCodeLocked: parcel.PickupCodeLocked(),
+			PVZ: toPVZResponse(entity.PVZ{
+				ID:           parcel.PVZID,
+				City:         parcel.City,
+				Address:      parcel.Address,
+				Phone:        parcel.Phone,
+				WorkingHours: parcel.WorkingHours,
+			}),
+		})
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// RenewPickupCode godoc
+// @Summary Renew pickup code
+// @Tags issuance
+// @Description Новый код получения для товара текущего клиента. Прежний код перестаёт действовать, счётчик неверных попыток сбрасывается
+// @Security BearerAuth
+// @Produce json
+// @Param productId path string true "Product ID"
+// @Success 200 {object} dto.PickupCodeResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /me/parcels/{productId}/pickup-code [post]
+func (h *IssuanceHandler) RenewPickupCode(c *gin.Context) {
+	log := logger.FromContext(c.Request.Context(), h.log)
+
+	ownerID, err := uuid.Parse(middleware.GetUserID(c))
+	if err != nil {
+		log.Warnf("invalid user id in token: %v", err)
+		dto.Unauthorized(c, "invalid token")
+		return
+	}
+
+	productID, err := uuid.Parse(c.Param("productId"))
+	if err != nil {
+		log.Warnf("invalid productId: %v", err)
+		dto.BadRequest(c, "invalid productId")
+		return
+	}
+
+	code, err := h.service.RenewPickupCode(c.Request.Context(), ownerID, productID)
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrProductNotFound):
+			dto.NotFound(c, "product not found")
+		case errors.Is(err, entity.ErrProductAlreadyIssued),
+			errors.Is(err, entity.ErrProductOverdue),
+			errors.Is(err, entity.ErrProductInTransit):
+			dto.BadRequest(c, err.Error())
+		default:
+			dto.InternalError(c, "failed to renew pickup code")
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.PickupCodeResponse{
+		ProductID:  productID.String(),
+		PickupCode: code,
+	})
+}
+
+func toIssuedProductResponse(product *entity.Product) dto.IssuedProductResponse {
+	resp := dto.IssuedProductResponse{
+		ID:          product.ID.String(),
+		Type:        string(product.Type),
+		ReceptionID: product.ReceptionID.String(),
+		Status:      string(product.Status),
+	}
+
+	if product.OwnerID != nil {
+		resp.OwnerID = product.OwnerID.String()
+	}
+	if product.IssuedAt != nil {
+		resp.IssuedAt = product.IssuedAt.Format(time.RFC3339)
+	}
+	if product.IssuedBy != nil {
+		resp.IssuedBy = product.IssuedBy.String()
+	}
+
+	return resp
+}
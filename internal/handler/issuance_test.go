@@ -0,0 +1,348 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/senyabanana/pvz-service/internal/dto"
+	"github.com/senyabanana/pvz-service/internal/entity"
+	mocks "github.com/senyabanana/pvz-service/internal/service/mocks"
+)
+
+func TestIssuanceHandler_AssignProductOwner(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockIssuanceOperations(ctrl)
+	mockLog := logrus.New()
+	h := NewIssuanceHandler(mockService, mockLog)
+	gin.SetMode(gin.TestMode)
+
+	pvzID, productID, ownerID := uuid.New(), uuid.New(), uuid.New()
+
+	tests := []struct {
+		name       string
+		productID  string
+		inputBody  string
+		mock       func()
+		wantStatus int
+	}{
+		{
+			name:      "success",
+			productID: productID.String(),
+			inputBody: `{"ownerId":"` + ownerID.String() + `"}`,
+			mock: func() {
+				mockService.EXPECT().AssignProductOwner(gomock.Any(), pvzID, productID, ownerID).Return("123456", nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "invalid productId",
+			productID:  "abc",
+			inputBody:  `{"ownerId":"` + ownerID.String() + `"}`,
+			mock:       func() {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "missing ownerId",
+			productID:  productID.String(),
+			inputBody:  `{}`,
+			mock:       func() {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:      "product not found",
+			productID: productID.String(),
+			inputBody: `{"ownerId":"` + ownerID.String() + `"}`,
+			mock: func() {
+				mockService.EXPECT().AssignProductOwner(gomock.Any(), pvzID, productID, ownerID).Return("", entity.ErrProductNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:      "owner not client",
+			productID: productID.String(),
+			inputBody: `{"ownerId":"` + ownerID.String() + `"}`,
+			mock: func() {
+				mockService.EXPECT().AssignProductOwner(gomock.Any(), pvzID, productID, ownerID).Return("", entity.ErrOwnerNotClient)
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:      "internal error",
+			productID: productID.String(),
+			inputBody: `{"ownerId":"` + ownerID.String() + `"}`,
+			mock: func() {
+				mockService.EXPECT().AssignProductOwner(gomock.Any(), pvzID, productID, ownerID).Return("", errors.New("db down"))
+			},
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest(http.MethodPost, "/", bytes.NewBufferString(tt.inputBody))
+			c.Request.Header.Set("Content-Type", "application/json")
+			c.Params = []gin.Param{{Key: "pvzId", Value: pvzID.String()}, {Key: "productId", Value: tt.productID}}
+
+			tt.mock()
+			h.AssignProductOwner(c)
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
+
+func TestIssuanceHandler_IssueProduct(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockIssuanceOperations(ctrl)
+	mockLog := logrus.New()
+	h := NewIssuanceHandler(mockService, mockLog)
+	gin.SetMode(gin.TestMode)
+
+	pvzID, productID, ownerID, employeeID := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	issuedAt := time.Now()
+
+	tests := []struct {
+		name       string
+		inputBody  string
+		userID     string
+		mock       func()
+		wantStatus int
+	}{
+		{
+			name:      "success",
+			inputBody: `{"pickupCode":"123456"}`,
+			userID:    employeeID.String(),
+			mock: func() {
+				mockService.EXPECT().IssueProduct(gomock.Any(), pvzID, productID, "123456", &employeeID).Return(&entity.Product{
+					ID: productID, Status: entity.ProductStatusIssued, OwnerID: &ownerID, IssuedAt: &issuedAt, IssuedBy: &employeeID,
+				}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:      "api key caller",
+			inputBody: `{"pickupCode":"123456"}`,
+			mock: func() {
+				mockService.EXPECT().IssueProduct(gomock.Any(), pvzID, productID, "123456", nil).Return(&entity.Product{
+					ID: productID, Status: entity.ProductStatusIssued, OwnerID: &ownerID, IssuedAt: &issuedAt,
+				}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "malformed code",
+			inputBody:  `{"pickupCode":"12ab"}`,
+			mock:       func() {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:      "wrong code",
+			inputBody: `{"pickupCode":"000000"}`,
+			mock: func() {
+				mockService.EXPECT().IssueProduct(gomock.Any(), pvzID, productID, "000000", nil).Return(nil, entity.ErrInvalidPickupCode)
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:      "code locked",
+			inputBody: `{"pickupCode":"000000"}`,
+			mock: func() {
+				mockService.EXPECT().IssueProduct(gomock.Any(), pvzID, productID, "000000", nil).Return(nil, entity.ErrPickupCodeLocked)
+			},
+			wantStatus: http.StatusConflict,
+		},
+		{
+			name:      "product not found",
+			inputBody: `{"pickupCode":"123456"}`,
+			mock: func() {
+				mockService.EXPECT().IssueProduct(gomock.Any(), pvzID, productID, "123456", nil).Return(nil, entity.ErrProductNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest(http.MethodPost, "/", bytes.NewBufferString(tt.inputBody))
+			c.Request.Header.Set("Content-Type", "application/json")
+			c.Params = []gin.Param{{Key: "pvzId", Value: pvzID.String()}, {Key: "productId", Value: productID.String()}}
+			if tt.userID != "" {
+				c.Set("user_id", tt.userID)
+			}
+
+			tt.mock()
+			h.IssueProduct(c)
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
+
+func TestIssuanceHandler_GetMyParcels(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockIssuanceOperations(ctrl)
+	mockLog := logrus.New()
+	h := NewIssuanceHandler(mockService, mockLog)
+	gin.SetMode(gin.TestMode)
+
+	ownerID, pvzID := uuid.New(), uuid.New()
+
+	tests := []struct {
+		name       string
+		userID     string
+		mock       func()
+		wantStatus int
+		check      func(t *testing.T, body []byte)
+	}{
+		{
+			name:   "success",
+			userID: ownerID.String(),
+			mock: func() {
+				mockService.EXPECT().GetMyParcels(gomock.Any(), ownerID).Return([]entity.Parcel{{
+					ProductID: uuid.New(), Type: entity.ProductShoes, ReceivedAt: time.Now(), CodeAttempts: entity.MaxPickupCodeAttempts,
+					PVZID: pvzID, City: entity.CityMoscow, Address: "ул. Тверская, 1",
+				}}, nil)
+			},
+			wantStatus: http.StatusOK,
+			check: func(t *testing.T, body []byte) {
+				var resp []dto.ParcelResponse
+				assert.NoError(t, json.Unmarshal(body, &resp))
+				assert.Len(t, resp, 1)
+				assert.True(t, resp[0].PickupCodeLocked)
+				assert.Equal(t, pvzID.String(), resp[0].PVZ.ID)
+			},
+		},
+		{
+			name:       "no user in token",
+			mock:       func() {},
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:   "internal error",
+			userID: ownerID.String(),
+			mock: func() {
+				mockService.EXPECT().GetMyParcels(gomock.Any(), ownerID).Return(nil, errors.New("db down"))
+			},
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest(http.MethodGet, "/me/parcels", nil)
+			if tt.userID != "" {
+				c.Set("user_id", tt.userID)
+			}
+
+			tt.mock()
+			h.GetMyParcels(c)
+			assert.Equal(t, tt.wantStatus, w.Code)
+			if tt.check != nil {
+				tt.check(t, w.Body.Bytes())
+			}
+		})
+	}
+}
+
+func TestIssuanceHandler_RenewPickupCode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockIssuanceOperations(ctrl)
+	mockLog := logrus.New()
+	h := NewIssuanceHandler(mockService, mockLog)
+	gin.SetMode(gin.TestMode)
+
+	ownerID, productID := uuid.New(), uuid.New()
+
+	tests := []struct {
+		name       string
+		userID     string
+		productID  string
+		mock       func()
+		wantStatus int
+	}{
+		{
+			name:      "success",
+			userID:    ownerID.String(),
+			productID: productID.String(),
+			mock: func() {
+				mockService.EXPECT().RenewPickupCode(gomock.Any(), ownerID, productID).Return("654321", nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "no user in token",
+			productID:  productID.String(),
+			mock:       func() {},
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "invalid product id",
+			userID:     ownerID.String(),
+			productID:  "bad",
+			mock:       func() {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:      "product not found",
+			userID:    ownerID.String(),
+			productID: productID.String(),
+			mock: func() {
+				mockService.EXPECT().RenewPickupCode(gomock.Any(), ownerID, productID).Return("", entity.ErrProductNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:      "already issued",
+			userID:    ownerID.String(),
+			productID: productID.String(),
+			mock: func() {
+				mockService.EXPECT().RenewPickupCode(gomock.Any(), ownerID, productID).Return("", entity.ErrProductAlreadyIssued)
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest(http.MethodPost, "/", nil)
+			c.Params = []gin.Param{{Key: "productId", Value: tt.productID}}
+			if tt.userID != "" {
+				c.Set("user_id", tt.userID)
+			}
+
+			tt.mock()
+			h.RenewPickupCode(c)
+			assert.Equal(t, tt.wantStatus, w.Code)
+			if tt.wantStatus == http.StatusOK {
+				var resp dto.PickupCodeResponse
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+				assert.Equal(t, "654321", resp.PickupCode)
+			}
+		})
+	}
+}
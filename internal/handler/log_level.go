@@ -0,0 +1,76 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+
+	"github.com/senyabanana/pvz-service/internal/dto"
+	"github.com/senyabanana/pvz-service/internal/entity"
+	"github.com/senyabanana/pvz-service/internal/infrastructure/logger"
+	"github.com/senyabanana/pvz-service/internal/service"
+)
+
+type LogLevelHandler struct {
+	service service.LogLevelOperations
+	log     *logrus.Logger
+}
+
+func NewLogLevelHandler(service service.LogLevelOperations, log *logrus.Logger) *LogLevelHandler {
+	return &LogLevelHandler{
+		service: service,
+		log:     log,
+	}
+}
+
+// GetLogLevel godoc
+// @Summary Get log level
+// @Tags system
+// @Description Текущий уровень логирования сервиса. Только для модераторов
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} dto.LogLevelResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Router /log-level [get]
+func (h *LogLevelHandler) GetLogLevel(c *gin.Context) {
+	c.JSON(http.StatusOK, dto.LogLevelResponse{Level: h.service.GetLogLevel(c.Request.Context())})
+}
+
+// SetLogLevel godoc
+// @Summary Set log level
+// @Tags system
+// @Description Меняет уровень логирования без перезапуска: panic, fatal, error, warn, info, debug или trace.
+// @Description Изменение не сохраняется: после перезапуска или SIGHUP снова действует LOG_LEVEL
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param input body dto.LogLevelRequest true "Новый уровень"
+// @Success 200 {object} dto.LogLevelResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Router /log-level [put]
+func (h *LogLevelHandler) SetLogLevel(c *gin.Context) {
+	log := logger.FromContext(c.Request.Context(), h.log)
+
+	var req dto.LogLevelRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Warnf("invalid log level input: %v", err)
+		dto.BadRequest(c, "invalid level")
+		return
+	}
+
+	if err := h.service.SetLogLevel(c.Request.Context(), req.Level); err != nil {
+		if errors.Is(err, entity.ErrInvalidLogLevel) {
+			dto.BadRequest(c, err.Error())
+			return
+		}
+		dto.InternalError(c, "failed to set log level")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.LogLevelResponse{Level: h.service.GetLogLevel(c.Request.Context())})
+}
@@ -0,0 +1,104 @@
+package handler
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/senyabanana/pvz-service/internal/entity"
+	mocks "github.com/senyabanana/pvz-service/internal/service/mocks"
+)
+
+func TestLogLevelHandler_SetLogLevel(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockLogLevelOperations(ctrl)
+	mockLog := logrus.New()
+	h := NewLogLevelHandler(mockService, mockLog)
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name       string
+		body       string
+		mock       func()
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name: "debug",
+			body: `{"level":"debug"}`,
+			mock: func() {
+				mockService.EXPECT().SetLogLevel(gomock.Any(), "debug").Return(nil)
+				mockService.EXPECT().GetLogLevel(gomock.Any()).Return("debug")
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   `{"level":"debug"}`,
+		},
+		{
+			name:       "missing level",
+			body:       `{}`,
+			mock:       func() {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "unknown level",
+			body: `{"level":"verbose"}`,
+			mock: func() {
+				mockService.EXPECT().SetLogLevel(gomock.Any(), "verbose").
+					Return(fmt.Errorf("%w: verbose", entity.ErrInvalidLogLevel))
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "internal error",
+			body: `{"level":"info"}`,
+			mock: func() {
+				mockService.EXPECT().SetLogLevel(gomock.Any(), "info").Return(errors.New("unexpected"))
+			},
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest(http.MethodPut, "/log-level", bytes.NewBufferString(tt.body))
+			c.Request.Header.Set("Content-Type", "application/json")
+
+			tt.mock()
+			h.SetLogLevel(c)
+			assert.Equal(t, tt.wantStatus, w.Code)
+			if tt.wantBody != "" {
+				assert.JSONEq(t, tt.wantBody, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestLogLevelHandler_GetLogLevel(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockLogLevelOperations(ctrl)
+	h := NewLogLevelHandler(mockService, logrus.New())
+	gin.SetMode(gin.TestMode)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/log-level", nil)
+
+	mockService.EXPECT().GetLogLevel(gomock.Any()).Return("info")
+	h.GetLogLevel(c)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"level":"info"}`, w.Body.String())
+}
@@ -0,0 +1,146 @@
+package handler
+
+import (
+	"crypto/subtle"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+
+	"github.com/senyabanana/pvz-service/internal/dto"
+	"github.com/senyabanana/pvz-service/internal/entity"
+	"github.com/senyabanana/pvz-service/internal/infrastructure/logger"
+	"github.com/senyabanana/pvz-service/internal/infrastructure/security"
+	"github.com/senyabanana/pvz-service/internal/service"
+)
+
+const (
+	oidcStateCookie  = "oidc_state"
+	oidcNonceCookie  = "oidc_nonce"
+	oidcCookieMaxAge = 10 * 60
+	oidcRandomBytes  = 32
+)
+
+type OIDCHandler struct {
+	service service.OIDCAuthorization
+	log     *logrus.Logger
+}
+
+func NewOIDCHandler(service service.OIDCAuthorization, log *logrus.Logger) *OIDCHandler {
+	return &OIDCHandler{
+		service: service,
+		log:     log,
+	}
+}
+
+// OIDCLogin godoc
+// @Summary OIDC Login
+// @Tags auth
+// @Description Перенаправление сотрудника на страницу входа внешнего провайдера идентификации
+// @Success 302
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /auth/oidc/login [get]
+func (h *OIDCHandler) OIDCLogin(c *gin.Context) {
+	log := logger.FromContext(c.Request.Context(), h.log)
+
+	state, err := security.GenerateRandomString(oidcRandomBytes)
+	if err != nil {
+		log.Errorf("failed to generate oidc state: %v", err)
+		dto.InternalError(c, "failed to start oidc login")
+		return
+	}
+
+	nonce, err := security.GenerateRandomString(oidcRandomBytes)
+	if err != nil {
+		log.Errorf("failed to generate oidc nonce: %v", err)
+		dto.InternalError(c, "failed to start oidc login")
+		return
+	}
+
+	authURL, err := h.service.OIDCAuthURL(state, nonce)
+	if err != nil {
+		if errors.Is(err, entity.ErrOIDCDisabled) {
+			dto.NotFound(c, "oidc login is not configured")
+			return
+		}
+
+		log.Errorf("failed to build oidc auth url: %v", err)
+		dto.InternalError(c, "failed to start oidc login")
+		return
+	}
+
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(oidcStateCookie, state, oidcCookieMaxAge, "/auth/oidc", "", c.Request.TLS != nil, true)
+	c.SetCookie(oidcNonceCookie, nonce, oidcCookieMaxAge, "/auth/oidc", "", c.Request.TLS != nil, true)
+
+	c.Redirect(http.StatusFound, authURL)
+}
+
+// OIDCCallback godoc
+// @Summary OIDC Callback
+// @Tags auth
+// @Description Обработка ответа провайдера идентификации и выдача токена сервиса
+// @Produce json
+// @Param code query string true "Authorization code"
+// @Param state query string true "State"
+// @Success 200 {object} dto.TokenResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /auth/oidc/callback [get]
+func (h *OIDCHandler) OIDCCallback(c *gin.Context) {
+	log := logger.FromContext(c.Request.Context(), h.log)
+
+	if idpErr := c.Query("error"); idpErr != "" {
+		log.Warnf("oidc provider returned error: %s", idpErr)
+		dto.Unauthorized(c, "identity provider rejected login")
+		return
+	}
+
+	code, state := c.Query("code"), c.Query("state")
+	if code == "" || state == "" {
+		dto.BadRequest(c, "code and state are required")
+		return
+	}
+
+	expectedState, err := c.Cookie(oidcStateCookie)
+	if err != nil || subtle.ConstantTimeCompare([]byte(state), []byte(expectedState)) != 1 {
+		log.Warn("oidc callback with invalid state")
+		dto.BadRequest(c, "invalid state")
+		return
+	}
+
+	nonce, err := c.Cookie(oidcNonceCookie)
+	if err != nil {
+		dto.BadRequest(c, "missing nonce")
+		return
+	}
+
+	c.SetCookie(oidcStateCookie, "", -1, "/auth/oidc", "", c.Request.TLS != nil, true)
+	c.SetCookie(oidcNonceCookie, "", -1, "/auth/oidc", "", c.Request.TLS != nil, true)
+
+	token, err := h.service.LoginOIDC(c.Request.Context(), code, nonce)
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrOIDCDisabled):
+			dto.NotFound(c, "oidc login is not configured")
+		case errors.Is(err, entity.ErrOIDCAuthFailed):
+			dto.Unauthorized(c, "oidc authentication failed")
+		case errors.Is(err, entity.ErrOIDCNoRole):
+			dto.Forbidden(c, "no role is mapped for your groups")
+		case errors.Is(err, entity.ErrOIDCAccountConflict):
+			dto.Conflict(c, "email is already used by another account")
+		default:
+			log.Errorf("oidc login error: %v", err)
+			dto.InternalError(c, "oidc login failed due to internal error")
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.TokenResponse{Token: token})
+}
@@ -0,0 +1,169 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/senyabanana/pvz-service/internal/entity"
+	mocks "github.com/senyabanana/pvz-service/internal/service/mocks"
+)
+
+func TestOIDCHandler_OIDCLogin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockOIDCAuthorization(ctrl)
+	mockLog := logrus.New()
+	h := NewOIDCHandler(mockService, mockLog)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/auth/oidc/login", h.OIDCLogin)
+
+	tests := []struct {
+		name       string
+		mock       func()
+		wantStatus int
+	}{
+		{
+			name: "redirect to provider",
+			mock: func() {
+				mockService.EXPECT().OIDCAuthURL(gomock.Any(), gomock.Any()).
+					Return("https://idp.example.com/auth", nil)
+			},
+			wantStatus: http.StatusFound,
+		},
+		{
+			name: "oidc disabled",
+			mock: func() {
+				mockService.EXPECT().OIDCAuthURL(gomock.Any(), gomock.Any()).
+					Return("", entity.ErrOIDCDisabled)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+
+			rr := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodGet, "/auth/oidc/login", nil)
+			router.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.wantStatus, rr.Code)
+			if tt.wantStatus == http.StatusFound {
+				assert.Equal(t, "https://idp.example.com/auth", rr.Header().Get("Location"))
+				assert.Len(t, rr.Result().Cookies(), 2)
+			}
+		})
+	}
+}
+
+func TestOIDCHandler_OIDCCallback(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockOIDCAuthorization(ctrl)
+	mockLog := logrus.New()
+	h := NewOIDCHandler(mockService, mockLog)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/auth/oidc/callback", h.OIDCCallback)
+
+	tests := []struct {
+		name       string
+		query      string
+		cookies    bool
+		mock       func()
+		wantStatus int
+	}{
+		{
+			name:    "success",
+			query:   "?code=code&state=state",
+			cookies: true,
+			mock: func() {
+				mockService.EXPECT().LoginOIDC(gomock.Any(), "code", "nonce").Return("token", nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "state mismatch",
+			query:      "?code=code&state=other",
+			cookies:    true,
+			mock:       func() {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "missing state cookie",
+			query:      "?code=code&state=state",
+			mock:       func() {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "provider error",
+			query:      "?error=access_denied",
+			mock:       func() {},
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:    "auth failed",
+			query:   "?code=code&state=state",
+			cookies: true,
+			mock: func() {
+				mockService.EXPECT().LoginOIDC(gomock.Any(), "code", "nonce").Return("", entity.ErrOIDCAuthFailed)
+			},
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:    "no role",
+			query:   "?code=code&state=state",
+			cookies: true,
+			mock: func() {
+				mockService.EXPECT().LoginOIDC(gomock.Any(), "code", "nonce").Return("", entity.ErrOIDCNoRole)
+			},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:    "email used by another account",
+			query:   "?code=code&state=state",
+			cookies: true,
+			mock: func() {
+				mockService.EXPECT().LoginOIDC(gomock.Any(), "code", "nonce").Return("", entity.ErrOIDCAccountConflict)
+			},
+			wantStatus: http.StatusConflict,
+		},
+		{
+			name:    "internal error",
+			query:   "?code=code&state=state",
+			cookies: true,
+			mock: func() {
+				mockService.EXPECT().LoginOIDC(gomock.Any(), "code", "nonce").Return("", assert.AnError)
+			},
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+
+			rr := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodGet, "/auth/oidc/callback"+tt.query, nil)
+			if tt.cookies {
+				req.AddCookie(&http.Cookie{Name: oidcStateCookie, Value: "state"})
+				req.AddCookie(&http.Cookie{Name: oidcNonceCookie, Value: "nonce"})
+			}
+			router.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.wantStatus, rr.Code)
+		})
+	}
+}
@@ -0,0 +1,149 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+
+	"github.com/senyabanana/pvz-service/internal/dto"
+	"github.com/senyabanana/pvz-service/internal/entity"
+	"github.com/senyabanana/pvz-service/internal/infrastructure/logger"
+	"github.com/senyabanana/pvz-service/internal/middleware"
+	"github.com/senyabanana/pvz-service/internal/service"
+)
+
+type PasswordHandler struct {
+	service service.PasswordOperations
+	log     *logrus.Logger
+}
+
+func NewPasswordHandler(service service.PasswordOperations, log *logrus.Logger) *PasswordHandler {
+	return &PasswordHandler{
+		service: service,
+		log:     log,
+	}
+}
+
+// ChangePassword godoc
+// @Summary Change Password
+// @Tags auth
+// @Security BearerAuth
+// @Description Смена пароля текущего пользователя
+// @Accept json
+// @Produce json
+// @Param input body dto.ChangePasswordRequest true "Current and new password"
+// @Success 204
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /me/password [post]
+func (h *PasswordHandler) ChangePassword(c *gin.Context) {
+	log := logger.FromContext(c.Request.Context(), h.log)
+
+	var req dto.ChangePasswordRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Warnf("invalid change password input: %v", err)
+		dto.BadRequest(c, "currentPassword and newPassword are required")
+		return
+	}
+
+	userID, err := uuid.Parse(middleware.GetUserID(c))
+	if err != nil {
+		log.Warnf("invalid user id in token: %v", err)
+		dto.Unauthorized(c, "invalid token")
+		return
+	}
+
+	err = h.service.ChangePassword(c.Request.Context(), userID, req.CurrentPassword, req.NewPassword)
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrWeakPassword):
+			dto.BadRequest(c, err.Error())
+		case errors.Is(err, entity.ErrInvalidCredentials):
+			dto.BadRequest(c, "current password is incorrect")
+		case errors.Is(err, entity.ErrSamePassword):
+			dto.BadRequest(c, "new password must differ from the current one")
+		case errors.Is(err, entity.ErrPasswordNotManaged):
+			dto.BadRequest(c, "password is managed by identity provider")
+		case errors.Is(err, entity.ErrUserNotFound):
+			dto.NotFound(c, "user not found")
+		default:
+			dto.InternalError(c, "failed to change password")
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// RequestPasswordReset godoc
+// @Summary Request Password Reset
+// @Tags auth
+// @Description Запрос на сброс пароля. Ответ не зависит от того, существует ли пользователь
+// @Accept json
+// @Produce json
+// @Param input body dto.PasswordResetRequest true "Email"
+// @Success 202
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /password/reset/request [post]
+func (h *PasswordHandler) RequestPasswordReset(c *gin.Context) {
+	log := logger.FromContext(c.Request.Context(), h.log)
+
+	var req dto.PasswordResetRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Warnf("invalid password reset input: %v", err)
+		dto.BadRequest(c, "invalid email")
+		return
+	}
+
+	if err := h.service.RequestPasswordReset(c.Request.Context(), req.Email); err != nil {
+		dto.InternalError(c, "failed to request password reset")
+		return
+	}
+
+	c.Status(http.StatusAccepted)
+}
+
+// ResetPassword godoc
+// @Summary Reset Password
+// @Tags auth
+// @Description Установка нового пароля по одноразовому токену сброса
+// @Accept json
+// @Produce json
+// @Param input body dto.PasswordResetConfirmRequest true "Reset token and new password"
+// @Success 204
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /password/reset [post]
+func (h *PasswordHandler) ResetPassword(c *gin.Context) {
+	log := logger.FromContext(c.Request.Context(), h.log)
+
+	var req dto.PasswordResetConfirmRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Warnf("invalid password reset confirm input: %v", err)
+		dto.BadRequest(c, "token and newPassword are required")
+		return
+	}
+
+	if err := h.service.ResetPassword(c.Request.Context(), req.Token, req.NewPassword); err != nil {
+		switch {
+		case errors.Is(err, entity.ErrWeakPassword):
+			dto.BadRequest(c, err.Error())
+		case errors.Is(err, entity.ErrInvalidResetToken):
+			dto.BadRequest(c, "invalid or expired reset token")
+		default:
+			dto.InternalError(c, "failed to reset password")
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
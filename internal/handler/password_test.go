@@ -0,0 +1,245 @@
+package handler
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/senyabanana/pvz-service/internal/entity"
+	mocks "github.com/senyabanana/pvz-service/internal/service/mocks"
+)
+
+func TestPasswordHandler_ChangePassword(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockPasswordOperations(ctrl)
+	mockLog := logrus.New()
+	h := NewPasswordHandler(mockService, mockLog)
+
+	gin.SetMode(gin.TestMode)
+
+	userID := uuid.New()
+	validBody := `{"currentPassword":"old-s3cret","newPassword":"new-s3cret"}`
+
+	tests := []struct {
+		name       string
+		userID     string
+		input      string
+		mock       func()
+		wantStatus int
+	}{
+		{
+			name:   "success",
+			userID: userID.String(),
+			input:  validBody,
+			mock: func() {
+				mockService.EXPECT().ChangePassword(gomock.Any(), userID, "old-s3cret", "new-s3cret").Return(nil)
+			},
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name:       "invalid JSON",
+			userID:     userID.String(),
+			input:      `{"currentPassword":""}`,
+			mock:       func() {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "invalid user id",
+			userID:     "not-a-uuid",
+			input:      validBody,
+			mock:       func() {},
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:   "weak password",
+			userID: userID.String(),
+			input:  validBody,
+			mock: func() {
+				mockService.EXPECT().ChangePassword(gomock.Any(), userID, "old-s3cret", "new-s3cret").
+					Return(fmt.Errorf("%w: must contain a digit", entity.ErrWeakPassword))
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "wrong current password",
+			userID: userID.String(),
+			input:  validBody,
+			mock: func() {
+				mockService.EXPECT().ChangePassword(gomock.Any(), userID, "old-s3cret", "new-s3cret").
+					Return(entity.ErrInvalidCredentials)
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "user not found",
+			userID: userID.String(),
+			input:  validBody,
+			mock: func() {
+				mockService.EXPECT().ChangePassword(gomock.Any(), userID, "old-s3cret", "new-s3cret").
+					Return(entity.ErrUserNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:   "internal error",
+			userID: userID.String(),
+			input:  validBody,
+			mock: func() {
+				mockService.EXPECT().ChangePassword(gomock.Any(), userID, "old-s3cret", "new-s3cret").
+					Return(assert.AnError)
+			},
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(rr)
+
+			ctx.Request, _ = http.NewRequest(http.MethodPost, "/me/password", bytes.NewBufferString(tt.input))
+			ctx.Request.Header.Set("Content-Type", "application/json")
+			ctx.Set("user_id", tt.userID)
+
+			tt.mock()
+			h.ChangePassword(ctx)
+			ctx.Writer.WriteHeaderNow()
+
+			assert.Equal(t, tt.wantStatus, rr.Code)
+		})
+	}
+}
+
+func TestPasswordHandler_RequestPasswordReset(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockPasswordOperations(ctrl)
+	mockLog := logrus.New()
+	h := NewPasswordHandler(mockService, mockLog)
+
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name       string
+		input      string
+		mock       func()
+		wantStatus int
+	}{
+		{
+			name:  "accepted",
+			input: `{"email":"test@example.com"}`,
+			mock: func() {
+				mockService.EXPECT().RequestPasswordReset(gomock.Any(), "test@example.com").Return(nil)
+			},
+			wantStatus: http.StatusAccepted,
+		},
+		{
+			name:       "invalid email",
+			input:      `{"email":"not-an-email"}`,
+			mock:       func() {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:  "internal error",
+			input: `{"email":"test@example.com"}`,
+			mock: func() {
+				mockService.EXPECT().RequestPasswordReset(gomock.Any(), "test@example.com").Return(assert.AnError)
+			},
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(rr)
+
+			ctx.Request, _ = http.NewRequest(http.MethodPost, "/password/reset/request", bytes.NewBufferString(tt.input))
+			ctx.Request.Header.Set("Content-Type", "application/json")
+
+			tt.mock()
+			h.RequestPasswordReset(ctx)
+			ctx.Writer.WriteHeaderNow()
+
+			assert.Equal(t, tt.wantStatus, rr.Code)
+		})
+	}
+}
+
+func TestPasswordHandler_ResetPassword(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockPasswordOperations(ctrl)
+	mockLog := logrus.New()
+	h := NewPasswordHandler(mockService, mockLog)
+
+	gin.SetMode(gin.TestMode)
+
+	validBody := `{"token":"reset-token","newPassword":"new-s3cret"}`
+
+	tests := []struct {
+		name       string
+		input      string
+		mock       func()
+		wantStatus int
+	}{
+		{
+			name:  "success",
+			input: validBody,
+			mock: func() {
+				mockService.EXPECT().ResetPassword(gomock.Any(), "reset-token", "new-s3cret").Return(nil)
+			},
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name:       "missing token",
+			input:      `{"newPassword":"new-s3cret"}`,
+			mock:       func() {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:  "invalid token",
+			input: validBody,
+			mock: func() {
+				mockService.EXPECT().ResetPassword(gomock.Any(), "reset-token", "new-s3cret").Return(entity.ErrInvalidResetToken)
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:  "internal error",
+			input: validBody,
+			mock: func() {
+				mockService.EXPECT().ResetPassword(gomock.Any(), "reset-token", "new-s3cret").Return(assert.AnError)
+			},
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(rr)
+
+			ctx.Request, _ = http.NewRequest(http.MethodPost, "/password/reset", bytes.NewBufferString(tt.input))
+			ctx.Request.Header.Set("Content-Type", "application/json")
+
+			tt.mock()
+			h.ResetPassword(ctx)
+			ctx.Writer.WriteHeaderNow()
+
+			assert.Equal(t, tt.wantStatus, rr.Code)
+		})
+	}
+}
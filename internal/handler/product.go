@@ -11,6 +11,8 @@ import (
 
 	"github.com/senyabanana/pvz-service/internal/dto"
 	"github.com/senyabanana/pvz-service/internal/entity"
+	"github.com/senyabanana/pvz-service/internal/infrastructure/logger"
+	"github.com/senyabanana/pvz-service/internal/middleware"
 	"github.com/senyabanana/pvz-service/internal/service"
 )
 
@@ -31,29 +33,40 @@ func NewProductHandler(service service.ProductOperations, log *logrus.Logger) *P
 // @Tags product
 // @Description Добавление товара в текущую приёмку ПВЗ
 // @Security BearerAuth
+// @Security APIKeyAuth
 // @Accept json
 // @Produce json
 // @Param input body dto.ProductRequest true "Product payload"
 // @Success 201 {object} dto.ProductResponse
 // @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
 // @Failure 500 {object} dto.ErrorResponse
 // @Router /products [post]
 func (h *ProductHandler) AddProduct(c *gin.Context) {
+	log := logger.FromContext(c.Request.Context(), h.log)
+
 	var req dto.ProductRequest
 
 	if err := c.ShouldBindJSON(&req); err != nil {
-		h.log.Warnf("invalid product input: %v", err)
+		log.Warnf("invalid product input: %v", err)
 		dto.BadRequest(c, "invalid request body")
 		return
 	}
 
 	pvzID, err := uuid.Parse(req.PVZID)
 	if err != nil {
-		h.log.Warnf("invalid UUID format: %v", err)
+		log.Warnf("invalid UUID format: %v", err)
 		dto.BadRequest(c, "invalid UUID format")
 		return
 	}
 
+	if !middleware.CanAccessPVZ(c, pvzID) {
+		log.Warnf("api key is not allowed to access pvz: %s", pvzID)
+		dto.Forbidden(c, "access to this PVZ is not allowed")
+		return
+	}
+
 	product, err := h.service.AddProduct(c.Request.Context(), pvzID, entity.ProductType(req.Type))
 	if err != nil {
 		switch {
@@ -63,6 +76,15 @@ func (h *ProductHandler) AddProduct(c *gin.Context) {
 		case errors.Is(err, entity.ErrInvalidProductType):
 			dto.BadRequest(c, "invalid product type")
 			return
+		case errors.Is(err, entity.ErrPVZNotActive):
+			dto.BadRequest(c, "pvz is not active")
+			return
+		case errors.Is(err, entity.ErrCapacityExceeded):
+			dto.BadRequest(c, "pvz capacity exceeded")
+			return
+		case errors.Is(err, entity.ErrPVZNotFound):
+			dto.NotFound(c, "pvz not found")
+			return
 		default:
 			dto.InternalError(c, "failed to add product")
 			return
@@ -82,22 +104,32 @@ func (h *ProductHandler) AddProduct(c *gin.Context) {
 // @Tags product
 // @Description Удаление последнего добавленного товара из текущей приёмки
 // @Security BearerAuth
+// @Security APIKeyAuth
 // @Accept json
 // @Produce json
 // @Param pvzId path string true "PVZ ID"
 // @Success 200
 // @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
 // @Failure 500 {object} dto.ErrorResponse
 // @Router /pvz/{pvzId}/delete_last_product [post]
 func (h *ProductHandler) DeleteLastProduct(c *gin.Context) {
+	log := logger.FromContext(c.Request.Context(), h.log)
+
 	pvzIDParam := c.Param("pvzId")
 	pvzID, err := uuid.Parse(pvzIDParam)
 	if err != nil {
-		h.log.Warnf("invalid pvzId: %s", pvzIDParam)
+		log.Warnf("invalid pvzId: %s", pvzIDParam)
 		dto.BadRequest(c, "invalid pvzId")
 		return
 	}
 
+	if !middleware.CanAccessPVZ(c, pvzID) {
+		log.Warnf("api key is not allowed to access pvz: %s", pvzID)
+		dto.Forbidden(c, "access to this PVZ is not allowed")
+		return
+	}
+
 	if err := h.service.DeleteLastProduct(c.Request.Context(), pvzID); err != nil {
 		switch {
 		case errors.Is(err, entity.ErrNoOpenReception):
@@ -106,6 +138,9 @@ func (h *ProductHandler) DeleteLastProduct(c *gin.Context) {
 		case errors.Is(err, entity.ErrNoProductsToDelete):
 			dto.BadRequest(c, "no products to delete")
 			return
+		case errors.Is(err, entity.ErrProductReferenced):
+			dto.BadRequest(c, err.Error())
+			return
 		default:
 			dto.InternalError(c, "failed to delete product")
 			return
@@ -114,3 +149,68 @@ func (h *ProductHandler) DeleteLastProduct(c *gin.Context) {
 
 	c.Status(http.StatusOK)
 }
+
+// GetProductHistory godoc
+// @Summary Get product status history
+// @Tags product
+// @Description История статусов товара от приёмки до выдачи, возврата или перемещения, в хронологическом порядке
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Produce json
+// @Param productId path string true "Product ID"
+// @Success 200 {array} dto.ProductStatusChangeResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /products/{productId}/history [get]
+func (h *ProductHandler) GetProductHistory(c *gin.Context) {
+	log := logger.FromContext(c.Request.Context(), h.log)
+
+	productID, err := uuid.Parse(c.Param("productId"))
+	if err != nil {
+		log.Warnf("invalid productId: %v", err)
+		dto.BadRequest(c, "invalid productId")
+		return
+	}
+
+	history, err := h.service.GetProductHistory(c.Request.Context(), productID)
+	if err != nil {
+		if errors.Is(err, entity.ErrProductNotFound) {
+			dto.NotFound(c, "product not found")
+			return
+		}
+
+		dto.InternalError(c, "failed to get product history")
+		return
+	}
+
+	pvzIDs := make([]uuid.UUID, 0, len(history))
+	resp := make([]dto.ProductStatusChangeResponse, 0, len(history))
+	for _, change := range history {
+		item := dto.ProductStatusChangeResponse{
+			ToStatus:  string(change.To),
+			ChangedAt: change.ChangedAt.Format(time.RFC3339),
+			Comment:   change.Comment,
+		}
+		if change.From != nil {
+			item.FromStatus = string(*change.From)
+		}
+		if change.PVZID != nil {
+			item.PVZID = change.PVZID.String()
+			pvzIDs = append(pvzIDs, *change.PVZID)
+		}
+		if change.ChangedBy != nil {
+			item.ChangedBy = change.ChangedBy.String()
+		}
+		resp = append(resp, item)
+	}
+
+	if !canAccessAnyPVZ(c, pvzIDs) {
+		log.Warnf("api key is not allowed to access product: %s", productID)
+		dto.NotFound(c, "product not found")
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
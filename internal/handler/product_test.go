@@ -68,6 +68,22 @@ func TestProductHandler_AddProduct(t *testing.T) {
 			},
 			wantStatus: http.StatusBadRequest,
 		},
+		{
+			name:  "pvz closed",
+			input: `{"pvzId":"` + validID + `", "type":"электроника"}`,
+			mock: func() {
+				mockService.EXPECT().AddProduct(gomock.Any(), gomock.Any(), entity.ProductElectronics).Return(nil, entity.ErrPVZNotActive)
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:  "capacity exceeded",
+			input: `{"pvzId":"` + validID + `", "type":"электроника"}`,
+			mock: func() {
+				mockService.EXPECT().AddProduct(gomock.Any(), gomock.Any(), entity.ProductElectronics).Return(nil, entity.ErrCapacityExceeded)
+			},
+			wantStatus: http.StatusBadRequest,
+		},
 		{
 			name:  "invalid product type",
 			input: `{"pvzId":"` + validID + `", "type":"invalid"}`,
@@ -172,3 +188,84 @@ func TestProductHandler_DeleteLastProduct(t *testing.T) {
 		})
 	}
 }
+
+func TestProductHandler_GetProductHistory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockProductOperations(ctrl)
+	mockLog := logrus.New()
+	h := NewProductHandler(mockService, mockLog)
+	gin.SetMode(gin.TestMode)
+
+	productID, pvzID := uuid.New(), uuid.New()
+	received := entity.ProductStatusReceived
+	history := []entity.ProductStatusChange{
+		{ProductID: productID, To: entity.ProductStatusReceived, PVZID: &pvzID, ChangedAt: time.Now()},
+		{ProductID: productID, From: &received, To: entity.ProductStatusIssued, PVZID: &pvzID, ChangedAt: time.Now()},
+	}
+
+	tests := []struct {
+		name       string
+		productID  string
+		restricted *uuid.UUID
+		mock       func()
+		wantStatus int
+	}{
+		{
+			name:      "success",
+			productID: productID.String(),
+			mock: func() {
+				mockService.EXPECT().GetProductHistory(gomock.Any(), productID).Return(history, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "invalid product id",
+			productID:  "abc",
+			mock:       func() {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:      "not found",
+			productID: productID.String(),
+			mock: func() {
+				mockService.EXPECT().GetProductHistory(gomock.Any(), productID).Return(nil, entity.ErrProductNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "api key bound to another pvz",
+			productID:  productID.String(),
+			restricted: func() *uuid.UUID { id := uuid.New(); return &id }(),
+			mock: func() {
+				mockService.EXPECT().GetProductHistory(gomock.Any(), productID).Return(history, nil)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:      "internal error",
+			productID: productID.String(),
+			mock: func() {
+				mockService.EXPECT().GetProductHistory(gomock.Any(), productID).Return(nil, errors.New("db down"))
+			},
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest(http.MethodGet, "/", nil)
+			c.Params = []gin.Param{{Key: "productId", Value: tt.productID}}
+			if tt.restricted != nil {
+				c.Set("restricted_pvz_id", *tt.restricted)
+			}
+
+			tt.mock()
+			h.GetProductHistory(c)
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
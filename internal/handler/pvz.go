@@ -7,10 +7,13 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 
 	"github.com/senyabanana/pvz-service/internal/dto"
 	"github.com/senyabanana/pvz-service/internal/entity"
+	"github.com/senyabanana/pvz-service/internal/infrastructure/logger"
+	"github.com/senyabanana/pvz-service/internal/middleware"
 	"github.com/senyabanana/pvz-service/internal/service"
 )
 
@@ -31,6 +34,7 @@ func NewPVZHandler(service service.PVZOperations, log *logrus.Logger) *PVZHandle
 // @Tags pvz
 // @Description Создание ПВЗ
 // @Security BearerAuth
+// @Security APIKeyAuth
 // @Accept json
 // @Produce json
 // @Param request body dto.PVZRequest true "Город ПВЗ"
@@ -39,10 +43,12 @@ func NewPVZHandler(service service.PVZOperations, log *logrus.Logger) *PVZHandle
 // @Failure 500 {object} dto.ErrorResponse
 // @Router /pvz [post]
 func (h *PVZHandler) CreatePVZ(c *gin.Context) {
+	log := logger.FromContext(c.Request.Context(), h.log)
+
 	var req dto.PVZRequest
 
 	if err := c.ShouldBindJSON(&req); err != nil {
-		h.log.Warnf("invalid create PVZ input: %v", err)
+		log.Warnf("invalid create PVZ input: %v", err)
 		dto.BadRequest(c, "invalid city")
 		return
 	}
@@ -50,21 +56,77 @@ func (h *PVZHandler) CreatePVZ(c *gin.Context) {
 	pvz, err := h.service.CreatePVZ(c.Request.Context(), req.City)
 	if err != nil {
 		if errors.Is(err, entity.ErrInvalidCity) {
-			h.log.Warnf("create pvz failed: unsupported city: %s", req.City)
+			log.Warnf("create pvz failed: unsupported city: %s", req.City)
 			dto.BadRequest(c, "unsupported city")
 			return
 		}
 
-		h.log.Errorf("failed to create PVZ: %v", err)
+		log.Errorf("failed to create PVZ: %v", err)
 		dto.InternalError(c, "internal error")
 		return
 	}
 
-	c.JSON(http.StatusCreated, dto.PVZResponse{
-		ID:               pvz.ID.String(),
-		RegistrationDate: pvz.RegistrationDate.Format(time.RFC3339),
-		City:             string(pvz.City),
-	})
+	c.JSON(http.StatusCreated, toPVZResponse(*pvz))
+}
+
+// UpdatePVZ godoc
+// @Summary Update PVZ details
+// @Tags pvz
+// @Description Частичное обновление адреса, координат, телефона и графика работы ПВЗ
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Accept json
+// @Produce json
+// @Param pvzId path string true "PVZ ID"
+// @Param request body dto.PVZUpdateRequest true "Изменяемые поля ПВЗ"
+// @Success 200 {object} dto.PVZResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /pvz/{pvzId} [patch]
+func (h *PVZHandler) UpdatePVZ(c *gin.Context) {
+	log := logger.FromContext(c.Request.Context(), h.log)
+
+	pvzID, ok := parsePVZParam(c, log)
+	if !ok {
+		return
+	}
+
+	var req dto.PVZUpdateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Warnf("invalid update PVZ input: %v", err)
+		dto.BadRequest(c, "invalid request body")
+		return
+	}
+
+	update := entity.PVZDetailsUpdate{
+		Address:   req.Address,
+		Latitude:  req.Latitude,
+		Longitude: req.Longitude,
+		Phone:     req.Phone,
+	}
+	if req.WorkingHours != nil {
+		update.WorkingHours = toEntityWorkingHours(req.WorkingHours)
+	}
+
+	pvz, err := h.service.UpdatePVZDetails(c.Request.Context(), pvzID, update)
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrPVZNotFound):
+			dto.NotFound(c, "pvz not found")
+		case errors.Is(err, entity.ErrInvalidCoordinates),
+			errors.Is(err, entity.ErrInvalidWorkingHours),
+			errors.Is(err, entity.ErrInvalidPVZDetails):
+			dto.BadRequest(c, err.Error())
+		default:
+			log.Errorf("failed to update PVZ %s: %v", pvzID, err)
+			dto.InternalError(c, "failed to update PVZ")
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, toPVZResponse(*pvz))
 }
 
 // GetFullInfoPVZ godoc
@@ -72,17 +134,21 @@ func (h *PVZHandler) CreatePVZ(c *gin.Context) {
 // @Tags pvz
 // @Description Получение информации о ПВЗ с приёмками и товарами
 // @Security BearerAuth
+// @Security APIKeyAuth
 // @Accept json
 // @Produce json
 // @Param startDate query string false "Фильтрация по дате начала (RFC3339)"
 // @Param endDate query string false "Фильтрация по дате окончания (RFC3339)"
 // @Param page query int false "Номер страницы (по умолчанию 1)"
 // @Param limit query int false "Лимит элементов на странице (по умолчанию 10)"
+// @Param includeClosed query bool false "Включить закрытые ПВЗ (по умолчанию false)"
 // @Success 200 {array} dto.FullPVZResponse
 // @Failure 400 {object} dto.ErrorResponse
 // @Failure 500 {object} dto.ErrorResponse
 // @Router /pvz [get]
 func (h *PVZHandler) GetFullInfoPVZ(c *gin.Context) {
+	log := logger.FromContext(c.Request.Context(), h.log)
+
 	var query dto.FullPVZQueryParams
 
 	if err := c.ShouldBindQuery(&query); err != nil {
@@ -90,11 +156,11 @@ func (h *PVZHandler) GetFullInfoPVZ(c *gin.Context) {
 		return
 	}
 
-	startDate := parseQueryTime(query.StartDate, "startDate", c, h.log)
+	startDate := parseQueryTime(query.StartDate, "startDate", c, log)
 	if c.IsAborted() {
 		return
 	}
-	endDate := parseQueryTime(query.EndDate, "endDate", c, h.log)
+	endDate := parseQueryTime(query.EndDate, "endDate", c, log)
 	if c.IsAborted() {
 		return
 	}
@@ -108,9 +174,20 @@ func (h *PVZHandler) GetFullInfoPVZ(c *gin.Context) {
 		limit = 10
 	}
 
-	pvzInfo, err := h.service.GetFullPVZInfo(c.Request.Context(), startDate, endDate, page, limit)
+	filter := entity.PVZFilter{
+		StartDate:     startDate,
+		EndDate:       endDate,
+		Page:          page,
+		Limit:         limit,
+		IncludeClosed: query.IncludeClosed,
+	}
+	if pvzID, ok := middleware.RestrictedPVZ(c); ok {
+		filter.PVZID = &pvzID
+	}
+
+	pvzInfo, err := h.service.GetFullPVZInfo(c.Request.Context(), filter)
 	if err != nil {
-		h.log.Errorf("failed to get full PVZ info: %v", err)
+		log.Errorf("failed to get full PVZ info: %v", err)
 		dto.InternalError(c, "failed to get PVZ list")
 		return
 	}
@@ -118,7 +195,181 @@ func (h *PVZHandler) GetFullInfoPVZ(c *gin.Context) {
 	c.JSON(http.StatusOK, convertToResponse(pvzInfo))
 }
 
-func parseQueryTime(raw string, field string, c *gin.Context, log *logrus.Logger) *time.Time {
+// ChangePVZStatus godoc
+// @Summary Change PVZ status
+// @Tags pvz
+// @Description Перевод ПВЗ в статус active, suspended или closed. Для приостановки и закрытия нужна причина, закрытие необратимо
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Accept json
+// @Produce json
+// @Param pvzId path string true "PVZ ID"
+// @Param request body dto.PVZStatusRequest true "Новый статус и причина"
+// @Success 200 {object} dto.PVZResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /pvz/{pvzId}/status [post]
+func (h *PVZHandler) ChangePVZStatus(c *gin.Context) {
+	log := logger.FromContext(c.Request.Context(), h.log)
+
+	pvzID, ok := parsePVZParam(c, log)
+	if !ok {
+		return
+	}
+
+	var req dto.PVZStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Warnf("invalid PVZ status input: %v", err)
+		dto.BadRequest(c, "invalid request body")
+		return
+	}
+
+	h.changeStatus(c, pvzID, entity.PVZStatus(req.Status), req.Reason)
+}
+
+// ClosePVZ godoc
+// @Summary Close PVZ
+// @Tags pvz
+// @Description Мягкое удаление ПВЗ: перевод в статус closed. Приёмки и товары остаются доступны через includeClosed
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Produce json
+// @Param pvzId path string true "PVZ ID"
+// @Param reason query string true "Причина закрытия"
+// @Success 200 {object} dto.PVZResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /pvz/{pvzId} [delete]
+func (h *PVZHandler) ClosePVZ(c *gin.Context) {
+	log := logger.FromContext(c.Request.Context(), h.log)
+
+	pvzID, ok := parsePVZParam(c, log)
+	if !ok {
+		return
+	}
+
+	h.changeStatus(c, pvzID, entity.PVZStatusClosed, c.Query("reason"))
+}
+
+// parsePVZParam reads the :pvzId path parameter and checks that the caller may access it,
+// writing the error response itself when it returns false.
+func parsePVZParam(c *gin.Context, log *logrus.Entry) (uuid.UUID, bool) {
+	pvzIDParam := c.Param("pvzId")
+	pvzID, err := uuid.Parse(pvzIDParam)
+	if err != nil {
+		log.Warnf("invalid pvzId: %s", pvzIDParam)
+		dto.BadRequest(c, "invalid pvzId")
+		return uuid.Nil, false
+	}
+
+	if !middleware.CanAccessPVZ(c, pvzID) {
+		log.Warnf("api key is not allowed to access pvz: %s", pvzID)
+		dto.Forbidden(c, "access to this PVZ is not allowed")
+		return uuid.Nil, false
+	}
+
+	return pvzID, true
+}
+
+// canAccessAnyPVZ reports whether the caller may access at least one of the PVZs,
+// used for resources such as products that move between PVZs.
+func canAccessAnyPVZ(c *gin.Context, pvzIDs []uuid.UUID) bool {
+	for _, pvzID := range pvzIDs {
+		if middleware.CanAccessPVZ(c, pvzID) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (h *PVZHandler) changeStatus(c *gin.Context, pvzID uuid.UUID, status entity.PVZStatus, reason string) {
+	log := logger.FromContext(c.Request.Context(), h.log)
+
+	pvz, err := h.service.ChangePVZStatus(c.Request.Context(), pvzID, status, reason)
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrPVZNotFound):
+			dto.NotFound(c, "pvz not found")
+		case errors.Is(err, entity.ErrInvalidPVZStatus),
+			errors.Is(err, entity.ErrPVZStatusReason),
+			errors.Is(err, entity.ErrPVZStatusTransition),
+			errors.Is(err, entity.ErrPVZHasOpenReception):
+			dto.BadRequest(c, err.Error())
+		default:
+			log.Errorf("failed to change PVZ %s status: %v", pvzID, err)
+			dto.InternalError(c, "failed to change PVZ status")
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, toPVZResponse(*pvz))
+}
+
+// GetNearbyPVZ godoc
+// @Summary Get nearby PVZ
+// @Tags pvz
+// @Description Поиск ПВЗ рядом с точкой, отсортированных по расстоянию
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Produce json
+// @Param lat query number true "Широта"
+// @Param lon query number true "Долгота"
+// @Param radius query number false "Радиус поиска в метрах (по умолчанию 5000, максимум 50000)"
+// @Param limit query int false "Максимальное число ПВЗ (по умолчанию 10, максимум 50)"
+// @Success 200 {array} dto.NearbyPVZResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /pvz/nearby [get]
+func (h *PVZHandler) GetNearbyPVZ(c *gin.Context) {
+	log := logger.FromContext(c.Request.Context(), h.log)
+
+	var query dto.NearbyPVZQueryParams
+
+	if err := c.ShouldBindQuery(&query); err != nil {
+		log.Warnf("invalid nearby PVZ query: %v", err)
+		dto.BadRequest(c, "invalid query parameters")
+		return
+	}
+
+	filter := entity.NearbyFilter{
+		Latitude:     *query.Lat,
+		Longitude:    *query.Lon,
+		RadiusMeters: query.Radius,
+		Limit:        query.Limit,
+	}
+	if pvzID, ok := middleware.RestrictedPVZ(c); ok {
+		filter.PVZID = &pvzID
+	}
+
+	nearby, err := h.service.FindNearbyPVZ(c.Request.Context(), filter)
+	if err != nil {
+		if errors.Is(err, entity.ErrInvalidCoordinates) || errors.Is(err, entity.ErrInvalidSearchRadius) {
+			dto.BadRequest(c, err.Error())
+			return
+		}
+
+		log.Errorf("failed to search nearby PVZ: %v", err)
+		dto.InternalError(c, "failed to search PVZ")
+		return
+	}
+
+	result := make([]dto.NearbyPVZResponse, 0, len(nearby))
+	for _, n := range nearby {
+		result = append(result, dto.NearbyPVZResponse{
+			PVZ:            toPVZResponse(n.PVZ),
+			DistanceMeters: n.DistanceMeters,
+		})
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+func parseQueryTime(raw string, field string, c *gin.Context, log *logrus.Entry) *time.Time {
 	if raw == "" {
 		return nil
 	}
@@ -158,15 +409,62 @@ func convertToResponse(pvzInfo []entity.FullPVZInfo) []dto.FullPVZResponse {
 			})
 		}
 
+		returns := make([]dto.ReturnResponse, 0, len(info.Returns))
+		for _, ret := range info.Returns {
+			returns = append(returns, toReturnResponse(&ret))
+		}
+
 		result = append(result, dto.FullPVZResponse{
-			PVZ: dto.PVZResponse{
-				ID:               info.PVZ.ID.String(),
-				RegistrationDate: info.PVZ.RegistrationDate.Format(time.RFC3339),
-				City:             string(info.PVZ.City),
-			},
+			PVZ:        toPVZResponse(info.PVZ),
 			Receptions: receptions,
+			Returns:    returns,
 		})
 	}
 
 	return result
 }
+
+func toPVZResponse(pvz entity.PVZ) dto.PVZResponse {
+	resp := dto.PVZResponse{
+		ID:               pvz.ID.String(),
+		RegistrationDate: pvz.RegistrationDate.Format(time.RFC3339),
+		City:             string(pvz.City),
+		Address:          pvz.Address,
+		Latitude:         pvz.Latitude,
+		Longitude:        pvz.Longitude,
+		Phone:            pvz.Phone,
+		Status:           string(pvz.Status),
+		StatusReason:     pvz.StatusReason,
+	}
+
+	if pvz.StatusChangedAt != nil {
+		resp.StatusChangedAt = pvz.StatusChangedAt.Format(time.RFC3339)
+	}
+
+	if pvz.WorkingHours != nil {
+		hours := &dto.WorkingHours{}
+		for _, day := range pvz.WorkingHours.Weekly {
+			hours.Weekly = append(hours.Weekly, dto.DailyHours(day))
+		}
+		for _, exception := range pvz.WorkingHours.Exceptions {
+			hours.Exceptions = append(hours.Exceptions, dto.HoursException(exception))
+		}
+		resp.WorkingHours = hours
+	}
+
+	return resp
+}
+
+func toEntityWorkingHours(hours *dto.WorkingHours) *entity.WorkingHours {
+	result := &entity.WorkingHours{
+		Weekly: make([]entity.DailyHours, 0, len(hours.Weekly)),
+	}
+	for _, day := range hours.Weekly {
+		result.Weekly = append(result.Weekly, entity.DailyHours(day))
+	}
+	for _, exception := range hours.Exceptions {
+		result.Exceptions = append(result.Exceptions, entity.HoursException(exception))
+	}
+
+	return result
+}
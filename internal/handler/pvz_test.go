@@ -113,7 +113,7 @@ func TestPVZHandler_GetFullInfoPVZ(t *testing.T) {
 			input: "",
 			mock: func() {
 				mockService.EXPECT().
-					GetFullPVZInfo(gomock.Any(), nil, nil, 1, 10).
+					GetFullPVZInfo(gomock.Any(), entity.PVZFilter{Page: 1, Limit: 10}).
 					Return([]entity.FullPVZInfo{}, nil)
 			},
 			wantStatus: http.StatusOK,
@@ -129,7 +129,7 @@ func TestPVZHandler_GetFullInfoPVZ(t *testing.T) {
 			input: "",
 			mock: func() {
 				mockService.EXPECT().
-					GetFullPVZInfo(gomock.Any(), nil, nil, 1, 10).
+					GetFullPVZInfo(gomock.Any(), entity.PVZFilter{Page: 1, Limit: 10}).
 					Return(nil, assert.AnError)
 			},
 			wantStatus: http.StatusInternalServerError,
@@ -185,7 +185,7 @@ func TestParseQueryTime(t *testing.T) {
 			w := httptest.NewRecorder()
 			c, _ := gin.CreateTestContext(w)
 
-			res := parseQueryTime(tt.input, "testField", c, mockLog)
+			res := parseQueryTime(tt.input, "testField", c, logrus.NewEntry(mockLog))
 
 			if tt.expectNil {
 				assert.Nil(t, res)
@@ -235,9 +235,361 @@ func TestConvertToResponse(t *testing.T) {
 		},
 	}
 
+	full[0].Returns = []entity.CustomerReturn{
+		{ID: uuid.New(), PVZID: id, ProductID: uuid.New(), Status: entity.ReturnStatusAccepted, AcceptedAt: now},
+	}
+
 	resp := convertToResponse(full)
 	assert.Len(t, resp, 1)
+	assert.Len(t, resp[0].Returns, 1)
 	assert.Equal(t, string(entity.CityMoscow), resp[0].PVZ.City)
 	assert.Len(t, resp[0].Receptions, 1)
 	assert.Len(t, resp[0].Receptions[0].Products, 1)
 }
+
+func TestPVZHandler_UpdatePVZ(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockPVZOperations(ctrl)
+	mockLog := logrus.New()
+	h := NewPVZHandler(mockService, mockLog)
+
+	gin.SetMode(gin.TestMode)
+
+	pvzID := uuid.New()
+	lat, lon := 55.75, 37.61
+
+	tests := []struct {
+		name       string
+		param      string
+		input      string
+		mock       func()
+		wantStatus int
+	}{
+		{
+			name:  "success",
+			param: pvzID.String(),
+			input: `{"address":"Тверская, 1","latitude":55.75,"longitude":37.61,"workingHours":{"weekly":[{"weekday":"monday","open":"09:00","close":"21:00"}]}}`,
+			mock: func() {
+				mockService.EXPECT().
+					UpdatePVZDetails(gomock.Any(), pvzID, gomock.Any()).
+					DoAndReturn(func(_ interface{}, _ uuid.UUID, update entity.PVZDetailsUpdate) (*entity.PVZ, error) {
+						assert.Equal(t, "Тверская, 1", *update.Address)
+						assert.Len(t, update.WorkingHours.Weekly, 1)
+						return &entity.PVZ{
+							ID:               pvzID,
+							RegistrationDate: time.Now(),
+							City:             entity.CityMoscow,
+							Address:          *update.Address,
+							Latitude:         &lat,
+							Longitude:        &lon,
+							WorkingHours:     update.WorkingHours,
+						}, nil
+					})
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "invalid pvzId",
+			param:      "not-a-uuid",
+			input:      `{"address":"Тверская, 1"}`,
+			mock:       func() {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "latitude out of range",
+			param:      pvzID.String(),
+			input:      `{"latitude":91,"longitude":37.61}`,
+			mock:       func() {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:  "invalid working hours",
+			param: pvzID.String(),
+			input: `{"workingHours":{"weekly":[{"weekday":"funday","open":"09:00","close":"21:00"}]}}`,
+			mock: func() {
+				mockService.EXPECT().
+					UpdatePVZDetails(gomock.Any(), pvzID, gomock.Any()).
+					Return(nil, entity.ErrInvalidWorkingHours)
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:  "pvz not found",
+			param: pvzID.String(),
+			input: `{"phone":"+7 495 000-00-00"}`,
+			mock: func() {
+				mockService.EXPECT().
+					UpdatePVZDetails(gomock.Any(), pvzID, gomock.Any()).
+					Return(nil, entity.ErrPVZNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:  "internal error",
+			param: pvzID.String(),
+			input: `{"phone":"+7 495 000-00-00"}`,
+			mock: func() {
+				mockService.EXPECT().
+					UpdatePVZDetails(gomock.Any(), pvzID, gomock.Any()).
+					Return(nil, errors.New("db failure"))
+			},
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(rr)
+
+			ctx.Request, _ = http.NewRequest(http.MethodPatch, "/pvz/"+tt.param, bytes.NewBufferString(tt.input))
+			ctx.Request.Header.Set("Content-Type", "application/json")
+			ctx.Params = []gin.Param{{Key: "pvzId", Value: tt.param}}
+
+			tt.mock()
+			h.UpdatePVZ(ctx)
+
+			assert.Equal(t, tt.wantStatus, rr.Code)
+		})
+	}
+}
+
+func TestPVZHandler_GetNearbyPVZ(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockPVZOperations(ctrl)
+	mockLog := logrus.New()
+	h := NewPVZHandler(mockService, mockLog)
+
+	gin.SetMode(gin.TestMode)
+
+	lat, lon := 55.757, 37.613
+
+	tests := []struct {
+		name       string
+		query      string
+		mock       func()
+		wantStatus int
+	}{
+		{
+			name:  "success",
+			query: "lat=55.75&lon=37.61&radius=3000&limit=5",
+			mock: func() {
+				mockService.EXPECT().
+					FindNearbyPVZ(gomock.Any(), entity.NearbyFilter{Latitude: 55.75, Longitude: 37.61, RadiusMeters: 3000, Limit: 5}).
+					Return([]entity.NearbyPVZ{{
+						PVZ:            entity.PVZ{ID: uuid.New(), City: entity.CityMoscow, Latitude: &lat, Longitude: &lon},
+						DistanceMeters: 812.4,
+					}}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "missing lon",
+			query:      "lat=55.75",
+			mock:       func() {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "radius too large",
+			query:      "lat=55.75&lon=37.61&radius=100000",
+			mock:       func() {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:  "internal error",
+			query: "lat=55.75&lon=37.61",
+			mock: func() {
+				mockService.EXPECT().
+					FindNearbyPVZ(gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("db failure"))
+			},
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(rr)
+
+			ctx.Request, _ = http.NewRequest(http.MethodGet, "/pvz/nearby?"+tt.query, nil)
+
+			tt.mock()
+			h.GetNearbyPVZ(ctx)
+
+			assert.Equal(t, tt.wantStatus, rr.Code)
+		})
+	}
+}
+
+func TestPVZHandler_ChangePVZStatus(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockPVZOperations(ctrl)
+	mockLog := logrus.New()
+	h := NewPVZHandler(mockService, mockLog)
+
+	gin.SetMode(gin.TestMode)
+
+	pvzID := uuid.New()
+	changedAt := time.Now()
+
+	tests := []struct {
+		name       string
+		param      string
+		input      string
+		mock       func()
+		wantStatus int
+	}{
+		{
+			name:  "success",
+			param: pvzID.String(),
+			input: `{"status":"suspended","reason":"ремонт"}`,
+			mock: func() {
+				mockService.EXPECT().
+					ChangePVZStatus(gomock.Any(), pvzID, entity.PVZStatusSuspended, "ремонт").
+					Return(&entity.PVZ{
+						ID:              pvzID,
+						City:            entity.CityMoscow,
+						Status:          entity.PVZStatusSuspended,
+						StatusReason:    "ремонт",
+						StatusChangedAt: &changedAt,
+					}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "unknown status",
+			param:      pvzID.String(),
+			input:      `{"status":"deleted"}`,
+			mock:       func() {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "invalid pvzId",
+			param:      "123",
+			input:      `{"status":"active"}`,
+			mock:       func() {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:  "transition not allowed",
+			param: pvzID.String(),
+			input: `{"status":"active"}`,
+			mock: func() {
+				mockService.EXPECT().
+					ChangePVZStatus(gomock.Any(), pvzID, entity.PVZStatusActive, "").
+					Return(nil, entity.ErrPVZStatusTransition)
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:  "pvz not found",
+			param: pvzID.String(),
+			input: `{"status":"suspended","reason":"ремонт"}`,
+			mock: func() {
+				mockService.EXPECT().
+					ChangePVZStatus(gomock.Any(), pvzID, entity.PVZStatusSuspended, "ремонт").
+					Return(nil, entity.ErrPVZNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(rr)
+
+			ctx.Request, _ = http.NewRequest(http.MethodPost, "/pvz/"+tt.param+"/status", bytes.NewBufferString(tt.input))
+			ctx.Request.Header.Set("Content-Type", "application/json")
+			ctx.Params = []gin.Param{{Key: "pvzId", Value: tt.param}}
+
+			tt.mock()
+			h.ChangePVZStatus(ctx)
+
+			assert.Equal(t, tt.wantStatus, rr.Code)
+		})
+	}
+}
+
+func TestPVZHandler_ClosePVZ(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockPVZOperations(ctrl)
+	mockLog := logrus.New()
+	h := NewPVZHandler(mockService, mockLog)
+
+	gin.SetMode(gin.TestMode)
+
+	pvzID := uuid.New()
+
+	tests := []struct {
+		name       string
+		query      string
+		mock       func()
+		wantStatus int
+	}{
+		{
+			name:  "success",
+			query: "reason=moved",
+			mock: func() {
+				mockService.EXPECT().
+					ChangePVZStatus(gomock.Any(), pvzID, entity.PVZStatusClosed, "moved").
+					Return(&entity.PVZ{ID: pvzID, Status: entity.PVZStatusClosed, StatusReason: "moved"}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:  "missing reason",
+			query: "",
+			mock: func() {
+				mockService.EXPECT().
+					ChangePVZStatus(gomock.Any(), pvzID, entity.PVZStatusClosed, "").
+					Return(nil, entity.ErrPVZStatusReason)
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:  "open reception",
+			query: "reason=moved",
+			mock: func() {
+				mockService.EXPECT().
+					ChangePVZStatus(gomock.Any(), pvzID, entity.PVZStatusClosed, "moved").
+					Return(nil, entity.ErrPVZHasOpenReception)
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:  "internal error",
+			query: "reason=moved",
+			mock: func() {
+				mockService.EXPECT().
+					ChangePVZStatus(gomock.Any(), pvzID, entity.PVZStatusClosed, "moved").
+					Return(nil, errors.New("db failure"))
+			},
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(rr)
+
+			ctx.Request, _ = http.NewRequest(http.MethodDelete, "/pvz/"+pvzID.String()+"?"+tt.query, nil)
+			ctx.Params = []gin.Param{{Key: "pvzId", Value: pvzID.String()}}
+
+			tt.mock()
+			h.ClosePVZ(ctx)
+
+			assert.Equal(t, tt.wantStatus, rr.Code)
+		})
+	}
+}
@@ -11,6 +11,8 @@ import (
 
 	"github.com/senyabanana/pvz-service/internal/dto"
 	"github.com/senyabanana/pvz-service/internal/entity"
+	"github.com/senyabanana/pvz-service/internal/infrastructure/logger"
+	"github.com/senyabanana/pvz-service/internal/middleware"
 	"github.com/senyabanana/pvz-service/internal/service"
 )
 
@@ -28,30 +30,40 @@ func NewReceptionHandler(service service.ReceptionOperations, log *logrus.Logger
 // @Tags reception
 // @Description Создание приёмки для ПВЗ
 // @Security BearerAuth
+// @Security APIKeyAuth
 // @Accept json
 // @Produce json
 // @Param input body dto.ReceptionRequest true "PVZ ID"
 // @Success 201 {object} dto.ReceptionResponse
 // @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
 // @Failure 404 {object} dto.ErrorResponse
 // @Failure 500 {object} dto.ErrorResponse
 // @Router /receptions [post]
 func (h *ReceptionHandler) CreateReception(c *gin.Context) {
+	log := logger.FromContext(c.Request.Context(), h.log)
+
 	var req dto.ReceptionRequest
 
 	if err := c.ShouldBindJSON(&req); err != nil {
-		h.log.Warnf("invalid reception input: %v", err)
+		log.Warnf("invalid reception input: %v", err)
 		dto.BadRequest(c, "invalid pvzId")
 		return
 	}
 
 	pvzID, err := uuid.Parse(req.PVZID)
 	if err != nil {
-		h.log.Warnf("invalid UUID format: %v", err)
+		log.Warnf("invalid UUID format: %v", err)
 		dto.BadRequest(c, "invalid UUID format")
 		return
 	}
 
+	if !middleware.CanAccessPVZ(c, pvzID) {
+		log.Warnf("api key is not allowed to access pvz: %s", pvzID)
+		dto.Forbidden(c, "access to this PVZ is not allowed")
+		return
+	}
+
 	reception, err := h.service.CreateReception(c.Request.Context(), pvzID)
 	if err != nil {
 		switch {
@@ -61,6 +73,9 @@ func (h *ReceptionHandler) CreateReception(c *gin.Context) {
 		case errors.Is(err, entity.ErrPVZNotFound):
 			dto.NotFound(c, "pvz not found")
 			return
+		case errors.Is(err, entity.ErrPVZNotActive):
+			dto.BadRequest(c, "pvz is not active")
+			return
 		default:
 			dto.InternalError(c, "failed to create reception")
 			return
@@ -80,22 +95,32 @@ func (h *ReceptionHandler) CreateReception(c *gin.Context) {
 // @Tags reception
 // @Description Закрытие последней открытой приёмки у ПВЗ
 // @Security BearerAuth
+// @Security APIKeyAuth
 // @Accept json
 // @Produce json
 // @Param pvzId path string true "PVZ ID"
 // @Success 200 {object} dto.ReceptionResponse
 // @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
 // @Failure 500 {object} dto.ErrorResponse
 // @Router /pvz/{pvzId}/close_last_reception [post]
 func (h *ReceptionHandler) CloseLastReception(c *gin.Context) {
+	log := logger.FromContext(c.Request.Context(), h.log)
+
 	pvzIDParam := c.Param("pvzId")
 	pvzID, err := uuid.Parse(pvzIDParam)
 	if err != nil {
-		h.log.Warnf("invalid pvzId: %s", pvzIDParam)
+		log.Warnf("invalid pvzId: %s", pvzIDParam)
 		dto.BadRequest(c, "invalid pvzId")
 		return
 	}
 
+	if !middleware.CanAccessPVZ(c, pvzID) {
+		log.Warnf("api key is not allowed to access pvz: %s", pvzID)
+		dto.Forbidden(c, "access to this PVZ is not allowed")
+		return
+	}
+
 	reception, err := h.service.CloseLastReception(c.Request.Context(), pvzID)
 	if err != nil {
 		switch {
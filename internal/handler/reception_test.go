@@ -66,6 +66,14 @@ func TestReceptionHandler_CreateReception(t *testing.T) {
 			},
 			wantStatus: http.StatusNotFound,
 		},
+		{
+			name:      "PVZ suspended",
+			inputBody: `{"pvzId":"` + uuid.New().String() + `"}`,
+			mock: func() {
+				mockService.EXPECT().CreateReception(gomock.Any(), gomock.Any()).Return(nil, entity.ErrPVZNotActive)
+			},
+			wantStatus: http.StatusBadRequest,
+		},
 		{
 			name:      "reception already exists",
 			inputBody: `{"pvzId":"` + uuid.New().String() + `"}`,
@@ -0,0 +1,257 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+
+	"github.com/senyabanana/pvz-service/internal/dto"
+	"github.com/senyabanana/pvz-service/internal/entity"
+	"github.com/senyabanana/pvz-service/internal/infrastructure/logger"
+	"github.com/senyabanana/pvz-service/internal/middleware"
+	"github.com/senyabanana/pvz-service/internal/service"
+)
+
+type ReportJobHandler struct {
+	service service.ReportJobOperations
+	log     *logrus.Logger
+}
+
+func NewReportJobHandler(service service.ReportJobOperations, log *logrus.Logger) *ReportJobHandler {
+	return &ReportJobHandler{
+		service: service,
+		log:     log,
+	}
+}
+
+// CreateReportJob godoc
+// @Summary Create report job
+// @Tags jobs
+// @Description Постановка отчёта в очередь на фоновое формирование; аналитические отчёты доступны только модераторам
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Accept json
+// @Produce json
+// @Param request body dto.CreateReportJobRequest true "Параметры отчёта"
+// @Success 202 {object} dto.ReportJobResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /jobs [post]
+func (h *ReportJobHandler) CreateReportJob(c *gin.Context) {
+	log := logger.FromContext(c.Request.Context(), h.log)
+
+	var req dto.CreateReportJobRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Warnf("invalid report job input: %v", err)
+		dto.BadRequest(c, "invalid report job")
+		return
+	}
+
+	kind := entity.ReportKind(req.Kind)
+	if kind.IsAnalytics() && !isModerator(c) {
+		dto.Forbidden(c, "analytics reports are available to moderators only")
+		return
+	}
+
+	// Dates and the PVZ ID are validated by the binding, so parsing cannot fail here.
+	params := entity.ReportParams{
+		Format:        entity.ExportFormat(req.Format),
+		IncludeClosed: req.IncludeClosed,
+		GroupBy:       entity.AnalyticsGroupBy(req.GroupBy),
+		Period:        entity.AnalyticsPeriod(req.Period),
+		City:          req.City,
+	}
+	if req.StartDate != nil {
+		startDate, _ := time.Parse(time.RFC3339, *req.StartDate)
+		params.StartDate = &startDate
+	}
+	if req.EndDate != nil {
+		endDate, _ := time.Parse(time.RFC3339, *req.EndDate)
+		params.EndDate = &endDate
+	}
+	if req.PVZID != nil {
+		pvzID := uuid.MustParse(*req.PVZID)
+		params.PVZID = &pvzID
+	}
+
+	if restricted, ok := middleware.RestrictedPVZ(c); ok {
+		if params.PVZID != nil && *params.PVZID != restricted {
+			dto.Forbidden(c, "access to this PVZ is not allowed")
+			return
+		}
+		params.PVZID = &restricted
+	}
+
+	job, err := h.service.CreateReportJob(c.Request.Context(), entity.ReportJob{
+		Kind:      kind,
+		Params:    params,
+		CreatedBy: employeeIDFromContext(c),
+		APIKeyID:  apiKeyIDFromContext(c),
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrInvalidReportJob),
+			errors.Is(err, entity.ErrInvalidAnalyticsFilter):
+			dto.BadRequest(c, err.Error())
+		default:
+			dto.InternalError(c, "failed to create report job")
+		}
+		return
+	}
+
+	c.JSON(http.StatusAccepted, toReportJobResponse(job))
+}
+
+// GetReportJob godoc
+// @Summary Get report job
+// @Tags jobs
+// @Description Статус и прогресс фонового отчёта; доступен автору задачи и модераторам
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Produce json
+// @Param jobId path string true "Job ID"
+// @Success 200 {object} dto.ReportJobResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /jobs/{jobId} [get]
+func (h *ReportJobHandler) GetReportJob(c *gin.Context) {
+	job, ok := h.getReportJob(c)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, toReportJobResponse(job))
+}
+
+// GetReportArtifact godoc
+// @Summary Download report
+// @Tags jobs
+// @Description Скачивание готового отчёта
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Produce text/csv
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param jobId path string true "Job ID"
+// @Success 200 {file} file
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /jobs/{jobId}/artifact [get]
+func (h *ReportJobHandler) GetReportArtifact(c *gin.Context) {
+	job, ok := h.getReportJob(c)
+	if !ok {
+		return
+	}
+
+	if job.Status != entity.ReportJobSucceeded {
+		dto.BadRequest(c, fmt.Sprintf("%s: job is %s", entity.ErrReportNotReady, job.Status))
+		return
+	}
+
+	artifact, err := h.service.GetReportArtifact(c.Request.Context(), job.ID)
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrReportNotReady):
+			dto.BadRequest(c, err.Error())
+		default:
+			dto.InternalError(c, "failed to get report")
+		}
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, artifact.Name))
+	c.Data(http.StatusOK, artifact.ContentType, artifact.Data)
+}
+
+// getReportJob loads the job from the path; jobs of other users and API keys are reported as not found.
+func (h *ReportJobHandler) getReportJob(c *gin.Context) (*entity.ReportJob, bool) {
+	log := logger.FromContext(c.Request.Context(), h.log)
+
+	jobIDParam := c.Param("jobId")
+	jobID, err := uuid.Parse(jobIDParam)
+	if err != nil {
+		log.Warnf("invalid jobId: %s", jobIDParam)
+		dto.BadRequest(c, "invalid jobId")
+		return nil, false
+	}
+
+	job, err := h.service.GetReportJob(c.Request.Context(), jobID)
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrReportJobNotFound):
+			dto.NotFound(c, "report job not found")
+		default:
+			dto.InternalError(c, "failed to get report job")
+		}
+		return nil, false
+	}
+
+	if !canViewReportJob(c, job) {
+		log.Warnf("report job %s requested by another caller", jobID)
+		dto.NotFound(c, "report job not found")
+		return nil, false
+	}
+
+	return job, true
+}
+
+func canViewReportJob(c *gin.Context, job *entity.ReportJob) bool {
+	if isModerator(c) {
+		return true
+	}
+
+	if userID := employeeIDFromContext(c); userID != nil {
+		return job.CreatedBy != nil && *job.CreatedBy == *userID
+	}
+	if keyID := apiKeyIDFromContext(c); keyID != nil {
+		return job.APIKeyID != nil && *job.APIKeyID == *keyID
+	}
+
+	return false
+}
+
+// isModerator reports whether the request carries a moderator JWT; API keys never act as moderators.
+func isModerator(c *gin.Context) bool {
+	claims, ok := middleware.GetTokenClaims(c)
+
+	return ok && claims.Role == entity.RoleModerator
+}
+
+func apiKeyIDFromContext(c *gin.Context) *uuid.UUID {
+	keyID, err := uuid.Parse(middleware.GetAPIKeyID(c))
+	if err != nil {
+		return nil
+	}
+
+	return &keyID
+}
+
+func toReportJobResponse(job *entity.ReportJob) dto.ReportJobResponse {
+	resp := dto.ReportJobResponse{
+		ID:           job.ID.String(),
+		Kind:         string(job.Kind),
+		Format:       string(job.Params.Format),
+		Status:       string(job.Status),
+		Progress:     job.Progress,
+		Attempts:     job.Attempts,
+		Error:        job.Error,
+		CreatedAt:    job.CreatedAt.Format(time.RFC3339),
+		ArtifactName: job.ArtifactName,
+		ArtifactSize: job.ArtifactSize,
+	}
+	if job.StartedAt != nil {
+		resp.StartedAt = job.StartedAt.Format(time.RFC3339)
+	}
+	if job.FinishedAt != nil {
+		resp.FinishedAt = job.FinishedAt.Format(time.RFC3339)
+	}
+
+	return resp
+}
@@ -0,0 +1,277 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/senyabanana/pvz-service/internal/entity"
+	mocks "github.com/senyabanana/pvz-service/internal/service/mocks"
+)
+
+func TestReportJobHandler_CreateReportJob(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockReportJobOperations(ctrl)
+	mockLog := logrus.New()
+	h := NewReportJobHandler(mockService, mockLog)
+	gin.SetMode(gin.TestMode)
+
+	userID, keyID, pvzID := uuid.New(), uuid.New(), uuid.New()
+	moderator := entity.TokenClaims{UserID: userID.String(), Role: entity.RoleModerator}
+	employee := entity.TokenClaims{UserID: userID.String(), Role: entity.RoleEmployee}
+
+	tests := []struct {
+		name       string
+		body       string
+		claims     *entity.TokenClaims
+		restricted *uuid.UUID
+		mock       func()
+		wantStatus int
+	}{
+		{
+			name:   "export by employee",
+			body:   `{"kind":"export_products","format":"xlsx","startDate":"2025-04-01T00:00:00Z"}`,
+			claims: &employee,
+			mock: func() {
+				mockService.EXPECT().CreateReportJob(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, job entity.ReportJob) (*entity.ReportJob, error) {
+						assert.Equal(t, &userID, job.CreatedBy)
+						assert.NotNil(t, job.Params.StartDate)
+						job.ID, job.Status = uuid.New(), entity.ReportJobQueued
+						return &job, nil
+					})
+			},
+			wantStatus: http.StatusAccepted,
+		},
+		{
+			name:       "api key is bound to its pvz",
+			body:       `{"kind":"export_receptions"}`,
+			restricted: &pvzID,
+			mock: func() {
+				mockService.EXPECT().CreateReportJob(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, job entity.ReportJob) (*entity.ReportJob, error) {
+						assert.Equal(t, &pvzID, job.Params.PVZID)
+						assert.Equal(t, &keyID, job.APIKeyID)
+						return &job, nil
+					})
+			},
+			wantStatus: http.StatusAccepted,
+		},
+		{
+			name:       "api key asks for another pvz",
+			body:       `{"kind":"export_receptions","pvzId":"` + uuid.NewString() + `"}`,
+			restricted: &pvzID,
+			mock:       func() {},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "analytics by employee",
+			body:       `{"kind":"analytics_products","startDate":"2025-04-01T00:00:00Z","endDate":"2025-04-08T00:00:00Z"}`,
+			claims:     &employee,
+			mock:       func() {},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:   "invalid analytics filter",
+			body:   `{"kind":"analytics_products"}`,
+			claims: &moderator,
+			mock: func() {
+				mockService.EXPECT().CreateReportJob(gomock.Any(), gomock.Any()).Return(nil, entity.ErrInvalidAnalyticsFilter)
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "unknown kind",
+			body:       `{"kind":"users"}`,
+			claims:     &moderator,
+			mock:       func() {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "internal error",
+			body:   `{"kind":"export_pvz"}`,
+			claims: &moderator,
+			mock: func() {
+				mockService.EXPECT().CreateReportJob(gomock.Any(), gomock.Any()).Return(nil, errors.New("db down"))
+			},
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest(http.MethodPost, "/jobs", bytes.NewBufferString(tt.body))
+			c.Request.Header.Set("Content-Type", "application/json")
+			if tt.claims != nil {
+				c.Set("user_id", tt.claims.UserID)
+				c.Set("token_claims", *tt.claims)
+			}
+			if tt.restricted != nil {
+				c.Set("api_key_id", keyID.String())
+				c.Set("restricted_pvz_id", *tt.restricted)
+			}
+
+			tt.mock()
+			h.CreateReportJob(c)
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
+
+func TestReportJobHandler_GetReportJob(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockReportJobOperations(ctrl)
+	mockLog := logrus.New()
+	h := NewReportJobHandler(mockService, mockLog)
+	gin.SetMode(gin.TestMode)
+
+	jobID, ownerID := uuid.New(), uuid.New()
+	job := &entity.ReportJob{ID: jobID, Kind: entity.ReportExportPVZ, Status: entity.ReportJobRunning, CreatedBy: &ownerID}
+
+	tests := []struct {
+		name       string
+		jobID      string
+		claims     entity.TokenClaims
+		mock       func()
+		wantStatus int
+	}{
+		{
+			name:   "owner",
+			jobID:  jobID.String(),
+			claims: entity.TokenClaims{UserID: ownerID.String(), Role: entity.RoleEmployee},
+			mock: func() {
+				mockService.EXPECT().GetReportJob(gomock.Any(), jobID).Return(job, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:   "moderator",
+			jobID:  jobID.String(),
+			claims: entity.TokenClaims{UserID: uuid.NewString(), Role: entity.RoleModerator},
+			mock: func() {
+				mockService.EXPECT().GetReportJob(gomock.Any(), jobID).Return(job, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:   "another employee",
+			jobID:  jobID.String(),
+			claims: entity.TokenClaims{UserID: uuid.NewString(), Role: entity.RoleEmployee},
+			mock: func() {
+				mockService.EXPECT().GetReportJob(gomock.Any(), jobID).Return(job, nil)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:   "not found",
+			jobID:  jobID.String(),
+			claims: entity.TokenClaims{UserID: ownerID.String(), Role: entity.RoleEmployee},
+			mock: func() {
+				mockService.EXPECT().GetReportJob(gomock.Any(), jobID).Return(nil, entity.ErrReportJobNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "invalid job id",
+			jobID:      "bad",
+			mock:       func() {},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest(http.MethodGet, "/jobs/"+tt.jobID, nil)
+			c.Params = gin.Params{{Key: "jobId", Value: tt.jobID}}
+			c.Set("user_id", tt.claims.UserID)
+			c.Set("token_claims", tt.claims)
+
+			tt.mock()
+			h.GetReportJob(c)
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
+
+func TestReportJobHandler_GetReportArtifact(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockReportJobOperations(ctrl)
+	mockLog := logrus.New()
+	h := NewReportJobHandler(mockService, mockLog)
+	gin.SetMode(gin.TestMode)
+
+	jobID, keyID := uuid.New(), uuid.New()
+
+	tests := []struct {
+		name       string
+		mock       func()
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name: "success",
+			mock: func() {
+				mockService.EXPECT().GetReportJob(gomock.Any(), jobID).
+					Return(&entity.ReportJob{ID: jobID, Status: entity.ReportJobSucceeded, APIKeyID: &keyID}, nil)
+				mockService.EXPECT().GetReportArtifact(gomock.Any(), jobID).
+					Return(&entity.ReportArtifact{Name: "export_pvz.csv", ContentType: "text/csv; charset=utf-8", Data: []byte("id\n")}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   "id\n",
+		},
+		{
+			name: "not ready",
+			mock: func() {
+				mockService.EXPECT().GetReportJob(gomock.Any(), jobID).
+					Return(&entity.ReportJob{ID: jobID, Status: entity.ReportJobQueued, APIKeyID: &keyID}, nil)
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "another api key",
+			mock: func() {
+				otherKeyID := uuid.New()
+				mockService.EXPECT().GetReportJob(gomock.Any(), jobID).
+					Return(&entity.ReportJob{ID: jobID, Status: entity.ReportJobSucceeded, APIKeyID: &otherKeyID}, nil)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest(http.MethodGet, "/jobs/"+jobID.String()+"/artifact", nil)
+			c.Params = gin.Params{{Key: "jobId", Value: jobID.String()}}
+			c.Set("api_key_id", keyID.String())
+
+			tt.mock()
+			h.GetReportArtifact(c)
+			assert.Equal(t, tt.wantStatus, w.Code)
+			if tt.wantBody != "" {
+				assert.Equal(t, tt.wantBody, w.Body.String())
+				assert.Contains(t, w.Header().Get("Content-Disposition"), "export_pvz.csv")
+			}
+		})
+	}
+}
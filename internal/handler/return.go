@@ -0,0 +1,177 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+
+	"github.com/senyabanana/pvz-service/internal/dto"
+	"github.com/senyabanana/pvz-service/internal/entity"
+	"github.com/senyabanana/pvz-service/internal/infrastructure/logger"
+	"github.com/senyabanana/pvz-service/internal/middleware"
+	"github.com/senyabanana/pvz-service/internal/service"
+)
+
+type ReturnHandler struct {
+	service service.ReturnOperations
+	log     *logrus.Logger
+}
+
+func NewReturnHandler(service service.ReturnOperations, log *logrus.Logger) *ReturnHandler {
+	return &ReturnHandler{
+		service: service,
+		log:     log,
+	}
+}
+
+// AcceptReturn godoc
+// @Summary Accept customer return
+// @Tags returns
+// @Description Приём возврата от клиента: ранее выданный товар, причина возврата и оценка состояния
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Accept json
+// @Produce json
+// @Param pvzId path string true "PVZ ID"
+// @Param request body dto.ReturnRequest true "Возврат"
+// @Success 201 {object} dto.ReturnResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /pvz/{pvzId}/returns [post]
+func (h *ReturnHandler) AcceptReturn(c *gin.Context) {
+	log := logger.FromContext(c.Request.Context(), h.log)
+
+	pvzID, ok := parsePVZParam(c, log)
+	if !ok {
+		return
+	}
+
+	var req dto.ReturnRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Warnf("invalid return input: %v", err)
+		dto.BadRequest(c, "invalid productId, reason or condition")
+		return
+	}
+
+	ret := entity.CustomerReturn{
+		PVZID:     pvzID,
+		ProductID: uuid.MustParse(req.ProductID),
+		Reason:    entity.ReturnReason(req.Reason),
+		Condition: entity.ItemCondition(req.Condition),
+		Comment:   req.Comment,
+	}
+	if employeeID, err := uuid.Parse(middleware.GetUserID(c)); err == nil {
+		ret.AcceptedBy = &employeeID
+	}
+
+	accepted, err := h.service.AcceptReturn(c.Request.Context(), ret)
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrPVZNotFound):
+			dto.NotFound(c, "pvz not found")
+		case errors.Is(err, entity.ErrProductNotFound):
+			dto.NotFound(c, "product not found")
+		case errors.Is(err, entity.ErrPVZNotActive),
+			errors.Is(err, entity.ErrProductNotIssued),
+			errors.Is(err, entity.ErrProductStatusTransition),
+			errors.Is(err, entity.ErrInvalidReturnReason),
+			errors.Is(err, entity.ErrInvalidItemCondition):
+			dto.BadRequest(c, err.Error())
+		case errors.Is(err, entity.ErrReturnAlreadyExists):
+			dto.Conflict(c, err.Error())
+		default:
+			dto.InternalError(c, "failed to accept return")
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, toReturnResponse(accepted))
+}
+
+// ChangeReturnStatus godoc
+// @Summary Change customer return status
+// @Tags returns
+// @Description Перевод возврата на следующий этап: accepted → ready_to_ship → shipped_to_warehouse
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Accept json
+// @Produce json
+// @Param pvzId path string true "PVZ ID"
+// @Param returnId path string true "Return ID"
+// @Param request body dto.ReturnStatusRequest true "Новый статус"
+// @Success 200 {object} dto.ReturnResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /pvz/{pvzId}/returns/{returnId}/status [post]
+func (h *ReturnHandler) ChangeReturnStatus(c *gin.Context) {
+	log := logger.FromContext(c.Request.Context(), h.log)
+
+	pvzID, ok := parsePVZParam(c, log)
+	if !ok {
+		return
+	}
+
+	returnID, err := uuid.Parse(c.Param("returnId"))
+	if err != nil {
+		log.Warnf("invalid returnId: %v", err)
+		dto.BadRequest(c, "invalid returnId")
+		return
+	}
+
+	var req dto.ReturnStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Warnf("invalid return status input: %v", err)
+		dto.BadRequest(c, "status must be ready_to_ship or shipped_to_warehouse")
+		return
+	}
+
+	ret, err := h.service.ChangeReturnStatus(c.Request.Context(), pvzID, returnID, entity.ReturnStatus(req.Status))
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrReturnNotFound):
+			dto.NotFound(c, "return not found")
+		case errors.Is(err, entity.ErrReturnStatusTransition), errors.Is(err, entity.ErrInvalidReturnStatus):
+			dto.BadRequest(c, err.Error())
+		default:
+			dto.InternalError(c, "failed to change return status")
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, toReturnResponse(ret))
+}
+
+func toReturnResponse(ret *entity.CustomerReturn) dto.ReturnResponse {
+	resp := dto.ReturnResponse{
+		ID:              ret.ID.String(),
+		PVZID:           ret.PVZID.String(),
+		ProductID:       ret.ProductID.String(),
+		Reason:          string(ret.Reason),
+		Condition:       string(ret.Condition),
+		Comment:         ret.Comment,
+		Status:          string(ret.Status),
+		AcceptedAt:      ret.AcceptedAt.Format(time.RFC3339),
+		StatusChangedAt: ret.StatusChangedAt.Format(time.RFC3339),
+	}
+
+	if ret.ClientID != nil {
+		resp.ClientID = ret.ClientID.String()
+	}
+	if ret.AcceptedBy != nil {
+		resp.AcceptedBy = ret.AcceptedBy.String()
+	}
+	if ret.ShippedAt != nil {
+		resp.ShippedAt = ret.ShippedAt.Format(time.RFC3339)
+	}
+
+	return resp
+}
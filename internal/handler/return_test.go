@@ -0,0 +1,181 @@
+package handler
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/senyabanana/pvz-service/internal/entity"
+	mocks "github.com/senyabanana/pvz-service/internal/service/mocks"
+)
+
+func TestReturnHandler_AcceptReturn(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockReturnOperations(ctrl)
+	mockLog := logrus.New()
+	h := NewReturnHandler(mockService, mockLog)
+	gin.SetMode(gin.TestMode)
+
+	pvzID, productID := uuid.New(), uuid.New()
+	validBody := fmt.Sprintf(`{"productId":"%s","reason":"defective","condition":"opened"}`, productID)
+
+	tests := []struct {
+		name       string
+		inputBody  string
+		mock       func()
+		wantStatus int
+	}{
+		{
+			name:      "success",
+			inputBody: validBody,
+			mock: func() {
+				mockService.EXPECT().AcceptReturn(gomock.Any(), gomock.Any()).
+					Return(&entity.CustomerReturn{ID: uuid.New(), PVZID: pvzID, ProductID: productID, AcceptedAt: time.Now()}, nil)
+			},
+			wantStatus: http.StatusCreated,
+		},
+		{
+			name:       "unknown reason",
+			inputBody:  fmt.Sprintf(`{"productId":"%s","reason":"bored","condition":"opened"}`, productID),
+			mock:       func() {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:      "product not issued",
+			inputBody: validBody,
+			mock: func() {
+				mockService.EXPECT().AcceptReturn(gomock.Any(), gomock.Any()).Return(nil, entity.ErrProductNotIssued)
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:      "product already returned",
+			inputBody: validBody,
+			mock: func() {
+				mockService.EXPECT().AcceptReturn(gomock.Any(), gomock.Any()).Return(nil, entity.ErrReturnAlreadyExists)
+			},
+			wantStatus: http.StatusConflict,
+		},
+		{
+			name:      "product not found",
+			inputBody: validBody,
+			mock: func() {
+				mockService.EXPECT().AcceptReturn(gomock.Any(), gomock.Any()).Return(nil, entity.ErrProductNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:      "internal error",
+			inputBody: validBody,
+			mock: func() {
+				mockService.EXPECT().AcceptReturn(gomock.Any(), gomock.Any()).Return(nil, errors.New("db down"))
+			},
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest(http.MethodPost, "/", bytes.NewBufferString(tt.inputBody))
+			c.Request.Header.Set("Content-Type", "application/json")
+			c.Params = []gin.Param{{Key: "pvzId", Value: pvzID.String()}}
+
+			tt.mock()
+			h.AcceptReturn(c)
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
+
+func TestReturnHandler_ChangeReturnStatus(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockReturnOperations(ctrl)
+	mockLog := logrus.New()
+	h := NewReturnHandler(mockService, mockLog)
+	gin.SetMode(gin.TestMode)
+
+	pvzID, returnID := uuid.New(), uuid.New()
+
+	tests := []struct {
+		name       string
+		returnID   string
+		inputBody  string
+		mock       func()
+		wantStatus int
+	}{
+		{
+			name:      "success",
+			returnID:  returnID.String(),
+			inputBody: `{"status":"ready_to_ship"}`,
+			mock: func() {
+				mockService.EXPECT().ChangeReturnStatus(gomock.Any(), pvzID, returnID, entity.ReturnStatusReadyToShip).
+					Return(&entity.CustomerReturn{ID: returnID, Status: entity.ReturnStatusReadyToShip}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "invalid returnId",
+			returnID:   "abc",
+			inputBody:  `{"status":"ready_to_ship"}`,
+			mock:       func() {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "back to accepted",
+			returnID:   returnID.String(),
+			inputBody:  `{"status":"accepted"}`,
+			mock:       func() {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:      "transition not allowed",
+			returnID:  returnID.String(),
+			inputBody: `{"status":"shipped_to_warehouse"}`,
+			mock: func() {
+				mockService.EXPECT().ChangeReturnStatus(gomock.Any(), pvzID, returnID, entity.ReturnStatusShipped).
+					Return(nil, entity.ErrReturnStatusTransition)
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:      "not found",
+			returnID:  returnID.String(),
+			inputBody: `{"status":"ready_to_ship"}`,
+			mock: func() {
+				mockService.EXPECT().ChangeReturnStatus(gomock.Any(), pvzID, returnID, entity.ReturnStatusReadyToShip).
+					Return(nil, entity.ErrReturnNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest(http.MethodPost, "/", bytes.NewBufferString(tt.inputBody))
+			c.Request.Header.Set("Content-Type", "application/json")
+			c.Params = []gin.Param{{Key: "pvzId", Value: pvzID.String()}, {Key: "returnId", Value: tt.returnID}}
+
+			tt.mock()
+			h.ChangeReturnStatus(c)
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
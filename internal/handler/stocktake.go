@@ -0,0 +1,269 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+
+	"github.com/senyabanana/pvz-service/internal/dto"
+	"github.com/senyabanana/pvz-service/internal/entity"
+	"github.com/senyabanana/pvz-service/internal/infrastructure/logger"
+	"github.com/senyabanana/pvz-service/internal/middleware"
+	"github.com/senyabanana/pvz-service/internal/service"
+)
+
+type StocktakeHandler struct {
+	service service.StocktakeOperations
+	log     *logrus.Logger
+}
+
+func NewStocktakeHandler(service service.StocktakeOperations, log *logrus.Logger) *StocktakeHandler {
+	return &StocktakeHandler{
+		service: service,
+		log:     log,
+	}
+}
+
+// StartStocktake godoc
+// @Summary Start stocktake
+// @Tags stocktakes
+// @Description Начало инвентаризации ПВЗ; одновременно в ПВЗ может быть открыта только одна инвентаризация
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Produce json
+// @Param pvzId path string true "PVZ ID"
+// @Success 201 {object} dto.StocktakeResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /pvz/{pvzId}/stocktakes [post]
+func (h *StocktakeHandler) StartStocktake(c *gin.Context) {
+	log := logger.FromContext(c.Request.Context(), h.log)
+
+	pvzID, ok := parsePVZParam(c, log)
+	if !ok {
+		return
+	}
+
+	stocktake, err := h.service.StartStocktake(c.Request.Context(), pvzID, employeeIDFromContext(c))
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrPVZNotFound):
+			dto.NotFound(c, "pvz not found")
+		case errors.Is(err, entity.ErrPVZNotActive),
+			errors.Is(err, entity.ErrStocktakeInProgress):
+			dto.BadRequest(c, err.Error())
+		default:
+			dto.InternalError(c, "failed to start stocktake")
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, toStocktakeResponse(stocktake))
+}
+
+// ScanProducts godoc
+// @Summary Scan products during stocktake
+// @Tags stocktakes
+// @Description Регистрация товаров, найденных на полках; повторное сканирование товара не учитывается
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Accept json
+// @Produce json
+// @Param pvzId path string true "PVZ ID"
+// @Param stocktakeId path string true "Stocktake ID"
+// @Param request body dto.StocktakeScanRequest true "Отсканированные товары"
+// @Success 200 {object} dto.StocktakeResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /pvz/{pvzId}/stocktakes/{stocktakeId}/scans [post]
+func (h *StocktakeHandler) ScanProducts(c *gin.Context) {
+	log := logger.FromContext(c.Request.Context(), h.log)
+
+	pvzID, stocktakeID, ok := h.parseStocktakeParams(c)
+	if !ok {
+		return
+	}
+
+	var req dto.StocktakeScanRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Warnf("invalid stocktake scan input: %v", err)
+		dto.BadRequest(c, "invalid productIds")
+		return
+	}
+
+	productIDs := make([]uuid.UUID, 0, len(req.ProductIDs))
+	for _, id := range req.ProductIDs {
+		productIDs = append(productIDs, uuid.MustParse(id))
+	}
+
+	stocktake, err := h.service.ScanProducts(c.Request.Context(), pvzID, stocktakeID, productIDs, employeeIDFromContext(c))
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrStocktakeNotFound):
+			dto.NotFound(c, "stocktake not found")
+		case errors.Is(err, entity.ErrStocktakeCompleted),
+			errors.Is(err, entity.ErrInvalidStocktakeScan):
+			dto.BadRequest(c, err.Error())
+		default:
+			dto.InternalError(c, "failed to record stocktake scans")
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, toStocktakeResponse(stocktake))
+}
+
+// CompleteStocktake godoc
+// @Summary Complete stocktake
+// @Tags stocktakes
+// @Description Завершение инвентаризации: сверка отсканированных товаров с ожидаемыми остатками и подписание отчёта сотрудником
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param pvzId path string true "PVZ ID"
+// @Param stocktakeId path string true "Stocktake ID"
+// @Param request body dto.StocktakeCompleteRequest false "Комментарий к отчёту"
+// @Success 200 {object} dto.StocktakeResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /pvz/{pvzId}/stocktakes/{stocktakeId}/complete [post]
+func (h *StocktakeHandler) CompleteStocktake(c *gin.Context) {
+	log := logger.FromContext(c.Request.Context(), h.log)
+
+	pvzID, stocktakeID, ok := h.parseStocktakeParams(c)
+	if !ok {
+		return
+	}
+
+	var req dto.StocktakeCompleteRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			log.Warnf("invalid stocktake completion input: %v", err)
+			dto.BadRequest(c, "invalid comment")
+			return
+		}
+	}
+
+	stocktake, err := h.service.CompleteStocktake(c.Request.Context(), pvzID, stocktakeID, employeeIDFromContext(c), req.Comment)
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrStocktakeSignOff):
+			dto.Forbidden(c, err.Error())
+		case errors.Is(err, entity.ErrStocktakeNotFound):
+			dto.NotFound(c, "stocktake not found")
+		case errors.Is(err, entity.ErrStocktakeCompleted):
+			dto.BadRequest(c, err.Error())
+		default:
+			dto.InternalError(c, "failed to complete stocktake")
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, toStocktakeResponse(stocktake))
+}
+
+// GetStocktake godoc
+// @Summary Get stocktake
+// @Tags stocktakes
+// @Description Получение инвентаризации; для завершённой возвращается отчёт о расхождениях
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Produce json
+// @Param pvzId path string true "PVZ ID"
+// @Param stocktakeId path string true "Stocktake ID"
+// @Success 200 {object} dto.StocktakeResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /pvz/{pvzId}/stocktakes/{stocktakeId} [get]
+func (h *StocktakeHandler) GetStocktake(c *gin.Context) {
+	pvzID, stocktakeID, ok := h.parseStocktakeParams(c)
+	if !ok {
+		return
+	}
+
+	stocktake, err := h.service.GetStocktake(c.Request.Context(), pvzID, stocktakeID)
+	if err != nil {
+		if errors.Is(err, entity.ErrStocktakeNotFound) {
+			dto.NotFound(c, "stocktake not found")
+			return
+		}
+
+		dto.InternalError(c, "failed to get stocktake")
+		return
+	}
+
+	c.JSON(http.StatusOK, toStocktakeResponse(stocktake))
+}
+
+func (h *StocktakeHandler) parseStocktakeParams(c *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	log := logger.FromContext(c.Request.Context(), h.log)
+
+	pvzID, ok := parsePVZParam(c, log)
+	if !ok {
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	stocktakeID, err := uuid.Parse(c.Param("stocktakeId"))
+	if err != nil {
+		log.Warnf("invalid stocktakeId: %v", err)
+		dto.BadRequest(c, "invalid stocktakeId")
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return pvzID, stocktakeID, true
+}
+
+// employeeIDFromContext returns the authenticated employee, or nil for requests made with an API key.
+func employeeIDFromContext(c *gin.Context) *uuid.UUID {
+	employeeID, err := uuid.Parse(middleware.GetUserID(c))
+	if err != nil {
+		return nil
+	}
+
+	return &employeeID
+}
+
+func toStocktakeResponse(stocktake *entity.Stocktake) dto.StocktakeResponse {
+	resp := dto.StocktakeResponse{
+		ID:              stocktake.ID.String(),
+		PVZID:           stocktake.PVZID.String(),
+		Status:          string(stocktake.Status),
+		StartedAt:       stocktake.StartedAt.Format(time.RFC3339),
+		Comment:         stocktake.Comment,
+		ScannedCount:    stocktake.ScannedCount,
+		ExpectedCount:   stocktake.ExpectedCount,
+		MatchedCount:    stocktake.MatchedCount,
+		MissingCount:    stocktake.MissingCount,
+		UnexpectedCount: stocktake.UnexpectedCount,
+	}
+
+	if stocktake.StartedBy != nil {
+		resp.StartedBy = stocktake.StartedBy.String()
+	}
+	if stocktake.CompletedAt != nil {
+		resp.CompletedAt = stocktake.CompletedAt.Format(time.RFC3339)
+	}
+	if stocktake.CompletedBy != nil {
+		resp.CompletedBy = stocktake.CompletedBy.String()
+	}
+	for _, item := range stocktake.Items {
+		resp.Items = append(resp.Items, dto.StocktakeItemResponse{
+			ProductID: item.ProductID.String(),
+			Result:    string(item.Result),
+		})
+	}
+
+	return resp
+}
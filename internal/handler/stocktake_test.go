@@ -0,0 +1,294 @@
+package handler
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/senyabanana/pvz-service/internal/entity"
+	mocks "github.com/senyabanana/pvz-service/internal/service/mocks"
+)
+
+func TestStocktakeHandler_StartStocktake(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockStocktakeOperations(ctrl)
+	mockLog := logrus.New()
+	h := NewStocktakeHandler(mockService, mockLog)
+	gin.SetMode(gin.TestMode)
+
+	pvzID, employeeID := uuid.New(), uuid.New()
+
+	tests := []struct {
+		name       string
+		mock       func()
+		wantStatus int
+	}{
+		{
+			name: "success",
+			mock: func() {
+				mockService.EXPECT().StartStocktake(gomock.Any(), pvzID, &employeeID).
+					Return(&entity.Stocktake{ID: uuid.New(), PVZID: pvzID, Status: entity.StocktakeStatusOpen, StartedAt: time.Now()}, nil)
+			},
+			wantStatus: http.StatusCreated,
+		},
+		{
+			name: "already in progress",
+			mock: func() {
+				mockService.EXPECT().StartStocktake(gomock.Any(), pvzID, &employeeID).Return(nil, entity.ErrStocktakeInProgress)
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "pvz not found",
+			mock: func() {
+				mockService.EXPECT().StartStocktake(gomock.Any(), pvzID, &employeeID).Return(nil, entity.ErrPVZNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name: "internal error",
+			mock: func() {
+				mockService.EXPECT().StartStocktake(gomock.Any(), pvzID, &employeeID).Return(nil, errors.New("db down"))
+			},
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest(http.MethodPost, "/", nil)
+			c.Params = []gin.Param{{Key: "pvzId", Value: pvzID.String()}}
+			c.Set("user_id", employeeID.String())
+
+			tt.mock()
+			h.StartStocktake(c)
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
+
+func TestStocktakeHandler_ScanProducts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockStocktakeOperations(ctrl)
+	mockLog := logrus.New()
+	h := NewStocktakeHandler(mockService, mockLog)
+	gin.SetMode(gin.TestMode)
+
+	pvzID, stocktakeID, productID := uuid.New(), uuid.New(), uuid.New()
+	validBody := fmt.Sprintf(`{"productIds":["%s"]}`, productID)
+
+	tests := []struct {
+		name        string
+		stocktakeID string
+		inputBody   string
+		mock        func()
+		wantStatus  int
+	}{
+		{
+			name:        "success",
+			stocktakeID: stocktakeID.String(),
+			inputBody:   validBody,
+			mock: func() {
+				mockService.EXPECT().ScanProducts(gomock.Any(), pvzID, stocktakeID, []uuid.UUID{productID}, nil).
+					Return(&entity.Stocktake{ID: stocktakeID, PVZID: pvzID, Status: entity.StocktakeStatusOpen, ScannedCount: 1}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:        "invalid stocktake id",
+			stocktakeID: "abc",
+			inputBody:   validBody,
+			mock:        func() {},
+			wantStatus:  http.StatusBadRequest,
+		},
+		{
+			name:        "empty product list",
+			stocktakeID: stocktakeID.String(),
+			inputBody:   `{"productIds":[]}`,
+			mock:        func() {},
+			wantStatus:  http.StatusBadRequest,
+		},
+		{
+			name:        "stocktake completed",
+			stocktakeID: stocktakeID.String(),
+			inputBody:   validBody,
+			mock: func() {
+				mockService.EXPECT().ScanProducts(gomock.Any(), pvzID, stocktakeID, gomock.Any(), nil).Return(nil, entity.ErrStocktakeCompleted)
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:        "stocktake not found",
+			stocktakeID: stocktakeID.String(),
+			inputBody:   validBody,
+			mock: func() {
+				mockService.EXPECT().ScanProducts(gomock.Any(), pvzID, stocktakeID, gomock.Any(), nil).Return(nil, entity.ErrStocktakeNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest(http.MethodPost, "/", bytes.NewBufferString(tt.inputBody))
+			c.Request.Header.Set("Content-Type", "application/json")
+			c.Params = []gin.Param{{Key: "pvzId", Value: pvzID.String()}, {Key: "stocktakeId", Value: tt.stocktakeID}}
+
+			tt.mock()
+			h.ScanProducts(c)
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
+
+func TestStocktakeHandler_CompleteStocktake(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockStocktakeOperations(ctrl)
+	mockLog := logrus.New()
+	h := NewStocktakeHandler(mockService, mockLog)
+	gin.SetMode(gin.TestMode)
+
+	pvzID, stocktakeID, employeeID := uuid.New(), uuid.New(), uuid.New()
+
+	tests := []struct {
+		name       string
+		inputBody  string
+		userID     string
+		mock       func()
+		wantStatus int
+	}{
+		{
+			name:      "success",
+			inputBody: `{"comment":"recount after audit"}`,
+			userID:    employeeID.String(),
+			mock: func() {
+				completedAt := time.Now()
+				mockService.EXPECT().CompleteStocktake(gomock.Any(), pvzID, stocktakeID, &employeeID, "recount after audit").
+					Return(&entity.Stocktake{
+						ID: stocktakeID, PVZID: pvzID, Status: entity.StocktakeStatusCompleted,
+						CompletedAt: &completedAt, CompletedBy: &employeeID, MissingCount: 1,
+						Items: []entity.StocktakeItem{{ProductID: uuid.New(), Result: entity.StocktakeMissing}},
+					}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:   "success without body",
+			userID: employeeID.String(),
+			mock: func() {
+				mockService.EXPECT().CompleteStocktake(gomock.Any(), pvzID, stocktakeID, &employeeID, "").
+					Return(&entity.Stocktake{ID: stocktakeID, PVZID: pvzID, Status: entity.StocktakeStatusCompleted}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "api key cannot sign off",
+			mock: func() {
+				mockService.EXPECT().CompleteStocktake(gomock.Any(), pvzID, stocktakeID, nil, "").Return(nil, entity.ErrStocktakeSignOff)
+			},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:   "already completed",
+			userID: employeeID.String(),
+			mock: func() {
+				mockService.EXPECT().CompleteStocktake(gomock.Any(), pvzID, stocktakeID, &employeeID, "").Return(nil, entity.ErrStocktakeCompleted)
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest(http.MethodPost, "/", bytes.NewBufferString(tt.inputBody))
+			c.Request.Header.Set("Content-Type", "application/json")
+			c.Params = []gin.Param{{Key: "pvzId", Value: pvzID.String()}, {Key: "stocktakeId", Value: stocktakeID.String()}}
+			if tt.userID != "" {
+				c.Set("user_id", tt.userID)
+			}
+
+			tt.mock()
+			h.CompleteStocktake(c)
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
+
+func TestStocktakeHandler_GetStocktake(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockStocktakeOperations(ctrl)
+	mockLog := logrus.New()
+	h := NewStocktakeHandler(mockService, mockLog)
+	gin.SetMode(gin.TestMode)
+
+	pvzID, stocktakeID := uuid.New(), uuid.New()
+
+	tests := []struct {
+		name       string
+		restricted *uuid.UUID
+		mock       func()
+		wantStatus int
+	}{
+		{
+			name: "success",
+			mock: func() {
+				mockService.EXPECT().GetStocktake(gomock.Any(), pvzID, stocktakeID).
+					Return(&entity.Stocktake{ID: stocktakeID, PVZID: pvzID, Status: entity.StocktakeStatusOpen}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "api key of another pvz",
+			restricted: func() *uuid.UUID { id := uuid.New(); return &id }(),
+			mock:       func() {},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name: "not found",
+			mock: func() {
+				mockService.EXPECT().GetStocktake(gomock.Any(), pvzID, stocktakeID).Return(nil, entity.ErrStocktakeNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest(http.MethodGet, "/", nil)
+			c.Params = []gin.Param{{Key: "pvzId", Value: pvzID.String()}, {Key: "stocktakeId", Value: stocktakeID.String()}}
+			if tt.restricted != nil {
+				c.Set("restricted_pvz_id", *tt.restricted)
+			}
+
+			tt.mock()
+			h.GetStocktake(c)
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
@@ -0,0 +1,152 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+
+	"github.com/senyabanana/pvz-service/internal/dto"
+	"github.com/senyabanana/pvz-service/internal/entity"
+	"github.com/senyabanana/pvz-service/internal/infrastructure/logger"
+	"github.com/senyabanana/pvz-service/internal/middleware"
+	"github.com/senyabanana/pvz-service/internal/service"
+)
+
+type StorageHandler struct {
+	service service.StorageOperations
+	log     *logrus.Logger
+}
+
+func NewStorageHandler(service service.StorageOperations, log *logrus.Logger) *StorageHandler {
+	return &StorageHandler{
+		service: service,
+		log:     log,
+	}
+}
+
+// GetOverdueProducts godoc
+// @Summary Get overdue products at PVZ
+// @Tags storage
+// @Description Товары ПВЗ с истёкшим сроком хранения, ожидающие возврата отправителю, по возрастанию крайнего срока
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Produce json
+// @Param pvzId path string true "PVZ ID"
+// @Success 200 {array} dto.OverdueProductResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /pvz/{pvzId}/overdue [get]
+func (h *StorageHandler) GetOverdueProducts(c *gin.Context) {
+	log := logger.FromContext(c.Request.Context(), h.log)
+
+	pvzID, ok := parsePVZParam(c, log)
+	if !ok {
+		return
+	}
+
+	overdue, err := h.service.GetOverdueProducts(c.Request.Context(), pvzID)
+	if err != nil {
+		if errors.Is(err, entity.ErrPVZNotFound) {
+			dto.NotFound(c, "pvz not found")
+			return
+		}
+
+		dto.InternalError(c, "failed to get overdue products")
+		return
+	}
+
+	resp := make([]dto.OverdueProductResponse, 0, len(overdue))
+	for _, product := range overdue {
+		item := dto.OverdueProductResponse{
+			ProductID:   product.ProductID.String(),
+			ReceptionID: product.ReceptionID.String(),
+			Type:        string(product.Type),
+			ReadyAt:     product.ReadyAt.Format(time.RFC3339),
+			Deadline:    product.Deadline.Format(time.RFC3339),
+			QueuedAt:    product.QueuedAt.Format(time.RFC3339),
+		}
+		if product.OwnerID != nil {
+			item.OwnerID = product.OwnerID.String()
+		}
+		resp = append(resp, item)
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// ShipOverdueProducts godoc
+// @Summary Ship overdue products to sender
+// @Tags storage
+// @Description Передача курьеру товаров с истёкшим сроком хранения: товары покидают ПВЗ и переходят в статус shipped_to_sender. Если хотя бы один товар не ожидает возврата в этом ПВЗ, не отправляется ни один
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Accept json
+// @Produce json
+// @Param pvzId path string true "PVZ ID"
+// @Param request body dto.SenderShipmentRequest true "Товары"
+// @Success 200 {object} dto.SenderShipmentResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /pvz/{pvzId}/overdue/ship [post]
+func (h *StorageHandler) ShipOverdueProducts(c *gin.Context) {
+	log := logger.FromContext(c.Request.Context(), h.log)
+
+	pvzID, ok := parsePVZParam(c, log)
+	if !ok {
+		return
+	}
+
+	var req dto.SenderShipmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Warnf("invalid shipment input: %v", err)
+		dto.BadRequest(c, "invalid productIds")
+		return
+	}
+
+	shipment := entity.SenderShipment{
+		PVZID:      pvzID,
+		ProductIDs: make([]uuid.UUID, 0, len(req.ProductIDs)),
+	}
+	for _, id := range req.ProductIDs {
+		shipment.ProductIDs = append(shipment.ProductIDs, uuid.MustParse(id))
+	}
+	if employeeID, err := uuid.Parse(middleware.GetUserID(c)); err == nil {
+		shipment.ShippedBy = &employeeID
+	}
+
+	shipped, err := h.service.ShipOverdueProducts(c.Request.Context(), shipment)
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrPVZNotFound):
+			dto.NotFound(c, "pvz not found")
+		case errors.Is(err, entity.ErrInvalidSenderShipment),
+			errors.Is(err, entity.ErrProductNotOverdue):
+			dto.BadRequest(c, err.Error())
+		default:
+			dto.InternalError(c, "failed to ship overdue products")
+		}
+		return
+	}
+
+	resp := dto.SenderShipmentResponse{
+		PVZID:      shipped.PVZID.String(),
+		ProductIDs: make([]string, 0, len(shipped.ProductIDs)),
+		ShippedAt:  shipped.ShippedAt.Format(time.RFC3339),
+	}
+	for _, id := range shipped.ProductIDs {
+		resp.ProductIDs = append(resp.ProductIDs, id.String())
+	}
+	if shipped.ShippedBy != nil {
+		resp.ShippedBy = shipped.ShippedBy.String()
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
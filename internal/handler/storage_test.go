@@ -0,0 +1,181 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/senyabanana/pvz-service/internal/dto"
+	"github.com/senyabanana/pvz-service/internal/entity"
+	mocks "github.com/senyabanana/pvz-service/internal/service/mocks"
+)
+
+func TestStorageHandler_GetOverdueProducts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockStorageOperations(ctrl)
+	mockLog := logrus.New()
+	h := NewStorageHandler(mockService, mockLog)
+	gin.SetMode(gin.TestMode)
+
+	pvzID := uuid.New()
+	now := time.Now()
+
+	tests := []struct {
+		name       string
+		param      string
+		mock       func()
+		wantStatus int
+		wantItems  int
+	}{
+		{
+			name:  "success",
+			param: pvzID.String(),
+			mock: func() {
+				mockService.EXPECT().GetOverdueProducts(gomock.Any(), pvzID).Return([]entity.OverdueProduct{
+					{ProductID: uuid.New(), PVZID: pvzID, Type: entity.ProductShoes, ReadyAt: now, Deadline: now, QueuedAt: now},
+				}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantItems:  1,
+		},
+		{
+			name:       "invalid pvzId",
+			param:      "abc",
+			mock:       func() {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:  "pvz not found",
+			param: pvzID.String(),
+			mock: func() {
+				mockService.EXPECT().GetOverdueProducts(gomock.Any(), pvzID).Return(nil, entity.ErrPVZNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:  "internal error",
+			param: pvzID.String(),
+			mock: func() {
+				mockService.EXPECT().GetOverdueProducts(gomock.Any(), pvzID).Return(nil, errors.New("db down"))
+			},
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest(http.MethodGet, "/pvz/"+tt.param+"/overdue", nil)
+			c.Params = []gin.Param{{Key: "pvzId", Value: tt.param}}
+
+			tt.mock()
+			h.GetOverdueProducts(c)
+			assert.Equal(t, tt.wantStatus, w.Code)
+			if tt.wantStatus == http.StatusOK {
+				var resp []dto.OverdueProductResponse
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+				assert.Len(t, resp, tt.wantItems)
+			}
+		})
+	}
+}
+
+func TestStorageHandler_ShipOverdueProducts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockStorageOperations(ctrl)
+	mockLog := logrus.New()
+	h := NewStorageHandler(mockService, mockLog)
+	gin.SetMode(gin.TestMode)
+
+	pvzID, productID, employeeID := uuid.New(), uuid.New(), uuid.New()
+	body := `{"productIds":["` + productID.String() + `"]}`
+
+	tests := []struct {
+		name       string
+		inputBody  string
+		userID     string
+		mock       func()
+		wantStatus int
+	}{
+		{
+			name:      "success",
+			inputBody: body,
+			userID:    employeeID.String(),
+			mock: func() {
+				shipment := entity.SenderShipment{PVZID: pvzID, ProductIDs: []uuid.UUID{productID}, ShippedBy: &employeeID}
+				shipped := shipment
+				shipped.ShippedAt = time.Now()
+				mockService.EXPECT().ShipOverdueProducts(gomock.Any(), shipment).Return(&shipped, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "empty product list",
+			inputBody:  `{"productIds":[]}`,
+			mock:       func() {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:      "product not overdue",
+			inputBody: body,
+			mock: func() {
+				mockService.EXPECT().ShipOverdueProducts(gomock.Any(), gomock.Any()).Return(nil, entity.ErrProductNotOverdue)
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:      "pvz not found",
+			inputBody: body,
+			mock: func() {
+				mockService.EXPECT().ShipOverdueProducts(gomock.Any(), gomock.Any()).Return(nil, entity.ErrPVZNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:      "internal error",
+			inputBody: body,
+			mock: func() {
+				mockService.EXPECT().ShipOverdueProducts(gomock.Any(), gomock.Any()).Return(nil, errors.New("db down"))
+			},
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest(http.MethodPost, "/", bytes.NewBufferString(tt.inputBody))
+			c.Request.Header.Set("Content-Type", "application/json")
+			c.Params = []gin.Param{{Key: "pvzId", Value: pvzID.String()}}
+			if tt.userID != "" {
+				c.Set("user_id", tt.userID)
+			}
+
+			tt.mock()
+			h.ShipOverdueProducts(c)
+			assert.Equal(t, tt.wantStatus, w.Code)
+			if tt.wantStatus == http.StatusOK {
+				var resp dto.SenderShipmentResponse
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+				assert.Equal(t, []string{productID.String()}, resp.ProductIDs)
+				assert.Equal(t, employeeID.String(), resp.ShippedBy)
+			}
+		})
+	}
+}
@@ -0,0 +1,251 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+
+	"github.com/senyabanana/pvz-service/internal/dto"
+	"github.com/senyabanana/pvz-service/internal/entity"
+	"github.com/senyabanana/pvz-service/internal/infrastructure/logger"
+	"github.com/senyabanana/pvz-service/internal/middleware"
+	"github.com/senyabanana/pvz-service/internal/service"
+)
+
+type TransferHandler struct {
+	service service.TransferOperations
+	log     *logrus.Logger
+}
+
+func NewTransferHandler(service service.TransferOperations, log *logrus.Logger) *TransferHandler {
+	return &TransferHandler{
+		service: service,
+		log:     log,
+	}
+}
+
+// CreateTransfer godoc
+// @Summary Create transfer order
+// @Tags transfers
+// @Description Перемещение товаров из ПВЗ в другой ПВЗ: товары покидают приёмку и переходят в статус in_transit
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Accept json
+// @Produce json
+// @Param pvzId path string true "ID ПВЗ-отправителя"
+// @Param request body dto.TransferRequest true "ПВЗ-получатель и товары"
+// @Success 201 {object} dto.TransferResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /pvz/{pvzId}/transfers [post]
+func (h *TransferHandler) CreateTransfer(c *gin.Context) {
+	log := logger.FromContext(c.Request.Context(), h.log)
+
+	pvzID, ok := parsePVZParam(c, log)
+	if !ok {
+		return
+	}
+
+	var req dto.TransferRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Warnf("invalid transfer input: %v", err)
+		dto.BadRequest(c, "invalid destinationPvzId or productIds")
+		return
+	}
+
+	order := entity.TransferOrder{
+		SourcePVZID:      pvzID,
+		DestinationPVZID: uuid.MustParse(req.DestinationPVZID),
+		ProductIDs:       make([]uuid.UUID, 0, len(req.ProductIDs)),
+	}
+	for _, id := range req.ProductIDs {
+		order.ProductIDs = append(order.ProductIDs, uuid.MustParse(id))
+	}
+	if employeeID, err := uuid.Parse(middleware.GetUserID(c)); err == nil {
+		order.CreatedBy = &employeeID
+	}
+
+	created, err := h.service.CreateTransfer(c.Request.Context(), order)
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrPVZNotFound):
+			dto.NotFound(c, "pvz not found")
+		case errors.Is(err, entity.ErrProductNotFound):
+			dto.NotFound(c, "product not found")
+		case errors.Is(err, entity.ErrInvalidTransfer),
+			errors.Is(err, entity.ErrPVZNotActive),
+			errors.Is(err, entity.ErrProductNotAtPVZ),
+			errors.Is(err, entity.ErrProductInTransit),
+			errors.Is(err, entity.ErrProductNotReady),
+			errors.Is(err, entity.ErrProductAlreadyIssued),
+			errors.Is(err, entity.ErrProductOverdue):
+			dto.BadRequest(c, err.Error())
+		default:
+			dto.InternalError(c, "failed to create transfer")
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, toTransferResponse(created))
+}
+
+// ReceiveTransfer godoc
+// @Summary Receive transfer order
+// @Tags transfers
+// @Description Приём перемещения в ПВЗ-получателе: товары добавляются в открытую приёмку
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Produce json
+// @Param pvzId path string true "ID ПВЗ-получателя"
+// @Param transferId path string true "Transfer ID"
+// @Success 200 {object} dto.TransferResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /pvz/{pvzId}/transfers/{transferId}/receive [post]
+func (h *TransferHandler) ReceiveTransfer(c *gin.Context) {
+	log := logger.FromContext(c.Request.Context(), h.log)
+
+	pvzID, ok := parsePVZParam(c, log)
+	if !ok {
+		return
+	}
+
+	transferID, err := uuid.Parse(c.Param("transferId"))
+	if err != nil {
+		log.Warnf("invalid transferId: %v", err)
+		dto.BadRequest(c, "invalid transferId")
+		return
+	}
+
+	var receivedBy *uuid.UUID
+	if employeeID, err := uuid.Parse(middleware.GetUserID(c)); err == nil {
+		receivedBy = &employeeID
+	}
+
+	order, err := h.service.ReceiveTransfer(c.Request.Context(), pvzID, transferID, receivedBy)
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrPVZNotFound):
+			dto.NotFound(c, "pvz not found")
+		case errors.Is(err, entity.ErrTransferNotFound):
+			dto.NotFound(c, "transfer not found")
+		case errors.Is(err, entity.ErrPVZNotActive),
+			errors.Is(err, entity.ErrTransferAlreadyClosed),
+			errors.Is(err, entity.ErrNoOpenReception),
+			errors.Is(err, entity.ErrCapacityExceeded),
+			errors.Is(err, entity.ErrProductNotInTransit):
+			dto.BadRequest(c, err.Error())
+		default:
+			dto.InternalError(c, "failed to receive transfer")
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, toTransferResponse(order))
+}
+
+// GetProductCustody godoc
+// @Summary Get product chain of custody
+// @Tags transfers
+// @Description История перемещений товара: приёмка, отправки и прибытия в хронологическом порядке
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Produce json
+// @Param productId path string true "Product ID"
+// @Success 200 {array} dto.CustodyEventResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /products/{productId}/custody [get]
+func (h *TransferHandler) GetProductCustody(c *gin.Context) {
+	log := logger.FromContext(c.Request.Context(), h.log)
+
+	productID, err := uuid.Parse(c.Param("productId"))
+	if err != nil {
+		log.Warnf("invalid productId: %v", err)
+		dto.BadRequest(c, "invalid productId")
+		return
+	}
+
+	events, err := h.service.GetProductCustody(c.Request.Context(), productID)
+	if err != nil {
+		if errors.Is(err, entity.ErrProductNotFound) {
+			dto.NotFound(c, "product not found")
+			return
+		}
+
+		dto.InternalError(c, "failed to get product custody")
+		return
+	}
+
+	pvzIDs := make([]uuid.UUID, 0, len(events))
+	for _, event := range events {
+		pvzIDs = append(pvzIDs, event.PVZID)
+	}
+
+	// An API key bound to a PVZ only sees products that have passed through it.
+	if !canAccessAnyPVZ(c, pvzIDs) {
+		log.Warnf("api key is not allowed to access product: %s", productID)
+		dto.NotFound(c, "product not found")
+		return
+	}
+
+	resp := make([]dto.CustodyEventResponse, 0, len(events))
+	for _, event := range events {
+		item := dto.CustodyEventResponse{
+			Kind:  string(event.Kind),
+			PVZID: event.PVZID.String(),
+			At:    event.At.Format(time.RFC3339),
+		}
+		if event.ReceptionID != nil {
+			item.ReceptionID = event.ReceptionID.String()
+		}
+		if event.TransferID != nil {
+			item.TransferID = event.TransferID.String()
+		}
+		if event.By != nil {
+			item.By = event.By.String()
+		}
+		resp = append(resp, item)
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func toTransferResponse(order *entity.TransferOrder) dto.TransferResponse {
+	resp := dto.TransferResponse{
+		ID:               order.ID.String(),
+		SourcePVZID:      order.SourcePVZID.String(),
+		DestinationPVZID: order.DestinationPVZID.String(),
+		Status:           string(order.Status),
+		CreatedAt:        order.CreatedAt.Format(time.RFC3339),
+		ProductIDs:       make([]string, 0, len(order.ProductIDs)),
+	}
+
+	for _, id := range order.ProductIDs {
+		resp.ProductIDs = append(resp.ProductIDs, id.String())
+	}
+	if order.CreatedBy != nil {
+		resp.CreatedBy = order.CreatedBy.String()
+	}
+	if order.ReceivedAt != nil {
+		resp.ReceivedAt = order.ReceivedAt.Format(time.RFC3339)
+	}
+	if order.ReceivedBy != nil {
+		resp.ReceivedBy = order.ReceivedBy.String()
+	}
+	if order.DestinationReceptionID != nil {
+		resp.DestinationReceptionID = order.DestinationReceptionID.String()
+	}
+
+	return resp
+}
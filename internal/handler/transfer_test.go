@@ -0,0 +1,257 @@
+package handler
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/senyabanana/pvz-service/internal/entity"
+	mocks "github.com/senyabanana/pvz-service/internal/service/mocks"
+)
+
+func TestTransferHandler_CreateTransfer(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockTransferOperations(ctrl)
+	mockLog := logrus.New()
+	h := NewTransferHandler(mockService, mockLog)
+	gin.SetMode(gin.TestMode)
+
+	pvzID, destinationID, productID := uuid.New(), uuid.New(), uuid.New()
+	validBody := fmt.Sprintf(`{"destinationPvzId":"%s","productIds":["%s"]}`, destinationID, productID)
+
+	tests := []struct {
+		name       string
+		inputBody  string
+		mock       func()
+		wantStatus int
+	}{
+		{
+			name:      "success",
+			inputBody: validBody,
+			mock: func() {
+				mockService.EXPECT().CreateTransfer(gomock.Any(), gomock.Any()).
+					Return(&entity.TransferOrder{
+						ID: uuid.New(), SourcePVZID: pvzID, DestinationPVZID: destinationID,
+						Status: entity.TransferStatusInTransit, CreatedAt: time.Now(), ProductIDs: []uuid.UUID{productID},
+					}, nil)
+			},
+			wantStatus: http.StatusCreated,
+		},
+		{
+			name:       "empty product list",
+			inputBody:  fmt.Sprintf(`{"destinationPvzId":"%s","productIds":[]}`, destinationID),
+			mock:       func() {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "invalid product id",
+			inputBody:  fmt.Sprintf(`{"destinationPvzId":"%s","productIds":["abc"]}`, destinationID),
+			mock:       func() {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:      "product in transit",
+			inputBody: validBody,
+			mock: func() {
+				mockService.EXPECT().CreateTransfer(gomock.Any(), gomock.Any()).Return(nil, entity.ErrProductInTransit)
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:      "destination not found",
+			inputBody: validBody,
+			mock: func() {
+				mockService.EXPECT().CreateTransfer(gomock.Any(), gomock.Any()).Return(nil, entity.ErrPVZNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:      "internal error",
+			inputBody: validBody,
+			mock: func() {
+				mockService.EXPECT().CreateTransfer(gomock.Any(), gomock.Any()).Return(nil, errors.New("db down"))
+			},
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest(http.MethodPost, "/", bytes.NewBufferString(tt.inputBody))
+			c.Request.Header.Set("Content-Type", "application/json")
+			c.Params = []gin.Param{{Key: "pvzId", Value: pvzID.String()}}
+
+			tt.mock()
+			h.CreateTransfer(c)
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
+
+func TestTransferHandler_ReceiveTransfer(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockTransferOperations(ctrl)
+	mockLog := logrus.New()
+	h := NewTransferHandler(mockService, mockLog)
+	gin.SetMode(gin.TestMode)
+
+	pvzID, transferID, employeeID := uuid.New(), uuid.New(), uuid.New()
+
+	tests := []struct {
+		name       string
+		transferID string
+		mock       func()
+		wantStatus int
+	}{
+		{
+			name:       "success",
+			transferID: transferID.String(),
+			mock: func() {
+				receivedAt := time.Now()
+				mockService.EXPECT().ReceiveTransfer(gomock.Any(), pvzID, transferID, &employeeID).
+					Return(&entity.TransferOrder{
+						ID: transferID, DestinationPVZID: pvzID, Status: entity.TransferStatusReceived, ReceivedAt: &receivedAt,
+					}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "invalid transfer id",
+			transferID: "abc",
+			mock:       func() {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "not found",
+			transferID: transferID.String(),
+			mock: func() {
+				mockService.EXPECT().ReceiveTransfer(gomock.Any(), pvzID, transferID, &employeeID).Return(nil, entity.ErrTransferNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "already received",
+			transferID: transferID.String(),
+			mock: func() {
+				mockService.EXPECT().ReceiveTransfer(gomock.Any(), pvzID, transferID, &employeeID).Return(nil, entity.ErrTransferAlreadyClosed)
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "no open reception",
+			transferID: transferID.String(),
+			mock: func() {
+				mockService.EXPECT().ReceiveTransfer(gomock.Any(), pvzID, transferID, &employeeID).Return(nil, entity.ErrNoOpenReception)
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest(http.MethodPost, "/", nil)
+			c.Params = []gin.Param{{Key: "pvzId", Value: pvzID.String()}, {Key: "transferId", Value: tt.transferID}}
+			c.Set("user_id", employeeID.String())
+
+			tt.mock()
+			h.ReceiveTransfer(c)
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
+
+func TestTransferHandler_GetProductCustody(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockTransferOperations(ctrl)
+	mockLog := logrus.New()
+	h := NewTransferHandler(mockService, mockLog)
+	gin.SetMode(gin.TestMode)
+
+	productID, pvzID, receptionID := uuid.New(), uuid.New(), uuid.New()
+	events := []entity.CustodyEvent{{Kind: entity.CustodyReceived, PVZID: pvzID, ReceptionID: &receptionID, At: time.Now()}}
+
+	tests := []struct {
+		name       string
+		productID  string
+		restricted *uuid.UUID
+		mock       func()
+		wantStatus int
+	}{
+		{
+			name:      "success",
+			productID: productID.String(),
+			mock: func() {
+				mockService.EXPECT().GetProductCustody(gomock.Any(), productID).Return(events, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "invalid product id",
+			productID:  "abc",
+			mock:       func() {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:      "not found",
+			productID: productID.String(),
+			mock: func() {
+				mockService.EXPECT().GetProductCustody(gomock.Any(), productID).Return(nil, entity.ErrProductNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "api key bound to another pvz",
+			productID:  productID.String(),
+			restricted: func() *uuid.UUID { id := uuid.New(); return &id }(),
+			mock: func() {
+				mockService.EXPECT().GetProductCustody(gomock.Any(), productID).Return(events, nil)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "api key bound to a pvz on the route",
+			productID:  productID.String(),
+			restricted: &pvzID,
+			mock: func() {
+				mockService.EXPECT().GetProductCustody(gomock.Any(), productID).Return(events, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest(http.MethodGet, "/", nil)
+			c.Params = []gin.Param{{Key: "productId", Value: tt.productID}}
+			if tt.restricted != nil {
+				c.Set("restricted_pvz_id", *tt.restricted)
+			}
+
+			tt.mock()
+			h.GetProductCustody(c)
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
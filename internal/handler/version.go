@@ -0,0 +1,51 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+
+	"github.com/senyabanana/pvz-service/internal/dto"
+	"github.com/senyabanana/pvz-service/internal/service"
+)
+
+type VersionHandler struct {
+	service service.VersionOperations
+	log     *logrus.Logger
+}
+
+func NewVersionHandler(service service.VersionOperations, log *logrus.Logger) *VersionHandler {
+	return &VersionHandler{
+		service: service,
+		log:     log,
+	}
+}
+
+// GetVersion godoc
+// @Summary Service version
+// @Tags system
+// @Description Версия сборки, текущая версия схемы БД и версия последней миграции, известной сервису.
+// @Description schemaVersion равен null, если база создана без migrate
+// @Produce json
+// @Success 200 {object} dto.VersionResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /version [get]
+func (h *VersionHandler) GetVersion(c *gin.Context) {
+	info, err := h.service.GetVersion(c.Request.Context())
+	if err != nil {
+		dto.InternalError(c, "failed to get version")
+		return
+	}
+
+	resp := dto.VersionResponse{
+		Version:             info.Version,
+		LatestSchemaVersion: info.LatestSchemaVersion,
+	}
+	if info.Schema != nil {
+		resp.SchemaVersion = &info.Schema.Version
+		resp.SchemaDirty = info.Schema.Dirty
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
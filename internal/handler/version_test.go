@@ -0,0 +1,76 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/senyabanana/pvz-service/internal/entity"
+	mocks "github.com/senyabanana/pvz-service/internal/service/mocks"
+)
+
+func TestVersionHandler_GetVersion(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockVersionOperations(ctrl)
+	mockLog := logrus.New()
+	h := NewVersionHandler(mockService, mockLog)
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name       string
+		mock       func()
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name: "migrated schema",
+			mock: func() {
+				mockService.EXPECT().GetVersion(gomock.Any()).Return(&entity.VersionInfo{
+					Version: "v1.2.0", Schema: &entity.SchemaVersion{Version: 18}, LatestSchemaVersion: 18,
+				}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   `{"version":"v1.2.0","schemaVersion":18,"schemaDirty":false,"latestSchemaVersion":18}`,
+		},
+		{
+			name: "unknown schema version",
+			mock: func() {
+				mockService.EXPECT().GetVersion(gomock.Any()).Return(&entity.VersionInfo{
+					Version: "dev", LatestSchemaVersion: 18,
+				}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   `{"version":"dev","schemaVersion":null,"schemaDirty":false,"latestSchemaVersion":18}`,
+		},
+		{
+			name: "internal error",
+			mock: func() {
+				mockService.EXPECT().GetVersion(gomock.Any()).Return(nil, errors.New("db down"))
+			},
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest(http.MethodGet, "/version", nil)
+
+			tt.mock()
+			h.GetVersion(c)
+			assert.Equal(t, tt.wantStatus, w.Code)
+			if tt.wantBody != "" {
+				assert.JSONEq(t, tt.wantBody, w.Body.String())
+			}
+		})
+	}
+}
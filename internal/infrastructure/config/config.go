@@ -1,7 +1,12 @@
 package config
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/spf13/viper"
+
+	"github.com/senyabanana/pvz-service/internal/entity"
 )
 
 type Config struct {
@@ -13,18 +18,132 @@ type Config struct {
 	PostgresPassword string `mapstructure:"POSTGRES_PASSWORD"`
 	PostgresDB       string `mapstructure:"POSTGRES_DB"`
 	SSLMode          string `mapstructure:"SSLMODE"`
+	AutoMigrate      bool   `mapstructure:"AUTO_MIGRATE"`
 	JWTSecretKey     string `mapstructure:"JWTKEY"`
+	OIDCIssuerURL    string `mapstructure:"OIDC_ISSUER_URL"`
+	OIDCClientID     string `mapstructure:"OIDC_CLIENT_ID"`
+	OIDCClientSecret string `mapstructure:"OIDC_CLIENT_SECRET"`
+	OIDCRedirectURL  string `mapstructure:"OIDC_REDIRECT_URL"`
+	OIDCGroupsClaim  string `mapstructure:"OIDC_GROUPS_CLAIM"`
+	OIDCRoleMapping  string `mapstructure:"OIDC_ROLE_MAPPING"`
+
+	PasswordHashAlgorithm  string        `mapstructure:"PASSWORD_HASH_ALGORITHM"`
+	BcryptCost             int           `mapstructure:"BCRYPT_COST"`
+	Argon2Memory           uint32        `mapstructure:"ARGON2_MEMORY"`
+	Argon2Iterations       uint32        `mapstructure:"ARGON2_ITERATIONS"`
+	Argon2Parallelism      uint8         `mapstructure:"ARGON2_PARALLELISM"`
+	PasswordMinLength      int           `mapstructure:"PASSWORD_MIN_LENGTH"`
+	PasswordRequireUpper   bool          `mapstructure:"PASSWORD_REQUIRE_UPPER"`
+	PasswordRequireLower   bool          `mapstructure:"PASSWORD_REQUIRE_LOWER"`
+	PasswordRequireDigit   bool          `mapstructure:"PASSWORD_REQUIRE_DIGIT"`
+	PasswordRequireSpecial bool          `mapstructure:"PASSWORD_REQUIRE_SPECIAL"`
+	PasswordRejectCommon   bool          `mapstructure:"PASSWORD_REJECT_COMMON"`
+	PasswordResetTTL       time.Duration `mapstructure:"PASSWORD_RESET_TTL"`
+	NotifierType           string        `mapstructure:"NOTIFIER_TYPE"`
+	NotifierFilePath       string        `mapstructure:"NOTIFIER_FILE_PATH"`
+
+	CapacityPolicy string `mapstructure:"CAPACITY_POLICY"`
+
+	StoragePeriod        time.Duration `mapstructure:"STORAGE_PERIOD"`
+	StoragePeriodsByType string        `mapstructure:"STORAGE_PERIODS_BY_TYPE"`
+	StorageCheckInterval time.Duration `mapstructure:"STORAGE_CHECK_INTERVAL"`
+	EventsPublisher      string        `mapstructure:"EVENTS_PUBLISHER"`
+	EventsFilePath       string        `mapstructure:"EVENTS_FILE_PATH"`
+
+	ReportWorkers      int           `mapstructure:"REPORT_WORKERS"`
+	ReportPollInterval time.Duration `mapstructure:"REPORT_POLL_INTERVAL"`
+	ReportJobTimeout   time.Duration `mapstructure:"REPORT_JOB_TIMEOUT"`
+
+	ReadinessTimeout       time.Duration `mapstructure:"READINESS_TIMEOUT"`
+	ReadinessCheckInterval time.Duration `mapstructure:"READINESS_CHECK_INTERVAL"`
+
+	TracingExporter     string  `mapstructure:"TRACING_EXPORTER"`
+	TracingOTLPEndpoint string  `mapstructure:"TRACING_OTLP_ENDPOINT"`
+	TracingOTLPInsecure bool    `mapstructure:"TRACING_OTLP_INSECURE"`
+	TracingSampleRatio  float64 `mapstructure:"TRACING_SAMPLE_RATIO"`
+
+	LogLevel  string `mapstructure:"LOG_LEVEL"`
+	LogFormat string `mapstructure:"LOG_FORMAT"`
+	LogOutput string `mapstructure:"LOG_OUTPUT"`
 }
 
 func LoadConfig(path string) (cfg *Config, err error) {
 	viper.AddConfigPath(path)
 	viper.SetConfigFile(".env")
 
+	viper.SetDefault("AUTO_MIGRATE", false)
+	viper.SetDefault("OIDC_GROUPS_CLAIM", "groups")
+	viper.SetDefault("PASSWORD_HASH_ALGORITHM", "bcrypt")
+	viper.SetDefault("BCRYPT_COST", 10)
+	viper.SetDefault("ARGON2_MEMORY", 64*1024)
+	viper.SetDefault("ARGON2_ITERATIONS", 3)
+	viper.SetDefault("ARGON2_PARALLELISM", 2)
+	viper.SetDefault("PASSWORD_MIN_LENGTH", 8)
+	viper.SetDefault("PASSWORD_REQUIRE_UPPER", true)
+	viper.SetDefault("PASSWORD_REQUIRE_LOWER", true)
+	viper.SetDefault("PASSWORD_REQUIRE_DIGIT", true)
+	viper.SetDefault("PASSWORD_REQUIRE_SPECIAL", false)
+	viper.SetDefault("PASSWORD_REJECT_COMMON", true)
+	viper.SetDefault("PASSWORD_RESET_TTL", "30m")
+	viper.SetDefault("NOTIFIER_TYPE", "log")
+	viper.SetDefault("NOTIFIER_FILE_PATH", "notifications.log")
+	viper.SetDefault("CAPACITY_POLICY", "reject")
+	viper.SetDefault("STORAGE_PERIOD", "168h")
+	viper.SetDefault("STORAGE_CHECK_INTERVAL", "1h")
+	viper.SetDefault("EVENTS_PUBLISHER", "log")
+	viper.SetDefault("EVENTS_FILE_PATH", "events.log")
+	viper.SetDefault("REPORT_WORKERS", 2)
+	viper.SetDefault("REPORT_POLL_INTERVAL", "2s")
+	viper.SetDefault("REPORT_JOB_TIMEOUT", "10m")
+	viper.SetDefault("READINESS_TIMEOUT", "2s")
+	viper.SetDefault("READINESS_CHECK_INTERVAL", "10s")
+	viper.SetDefault("TRACING_EXPORTER", "none")
+	viper.SetDefault("TRACING_OTLP_ENDPOINT", "localhost:4317")
+	viper.SetDefault("TRACING_OTLP_INSECURE", true)
+	viper.SetDefault("TRACING_SAMPLE_RATIO", 1.0)
+	viper.SetDefault("LOG_LEVEL", "info")
+	viper.SetDefault("LOG_FORMAT", "json")
+	viper.SetDefault("LOG_OUTPUT", "stdout")
+
 	err = viper.ReadInConfig()
 	if err != nil {
 		return
 	}
 
-	err = viper.Unmarshal(&cfg)
+	if err = viper.Unmarshal(&cfg); err != nil {
+		return
+	}
+
+	err = cfg.validate()
 	return
 }
+
+// validate rejects values the service cannot start with. Intervals drive tickers, which panic on
+// non-positive durations; a non-positive timeout fails every check or job at once.
+func (c *Config) validate() error {
+	durations := []struct {
+		name  string
+		value time.Duration
+	}{
+		{"STORAGE_CHECK_INTERVAL", c.StorageCheckInterval},
+		{"REPORT_POLL_INTERVAL", c.ReportPollInterval},
+		{"READINESS_CHECK_INTERVAL", c.ReadinessCheckInterval},
+		{"REPORT_JOB_TIMEOUT", c.ReportJobTimeout},
+		{"READINESS_TIMEOUT", c.ReadinessTimeout},
+	}
+	for _, d := range durations {
+		if d.value <= 0 {
+			return fmt.Errorf("%s must be positive, got %s", d.name, d.value)
+		}
+	}
+
+	minJobTimeout := entity.MinReportJobHeartbeats * entity.ReportHeartbeatInterval
+	if c.ReportJobTimeout < minJobTimeout {
+		return fmt.Errorf(
+			"REPORT_JOB_TIMEOUT must be at least %d report heartbeat intervals of %s (%s), got %s",
+			entity.MinReportJobHeartbeats, entity.ReportHeartbeatInterval, minJobTimeout, c.ReportJobTimeout,
+		)
+	}
+
+	return nil
+}
@@ -0,0 +1,68 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig_Validate(t *testing.T) {
+	valid := func() Config {
+		return Config{
+			StorageCheckInterval:   time.Hour,
+			ReportPollInterval:     2 * time.Second,
+			ReadinessCheckInterval: 10 * time.Second,
+			ReportJobTimeout:       10 * time.Minute,
+			ReadinessTimeout:       2 * time.Second,
+		}
+	}
+
+	tests := []struct {
+		name    string
+		modify  func(c *Config)
+		wantErr string
+	}{
+		{
+			name:   "defaults",
+			modify: func(c *Config) {},
+		},
+		{
+			name:    "zero poll interval",
+			modify:  func(c *Config) { c.ReportPollInterval = 0 },
+			wantErr: "REPORT_POLL_INTERVAL must be positive",
+		},
+		{
+			name:    "zero readiness timeout",
+			modify:  func(c *Config) { c.ReadinessTimeout = 0 },
+			wantErr: "READINESS_TIMEOUT must be positive",
+		},
+		{
+			name:    "negative job timeout",
+			modify:  func(c *Config) { c.ReportJobTimeout = -time.Minute },
+			wantErr: "REPORT_JOB_TIMEOUT must be positive",
+		},
+		{
+			name:    "job timeout shorter than heartbeats",
+			modify:  func(c *Config) { c.ReportJobTimeout = 3 * time.Second },
+			wantErr: "REPORT_JOB_TIMEOUT must be at least 5 report heartbeat intervals of 2s (10s), got 3s",
+		},
+		{
+			name:   "job timeout at the minimum",
+			modify: func(c *Config) { c.ReportJobTimeout = 10 * time.Second },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := valid()
+			tt.modify(&cfg)
+			err := cfg.validate()
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
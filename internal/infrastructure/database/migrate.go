@@ -0,0 +1,95 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database/postgres"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+	"github.com/jmoiron/sqlx"
+	"github.com/sirupsen/logrus"
+)
+
+// NewMigrator returns a golang-migrate instance for the service database reading migrations from source,
+// normally migrations.FS. Migrate holds a Postgres advisory lock while changing the schema, so replicas
+// starting at the same time apply migrations one after another. The migrator works on a single connection
+// taken from the pool: closing it returns the connection and leaves db open.
+func NewMigrator(ctx context.Context, db *sqlx.DB, source fs.FS) (*migrate.Migrate, error) {
+	sourceDriver, err := iofs.New(source, ".")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations: %w", err)
+	}
+
+	// postgres.WithInstance would take ownership of the pool and close it together with the migrator.
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		sourceDriver.Close()
+		return nil, fmt.Errorf("failed to acquire migration connection: %w", err)
+	}
+
+	driver, err := postgres.WithConnection(ctx, conn, &postgres.Config{})
+	if err != nil {
+		conn.Close()
+		sourceDriver.Close()
+		return nil, fmt.Errorf("failed to create migration driver: %w", err)
+	}
+
+	m, err := migrate.NewWithInstance("iofs", sourceDriver, postgresDriver, driver)
+	if err != nil {
+		driver.Close()
+		sourceDriver.Close()
+		return nil, fmt.Errorf("failed to create migrator: %w", err)
+	}
+
+	return m, nil
+}
+
+// ApplyMigrations brings the schema up to the latest migration in source.
+func ApplyMigrations(ctx context.Context, db *sqlx.DB, source fs.FS, log *logrus.Logger) error {
+	m, err := NewMigrator(ctx, db, source)
+	if err != nil {
+		return err
+	}
+	defer m.Close()
+
+	err = m.Up()
+	if errors.Is(err, migrate.ErrNoChange) {
+		log.Info("database schema is up to date")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to apply migrations: %w", err)
+	}
+
+	log.Info("database migrations applied")
+	return nil
+}
+
+// LatestMigration returns the version of the newest migration in source, the schema version
+// the binary expects.
+func LatestMigration(source fs.FS) (uint, error) {
+	sourceDriver, err := iofs.New(source, ".")
+	if err != nil {
+		return 0, fmt.Errorf("failed to read migrations: %w", err)
+	}
+	defer sourceDriver.Close()
+
+	version, err := sourceDriver.First()
+	if err != nil {
+		return 0, err
+	}
+
+	for {
+		next, err := sourceDriver.Next(version)
+		if errors.Is(err, fs.ErrNotExist) {
+			return version, nil
+		}
+		if err != nil {
+			return 0, err
+		}
+		version = next
+	}
+}
@@ -0,0 +1,49 @@
+package database
+
+import (
+	"context"
+	"io"
+	"testing"
+	"testing/fstest"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyMigrations_KeepsPoolOpen(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.MonitorPingsOption(true))
+	assert.NoError(t, err)
+	defer db.Close()
+
+	source := fstest.MapFS{
+		"000001_init.up.sql":   {Data: []byte("CREATE TABLE t (id int);")},
+		"000001_init.down.sql": {Data: []byte("DROP TABLE t;")},
+	}
+
+	mock.ExpectPing()
+	mock.ExpectQuery(`SELECT CURRENT_DATABASE\(\)`).
+		WillReturnRows(sqlmock.NewRows([]string{"current_database"}).AddRow("pvz"))
+	mock.ExpectQuery(`SELECT CURRENT_SCHEMA\(\)`).
+		WillReturnRows(sqlmock.NewRows([]string{"current_schema"}).AddRow("public"))
+	mock.ExpectExec(`SELECT pg_advisory_lock`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(`SELECT COUNT\(1\) FROM information_schema.tables`).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectExec(`SELECT pg_advisory_unlock`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`SELECT pg_advisory_lock`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(`SELECT version, dirty FROM "public"."schema_migrations" LIMIT 1`).
+		WillReturnRows(sqlmock.NewRows([]string{"version", "dirty"}).AddRow(1, false))
+	mock.ExpectExec(`SELECT pg_advisory_unlock`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectPing()
+
+	log := logrus.New()
+	log.SetOutput(io.Discard)
+
+	sqlxDB := sqlx.NewDb(db, postgresDriver)
+	err = ApplyMigrations(context.Background(), sqlxDB, source, log)
+	assert.NoError(t, err)
+
+	assert.NoError(t, sqlxDB.Ping(), "the service pool must stay usable after migrating")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
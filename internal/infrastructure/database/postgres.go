@@ -7,6 +7,7 @@ import (
 
 	"github.com/jmoiron/sqlx"
 	_ "github.com/lib/pq"
+	"github.com/uptrace/opentelemetry-go-extra/otelsql"
 
 	"github.com/senyabanana/pvz-service/internal/infrastructure/config"
 )
@@ -20,10 +21,19 @@ func NewPostgresDB(ctx context.Context, cfg *config.Config) (*sqlx.DB, error) {
 	connectCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
-	db, err := sqlx.ConnectContext(connectCtx, postgresDriver, dsn)
+	// Every query gets its own span under the span of the calling service method.
+	sqlDB, err := otelsql.Open(postgresDriver, dsn,
+		otelsql.WithDBSystem("postgresql"), otelsql.WithDBName(cfg.PostgresDB),
+	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to postgres: %w", err)
 	}
 
+	db := sqlx.NewDb(sqlDB, postgresDriver)
+	if err := db.PingContext(connectCtx); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to connect to postgres: %w", err)
+	}
+
 	return db, nil
 }
@@ -0,0 +1,66 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/senyabanana/pvz-service/internal/entity"
+)
+
+const (
+	TypeLog  = "log"
+	TypeFile = "file"
+)
+
+// LogPublisher writes events to the service log. Intended for local development only.
+type LogPublisher struct {
+	log *logrus.Logger
+}
+
+func NewLogPublisher(log *logrus.Logger) *LogPublisher {
+	return &LogPublisher{log: log}
+}
+
+func (p *LogPublisher) Publish(ctx context.Context, event entity.Event) error {
+	payload, err := json.Marshal(event.Payload)
+	if err != nil {
+		return err
+	}
+
+	p.log.Infof("event published: type=%s, payload=%s", event.Type, payload)
+	return nil
+}
+
+// FilePublisher appends events as JSON lines to a file for an external shipper to pick up.
+type FilePublisher struct {
+	path string
+	mu   sync.Mutex
+}
+
+func NewFilePublisher(path string) *FilePublisher {
+	return &FilePublisher{path: path}
+}
+
+func (p *FilePublisher) Publish(ctx context.Context, event entity.Event) error {
+	line, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	f, err := os.OpenFile(p.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open events file: %w", err)
+	}
+	defer f.Close()
+
+	_, err = f.Write(append(line, '\n'))
+	return err
+}
@@ -0,0 +1,157 @@
+package export
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+
+	"github.com/xuri/excelize/v2"
+
+	"github.com/senyabanana/pvz-service/internal/entity"
+)
+
+// csvFlushEvery is how many rows are buffered before a CSV export is pushed to the client.
+const csvFlushEvery = 100
+
+type flusher interface {
+	Flush()
+}
+
+// RowWriteCloser writes export rows. Close finishes the output; Discard releases the writer without
+// finishing it and does nothing after Close, so callers can always defer it.
+type RowWriteCloser interface {
+	WriteRow(values []interface{}) error
+	Close() error
+	Discard()
+}
+
+// NewWriter returns a writer for the format along with the content type of its output.
+// XLSX output gets a single sheet named after sheet.
+func NewWriter(format entity.ExportFormat, out io.Writer, sheet string) (RowWriteCloser, string, error) {
+	switch format {
+	case entity.ExportFormatCSV:
+		return NewCSVWriter(out), "text/csv; charset=utf-8", nil
+	case entity.ExportFormatXLSX:
+		w, err := NewXLSXWriter(out, sheet)
+		if err != nil {
+			return nil, "", err
+		}
+		return w, "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", nil
+	default:
+		return nil, "", fmt.Errorf("unsupported export format %q", format)
+	}
+}
+
+// CSVWriter writes rows straight to the response, flushing every csvFlushEvery rows.
+type CSVWriter struct {
+	out  io.Writer
+	csv  *csv.Writer
+	rows int
+}
+
+func NewCSVWriter(out io.Writer) *CSVWriter {
+	return &CSVWriter{
+		out: out,
+		csv: csv.NewWriter(out),
+	}
+}
+
+func (w *CSVWriter) WriteRow(values []interface{}) error {
+	record := make([]string, 0, len(values))
+	for _, v := range values {
+		if v == nil {
+			record = append(record, "")
+			continue
+		}
+		record = append(record, fmt.Sprint(v))
+	}
+
+	if err := w.csv.Write(record); err != nil {
+		return err
+	}
+
+	w.rows++
+	if w.rows%csvFlushEvery == 0 {
+		return w.flush()
+	}
+
+	return nil
+}
+
+func (w *CSVWriter) Close() error {
+	return w.flush()
+}
+
+// Discard drops the buffered rows. Rows already flushed to out stay there.
+func (w *CSVWriter) Discard() {}
+
+func (w *CSVWriter) flush() error {
+	w.csv.Flush()
+	if err := w.csv.Error(); err != nil {
+		return err
+	}
+	if f, ok := w.out.(flusher); ok {
+		f.Flush()
+	}
+
+	return nil
+}
+
+// XLSXWriter writes rows through the excelize stream writer, which spills to a temporary file
+// instead of keeping the sheet in memory. The workbook is sent to out on Close.
+type XLSXWriter struct {
+	out    io.Writer
+	file   *excelize.File
+	stream *excelize.StreamWriter
+	row    int
+	closed bool
+}
+
+func NewXLSXWriter(out io.Writer, sheet string) (*XLSXWriter, error) {
+	file := excelize.NewFile()
+	if err := file.SetSheetName(file.GetSheetName(0), sheet); err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	stream, err := file.NewStreamWriter(sheet)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	return &XLSXWriter{
+		out:    out,
+		file:   file,
+		stream: stream,
+	}, nil
+}
+
+func (w *XLSXWriter) WriteRow(values []interface{}) error {
+	w.row++
+	cell, err := excelize.CoordinatesToCellName(1, w.row)
+	if err != nil {
+		return err
+	}
+
+	return w.stream.SetRow(cell, values)
+}
+
+func (w *XLSXWriter) Close() error {
+	defer w.Discard()
+
+	if err := w.stream.Flush(); err != nil {
+		return err
+	}
+
+	return w.file.Write(w.out)
+}
+
+// Discard removes the temporary files of the workbook without writing it to out.
+func (w *XLSXWriter) Discard() {
+	if w.closed {
+		return
+	}
+	w.closed = true
+	w.file.Close()
+}
@@ -0,0 +1,88 @@
+package export
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/xuri/excelize/v2"
+)
+
+type flushRecorder struct {
+	bytes.Buffer
+	flushes int
+}
+
+func (r *flushRecorder) Flush() {
+	r.flushes++
+}
+
+func TestCSVWriter(t *testing.T) {
+	out := &flushRecorder{}
+	w := NewCSVWriter(out)
+
+	assert.NoError(t, w.WriteRow([]interface{}{"id", "city", "products"}))
+	assert.NoError(t, w.WriteRow([]interface{}{"1", "Казань, центр", 5}))
+	assert.NoError(t, w.WriteRow([]interface{}{"2", nil, 0}))
+	assert.Zero(t, out.Len(), "rows are buffered until the flush threshold")
+
+	assert.NoError(t, w.Close())
+	assert.Equal(t, "id,city,products\n1,\"Казань, центр\",5\n2,,0\n", out.String())
+	assert.Equal(t, 1, out.flushes)
+}
+
+func TestCSVWriter_FlushesPeriodically(t *testing.T) {
+	out := &flushRecorder{}
+	w := NewCSVWriter(out)
+
+	for i := 0; i < csvFlushEvery*2+1; i++ {
+		assert.NoError(t, w.WriteRow([]interface{}{i}))
+	}
+	assert.Equal(t, 2, out.flushes)
+
+	assert.NoError(t, w.Close())
+	assert.Equal(t, 3, out.flushes)
+}
+
+func TestXLSXWriter(t *testing.T) {
+	var out bytes.Buffer
+	w, err := NewXLSXWriter(&out, "receptions")
+	assert.NoError(t, err)
+
+	assert.NoError(t, w.WriteRow([]interface{}{"id", "products"}))
+	assert.NoError(t, w.WriteRow([]interface{}{"1", 5}))
+	assert.Zero(t, out.Len(), "workbook is written on close")
+	assert.NoError(t, w.Close())
+
+	file, err := excelize.OpenReader(&out)
+	assert.NoError(t, err)
+	defer file.Close()
+
+	rows, err := file.GetRows("receptions")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"id", "products"}, {"1", "5"}}, rows)
+}
+
+func TestXLSXWriter_Discard(t *testing.T) {
+	var out bytes.Buffer
+	w, err := NewXLSXWriter(&out, "receptions")
+	assert.NoError(t, err)
+
+	assert.NoError(t, w.WriteRow([]interface{}{"id", "products"}))
+	w.Discard()
+	w.Discard()
+	assert.Zero(t, out.Len(), "discarded workbook is not written")
+}
+
+func TestXLSXWriter_DiscardAfterClose(t *testing.T) {
+	var out bytes.Buffer
+	w, err := NewXLSXWriter(&out, "receptions")
+	assert.NoError(t, err)
+
+	assert.NoError(t, w.WriteRow([]interface{}{"id"}))
+	assert.NoError(t, w.Close())
+	written := out.Len()
+
+	w.Discard()
+	assert.Equal(t, written, out.Len())
+}
@@ -0,0 +1,298 @@
+package importer
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/senyabanana/pvz-service/internal/entity"
+)
+
+// CSV columns. A row with a reception date describes a reception of the PVZ with the given ref;
+// the PVZ columns may be repeated on every row of the PVZ or filled in only once.
+const (
+	columnRef              = "pvz_ref"
+	columnCity             = "city"
+	columnRegistrationDate = "registration_date"
+	columnAddress          = "address"
+	columnPhone            = "phone"
+	columnLatitude         = "latitude"
+	columnLongitude        = "longitude"
+	columnReceptionDate    = "reception_date"
+	columnClosedAt         = "closed_at"
+	columnProducts         = "products"
+)
+
+var (
+	knownColumns = map[string]struct{}{
+		columnRef: {}, columnCity: {}, columnRegistrationDate: {}, columnAddress: {}, columnPhone: {},
+		columnLatitude: {}, columnLongitude: {}, columnReceptionDate: {}, columnClosedAt: {}, columnProducts: {},
+	}
+	pvzColumns = []string{
+		columnCity, columnRegistrationDate, columnAddress, columnPhone, columnLatitude, columnLongitude,
+	}
+)
+
+// Parse reads an import file. Malformed files fail as a whole with ErrInvalidImportFile;
+// values that cannot be parsed are reported per row so that a dry run lists them all at once.
+func Parse(format entity.ImportFormat, r io.Reader) (*entity.ImportBatch, []entity.ImportRowError, error) {
+	switch format {
+	case entity.ImportFormatCSV:
+		return ParseCSV(r)
+	case entity.ImportFormatJSON:
+		return ParseJSON(r)
+	default:
+		return nil, nil, fmt.Errorf("%w: unsupported format %q", entity.ErrInvalidImportFile, format)
+	}
+}
+
+func ParseCSV(r io.Reader) (*entity.ImportBatch, []entity.ImportRowError, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: failed to read header: %w", entity.ErrInvalidImportFile, err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.TrimSpace(strings.TrimPrefix(name, "\ufeff"))
+		if _, ok := knownColumns[name]; !ok {
+			return nil, nil, fmt.Errorf("%w: unknown column %q", entity.ErrInvalidImportFile, name)
+		}
+		columns[name] = i
+	}
+	if _, ok := columns[columnRef]; !ok {
+		return nil, nil, fmt.Errorf("%w: column %q is required", entity.ErrInvalidImportFile, columnRef)
+	}
+
+	batch := &entity.ImportBatch{}
+	var rowErrs []entity.ImportRowError
+	byRef := make(map[string]int)
+	firstRows := make(map[string][]string)
+	rows := 0
+
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w: %w", entity.ErrInvalidImportFile, err)
+		}
+
+		rows++
+		if rows > entity.MaxImportRows {
+			return nil, nil, fmt.Errorf("%w: more than %d rows", entity.ErrInvalidImportFile, entity.MaxImportRows)
+		}
+
+		line, _ := reader.FieldPos(0)
+		p := &rowParser{row: line}
+		value := func(column string) string {
+			if i, ok := columns[column]; ok {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+
+		ref := value(columnRef)
+		if ref == "" {
+			p.fail(columnRef, "is required")
+			rowErrs = append(rowErrs, p.errs...)
+			continue
+		}
+
+		idx, seen := byRef[ref]
+		if !seen {
+			pvz := entity.ImportPVZ{Row: line, Ref: ref, PVZ: entity.PVZ{
+				City:    entity.PVZCity(value(columnCity)),
+				Address: value(columnAddress),
+				Phone:   value(columnPhone),
+			}}
+			if raw := value(columnRegistrationDate); raw != "" {
+				pvz.PVZ.RegistrationDate = p.time(columnRegistrationDate, raw)
+			}
+			pvz.PVZ.Latitude = p.float(columnLatitude, value(columnLatitude))
+			pvz.PVZ.Longitude = p.float(columnLongitude, value(columnLongitude))
+
+			idx = len(batch.PVZ)
+			byRef[ref] = idx
+			firstRows[ref] = make([]string, len(pvzColumns))
+			for i, column := range pvzColumns {
+				firstRows[ref][i] = value(column)
+			}
+			batch.PVZ = append(batch.PVZ, pvz)
+		} else {
+			for i, column := range pvzColumns {
+				if raw := value(column); raw != "" && raw != firstRows[ref][i] {
+					p.fail(column, fmt.Sprintf("differs from the first row of pvz %q", ref))
+				}
+			}
+		}
+
+		if raw := value(columnReceptionDate); raw != "" {
+			reception := entity.ImportReception{Row: line, DateTime: p.time(columnReceptionDate, raw)}
+			if rawClosed := value(columnClosedAt); rawClosed != "" {
+				closedAt := p.time(columnClosedAt, rawClosed)
+				reception.ClosedAt = &closedAt
+			}
+			reception.Products = p.products(columnProducts, value(columnProducts))
+			batch.PVZ[idx].Receptions = append(batch.PVZ[idx].Receptions, reception)
+		} else if value(columnClosedAt) != "" || value(columnProducts) != "" {
+			p.fail(columnReceptionDate, "is required for a reception")
+		}
+
+		rowErrs = append(rowErrs, p.errs...)
+	}
+
+	return batch, rowErrs, nil
+}
+
+type jsonDocument struct {
+	PVZ []jsonPVZ `json:"pvz"`
+}
+
+type jsonPVZ struct {
+	Ref              string               `json:"ref"`
+	City             string               `json:"city"`
+	RegistrationDate string               `json:"registrationDate"`
+	Address          string               `json:"address"`
+	Phone            string               `json:"phone"`
+	Latitude         *float64             `json:"latitude"`
+	Longitude        *float64             `json:"longitude"`
+	WorkingHours     *entity.WorkingHours `json:"workingHours"`
+	Receptions       []jsonReception      `json:"receptions"`
+}
+
+type jsonReception struct {
+	DateTime string         `json:"dateTime"`
+	ClosedAt string         `json:"closedAt"`
+	Products map[string]int `json:"products"`
+}
+
+// ParseJSON reads a {"pvz": [...]} document; rows in errors are 1-based positions in the pvz array.
+func ParseJSON(r io.Reader) (*entity.ImportBatch, []entity.ImportRowError, error) {
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields()
+
+	var doc jsonDocument
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, nil, fmt.Errorf("%w: %w", entity.ErrInvalidImportFile, err)
+	}
+
+	rows := len(doc.PVZ)
+	for _, pvz := range doc.PVZ {
+		rows += len(pvz.Receptions)
+	}
+	if rows > entity.MaxImportRows {
+		return nil, nil, fmt.Errorf("%w: more than %d rows", entity.ErrInvalidImportFile, entity.MaxImportRows)
+	}
+
+	batch := &entity.ImportBatch{PVZ: make([]entity.ImportPVZ, 0, len(doc.PVZ))}
+	var rowErrs []entity.ImportRowError
+
+	for i, raw := range doc.PVZ {
+		p := &rowParser{row: i + 1}
+		if raw.Ref == "" {
+			p.fail("ref", "is required")
+		}
+
+		pvz := entity.ImportPVZ{Row: p.row, Ref: raw.Ref, PVZ: entity.PVZ{
+			City:         entity.PVZCity(raw.City),
+			Address:      raw.Address,
+			Phone:        raw.Phone,
+			Latitude:     raw.Latitude,
+			Longitude:    raw.Longitude,
+			WorkingHours: raw.WorkingHours,
+		}}
+		if raw.RegistrationDate != "" {
+			pvz.PVZ.RegistrationDate = p.time("registrationDate", raw.RegistrationDate)
+		}
+
+		for j, rawReception := range raw.Receptions {
+			field := fmt.Sprintf("receptions[%d].", j)
+			reception := entity.ImportReception{Row: p.row, DateTime: p.time(field+"dateTime", rawReception.DateTime)}
+			if rawReception.ClosedAt != "" {
+				closedAt := p.time(field+"closedAt", rawReception.ClosedAt)
+				reception.ClosedAt = &closedAt
+			}
+
+			reception.Products = make(map[entity.ProductType]int, len(rawReception.Products))
+			for productType, count := range rawReception.Products {
+				reception.Products[entity.ProductType(productType)] = count
+			}
+			pvz.Receptions = append(pvz.Receptions, reception)
+		}
+
+		batch.PVZ = append(batch.PVZ, pvz)
+		rowErrs = append(rowErrs, p.errs...)
+	}
+
+	return batch, rowErrs, nil
+}
+
+// rowParser collects the errors of one row instead of stopping at the first one.
+type rowParser struct {
+	row  int
+	errs []entity.ImportRowError
+}
+
+func (p *rowParser) fail(field, message string) {
+	p.errs = append(p.errs, entity.ImportRowError{Row: p.row, Field: field, Message: message})
+}
+
+func (p *rowParser) time(field, raw string) time.Time {
+	t, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		p.fail(field, "must be an RFC3339 timestamp")
+	}
+
+	return t
+}
+
+func (p *rowParser) float(field, raw string) *float64 {
+	if raw == "" {
+		return nil
+	}
+
+	value, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		p.fail(field, "must be a number")
+		return nil
+	}
+
+	return &value
+}
+
+// products parses a "type=count,..." list such as "электроника=3,обувь=1".
+func (p *rowParser) products(field, raw string) map[entity.ProductType]int {
+	products := make(map[entity.ProductType]int)
+	for _, pair := range strings.Split(raw, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+
+		rawType, rawCount, ok := strings.Cut(pair, "=")
+		if !ok {
+			p.fail(field, fmt.Sprintf("invalid entry %q, expected type=count", pair))
+			continue
+		}
+
+		count, err := strconv.Atoi(strings.TrimSpace(rawCount))
+		if err != nil {
+			p.fail(field, fmt.Sprintf("invalid count in entry %q", pair))
+			continue
+		}
+
+		products[entity.ProductType(strings.TrimSpace(rawType))] += count
+	}
+
+	return products
+}
@@ -0,0 +1,92 @@
+package importer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/senyabanana/pvz-service/internal/entity"
+)
+
+func TestParseCSV(t *testing.T) {
+	tests := []struct {
+		name           string
+		file           string
+		wantPVZ        int
+		wantReceptions int
+		wantErrs       []entity.ImportRowError
+		wantErr        error
+	}{
+		{
+			name: "receptions grouped by ref",
+			file: "\ufeffpvz_ref,city,latitude,longitude,reception_date,closed_at,products\n" +
+				"A1,Москва,55.75,37.61,2024-02-01T10:00:00Z,2024-02-01T18:00:00Z,\"электроника=2, обувь=1\"\n" +
+				"B2,Казань,,,,,\n" +
+				"A1,Москва,,,2024-03-01T10:00:00Z,,\n",
+			wantPVZ:        2,
+			wantReceptions: 2,
+		},
+		{
+			name: "row errors",
+			file: "pvz_ref,city,latitude,reception_date,products\n" +
+				"A1,Москва,north,yesterday,электроника\n" +
+				"A1,Казань,,,\n" +
+				",Москва,,,\n",
+			wantPVZ:        1,
+			wantReceptions: 1,
+			wantErrs: []entity.ImportRowError{
+				{Row: 2, Field: "latitude", Message: "must be a number"},
+				{Row: 2, Field: "reception_date", Message: "must be an RFC3339 timestamp"},
+				{Row: 2, Field: "products", Message: `invalid entry "электроника", expected type=count`},
+				{Row: 3, Field: "city", Message: `differs from the first row of pvz "A1"`},
+				{Row: 4, Field: "pvz_ref", Message: "is required"},
+			},
+		},
+		{
+			name:    "unknown column",
+			file:    "pvz_ref,town\nA1,Москва\n",
+			wantErr: entity.ErrInvalidImportFile,
+		},
+		{
+			name:    "missing ref column",
+			file:    "city\nМосква\n",
+			wantErr: entity.ErrInvalidImportFile,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			batch, rowErrs, err := ParseCSV(strings.NewReader(tt.file))
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantErrs, rowErrs)
+			assert.Len(t, batch.PVZ, tt.wantPVZ)
+
+			receptions := 0
+			for _, pvz := range batch.PVZ {
+				receptions += len(pvz.Receptions)
+			}
+			assert.Equal(t, tt.wantReceptions, receptions)
+		})
+	}
+}
+
+func TestParseJSON(t *testing.T) {
+	file := `{"pvz":[{"ref":"A1","city":"Москва","registrationDate":"2024-01-10T09:00:00Z",
+		"receptions":[{"dateTime":"2024-02-01T10:00:00Z","closedAt":"soon","products":{"одежда":3}}]}]}`
+
+	batch, rowErrs, err := ParseJSON(strings.NewReader(file))
+	assert.NoError(t, err)
+	assert.Equal(t, []entity.ImportRowError{
+		{Row: 1, Field: "receptions[0].closedAt", Message: "must be an RFC3339 timestamp"},
+	}, rowErrs)
+	assert.Equal(t, 3, batch.PVZ[0].Receptions[0].Products[entity.ProductClothing])
+
+	_, _, err = ParseJSON(strings.NewReader(`{"pvz":[{"ref":"A1","town":"Москва"}]}`))
+	assert.ErrorIs(t, err, entity.ErrInvalidImportFile)
+}
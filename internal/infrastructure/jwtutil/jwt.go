@@ -1,21 +1,67 @@
 package jwtutil
 
 import (
+	"errors"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/senyabanana/pvz-service/internal/entity"
 )
 
 type JWTClaims struct {
 	UserID string `json:"user_id"`
 	Role   string `json:"role"`
+	Dummy  bool   `json:"dummy,omitempty"`
 	jwt.RegisteredClaims
 }
 
 func GenerateToken(userID, role, secretKey string, ttl time.Duration) (string, error) {
+	return generate(userID, role, false, secretKey, ttl)
+}
+
+// GenerateDummyToken issues a token for a user that doesn't exist in the database.
+func GenerateDummyToken(userID, role, secretKey string, ttl time.Duration) (string, error) {
+	return generate(userID, role, true, secretKey, ttl)
+}
+
+func ParseToken(tokenString, secretKey string) (*JWTClaims, error) {
+	token, err := jwt.ParseWithClaims(tokenString, &JWTClaims{}, func(token *jwt.Token) (interface{}, error) {
+		return []byte(secretKey), nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}))
+	if err != nil {
+		return nil, err
+	}
+
+	claims, ok := token.Claims.(*JWTClaims)
+	if !ok || !token.Valid {
+		return nil, errors.New("claims cannot be read")
+	}
+
+	return claims, nil
+}
+
+func (c *JWTClaims) ToEntity() entity.TokenClaims {
+	claims := entity.TokenClaims{
+		UserID: c.UserID,
+		Role:   entity.UserRole(c.Role),
+		Dummy:  c.Dummy,
+	}
+	if c.IssuedAt != nil {
+		claims.IssuedAt = c.IssuedAt.Time
+	}
+	if c.ExpiresAt != nil {
+		claims.ExpiresAt = c.ExpiresAt.Time
+	}
+
+	return claims
+}
+
+func generate(userID, role string, dummy bool, secretKey string, ttl time.Duration) (string, error) {
 	claims := JWTClaims{
 		UserID: userID,
 		Role:   role,
+		Dummy:  dummy,
 		RegisteredClaims: jwt.RegisteredClaims{
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(ttl)),
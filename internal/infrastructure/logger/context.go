@@ -0,0 +1,62 @@
+package logger
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+const maxRequestIDLength = 128
+
+const (
+	FieldRequestID  = "request_id"
+	FieldUserID     = "user_id"
+	FieldRole       = "role"
+	FieldPVZID      = "pvz_id"
+	FieldAPIKeyID   = "api_key_id"
+	FieldGRPCMethod = "grpc_method"
+	FieldJobID      = "job_id"
+)
+
+type entryKey struct{}
+
+// WithEntry binds a request-scoped entry to ctx; everything logged through FromContext(ctx) carries its fields.
+func WithEntry(ctx context.Context, entry *logrus.Entry) context.Context {
+	return context.WithValue(ctx, entryKey{}, entry)
+}
+
+// WithFields adds fields to the entry bound to ctx, starting from fallback when there is none.
+func WithFields(ctx context.Context, fallback *logrus.Logger, fields logrus.Fields) context.Context {
+	return WithEntry(ctx, entryFromContext(ctx, fallback).WithFields(fields))
+}
+
+// FromContext returns the request-scoped entry bound to ctx, or a bare entry of fallback outside a request.
+// The entry also carries ctx itself, so hooks can read the active trace.
+func FromContext(ctx context.Context, fallback *logrus.Logger) *logrus.Entry {
+	return entryFromContext(ctx, fallback).WithContext(ctx)
+}
+
+func entryFromContext(ctx context.Context, fallback *logrus.Logger) *logrus.Entry {
+	if entry, ok := ctx.Value(entryKey{}).(*logrus.Entry); ok {
+		return entry
+	}
+
+	return logrus.NewEntry(fallback)
+}
+
+// RequestIDOrNew returns the caller-supplied request ID, or a new UUID when it is missing or unsafe
+// to put into logs and response headers: only printable ASCII of reasonable length is accepted.
+func RequestIDOrNew(id string) string {
+	if id == "" || len(id) > maxRequestIDLength {
+		return uuid.NewString()
+	}
+
+	for i := 0; i < len(id); i++ {
+		if id[i] < 0x21 || id[i] > 0x7e {
+			return uuid.NewString()
+		}
+	}
+
+	return id
+}
@@ -0,0 +1,90 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFromContext(t *testing.T) {
+	var buf bytes.Buffer
+	log := logrus.New()
+	log.SetFormatter(&logrus.JSONFormatter{})
+	log.SetOutput(&buf)
+
+	requestCtx := WithEntry(context.Background(), log.WithField(FieldRequestID, "req-1"))
+
+	tests := []struct {
+		name       string
+		ctx        context.Context
+		wantFields map[string]any
+	}{
+		{
+			name:       "outside a request",
+			ctx:        context.Background(),
+			wantFields: map[string]any{},
+		},
+		{
+			name:       "request entry",
+			ctx:        requestCtx,
+			wantFields: map[string]any{FieldRequestID: "req-1"},
+		},
+		{
+			name:       "fields added after authentication",
+			ctx:        WithFields(requestCtx, log, logrus.Fields{FieldUserID: "u-1", FieldRole: "employee"}),
+			wantFields: map[string]any{FieldRequestID: "req-1", FieldUserID: "u-1", FieldRole: "employee"},
+		},
+		{
+			name:       "fields without a request entry",
+			ctx:        WithFields(context.Background(), log, logrus.Fields{FieldGRPCMethod: "/pvz.v1.PVZService/GetPVZList"}),
+			wantFields: map[string]any{FieldGRPCMethod: "/pvz.v1.PVZService/GetPVZList"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf.Reset()
+			FromContext(tt.ctx, log).Info("message")
+
+			var entry map[string]any
+			require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+			delete(entry, "level")
+			delete(entry, "msg")
+			delete(entry, "time")
+			assert.Equal(t, tt.wantFields, entry)
+		})
+	}
+}
+
+func TestRequestIDOrNew(t *testing.T) {
+	tests := []struct {
+		name     string
+		id       string
+		wantKeep bool
+	}{
+		{name: "client id", id: "3f1c2a7e-req", wantKeep: true},
+		{name: "missing", id: ""},
+		{name: "control characters", id: "abc\r\ninjected: true"},
+		{name: "too long", id: strings.Repeat("a", maxRequestIDLength+1)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := RequestIDOrNew(tt.id)
+			if tt.wantKeep {
+				assert.Equal(t, tt.id, got)
+				return
+			}
+
+			_, err := uuid.Parse(got)
+			assert.NoError(t, err)
+		})
+	}
+}
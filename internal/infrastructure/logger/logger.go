@@ -1,16 +1,86 @@
 package logger
 
 import (
+	"fmt"
+	"io"
 	"os"
 
 	"github.com/sirupsen/logrus"
 )
 
+const (
+	FormatJSON = "json"
+	FormatText = "text"
+
+	OutputStdout = "stdout"
+	OutputStderr = "stderr"
+)
+
+type Options struct {
+	Level  string
+	Format string
+	// Output is stdout, stderr or a file path; files are appended to.
+	Output string
+}
+
+// NewLogger returns a logger writing JSON to stdout at info level until Configure applies the config.
+// Emails, tokens and passwords are masked in every entry.
 func NewLogger() *logrus.Logger {
 	log := logrus.New()
 	log.SetFormatter(&logrus.JSONFormatter{})
 	log.SetOutput(os.Stdout)
-	log.SetLevel(logrus.DebugLevel)
+	log.SetLevel(logrus.InfoLevel)
+	log.AddHook(NewRedactionHook())
 
 	return log
 }
+
+// Configure applies level, format and output to log. For a file output the returned closer
+// must be closed on shutdown; otherwise it is nil.
+func Configure(log *logrus.Logger, opts Options) (io.Closer, error) {
+	level, err := ParseLevel(opts.Level)
+	if err != nil {
+		return nil, err
+	}
+
+	var formatter logrus.Formatter
+	switch opts.Format {
+	case FormatJSON:
+		formatter = &logrus.JSONFormatter{}
+	case FormatText:
+		formatter = &logrus.TextFormatter{FullTimestamp: true}
+	default:
+		return nil, fmt.Errorf("unsupported log format: %s", opts.Format)
+	}
+
+	var output io.Writer
+	var closer io.Closer
+	switch opts.Output {
+	case OutputStdout, "":
+		output = os.Stdout
+	case OutputStderr:
+		output = os.Stderr
+	default:
+		file, err := os.OpenFile(opts.Output, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open log file: %w", err)
+		}
+		output, closer = file, file
+	}
+
+	log.SetFormatter(formatter)
+	log.SetOutput(output)
+	log.SetLevel(level)
+
+	return closer, nil
+}
+
+// ParseLevel accepts the logrus level names: panic, fatal, error, warn, info, debug and trace.
+func ParseLevel(level string) (logrus.Level, error) {
+	parsed, err := logrus.ParseLevel(level)
+	if err != nil {
+		return 0, fmt.Errorf("unsupported log level: %s", level)
+	}
+
+	return parsed, nil
+}
@@ -40,6 +40,38 @@ var (
 			Help: "Количество добавленных товаров",
 		},
 	)
+
+	CapacityExceededCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "pvz_capacity_exceeded_total",
+			Help: "Количество попыток добавить товар сверх вместимости ПВЗ",
+		},
+		[]string{"policy"},
+	)
+
+	OverdueProductsCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "overdue_products_total",
+			Help: "Количество товаров, отправленных на возврат отправителю по истечении срока хранения",
+		},
+		[]string{"type"},
+	)
+
+	StocktakeDiscrepanciesCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "stocktake_discrepancies_total",
+			Help: "Количество расхождений, выявленных при инвентаризации ПВЗ",
+		},
+		[]string{"result"},
+	)
+
+	ReportJobsCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "report_jobs_total",
+			Help: "Количество завершенных фоновых отчетов",
+		},
+		[]string{"kind", "status"},
+	)
 )
 
 func RegisterMetrics() {
@@ -49,5 +81,9 @@ func RegisterMetrics() {
 		CreatedPVZCounter,
 		CreatedReceptionsCounter,
 		AddedProductsCounter,
+		CapacityExceededCounter,
+		OverdueProductsCounter,
+		StocktakeDiscrepanciesCounter,
+		ReportJobsCounter,
 	)
 }
@@ -0,0 +1,83 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/senyabanana/pvz-service/internal/infrastructure/logger"
+)
+
+const (
+	TypeLog  = "log"
+	TypeFile = "file"
+)
+
+// LogNotifier writes notifications to the service log. Intended for local development only.
+// The reset token is the whole point of the message, so it is logged with redaction turned off.
+type LogNotifier struct {
+	log *logrus.Logger
+}
+
+func NewLogNotifier(log *logrus.Logger) *LogNotifier {
+	return &LogNotifier{log: log}
+}
+
+func (n *LogNotifier) SendPasswordReset(ctx context.Context, email, token string, expiresAt time.Time) error {
+	log := logger.FromContext(logger.WithoutRedaction(ctx), n.log)
+	log.Infof("password reset requested: email=%s, token=%s, expiresAt=%s", email, token, expiresAt.Format(time.RFC3339))
+
+	return nil
+}
+
+// FileNotifier appends notifications as JSON lines to a file.
+type FileNotifier struct {
+	path string
+	mu   sync.Mutex
+}
+
+type fileMessage struct {
+	Type      string    `json:"type"`
+	Email     string    `json:"email"`
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expiresAt"`
+	SentAt    time.Time `json:"sentAt"`
+}
+
+func NewFileNotifier(path string) *FileNotifier {
+	return &FileNotifier{path: path}
+}
+
+func (n *FileNotifier) SendPasswordReset(ctx context.Context, email, token string, expiresAt time.Time) error {
+	return n.write(fileMessage{
+		Type:      "password_reset",
+		Email:     email,
+		Token:     token,
+		ExpiresAt: expiresAt,
+		SentAt:    time.Now(),
+	})
+}
+
+func (n *FileNotifier) write(msg fileMessage) error {
+	line, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	f, err := os.OpenFile(n.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open notification file: %w", err)
+	}
+	defer f.Close()
+
+	_, err = f.Write(append(line, '\n'))
+	return err
+}
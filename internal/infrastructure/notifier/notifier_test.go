@@ -0,0 +1,45 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/senyabanana/pvz-service/internal/infrastructure/logger"
+)
+
+func TestLogNotifier_SendPasswordReset_NotRedacted(t *testing.T) {
+	var out bytes.Buffer
+	log := logger.NewLogger()
+	log.SetOutput(&out)
+
+	n := NewLogNotifier(log)
+	err := n.SendPasswordReset(context.Background(), "ivan@example.com", "reset-token-123", time.Now().Add(time.Hour))
+	assert.NoError(t, err)
+
+	assert.Contains(t, out.String(), "reset-token-123")
+	assert.Contains(t, out.String(), "ivan@example.com")
+
+	out.Reset()
+	log.Infof("password reset requested: email=%s, token=%s", "ivan@example.com", "reset-token-123")
+
+	assert.NotContains(t, out.String(), "reset-token-123", "the service log itself must stay redacted")
+	assert.NotContains(t, out.String(), "ivan@example.com")
+}
+
+func TestLogNotifier_SendPasswordReset_FollowsLogLevel(t *testing.T) {
+	var out bytes.Buffer
+	log := logger.NewLogger()
+	log.SetOutput(&out)
+
+	n := NewLogNotifier(log)
+	log.SetLevel(logrus.WarnLevel)
+
+	err := n.SendPasswordReset(context.Background(), "ivan@example.com", "reset-token-123", time.Now().Add(time.Hour))
+	assert.NoError(t, err)
+	assert.Zero(t, out.Len(), "level changes of the service log apply to the notifier")
+}
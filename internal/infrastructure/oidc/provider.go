@@ -0,0 +1,129 @@
+package oidc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	gooidc "github.com/coreos/go-oidc/v3/oidc"
+	"golang.org/x/oauth2"
+
+	"github.com/senyabanana/pvz-service/internal/entity"
+	"github.com/senyabanana/pvz-service/internal/infrastructure/config"
+)
+
+type Provider struct {
+	oauth2Config oauth2.Config
+	verifier     *gooidc.IDTokenVerifier
+	groupsClaim  string
+}
+
+func NewProvider(ctx context.Context, cfg *config.Config) (*Provider, error) {
+	provider, err := gooidc.NewProvider(ctx, cfg.OIDCIssuerURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to discover oidc provider: %w", err)
+	}
+
+	return &Provider{
+		oauth2Config: oauth2.Config{
+			ClientID:     cfg.OIDCClientID,
+			ClientSecret: cfg.OIDCClientSecret,
+			RedirectURL:  cfg.OIDCRedirectURL,
+			Endpoint:     provider.Endpoint(),
+			Scopes:       []string{gooidc.ScopeOpenID, "profile", "email"},
+		},
+		verifier:    provider.Verifier(&gooidc.Config{ClientID: cfg.OIDCClientID}),
+		groupsClaim: cfg.OIDCGroupsClaim,
+	}, nil
+}
+
+func (p *Provider) AuthCodeURL(state, nonce string) string {
+	return p.oauth2Config.AuthCodeURL(state, gooidc.Nonce(nonce))
+}
+
+// Exchange redeems the authorization code and returns the identity from the verified ID token.
+func (p *Provider) Exchange(ctx context.Context, code, nonce string) (*entity.ExternalIdentity, error) {
+	token, err := p.oauth2Config.Exchange(ctx, code)
+	if err != nil {
+		return nil, fmt.Errorf("failed to exchange code: %w", err)
+	}
+
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok {
+		return nil, errors.New("token response has no id_token")
+	}
+
+	idToken, err := p.verifier.Verify(ctx, rawIDToken)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify id_token: %w", err)
+	}
+
+	if idToken.Nonce != nonce {
+		return nil, errors.New("id_token nonce mismatch")
+	}
+
+	var claims map[string]interface{}
+	if err := idToken.Claims(&claims); err != nil {
+		return nil, fmt.Errorf("failed to read id_token claims: %w", err)
+	}
+
+	email, _ := claims["email"].(string)
+	if email == "" {
+		return nil, errors.New("id_token has no email claim")
+	}
+
+	// A missing claim counts as unverified.
+	verified, _ := claims["email_verified"].(bool)
+
+	return &entity.ExternalIdentity{
+		Issuer:        idToken.Issuer,
+		Subject:       idToken.Subject,
+		Email:         entity.NormalizeEmail(email),
+		EmailVerified: verified,
+		Groups:        extractGroups(claims[p.groupsClaim]),
+	}, nil
+}
+
+// ParseRoleMapping parses "group=role,group=role" into a group to role lookup.
+func ParseRoleMapping(raw string) (map[string]entity.UserRole, error) {
+	mapping := make(map[string]entity.UserRole)
+
+	for _, pair := range strings.Split(raw, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+
+		group, role, ok := strings.Cut(pair, "=")
+		if !ok || strings.TrimSpace(group) == "" {
+			return nil, fmt.Errorf("invalid role mapping entry: %q", pair)
+		}
+
+		userRole := entity.UserRole(strings.TrimSpace(role))
+		if !entity.IsValidUserRole(userRole) {
+			return nil, fmt.Errorf("invalid role in mapping entry %q: %w", pair, entity.ErrInvalidUserRole)
+		}
+
+		mapping[strings.TrimSpace(group)] = userRole
+	}
+
+	return mapping, nil
+}
+
+func extractGroups(claim interface{}) []string {
+	switch v := claim.(type) {
+	case string:
+		return []string{v}
+	case []interface{}:
+		groups := make([]string, 0, len(v))
+		for _, g := range v {
+			if s, ok := g.(string); ok {
+				groups = append(groups, s)
+			}
+		}
+		return groups
+	default:
+		return nil
+	}
+}
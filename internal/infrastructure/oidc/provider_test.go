@@ -0,0 +1,197 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/coreos/go-oidc/v3/oidc/oidctest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/senyabanana/pvz-service/internal/entity"
+	"github.com/senyabanana/pvz-service/internal/infrastructure/config"
+)
+
+const (
+	testClientID = "pvz-service"
+	testKeyID    = "test-key"
+	testNonce    = "nonce"
+)
+
+// newMockIdP starts a local identity provider whose token endpoint returns the given ID token claims.
+func newMockIdP(t *testing.T, claims func(issuer string) string) *httptest.Server {
+	t.Helper()
+
+	priv, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	idp := &oidctest.Server{
+		PublicKeys: []oidctest.PublicKey{{PublicKey: priv.Public(), KeyID: testKeyID, Algorithm: "RS256"}},
+	}
+
+	var issuer string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("code") != "valid-code" {
+			http.Error(w, `{"error":"invalid_grant"}`, http.StatusBadRequest)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"access_token": "access",
+			"token_type":   "Bearer",
+			"expires_in":   3600,
+			"id_token":     oidctest.SignIDToken(priv, testKeyID, "RS256", claims(issuer)),
+		})
+	})
+	mux.Handle("/", idp)
+
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	issuer = srv.URL
+	idp.SetIssuer(issuer)
+
+	return srv
+}
+
+func TestProvider_Exchange(t *testing.T) {
+	exp := time.Now().Add(time.Hour).Unix()
+
+	tests := []struct {
+		name    string
+		code    string
+		claims  func(issuer string) string
+		want    *entity.ExternalIdentity
+		wantErr bool
+	}{
+		{
+			name: "success",
+			code: "valid-code",
+			claims: func(issuer string) string {
+				return fmt.Sprintf(`{"iss":%q,"aud":%q,"sub":"user-1","exp":%d,"nonce":%q,`+
+					`"email":"Staff@Example.com","email_verified":true,"groups":["pvz-employees","other"]}`,
+					issuer, testClientID, exp, testNonce)
+			},
+			want: &entity.ExternalIdentity{
+				Subject:       "user-1",
+				Email:         "staff@example.com",
+				EmailVerified: true,
+				Groups:        []string{"pvz-employees", "other"},
+			},
+		},
+		{
+			name: "email_verified missing",
+			code: "valid-code",
+			claims: func(issuer string) string {
+				return fmt.Sprintf(`{"iss":%q,"aud":%q,"sub":"user-1","exp":%d,"nonce":%q,"email":"a@b.c"}`,
+					issuer, testClientID, exp, testNonce)
+			},
+			want: &entity.ExternalIdentity{Subject: "user-1", Email: "a@b.c"},
+		},
+		{
+			name: "invalid code",
+			code: "bad-code",
+			claims: func(issuer string) string {
+				return "{}"
+			},
+			wantErr: true,
+		},
+		{
+			name: "nonce mismatch",
+			code: "valid-code",
+			claims: func(issuer string) string {
+				return fmt.Sprintf(`{"iss":%q,"aud":%q,"sub":"user-1","exp":%d,"nonce":"other","email":"a@b.c"}`,
+					issuer, testClientID, exp)
+			},
+			wantErr: true,
+		},
+		{
+			name: "wrong audience",
+			code: "valid-code",
+			claims: func(issuer string) string {
+				return fmt.Sprintf(`{"iss":%q,"aud":"another","sub":"user-1","exp":%d,"nonce":%q,"email":"a@b.c"}`,
+					issuer, exp, testNonce)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := newMockIdP(t, tt.claims)
+
+			p, err := NewProvider(context.Background(), &config.Config{
+				OIDCIssuerURL:   srv.URL,
+				OIDCClientID:    testClientID,
+				OIDCRedirectURL: "http://localhost:8080/auth/oidc/callback",
+				OIDCGroupsClaim: "groups",
+			})
+			require.NoError(t, err)
+
+			identity, err := p.Exchange(context.Background(), tt.code, testNonce)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			tt.want.Issuer = srv.URL
+			assert.Equal(t, tt.want, identity)
+		})
+	}
+}
+
+func TestParseRoleMapping(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     string
+		want    map[string]entity.UserRole
+		wantErr bool
+	}{
+		{
+			name: "success",
+			raw:  "pvz-moderators=moderator, pvz-employees=employee",
+			want: map[string]entity.UserRole{
+				"pvz-moderators": entity.RoleModerator,
+				"pvz-employees":  entity.RoleEmployee,
+			},
+		},
+		{
+			name: "empty",
+			raw:  "",
+			want: map[string]entity.UserRole{},
+		},
+		{
+			name:    "missing separator",
+			raw:     "pvz-moderators",
+			wantErr: true,
+		},
+		{
+			name:    "invalid role",
+			raw:     "pvz-admins=admin",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseRoleMapping(tt.raw)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
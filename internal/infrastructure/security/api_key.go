@@ -0,0 +1,36 @@
+package security
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+)
+
+const (
+	apiKeyPrefixBytes = 4
+	apiKeySecretBytes = 32
+)
+
+// GenerateAPIKey returns a new raw key in the form pvz_<prefix>_<secret> and its public prefix.
+// The raw key is shown to the caller once and only its hash is persisted.
+func GenerateAPIKey() (key, prefix string, err error) {
+	prefixBytes := make([]byte, apiKeyPrefixBytes)
+	if _, err = rand.Read(prefixBytes); err != nil {
+		return "", "", err
+	}
+
+	secret := make([]byte, apiKeySecretBytes)
+	if _, err = rand.Read(secret); err != nil {
+		return "", "", err
+	}
+
+	prefix = hex.EncodeToString(prefixBytes)
+	key = fmt.Sprintf("pvz_%s_%s", prefix, base64.RawURLEncoding.EncodeToString(secret))
+
+	return key, prefix, nil
+}
+
+func HashAPIKey(key string) string {
+	return HashToken(key)
+}
@@ -1,13 +1,160 @@
 package security
 
-import "golang.org/x/crypto/bcrypt"
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
 
-func GeneratePasswordHash(password string) (string, error) {
-	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	AlgorithmBcrypt   = "bcrypt"
+	AlgorithmArgon2id = "argon2id"
+
+	argon2SaltLength = 16
+	argon2KeyLength  = 32
+	argon2Prefix     = "$argon2id$"
+)
+
+var errInvalidHash = errors.New("invalid password hash format")
+
+// PasswordHasher hashes passwords with the configured algorithm and verifies hashes of any supported one,
+// so that the algorithm and its parameters can be changed without invalidating stored passwords.
+type PasswordHasher struct {
+	algorithm         string
+	bcryptCost        int
+	argon2Memory      uint32
+	argon2Iterations  uint32
+	argon2Parallelism uint8
+}
+
+type argon2Params struct {
+	memory      uint32
+	iterations  uint32
+	parallelism uint8
+	salt        []byte
+	key         []byte
+}
+
+func NewPasswordHasher(
+	algorithm string, bcryptCost int, argon2Memory, argon2Iterations uint32, argon2Parallelism uint8,
+) (*PasswordHasher, error) {
+	switch algorithm {
+	case AlgorithmBcrypt:
+		if bcryptCost < bcrypt.MinCost || bcryptCost > bcrypt.MaxCost {
+			return nil, fmt.Errorf("bcrypt cost must be between %d and %d", bcrypt.MinCost, bcrypt.MaxCost)
+		}
+	case AlgorithmArgon2id:
+		if argon2Memory == 0 || argon2Iterations == 0 || argon2Parallelism == 0 {
+			return nil, errors.New("argon2id memory, iterations and parallelism must be positive")
+		}
+	default:
+		return nil, fmt.Errorf("unsupported password hash algorithm: %s", algorithm)
+	}
+
+	return &PasswordHasher{
+		algorithm:         algorithm,
+		bcryptCost:        bcryptCost,
+		argon2Memory:      argon2Memory,
+		argon2Iterations:  argon2Iterations,
+		argon2Parallelism: argon2Parallelism,
+	}, nil
+}
+
+func (h *PasswordHasher) Hash(password string) (string, error) {
+	if h.algorithm == AlgorithmArgon2id {
+		return h.hashArgon2id(password)
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), h.bcryptCost)
 
 	return string(hash), err
 }
 
-func ComparePassword(password, hash string) error {
+func (h *PasswordHasher) Compare(password, hash string) error {
+	if strings.HasPrefix(hash, argon2Prefix) {
+		params, err := parseArgon2id(hash)
+		if err != nil {
+			return err
+		}
+
+		key := argon2.IDKey([]byte(password), params.salt, params.iterations, params.memory, params.parallelism, uint32(len(params.key)))
+		if subtle.ConstantTimeCompare(key, params.key) != 1 {
+			return bcrypt.ErrMismatchedHashAndPassword
+		}
+
+		return nil
+	}
+
 	return bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
 }
+
+// NeedsRehash reports whether the hash was produced by another algorithm or with other parameters than configured.
+func (h *PasswordHasher) NeedsRehash(hash string) bool {
+	if h.algorithm == AlgorithmArgon2id {
+		params, err := parseArgon2id(hash)
+		if err != nil {
+			return true
+		}
+
+		return params.memory != h.argon2Memory ||
+			params.iterations != h.argon2Iterations ||
+			params.parallelism != h.argon2Parallelism ||
+			len(params.key) != argon2KeyLength
+	}
+
+	cost, err := bcrypt.Cost([]byte(hash))
+	if err != nil {
+		return true
+	}
+
+	return cost != h.bcryptCost
+}
+
+func (h *PasswordHasher) hashArgon2id(password string) (string, error) {
+	salt := make([]byte, argon2SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+
+	key := argon2.IDKey([]byte(password), salt, h.argon2Iterations, h.argon2Memory, h.argon2Parallelism, argon2KeyLength)
+
+	return fmt.Sprintf("%sv=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2Prefix, argon2.Version, h.argon2Memory, h.argon2Iterations, h.argon2Parallelism,
+		base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+// parseArgon2id decodes a PHC string: $argon2id$v=19$m=65536,t=3,p=2$<salt>$<key>.
+func parseArgon2id(hash string) (*argon2Params, error) {
+	parts := strings.Split(hash, "$")
+	if len(parts) != 6 || parts[1] != AlgorithmArgon2id {
+		return nil, errInvalidHash
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return nil, errInvalidHash
+	}
+
+	var params argon2Params
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.memory, &params.iterations, &params.parallelism); err != nil {
+		return nil, errInvalidHash
+	}
+
+	var err error
+	if params.salt, err = base64.RawStdEncoding.DecodeString(parts[4]); err != nil {
+		return nil, errInvalidHash
+	}
+
+	if params.key, err = base64.RawStdEncoding.DecodeString(parts[5]); err != nil || len(params.key) == 0 {
+		return nil, errInvalidHash
+	}
+
+	return &params, nil
+}
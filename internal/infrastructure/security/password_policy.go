@@ -0,0 +1,81 @@
+package security
+
+import (
+	_ "embed"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/senyabanana/pvz-service/internal/entity"
+)
+
+//go:embed common_passwords.txt
+var commonPasswordsList string
+
+var commonPasswords = parseCommonPasswords(commonPasswordsList)
+
+type PasswordPolicy struct {
+	MinLength      int
+	RequireUpper   bool
+	RequireLower   bool
+	RequireDigit   bool
+	RequireSpecial bool
+	RejectCommon   bool
+}
+
+// Validate reports the first rule the password violates, wrapped in entity.ErrWeakPassword.
+func (p PasswordPolicy) Validate(password string) error {
+	if len([]rune(password)) < p.MinLength {
+		return fmt.Errorf("%w: must be at least %d characters long", entity.ErrWeakPassword, p.MinLength)
+	}
+
+	var hasUpper, hasLower, hasDigit, hasSpecial bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r):
+			hasSpecial = true
+		}
+	}
+
+	switch {
+	case p.RequireUpper && !hasUpper:
+		return fmt.Errorf("%w: must contain an uppercase letter", entity.ErrWeakPassword)
+	case p.RequireLower && !hasLower:
+		return fmt.Errorf("%w: must contain a lowercase letter", entity.ErrWeakPassword)
+	case p.RequireDigit && !hasDigit:
+		return fmt.Errorf("%w: must contain a digit", entity.ErrWeakPassword)
+	case p.RequireSpecial && !hasSpecial:
+		return fmt.Errorf("%w: must contain a special character", entity.ErrWeakPassword)
+	}
+
+	if p.RejectCommon && IsCommonPassword(password) {
+		return fmt.Errorf("%w: password is too common", entity.ErrWeakPassword)
+	}
+
+	return nil
+}
+
+func IsCommonPassword(password string) bool {
+	_, ok := commonPasswords[strings.ToLower(password)]
+
+	return ok
+}
+
+func parseCommonPasswords(list string) map[string]struct{} {
+	passwords := make(map[string]struct{})
+	for _, line := range strings.Split(list, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		passwords[strings.ToLower(line)] = struct{}{}
+	}
+
+	return passwords
+}
@@ -0,0 +1,78 @@
+package security
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestPasswordHasher_HashAndCompare(t *testing.T) {
+	tests := []struct {
+		name      string
+		algorithm string
+	}{
+		{name: "bcrypt", algorithm: AlgorithmBcrypt},
+		{name: "argon2id", algorithm: AlgorithmArgon2id},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hasher, err := NewPasswordHasher(tt.algorithm, bcrypt.MinCost, 1024, 1, 1)
+			require.NoError(t, err)
+
+			hash, err := hasher.Hash("s3cret-pass")
+			require.NoError(t, err)
+
+			assert.NoError(t, hasher.Compare("s3cret-pass", hash))
+			assert.Error(t, hasher.Compare("wrong-pass", hash))
+			assert.False(t, hasher.NeedsRehash(hash))
+		})
+	}
+}
+
+func TestPasswordHasher_NeedsRehash(t *testing.T) {
+	bcryptLow, _ := NewPasswordHasher(AlgorithmBcrypt, bcrypt.MinCost, 0, 0, 0)
+	bcryptHigh, _ := NewPasswordHasher(AlgorithmBcrypt, bcrypt.MinCost+1, 0, 0, 0)
+	argonLow, _ := NewPasswordHasher(AlgorithmArgon2id, 0, 1024, 1, 1)
+	argonHigh, _ := NewPasswordHasher(AlgorithmArgon2id, 0, 2048, 2, 1)
+
+	bcryptHash, _ := bcryptLow.Hash("s3cret-pass")
+	argonHash, _ := argonLow.Hash("s3cret-pass")
+
+	tests := []struct {
+		name   string
+		hasher *PasswordHasher
+		hash   string
+		want   bool
+	}{
+		{name: "bcrypt same cost", hasher: bcryptLow, hash: bcryptHash, want: false},
+		{name: "bcrypt cost changed", hasher: bcryptHigh, hash: bcryptHash, want: true},
+		{name: "bcrypt to argon2id", hasher: argonLow, hash: bcryptHash, want: true},
+		{name: "argon2id params changed", hasher: argonHigh, hash: argonHash, want: true},
+		{name: "argon2id to bcrypt", hasher: bcryptLow, hash: argonHash, want: true},
+		{name: "garbage", hasher: argonLow, hash: "$argon2id$broken", want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.hasher.NeedsRehash(tt.hash))
+		})
+	}
+
+	// Hashes stay verifiable after the algorithm is switched.
+	assert.NoError(t, argonHigh.Compare("s3cret-pass", bcryptHash))
+	assert.NoError(t, bcryptHigh.Compare("s3cret-pass", argonHash))
+}
+
+func TestNewPasswordHasher(t *testing.T) {
+	_, err := NewPasswordHasher("md5", 0, 0, 0, 0)
+	assert.Error(t, err)
+
+	_, err = NewPasswordHasher(AlgorithmBcrypt, 100, 0, 0, 0)
+	assert.Error(t, err)
+
+	_, err = NewPasswordHasher(AlgorithmArgon2id, 0, 0, 1, 1)
+	assert.Error(t, err)
+}
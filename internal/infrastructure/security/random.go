@@ -0,0 +1,48 @@
+package security
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"math/big"
+
+	"github.com/google/uuid"
+)
+
+// GenerateRandomString returns a URL-safe random string built from n random bytes.
+func GenerateRandomString(n int) (string, error) {
+	buf := make([]byte, n)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// GenerateNumericCode returns a uniformly random string of the given number of decimal digits.
+func GenerateNumericCode(digits int) (string, error) {
+	code := make([]byte, digits)
+	for i := range code {
+		n, err := rand.Int(rand.Reader, big.NewInt(10))
+		if err != nil {
+			return "", err
+		}
+		code[i] = byte('0' + n.Int64())
+	}
+
+	return string(code), nil
+}
+
+// HashToken returns the hex-encoded SHA-256 of a high-entropy token for storage and lookup.
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+
+	return hex.EncodeToString(sum[:])
+}
+
+// HashPickupCode returns the stored form of a pickup code. The product ID salts the hash, so equal codes
+// of different products do not share a hash.
+func HashPickupCode(productID uuid.UUID, code string) string {
+	return HashToken(productID.String() + ":" + code)
+}
@@ -0,0 +1,54 @@
+package tracing
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// LogrusHook adds trace_id and span_id to entries logged with WithContext when the context
+// carries a recording span. Entries at error level and above also mark that span failed, so every
+// failure a service logs shows up in the trace.
+type LogrusHook struct{}
+
+func NewLogrusHook() *LogrusHook {
+	return &LogrusHook{}
+}
+
+func (h *LogrusHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+func (h *LogrusHook) Fire(entry *logrus.Entry) error {
+	if entry.Context == nil {
+		return nil
+	}
+
+	span := trace.SpanFromContext(entry.Context)
+	spanCtx := span.SpanContext()
+	if !spanCtx.IsValid() {
+		return nil
+	}
+
+	entry.Data["trace_id"] = spanCtx.TraceID().String()
+	entry.Data["span_id"] = spanCtx.SpanID().String()
+
+	if entry.Level <= logrus.ErrorLevel && span.IsRecording() {
+		span.RecordError(entryError(entry))
+		span.SetStatus(codes.Error, entry.Message)
+	}
+
+	return nil
+}
+
+// entryError prefers the error attached with WithError and falls back to the message.
+func entryError(entry *logrus.Entry) error {
+	if err, ok := entry.Data[logrus.ErrorKey].(error); ok {
+		return fmt.Errorf("%s: %w", entry.Message, err)
+	}
+
+	return errors.New(entry.Message)
+}
@@ -0,0 +1,127 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestSetup(t *testing.T) {
+	tests := []struct {
+		name     string
+		exporter string
+		wantErr  bool
+	}{
+		{name: "disabled", exporter: ExporterNone},
+		{name: "stdout", exporter: ExporterStdout},
+		{name: "unknown exporter", exporter: "jaeger", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			shutdown, err := Setup(context.Background(), Config{Exporter: tt.exporter, SampleRatio: 1})
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.NoError(t, shutdown(context.Background()))
+		})
+	}
+}
+
+func TestLogrusHook_Fire(t *testing.T) {
+	var buf bytes.Buffer
+	log := logrus.New()
+	log.SetFormatter(&logrus.JSONFormatter{})
+	log.SetOutput(&buf)
+	log.AddHook(NewLogrusHook())
+
+	provider := sdktrace.NewTracerProvider()
+	defer provider.Shutdown(context.Background())
+
+	ctx, span := provider.Tracer("test").Start(context.Background(), "operation")
+	defer span.End()
+
+	tests := []struct {
+		name      string
+		ctx       context.Context
+		wantTrace bool
+	}{
+		{name: "context with span", ctx: ctx, wantTrace: true},
+		{name: "context without span", ctx: context.Background()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf.Reset()
+			log.WithContext(tt.ctx).Info("message")
+
+			var entry map[string]any
+			require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+			if tt.wantTrace {
+				assert.Equal(t, span.SpanContext().TraceID().String(), entry["trace_id"])
+				assert.Equal(t, span.SpanContext().SpanID().String(), entry["span_id"])
+			} else {
+				assert.NotContains(t, entry, "trace_id")
+			}
+		})
+	}
+}
+
+func TestLogrusHook_MarksSpanFailed(t *testing.T) {
+	var buf bytes.Buffer
+	log := logrus.New()
+	log.SetOutput(&buf)
+	log.AddHook(NewLogrusHook())
+
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	defer provider.Shutdown(context.Background())
+
+	tests := []struct {
+		name       string
+		logFn      func(entry *logrus.Entry)
+		wantStatus codes.Code
+		wantEvent  bool
+	}{
+		{
+			name:       "error entry",
+			logFn:      func(entry *logrus.Entry) { entry.Errorf("failed to create PVZ: %v", "db error") },
+			wantStatus: codes.Error,
+			wantEvent:  true,
+		},
+		{
+			name:       "warning entry",
+			logFn:      func(entry *logrus.Entry) { entry.Warn("attempt to create PVZ in unsupported city") },
+			wantStatus: codes.Unset,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, span := provider.Tracer("test").Start(context.Background(), tt.name)
+			tt.logFn(log.WithContext(ctx))
+			span.End()
+
+			ended := recorder.Ended()
+			got := ended[len(ended)-1]
+			assert.Equal(t, tt.wantStatus, got.Status().Code)
+			if tt.wantEvent {
+				require.Len(t, got.Events(), 1)
+				assert.Equal(t, "exception", got.Events()[0].Name)
+			} else {
+				assert.Empty(t, got.Events())
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+
+	"github.com/senyabanana/pvz-service/internal/dto"
+	"github.com/senyabanana/pvz-service/internal/entity"
+	"github.com/senyabanana/pvz-service/internal/infrastructure/logger"
+)
+
+const (
+	apiKeyHeader     = "X-API-Key"
+	apiKeyIDKey      = "api_key_id"
+	restrictedPVZKey = "restricted_pvz_id"
+)
+
+type APIKeyAuthenticator interface {
+	AuthenticateAPIKey(ctx context.Context, rawKey string) (*entity.APIKey, error)
+}
+
+// CanAccessPVZ reports whether the caller may operate on the PVZ.
+// Only API keys bound to a single PVZ are restricted.
+func CanAccessPVZ(c *gin.Context, pvzID uuid.UUID) bool {
+	restricted, ok := RestrictedPVZ(c)
+
+	return !ok || restricted == pvzID
+}
+
+func RestrictedPVZ(c *gin.Context) (uuid.UUID, bool) {
+	value, exists := c.Get(restrictedPVZKey)
+	if !exists {
+		return uuid.Nil, false
+	}
+
+	pvzID, ok := value.(uuid.UUID)
+	return pvzID, ok
+}
+
+// GetAPIKeyID returns the ID of the API key that authenticated the request, or an empty string for JWT requests.
+func GetAPIKeyID(c *gin.Context) string {
+	return c.GetString(apiKeyIDKey)
+}
+
+func authorizeAPIKey(c *gin.Context, apiKeys APIKeyAuthenticator, log *logrus.Logger, rawKey string, scope entity.APIKeyScope) {
+	entry := logger.FromContext(c.Request.Context(), log)
+	key, err := apiKeys.AuthenticateAPIKey(c.Request.Context(), rawKey)
+	if err != nil {
+		if errors.Is(err, entity.ErrInvalidAPIKey) {
+			entry.Warnf("invalid api key: %v", err)
+			dto.Unauthorized(c, "invalid api key")
+			return
+		}
+
+		entry.Errorf("failed to authenticate api key: %v", err)
+		dto.InternalError(c, "failed to authenticate api key")
+		return
+	}
+
+	if scope == "" || !key.HasScope(scope) {
+		entry.Infof("forbidden access: api key=%s lacks scope=%s", key.ID, scope)
+		dto.Forbidden(c, "insufficient access rights")
+		return
+	}
+
+	c.Set(apiKeyIDKey, key.ID.String())
+	if key.PVZID != nil {
+		c.Set(restrictedPVZKey, *key.PVZID)
+	}
+	addLogFields(c, log, logrus.Fields{logger.FieldAPIKeyID: key.ID.String()})
+
+	c.Next()
+}
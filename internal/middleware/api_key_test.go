@@ -0,0 +1,127 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/senyabanana/pvz-service/internal/entity"
+)
+
+type fakeAPIKeys map[string]*entity.APIKey
+
+func (f fakeAPIKeys) AuthenticateAPIKey(_ context.Context, rawKey string) (*entity.APIKey, error) {
+	if rawKey == "broken" {
+		return nil, errors.New("db down")
+	}
+
+	key, ok := f[rawKey]
+	if !ok {
+		return nil, entity.ErrInvalidAPIKey
+	}
+
+	return key, nil
+}
+
+func TestRequireAccess(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	pvzID := uuid.New()
+	otherPVZID := uuid.New()
+	keys := fakeAPIKeys{
+		"reader":     {ID: uuid.New(), Scopes: []entity.APIKeyScope{entity.ScopePVZRead}},
+		"restricted": {ID: uuid.New(), Scopes: []entity.APIKeyScope{entity.ScopeReceptionsManage}, PVZID: &pvzID},
+	}
+
+	tests := []struct {
+		name       string
+		apiKey     string
+		token      string
+		scope      entity.APIKeyScope
+		targetPVZ  uuid.UUID
+		wantStatus int
+	}{
+		{
+			name:       "api key with scope",
+			apiKey:     "reader",
+			scope:      entity.ScopePVZRead,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "api key without scope",
+			apiKey:     "reader",
+			scope:      entity.ScopeReceptionsManage,
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "api key on jwt-only route",
+			apiKey:     "reader",
+			scope:      "",
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "unknown api key",
+			apiKey:     "unknown",
+			scope:      entity.ScopePVZRead,
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "authenticator failure",
+			apiKey:     "broken",
+			scope:      entity.ScopePVZRead,
+			wantStatus: http.StatusInternalServerError,
+		},
+		{
+			name:       "restricted key on its pvz",
+			apiKey:     "restricted",
+			scope:      entity.ScopeReceptionsManage,
+			targetPVZ:  pvzID,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "restricted key on another pvz",
+			apiKey:     "restricted",
+			scope:      entity.ScopeReceptionsManage,
+			targetPVZ:  otherPVZID,
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "bearer token takes precedence over api key",
+			apiKey:     "reader",
+			token:      generateToken(t, "123", "employee", testSecret),
+			scope:      entity.ScopePVZRead,
+			wantStatus: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := gin.New()
+			r.Use(RequireAccess(testSecret, keys, logrus.New(), tt.scope, "employee"))
+			r.GET("/", func(c *gin.Context) {
+				if tt.targetPVZ != uuid.Nil && !CanAccessPVZ(c, tt.targetPVZ) {
+					c.Status(http.StatusForbidden)
+					return
+				}
+				c.Status(http.StatusOK)
+			})
+
+			req, _ := http.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set("X-API-Key", tt.apiKey)
+			if tt.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.token)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
@@ -4,61 +4,91 @@ import (
 	"strings"
 
 	"github.com/gin-gonic/gin"
-	"github.com/golang-jwt/jwt/v5"
 	"github.com/sirupsen/logrus"
 
 	"github.com/senyabanana/pvz-service/internal/dto"
+	"github.com/senyabanana/pvz-service/internal/entity"
 	"github.com/senyabanana/pvz-service/internal/infrastructure/jwtutil"
+	"github.com/senyabanana/pvz-service/internal/infrastructure/logger"
 )
 
 const (
-	authHeader   = "Authorization"
-	bearerPrefix = "Bearer "
-	userIDKey    = "user_id"
-	userRoleKey  = "user_role"
+	authHeader     = "Authorization"
+	bearerPrefix   = "Bearer "
+	userIDKey      = "user_id"
+	userRoleKey    = "user_role"
+	tokenClaimsKey = "token_claims"
 )
 
 func RequireRole(secretKey string, log *logrus.Logger, allowedRoles ...string) gin.HandlerFunc {
+	return RequireAccess(secretKey, nil, log, "", allowedRoles...)
+}
+
+// RequireAccess authorizes a request either by a bearer JWT whose role is in allowedRoles
+// or, when apiKeys is set and no Authorization header is present, by an X-API-Key granted the scope.
+func RequireAccess(
+	secretKey string, apiKeys APIKeyAuthenticator, log *logrus.Logger, scope entity.APIKeyScope, allowedRoles ...string,
+) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		header := c.GetHeader(authHeader)
-		if header == "" {
-			log.Warn("missing Authorization header")
-			dto.Unauthorized(c, "missing Authorization header")
-			return
+		if apiKeys != nil && c.GetHeader(authHeader) == "" {
+			if rawKey := c.GetHeader(apiKeyHeader); rawKey != "" {
+				authorizeAPIKey(c, apiKeys, log, rawKey, scope)
+				return
+			}
 		}
 
-		tokenString := strings.TrimPrefix(header, bearerPrefix)
-		if tokenString == header {
-			log.Warn("invalid bearer format")
-			dto.Unauthorized(c, "invalid bearer format")
-			return
-		}
+		authorizeJWT(c, secretKey, log, allowedRoles)
+	}
+}
 
-		token, err := jwt.ParseWithClaims(tokenString, &jwtutil.JWTClaims{}, func(token *jwt.Token) (interface{}, error) {
-			return []byte(secretKey), nil
-		})
-		if err != nil || !token.Valid {
-			log.Warnf("invalid token: %v", err)
-			dto.Unauthorized(c, "invalid token")
-			return
-		}
+func GetUserID(c *gin.Context) string {
+	return c.GetString(userIDKey)
+}
 
-		claims, ok := token.Claims.(*jwtutil.JWTClaims)
-		if !ok {
-			dto.Unauthorized(c, "claims cannot be read")
-			return
-		}
+// GetTokenClaims returns the claims of the bearer JWT; ok is false for API key requests.
+func GetTokenClaims(c *gin.Context) (entity.TokenClaims, bool) {
+	value, exists := c.Get(tokenClaimsKey)
+	if !exists {
+		return entity.TokenClaims{}, false
+	}
 
-		for _, role := range allowedRoles {
-			if claims.Role == role {
-				c.Set(userIDKey, claims.UserID)
-				c.Set(userRoleKey, claims.Role)
-				c.Next()
-				return
-			}
-		}
+	claims, ok := value.(entity.TokenClaims)
+	return claims, ok
+}
+
+func authorizeJWT(c *gin.Context, secretKey string, log *logrus.Logger, allowedRoles []string) {
+	header := c.GetHeader(authHeader)
+	if header == "" {
+		logger.FromContext(c.Request.Context(), log).Warn("missing Authorization header")
+		dto.Unauthorized(c, "missing Authorization header")
+		return
+	}
 
-		log.Infof("forbidden access: user role=%s not in allowedRoles=%v", claims.Role, allowedRoles)
-		dto.Forbidden(c, "insufficient access rights")
+	tokenString := strings.TrimPrefix(header, bearerPrefix)
+	if tokenString == header {
+		logger.FromContext(c.Request.Context(), log).Warn("invalid bearer format")
+		dto.Unauthorized(c, "invalid bearer format")
+		return
 	}
+
+	claims, err := jwtutil.ParseToken(tokenString, secretKey)
+	if err != nil {
+		logger.FromContext(c.Request.Context(), log).Warnf("invalid token: %v", err)
+		dto.Unauthorized(c, "invalid token")
+		return
+	}
+
+	for _, role := range allowedRoles {
+		if claims.Role == role {
+			c.Set(userIDKey, claims.UserID)
+			c.Set(userRoleKey, claims.Role)
+			c.Set(tokenClaimsKey, claims.ToEntity())
+			addLogFields(c, log, logrus.Fields{logger.FieldUserID: claims.UserID, logger.FieldRole: claims.Role})
+			c.Next()
+			return
+		}
+	}
+
+	logger.FromContext(c.Request.Context(), log).Infof("forbidden access: user role=%s not in allowedRoles=%v", claims.Role, allowedRoles)
+	dto.Forbidden(c, "insufficient access rights")
 }
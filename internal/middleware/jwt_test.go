@@ -11,6 +11,7 @@ import (
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/senyabanana/pvz-service/internal/entity"
 	"github.com/senyabanana/pvz-service/internal/infrastructure/jwtutil"
 )
 
@@ -85,3 +86,27 @@ func TestRequireRole(t *testing.T) {
 		})
 	}
 }
+
+func TestGetTokenClaims(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	var (
+		claims entity.TokenClaims
+		ok     bool
+	)
+
+	r := gin.New()
+	r.Use(RequireRole(testSecret, logrus.New(), "employee"))
+	r.GET("/", func(c *gin.Context) {
+		claims, ok = GetTokenClaims(c)
+		c.Status(http.StatusOK)
+	})
+
+	w := performRequest(t, r, generateToken(t, "123", "employee", testSecret))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.True(t, ok)
+	assert.Equal(t, "123", claims.UserID)
+	assert.Equal(t, entity.RoleEmployee, claims.Role)
+	assert.False(t, claims.ExpiresAt.IsZero())
+}
@@ -0,0 +1,33 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+
+	"github.com/senyabanana/pvz-service/internal/infrastructure/logger"
+)
+
+const RequestIDHeader = "X-Request-ID"
+
+// RequestID takes the request ID from X-Request-ID or generates one, echoes it in the response
+// and binds a logger with request_id (and pvz_id for /pvz/:pvzId routes) to the request context.
+func RequestID(log *logrus.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := logger.RequestIDOrNew(c.GetHeader(RequestIDHeader))
+
+		c.Header(RequestIDHeader, requestID)
+
+		fields := logrus.Fields{logger.FieldRequestID: requestID}
+		if pvzID := c.Param("pvzId"); pvzID != "" {
+			fields[logger.FieldPVZID] = pvzID
+		}
+		c.Request = c.Request.WithContext(logger.WithEntry(c.Request.Context(), log.WithFields(fields)))
+
+		c.Next()
+	}
+}
+
+// addLogFields enriches the request logger once the caller is known.
+func addLogFields(c *gin.Context, log *logrus.Logger, fields logrus.Fields) {
+	c.Request = c.Request.WithContext(logger.WithFields(c.Request.Context(), log, fields))
+}
@@ -0,0 +1,98 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/senyabanana/pvz-service/internal/infrastructure/logger"
+)
+
+func TestRequestID(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	var buf bytes.Buffer
+	log := logrus.New()
+	log.SetFormatter(&logrus.JSONFormatter{})
+	log.SetOutput(&buf)
+
+	pvzID := uuid.New().String()
+
+	tests := []struct {
+		name          string
+		requestID     string
+		token         string
+		wantRequestID string
+		wantFields    map[string]any
+	}{
+		{
+			name:          "incoming request id",
+			requestID:     "req-42",
+			wantRequestID: "req-42",
+			wantFields:    map[string]any{logger.FieldRequestID: "req-42", logger.FieldPVZID: pvzID},
+		},
+		{
+			name:          "authenticated caller",
+			requestID:     "req-43",
+			wantRequestID: "req-43",
+			token:         generateToken(t, "user-1", "employee", testSecret),
+			wantFields: map[string]any{
+				logger.FieldRequestID: "req-43", logger.FieldPVZID: pvzID,
+				logger.FieldUserID: "user-1", logger.FieldRole: "employee",
+			},
+		},
+		{
+			name:      "generated request id",
+			requestID: "bad id\twith spaces",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf.Reset()
+
+			r := gin.New()
+			r.Use(RequestID(log))
+			handlers := []gin.HandlerFunc{}
+			if tt.token != "" {
+				handlers = append(handlers, RequireRole(testSecret, log, "employee"))
+			}
+			handlers = append(handlers, func(c *gin.Context) {
+				logger.FromContext(c.Request.Context(), log).Info("handled")
+				c.Status(http.StatusOK)
+			})
+			r.GET("/pvz/:pvzId", handlers...)
+
+			req, _ := http.NewRequest(http.MethodGet, "/pvz/"+pvzID, nil)
+			req.Header.Set(RequestIDHeader, tt.requestID)
+			if tt.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.token)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			requestID := w.Header().Get(RequestIDHeader)
+			if tt.wantRequestID != "" {
+				assert.Equal(t, tt.wantRequestID, requestID)
+			} else {
+				_, err := uuid.Parse(requestID)
+				assert.NoError(t, err)
+			}
+
+			var entry map[string]any
+			require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+			assert.Equal(t, requestID, entry[logger.FieldRequestID])
+			for key, value := range tt.wantFields {
+				assert.Equal(t, value, entry[key])
+			}
+		})
+	}
+}
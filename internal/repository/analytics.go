@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	trmsqlx "github.com/avito-tech/go-transaction-manager/drivers/sqlx/v2"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/senyabanana/pvz-service/internal/entity"
+)
+
+type AnalyticsPostgres struct {
+	db     *sqlx.DB
+	getter *trmsqlx.CtxGetter
+}
+
+func NewAnalyticsPostgres(db *sqlx.DB) *AnalyticsPostgres {
+	return &AnalyticsPostgres{
+		db:     db,
+		getter: trmsqlx.DefaultCtxGetter,
+	}
+}
+
+// analyticsGrouping returns the key columns selected and grouped on for the requested grouping.
+// Grouping by city still selects pvz_id so both reports share one row shape.
+func analyticsGrouping(groupBy entity.AnalyticsGroupBy) (selectCols, groupCols string) {
+	if groupBy == entity.AnalyticsGroupByCity {
+		return "NULL::uuid AS pvz_id, pv.city", "pv.city"
+	}
+
+	return "pv.id AS pvz_id, pv.city", "pv.id, pv.city"
+}
+
+// GetReceptionThroughput counts items by the reception they arrived with, so transfers do not move them.
+func (r *AnalyticsPostgres) GetReceptionThroughput(
+	ctx context.Context, filter entity.AnalyticsFilter,
+) ([]entity.ReceptionThroughput, error) {
+	selectCols, groupCols := analyticsGrouping(filter.GroupBy)
+
+	var rows []entity.ReceptionThroughput
+	query := fmt.Sprintf(`
+		SELECT %s, date_trunc($3::text, r.created_at) AS period_start,
+		       count(*) AS receptions_opened,
+		       count(*) FILTER (WHERE r.status = 'close') AS receptions_closed,
+		       coalesce(sum(items.cnt), 0)::bigint AS items_received,
+		       coalesce(avg(items.cnt), 0)::float8 AS avg_items_per_reception,
+		       (avg(EXTRACT(EPOCH FROM r.closed_at - r.created_at)) FILTER (WHERE r.closed_at IS NOT NULL))::float8
+		           AS avg_reception_duration_sec
+		FROM receptions r
+		JOIN pvz pv ON pv.id = r.pvz_id
+		CROSS JOIN LATERAL (SELECT count(*) AS cnt FROM products p WHERE p.received_reception_id = r.id) items
+		WHERE r.created_at >= $1 AND r.created_at < $2
+		  AND ($4 = '' OR pv.city = $4)
+		  AND ($5::uuid IS NULL OR pv.id = $5)
+		GROUP BY %s, period_start
+		ORDER BY period_start, %s
+		`, selectCols, groupCols, groupCols)
+	err := r.getter.DefaultTrOrDB(ctx, r.db).SelectContext(ctx, &rows, query,
+		filter.StartDate, filter.EndDate, filter.Period, filter.City, filter.PVZID)
+	if err != nil {
+		return nil, err
+	}
+
+	return rows, nil
+}
+
+// GetProductMix attributes products to the reception they arrived with: transfers rewrite reception_id,
+// received_reception_id never changes, so a transferred product stays with its original PVZ and period.
+func (r *AnalyticsPostgres) GetProductMix(ctx context.Context, filter entity.AnalyticsFilter) ([]entity.ProductMix, error) {
+	selectCols, groupCols := analyticsGrouping(filter.GroupBy)
+
+	partition := "g.pvz_id, g.city"
+	if filter.GroupBy == entity.AnalyticsGroupByCity {
+		partition = "g.city"
+	}
+
+	var rows []entity.ProductMix
+	query := fmt.Sprintf(`
+		SELECT g.pvz_id, g.city, g.period_start, g.product_type, g.items,
+		       (g.items::float8 / sum(g.items) OVER (PARTITION BY %s, g.period_start))::float8 AS share
+		FROM (
+			SELECT %s, date_trunc($3::text, p.date_time) AS period_start, p.type AS product_type, count(*) AS items
+			FROM products p
+			JOIN receptions r ON r.id = p.received_reception_id
+			JOIN pvz pv ON pv.id = r.pvz_id
+			WHERE p.date_time >= $1 AND p.date_time < $2
+			  AND ($4 = '' OR pv.city = $4)
+			  AND ($5::uuid IS NULL OR pv.id = $5)
+			GROUP BY %s, period_start, p.type
+		) g
+		ORDER BY g.period_start, %s, g.product_type
+		`, partition, selectCols, groupCols, partition)
+	err := r.getter.DefaultTrOrDB(ctx, r.db).SelectContext(ctx, &rows, query,
+		filter.StartDate, filter.EndDate, filter.Period, filter.City, filter.PVZID)
+	if err != nil {
+		return nil, err
+	}
+
+	return rows, nil
+}
@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/senyabanana/pvz-service/internal/entity"
+)
+
+func TestAnalyticsPostgres_GetReceptionThroughput(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, testDriverName)
+	repo := NewAnalyticsPostgres(sqlxDB)
+
+	start := time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 0, 7)
+	columns := []string{
+		"pvz_id", "city", "period_start", "receptions_opened", "receptions_closed",
+		"items_received", "avg_items_per_reception", "avg_reception_duration_sec",
+	}
+
+	tests := []struct {
+		name     string
+		filter   entity.AnalyticsFilter
+		setup    func(filter entity.AnalyticsFilter)
+		wantRows int
+		wantErr  bool
+	}{
+		{
+			name:   "grouped by pvz",
+			filter: entity.AnalyticsFilter{StartDate: start, EndDate: end, GroupBy: entity.AnalyticsGroupByPVZ, Period: entity.AnalyticsPeriodDay},
+			setup: func(filter entity.AnalyticsFilter) {
+				mock.ExpectQuery(`SELECT pv.id AS pvz_id, pv.city, date_trunc\(\$3::text, r.created_at\) AS period_start, .* WHERE p.received_reception_id = r.id\) items .* GROUP BY pv.id, pv.city, period_start`).
+					WithArgs(start, end, entity.AnalyticsPeriodDay, "", nil).
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow(uuid.New(), "Казань", start, 2, 1, 10, 5.0, 3600.0).
+						AddRow(uuid.New(), "Москва", start, 1, 0, 0, 0.0, nil))
+			},
+			wantRows: 2,
+		},
+		{
+			name: "grouped by city",
+			filter: entity.AnalyticsFilter{
+				StartDate: start, EndDate: end, GroupBy: entity.AnalyticsGroupByCity, Period: entity.AnalyticsPeriodWeek, City: "Казань",
+			},
+			setup: func(filter entity.AnalyticsFilter) {
+				mock.ExpectQuery(`SELECT NULL::uuid AS pvz_id, pv.city, .* GROUP BY pv.city, period_start`).
+					WithArgs(start, end, entity.AnalyticsPeriodWeek, "Казань", nil).
+					WillReturnRows(sqlmock.NewRows(columns).AddRow(nil, "Казань", start, 3, 3, 12, 4.0, 1800.0))
+			},
+			wantRows: 1,
+		},
+		{
+			name:   "query error",
+			filter: entity.AnalyticsFilter{StartDate: start, EndDate: end, GroupBy: entity.AnalyticsGroupByPVZ, Period: entity.AnalyticsPeriodDay},
+			setup: func(filter entity.AnalyticsFilter) {
+				mock.ExpectQuery(`FROM receptions r`).WillReturnError(errors.New("db error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup(tt.filter)
+			rows, err := repo.GetReceptionThroughput(context.Background(), tt.filter)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, rows, tt.wantRows)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestAnalyticsPostgres_GetProductMix(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, testDriverName)
+	repo := NewAnalyticsPostgres(sqlxDB)
+
+	start := time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 1, 0)
+	pvzID := uuid.New()
+	filter := entity.AnalyticsFilter{
+		StartDate: start, EndDate: end, GroupBy: entity.AnalyticsGroupByPVZ, Period: entity.AnalyticsPeriodMonth, PVZID: &pvzID,
+	}
+
+	mock.ExpectQuery(`sum\(g.items\) OVER \(PARTITION BY g.pvz_id, g.city, g.period_start\)\).* p.type AS product_type, count\(\*\) AS items\s+FROM products p\s+JOIN receptions r ON r.id = p.received_reception_id`).
+		WithArgs(start, end, entity.AnalyticsPeriodMonth, "", &pvzID).
+		WillReturnRows(sqlmock.NewRows([]string{"pvz_id", "city", "period_start", "product_type", "items", "share"}).
+			AddRow(pvzID, "Казань", start, entity.ProductElectronics, 3, 0.75).
+			AddRow(pvzID, "Казань", start, entity.ProductShoes, 1, 0.25))
+
+	rows, err := repo.GetProductMix(context.Background(), filter)
+	assert.NoError(t, err)
+	assert.Len(t, rows, 2)
+	assert.Equal(t, 0.75, rows[0].Share)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
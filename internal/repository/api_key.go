@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	trmsqlx "github.com/avito-tech/go-transaction-manager/drivers/sqlx/v2"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"github.com/senyabanana/pvz-service/internal/entity"
+)
+
+type APIKeyPostgres struct {
+	db     *sqlx.DB
+	getter *trmsqlx.CtxGetter
+}
+
+type apiKeyRow struct {
+	entity.APIKey
+	Scopes pq.StringArray `db:"scopes"`
+}
+
+func (r apiKeyRow) toEntity() entity.APIKey {
+	key := r.APIKey
+	key.Scopes = make([]entity.APIKeyScope, 0, len(r.Scopes))
+	for _, scope := range r.Scopes {
+		key.Scopes = append(key.Scopes, entity.APIKeyScope(scope))
+	}
+
+	return key
+}
+
+func NewAPIKeyPostgres(db *sqlx.DB) *APIKeyPostgres {
+	return &APIKeyPostgres{
+		db:     db,
+		getter: trmsqlx.DefaultCtxGetter,
+	}
+}
+
+func (r *APIKeyPostgres) CreateAPIKey(ctx context.Context, key *entity.APIKey) error {
+	key.ID = uuid.New()
+	scopes := make(pq.StringArray, 0, len(key.Scopes))
+	for _, scope := range key.Scopes {
+		scopes = append(scopes, string(scope))
+	}
+
+	query := `
+		INSERT INTO api_keys (id, name, prefix, key_hash, scopes, pvz_id, created_by, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		`
+	_, err := r.getter.DefaultTrOrDB(ctx, r.db).ExecContext(ctx, query,
+		key.ID, key.Name, key.Prefix, key.KeyHash, scopes, key.PVZID, key.CreatedBy, key.ExpiresAt, key.CreatedAt)
+
+	return err
+}
+
+func (r *APIKeyPostgres) GetAPIKeyByHash(ctx context.Context, keyHash string) (*entity.APIKey, error) {
+	var row apiKeyRow
+	query := `
+		SELECT id, name, prefix, key_hash, scopes, pvz_id, created_by, expires_at, last_used_at, revoked_at, created_at
+		FROM api_keys WHERE key_hash = $1
+		`
+	err := r.getter.DefaultTrOrDB(ctx, r.db).GetContext(ctx, &row, query, keyHash)
+	if err != nil {
+		return nil, err
+	}
+
+	key := row.toEntity()
+	return &key, nil
+}
+
+func (r *APIKeyPostgres) GetAllAPIKeys(ctx context.Context) ([]entity.APIKey, error) {
+	var rows []apiKeyRow
+	query := `
+		SELECT id, name, prefix, key_hash, scopes, pvz_id, created_by, expires_at, last_used_at, revoked_at, created_at
+		FROM api_keys ORDER BY created_at DESC
+		`
+	err := r.getter.DefaultTrOrDB(ctx, r.db).SelectContext(ctx, &rows, query)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]entity.APIKey, 0, len(rows))
+	for _, row := range rows {
+		keys = append(keys, row.toEntity())
+	}
+
+	return keys, nil
+}
+
+func (r *APIKeyPostgres) RevokeAPIKey(ctx context.Context, keyID uuid.UUID, revokedAt time.Time) error {
+	query := `UPDATE api_keys SET revoked_at = $2 WHERE id = $1 AND revoked_at IS NULL`
+	res, err := r.getter.DefaultTrOrDB(ctx, r.db).ExecContext(ctx, query, keyID, revokedAt)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return entity.ErrAPIKeyNotFound
+	}
+
+	return nil
+}
+
+func (r *APIKeyPostgres) TouchAPIKey(ctx context.Context, keyID uuid.UUID, usedAt time.Time) error {
+	query := `UPDATE api_keys SET last_used_at = $2 WHERE id = $1`
+	_, err := r.getter.DefaultTrOrDB(ctx, r.db).ExecContext(ctx, query, keyID, usedAt)
+
+	return err
+}
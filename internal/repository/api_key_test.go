@@ -0,0 +1,189 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/senyabanana/pvz-service/internal/entity"
+)
+
+func TestAPIKeyPostgres_CreateAPIKey(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, testDriverName)
+	repo := NewAPIKeyPostgres(sqlxDB)
+
+	tests := []struct {
+		name    string
+		setup   func()
+		wantErr bool
+	}{
+		{
+			name: "success",
+			setup: func() {
+				mock.ExpectExec(`INSERT INTO api_keys`).
+					WithArgs(sqlmock.AnyArg(), "sorting-center", "abcd1234", "hash", sqlmock.AnyArg(),
+						sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+			wantErr: false,
+		},
+		{
+			name: "db error",
+			setup: func() {
+				mock.ExpectExec(`INSERT INTO api_keys`).
+					WillReturnError(errors.New("insert error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			key := &entity.APIKey{
+				Name:    "sorting-center",
+				Prefix:  "abcd1234",
+				KeyHash: "hash",
+				Scopes:  []entity.APIKeyScope{entity.ScopeReceptionsManage},
+			}
+			err := repo.CreateAPIKey(context.Background(), key)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.NotEqual(t, uuid.Nil, key.ID)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestAPIKeyPostgres_GetAPIKeyByHash(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, testDriverName)
+	repo := NewAPIKeyPostgres(sqlxDB)
+
+	keyID := uuid.New()
+	pvzID := uuid.New()
+	now := time.Now()
+	columns := []string{
+		"id", "name", "prefix", "key_hash", "scopes", "pvz_id", "created_by",
+		"expires_at", "last_used_at", "revoked_at", "created_at",
+	}
+
+	tests := []struct {
+		name    string
+		setup   func()
+		wantErr bool
+	}{
+		{
+			name: "success",
+			setup: func() {
+				mock.ExpectQuery(`SELECT (.+) FROM api_keys WHERE key_hash = \$1`).
+					WithArgs("hash").
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow(keyID, "sorting-center", "abcd1234", "hash", "{receptions:manage,pvz:read}", pvzID, nil,
+							nil, nil, nil, now))
+			},
+			wantErr: false,
+		},
+		{
+			name: "not found",
+			setup: func() {
+				mock.ExpectQuery(`SELECT (.+) FROM api_keys WHERE key_hash = \$1`).
+					WithArgs("hash").
+					WillReturnRows(sqlmock.NewRows(columns))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			key, err := repo.GetAPIKeyByHash(context.Background(), "hash")
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, key)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, keyID, key.ID)
+				assert.Equal(t, []entity.APIKeyScope{entity.ScopeReceptionsManage, entity.ScopePVZRead}, key.Scopes)
+				assert.Equal(t, pvzID, *key.PVZID)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestAPIKeyPostgres_RevokeAPIKey(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, testDriverName)
+	repo := NewAPIKeyPostgres(sqlxDB)
+
+	keyID := uuid.New()
+
+	tests := []struct {
+		name    string
+		setup   func()
+		wantErr error
+	}{
+		{
+			name: "success",
+			setup: func() {
+				mock.ExpectExec(`UPDATE api_keys SET revoked_at`).
+					WithArgs(keyID, sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			wantErr: nil,
+		},
+		{
+			name: "not found or already revoked",
+			setup: func() {
+				mock.ExpectExec(`UPDATE api_keys SET revoked_at`).
+					WithArgs(keyID, sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			wantErr: entity.ErrAPIKeyNotFound,
+		},
+		{
+			name: "db error",
+			setup: func() {
+				mock.ExpectExec(`UPDATE api_keys SET revoked_at`).
+					WithArgs(keyID, sqlmock.AnyArg()).
+					WillReturnError(errors.New("update error"))
+			},
+			wantErr: errors.New("update error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			err := repo.RevokeAPIKey(context.Background(), keyID, time.Now())
+			if tt.wantErr != nil {
+				assert.ErrorContains(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
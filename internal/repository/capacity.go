@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"context"
+
+	trmsqlx "github.com/avito-tech/go-transaction-manager/drivers/sqlx/v2"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"github.com/senyabanana/pvz-service/internal/entity"
+)
+
+type CapacityPostgres struct {
+	db     *sqlx.DB
+	getter *trmsqlx.CtxGetter
+}
+
+func NewCapacityPostgres(db *sqlx.DB) *CapacityPostgres {
+	return &CapacityPostgres{
+		db:     db,
+		getter: trmsqlx.DefaultCtxGetter,
+	}
+}
+
+func (r *CapacityPostgres) GetPVZCapacity(ctx context.Context, pvzID uuid.UUID) (*entity.PVZCapacity, error) {
+	tr := r.getter.DefaultTrOrDB(ctx, r.db)
+
+	capacity := entity.PVZCapacity{
+		PVZID:  pvzID,
+		ByType: make(map[entity.ProductType]int),
+	}
+	if err := tr.GetContext(ctx, &capacity.Total, `SELECT capacity FROM pvz WHERE id = $1`, pvzID); err != nil {
+		return nil, err
+	}
+
+	var limits []struct {
+		ProductType entity.ProductType `db:"product_type"`
+		Capacity    int                `db:"capacity"`
+	}
+	query := `SELECT product_type, capacity FROM pvz_type_capacity WHERE pvz_id = $1`
+	if err := tr.SelectContext(ctx, &limits, query, pvzID); err != nil {
+		return nil, err
+	}
+
+	for _, limit := range limits {
+		capacity.ByType[limit.ProductType] = limit.Capacity
+	}
+
+	return &capacity, nil
+}
+
+func (r *CapacityPostgres) ReplacePVZCapacity(ctx context.Context, capacity entity.PVZCapacity) error {
+	tr := r.getter.DefaultTrOrDB(ctx, r.db)
+
+	if _, err := tr.ExecContext(ctx, `UPDATE pvz SET capacity = $2 WHERE id = $1`, capacity.PVZID, capacity.Total); err != nil {
+		return err
+	}
+
+	if _, err := tr.ExecContext(ctx, `DELETE FROM pvz_type_capacity WHERE pvz_id = $1`, capacity.PVZID); err != nil {
+		return err
+	}
+
+	if len(capacity.ByType) == 0 {
+		return nil
+	}
+
+	types := make([]string, 0, len(capacity.ByType))
+	limits := make([]int64, 0, len(capacity.ByType))
+	for productType, limit := range capacity.ByType {
+		types = append(types, string(productType))
+		limits = append(limits, int64(limit))
+	}
+
+	query := `
+		INSERT INTO pvz_type_capacity (pvz_id, product_type, capacity)
+		SELECT $1, unnest($2::text[]), unnest($3::int[])
+		`
+	_, err := tr.ExecContext(ctx, query, capacity.PVZID, pq.Array(types), pq.Array(limits))
+
+	return err
+}
+
+// GetPVZOccupancy counts products received at the PVZ that have not been issued yet, by type.
+// Products on a transfer count towards the destination only once they are received there.
+// Products queued for return to sender are put aside for the courier and do not take shelf space,
+// and products shipped to the sender have left the PVZ.
+func (r *CapacityPostgres) GetPVZOccupancy(ctx context.Context, pvzID uuid.UUID) (map[entity.ProductType]int, error) {
+	var counts []struct {
+		Type  entity.ProductType `db:"type"`
+		Count int                `db:"count"`
+	}
+	query := `
+		SELECT p.type, COUNT(*) AS count
+		FROM products p
+		JOIN receptions r ON r.id = p.reception_id
+		WHERE r.pvz_id = $1 AND p.issued_at IS NULL
+		  AND p.status NOT IN ('in_transit', 'return_to_sender', 'shipped_to_sender')
+		GROUP BY p.type
+		`
+	if err := r.getter.DefaultTrOrDB(ctx, r.db).SelectContext(ctx, &counts, query, pvzID); err != nil {
+		return nil, err
+	}
+
+	occupied := make(map[entity.ProductType]int, len(counts))
+	for _, c := range counts {
+		occupied[c.Type] = c.Count
+	}
+
+	return occupied, nil
+}
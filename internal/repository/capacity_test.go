@@ -0,0 +1,214 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/senyabanana/pvz-service/internal/entity"
+)
+
+func TestCapacityPostgres_GetPVZCapacity(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, testDriverName)
+	repo := NewCapacityPostgres(sqlxDB)
+
+	pvzID := uuid.New()
+
+	tests := []struct {
+		name    string
+		setup   func()
+		check   func(t *testing.T, capacity *entity.PVZCapacity)
+		wantErr bool
+	}{
+		{
+			name: "total and per type",
+			setup: func() {
+				mock.ExpectQuery(`SELECT capacity FROM pvz WHERE id = \$1`).
+					WithArgs(pvzID).
+					WillReturnRows(sqlmock.NewRows([]string{"capacity"}).AddRow(100))
+				mock.ExpectQuery(`SELECT product_type, capacity FROM pvz_type_capacity WHERE pvz_id = \$1`).
+					WithArgs(pvzID).
+					WillReturnRows(sqlmock.NewRows([]string{"product_type", "capacity"}).
+						AddRow("электроника", 20).
+						AddRow("обувь", 30))
+			},
+			check: func(t *testing.T, capacity *entity.PVZCapacity) {
+				assert.Equal(t, 100, *capacity.Total)
+				assert.Equal(t, 20, capacity.ByType[entity.ProductElectronics])
+				assert.Equal(t, 30, capacity.ByType[entity.ProductShoes])
+			},
+		},
+		{
+			name: "unlimited",
+			setup: func() {
+				mock.ExpectQuery(`SELECT capacity FROM pvz`).
+					WithArgs(pvzID).
+					WillReturnRows(sqlmock.NewRows([]string{"capacity"}).AddRow(nil))
+				mock.ExpectQuery(`SELECT product_type, capacity FROM pvz_type_capacity`).
+					WithArgs(pvzID).
+					WillReturnRows(sqlmock.NewRows([]string{"product_type", "capacity"}))
+			},
+			check: func(t *testing.T, capacity *entity.PVZCapacity) {
+				assert.Nil(t, capacity.Total)
+				assert.Empty(t, capacity.ByType)
+			},
+		},
+		{
+			name: "pvz not found",
+			setup: func() {
+				mock.ExpectQuery(`SELECT capacity FROM pvz`).
+					WithArgs(pvzID).
+					WillReturnError(sql.ErrNoRows)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			capacity, err := repo.GetPVZCapacity(context.Background(), pvzID)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				tt.check(t, capacity)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestCapacityPostgres_ReplacePVZCapacity(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, testDriverName)
+	repo := NewCapacityPostgres(sqlxDB)
+
+	pvzID := uuid.New()
+	total := 50
+
+	tests := []struct {
+		name     string
+		capacity entity.PVZCapacity
+		setup    func()
+		wantErr  bool
+	}{
+		{
+			name: "with type limits",
+			capacity: entity.PVZCapacity{
+				PVZID:  pvzID,
+				Total:  &total,
+				ByType: map[entity.ProductType]int{entity.ProductClothing: 10},
+			},
+			setup: func() {
+				mock.ExpectExec(`UPDATE pvz SET capacity = \$2 WHERE id = \$1`).
+					WithArgs(pvzID, total).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(`DELETE FROM pvz_type_capacity WHERE pvz_id = \$1`).
+					WithArgs(pvzID).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectExec(`INSERT INTO pvz_type_capacity`).
+					WithArgs(pvzID, sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			name:     "unlimited",
+			capacity: entity.PVZCapacity{PVZID: pvzID},
+			setup: func() {
+				mock.ExpectExec(`UPDATE pvz SET capacity`).
+					WithArgs(pvzID, nil).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(`DELETE FROM pvz_type_capacity`).
+					WithArgs(pvzID).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+		},
+		{
+			name:     "update error",
+			capacity: entity.PVZCapacity{PVZID: pvzID, Total: &total},
+			setup: func() {
+				mock.ExpectExec(`UPDATE pvz SET capacity`).
+					WillReturnError(errors.New("update error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			err := repo.ReplacePVZCapacity(context.Background(), tt.capacity)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestCapacityPostgres_GetPVZOccupancy(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, testDriverName)
+	repo := NewCapacityPostgres(sqlxDB)
+
+	pvzID := uuid.New()
+
+	tests := []struct {
+		name    string
+		setup   func()
+		wantErr bool
+	}{
+		{
+			name: "success",
+			setup: func() {
+				mock.ExpectQuery(`SELECT p.type, COUNT\(\*\) AS count\s+FROM products p\s+JOIN receptions r ON r.id = p.reception_id\s+WHERE r.pvz_id = \$1 AND p.issued_at IS NULL`).
+					WithArgs(pvzID).
+					WillReturnRows(sqlmock.NewRows([]string{"type", "count"}).
+						AddRow("одежда", 7).
+						AddRow("обувь", 3))
+			},
+		},
+		{
+			name: "query error",
+			setup: func() {
+				mock.ExpectQuery(`SELECT p.type`).
+					WillReturnError(errors.New("query error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			occupied, err := repo.GetPVZOccupancy(context.Background(), pvzID)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, 7, occupied[entity.ProductClothing])
+				assert.Equal(t, 3, occupied[entity.ProductShoes])
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
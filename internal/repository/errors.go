@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/lib/pq"
+)
+
+// Postgres error codes translated into domain errors.
+const (
+	pgForeignKeyViolation pq.ErrorCode = "23503"
+	pgUniqueViolation     pq.ErrorCode = "23505"
+)
+
+func hasPGCode(err error, code pq.ErrorCode) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == code
+}
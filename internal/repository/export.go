@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"context"
+
+	trmsqlx "github.com/avito-tech/go-transaction-manager/drivers/sqlx/v2"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/senyabanana/pvz-service/internal/entity"
+)
+
+// Export queries share the arguments built by exportArgs: $1 and $2 bound the reception date,
+// $3 includes closed PVZs and $4 limits the export to one PVZ.
+const exportReceptionFilter = `
+	($1::timestamptz IS NULL OR r.date_time >= $1) AND ($2::timestamptz IS NULL OR r.date_time <= $2)`
+
+const exportPVZFilter = `($3 OR pv.status <> 'closed') AND ($4::uuid IS NULL OR pv.id = $4)`
+
+type ExportPostgres struct {
+	db     *sqlx.DB
+	getter *trmsqlx.CtxGetter
+}
+
+func NewExportPostgres(db *sqlx.DB) *ExportPostgres {
+	return &ExportPostgres{
+		db:     db,
+		getter: trmsqlx.DefaultCtxGetter,
+	}
+}
+
+func (r *ExportPostgres) StreamPVZ(
+	ctx context.Context, filter entity.ExportFilter, fn func(entity.PVZExportRow) error,
+) error {
+	query := `
+		SELECT pv.id, pv.city, pv.address, pv.status, pv.registration_date,
+		       count(DISTINCT r.id) AS receptions, count(p.id) AS products
+		FROM pvz pv
+		LEFT JOIN receptions r ON r.pvz_id = pv.id AND ` + exportReceptionFilter + `
+		LEFT JOIN products p ON p.reception_id = r.id
+		WHERE ` + exportPVZFilter + `
+		GROUP BY pv.id
+		ORDER BY pv.registration_date DESC, pv.id
+		`
+
+	return streamRows(ctx, r.getter.DefaultTrOrDB(ctx, r.db), fn, query, exportArgs(filter)...)
+}
+
+func (r *ExportPostgres) StreamReceptions(
+	ctx context.Context, filter entity.ExportFilter, fn func(entity.ReceptionExportRow) error,
+) error {
+	query := `
+		SELECT r.id, r.pvz_id, pv.city, r.date_time, r.status, r.closed_at, count(p.id) AS products
+		FROM receptions r
+		JOIN pvz pv ON pv.id = r.pvz_id
+		LEFT JOIN products p ON p.reception_id = r.id
+		WHERE ` + exportReceptionFilter + ` AND ` + exportPVZFilter + `
+		GROUP BY r.id, pv.city
+		ORDER BY r.date_time, r.id
+		`
+
+	return streamRows(ctx, r.getter.DefaultTrOrDB(ctx, r.db), fn, query, exportArgs(filter)...)
+}
+
+func (r *ExportPostgres) StreamProducts(
+	ctx context.Context, filter entity.ExportFilter, fn func(entity.ProductExportRow) error,
+) error {
+	query := `
+		SELECT p.id, p.date_time, p.type, p.status, p.reception_id, r.date_time AS reception_date_time,
+		       r.pvz_id, pv.city
+		FROM products p
+		JOIN receptions r ON r.id = p.reception_id
+		JOIN pvz pv ON pv.id = r.pvz_id
+		WHERE ` + exportReceptionFilter + ` AND ` + exportPVZFilter + `
+		ORDER BY r.date_time, p.date_time, p.id
+		`
+
+	return streamRows(ctx, r.getter.DefaultTrOrDB(ctx, r.db), fn, query, exportArgs(filter)...)
+}
+
+func exportArgs(filter entity.ExportFilter) []interface{} {
+	return []interface{}{filter.StartDate, filter.EndDate, filter.IncludeClosed, filter.PVZID}
+}
+
+// streamRows hands the result to fn one row at a time so that large exports are never held in memory.
+func streamRows[T any](ctx context.Context, db trmsqlx.Tr, fn func(T) error, query string, args ...interface{}) error {
+	rows, err := db.QueryxContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var row T
+		if err := rows.StructScan(&row); err != nil {
+			return err
+		}
+		if err := fn(row); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
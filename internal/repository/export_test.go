@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/senyabanana/pvz-service/internal/entity"
+)
+
+func TestExportPostgres_StreamPVZ(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, testDriverName)
+	repo := NewExportPostgres(sqlxDB)
+
+	columns := []string{"id", "city", "address", "status", "registration_date", "receptions", "products"}
+
+	mock.ExpectQuery(`SELECT pv.id, .* count\(DISTINCT r.id\) AS receptions, count\(p.id\) AS products FROM pvz pv LEFT JOIN receptions r`).
+		WithArgs(nil, nil, false, nil).
+		WillReturnRows(sqlmock.NewRows(columns).
+			AddRow(uuid.New(), entity.CityKazan, "", entity.PVZStatusActive, time.Now(), 2, 5).
+			AddRow(uuid.New(), entity.CityMoscow, "", entity.PVZStatusActive, time.Now(), 0, 0))
+
+	var rows []entity.PVZExportRow
+	err = repo.StreamPVZ(context.Background(), entity.ExportFilter{}, func(row entity.PVZExportRow) error {
+		rows = append(rows, row)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Len(t, rows, 2)
+	assert.Equal(t, 5, rows[0].Products)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestExportPostgres_StreamReceptions(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, testDriverName)
+	repo := NewExportPostgres(sqlxDB)
+
+	start, end := time.Now().Add(-time.Hour), time.Now()
+	pvzID := uuid.New()
+	filter := entity.ExportFilter{StartDate: &start, EndDate: &end, PVZID: &pvzID, IncludeClosed: true}
+	columns := []string{"id", "pvz_id", "city", "date_time", "status", "closed_at", "products"}
+	stopErr := errors.New("client went away")
+
+	tests := []struct {
+		name     string
+		setup    func()
+		fnErr    error
+		wantRows int
+		wantErr  error
+	}{
+		{
+			name: "success",
+			setup: func() {
+				mock.ExpectQuery(`FROM receptions r JOIN pvz pv .* WHERE \(\$1::timestamptz IS NULL OR r.date_time >= \$1\)`).
+					WithArgs(&start, &end, true, &pvzID).
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow(uuid.New(), pvzID, entity.CityKazan, start, entity.StatusClosed, end, 3))
+			},
+			wantRows: 1,
+		},
+		{
+			name: "callback error stops the stream",
+			setup: func() {
+				mock.ExpectQuery(`FROM receptions r`).
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow(uuid.New(), pvzID, entity.CityKazan, start, entity.StatusClosed, end, 3).
+						AddRow(uuid.New(), pvzID, entity.CityKazan, start, entity.StatusClosed, end, 1))
+			},
+			fnErr:    stopErr,
+			wantRows: 1,
+			wantErr:  stopErr,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			var count int
+			err := repo.StreamReceptions(context.Background(), filter, func(row entity.ReceptionExportRow) error {
+				count++
+				return tt.fnErr
+			})
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantRows, count)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestExportPostgres_StreamProducts(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, testDriverName)
+	repo := NewExportPostgres(sqlxDB)
+
+	mock.ExpectQuery(`SELECT p.id, .* r.date_time AS reception_date_time, .* FROM products p`).
+		WithArgs(nil, nil, false, nil).
+		WillReturnError(errors.New("db error"))
+
+	err = repo.StreamProducts(context.Background(), entity.ExportFilter{}, func(entity.ProductExportRow) error {
+		return nil
+	})
+	assert.Error(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -66,6 +66,51 @@ func (mr *MockUserRepositoryMockRecorder) GetUserByEmail(ctx, email interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByEmail", reflect.TypeOf((*MockUserRepository)(nil).GetUserByEmail), ctx, email)
 }
 
+// GetUserByID mocks base method.
+func (m *MockUserRepository) GetUserByID(ctx context.Context, userID uuid.UUID) (*entity.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByID", ctx, userID)
+	ret0, _ := ret[0].(*entity.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByID indicates an expected call of GetUserByID.
+func (mr *MockUserRepositoryMockRecorder) GetUserByID(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByID", reflect.TypeOf((*MockUserRepository)(nil).GetUserByID), ctx, userID)
+}
+
+// GetUserByOIDCIdentity mocks base method.
+func (m *MockUserRepository) GetUserByOIDCIdentity(ctx context.Context, issuer, subject string) (*entity.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByOIDCIdentity", ctx, issuer, subject)
+	ret0, _ := ret[0].(*entity.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByOIDCIdentity indicates an expected call of GetUserByOIDCIdentity.
+func (mr *MockUserRepositoryMockRecorder) GetUserByOIDCIdentity(ctx, issuer, subject interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByOIDCIdentity", reflect.TypeOf((*MockUserRepository)(nil).GetUserByOIDCIdentity), ctx, issuer, subject)
+}
+
+// GetUserPVZIDs mocks base method.
+func (m *MockUserRepository) GetUserPVZIDs(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserPVZIDs", ctx, userID)
+	ret0, _ := ret[0].([]uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserPVZIDs indicates an expected call of GetUserPVZIDs.
+func (mr *MockUserRepositoryMockRecorder) GetUserPVZIDs(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserPVZIDs", reflect.TypeOf((*MockUserRepository)(nil).GetUserPVZIDs), ctx, userID)
+}
+
 // IsEmailExists mocks base method.
 func (m *MockUserRepository) IsEmailExists(ctx context.Context, email string) (bool, error) {
 	m.ctrl.T.Helper()
@@ -81,6 +126,114 @@ func (mr *MockUserRepositoryMockRecorder) IsEmailExists(ctx, email interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsEmailExists", reflect.TypeOf((*MockUserRepository)(nil).IsEmailExists), ctx, email)
 }
 
+// ReplaceUserPVZIDs mocks base method.
+func (m *MockUserRepository) ReplaceUserPVZIDs(ctx context.Context, userID uuid.UUID, pvzIDs []uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceUserPVZIDs", ctx, userID, pvzIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplaceUserPVZIDs indicates an expected call of ReplaceUserPVZIDs.
+func (mr *MockUserRepositoryMockRecorder) ReplaceUserPVZIDs(ctx, userID, pvzIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceUserPVZIDs", reflect.TypeOf((*MockUserRepository)(nil).ReplaceUserPVZIDs), ctx, userID, pvzIDs)
+}
+
+// UpdatePassword mocks base method.
+func (m *MockUserRepository) UpdatePassword(ctx context.Context, userID uuid.UUID, passwordHash string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePassword", ctx, userID, passwordHash)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePassword indicates an expected call of UpdatePassword.
+func (mr *MockUserRepositoryMockRecorder) UpdatePassword(ctx, userID, passwordHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePassword", reflect.TypeOf((*MockUserRepository)(nil).UpdatePassword), ctx, userID, passwordHash)
+}
+
+// UpdateUserRole mocks base method.
+func (m *MockUserRepository) UpdateUserRole(ctx context.Context, userID uuid.UUID, role entity.UserRole) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserRole", ctx, userID, role)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateUserRole indicates an expected call of UpdateUserRole.
+func (mr *MockUserRepositoryMockRecorder) UpdateUserRole(ctx, userID, role interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserRole", reflect.TypeOf((*MockUserRepository)(nil).UpdateUserRole), ctx, userID, role)
+}
+
+// MockPasswordResetRepository is a mock of PasswordResetRepository interface.
+type MockPasswordResetRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPasswordResetRepositoryMockRecorder
+}
+
+// MockPasswordResetRepositoryMockRecorder is the mock recorder for MockPasswordResetRepository.
+type MockPasswordResetRepositoryMockRecorder struct {
+	mock *MockPasswordResetRepository
+}
+
+// NewMockPasswordResetRepository creates a new mock instance.
+func NewMockPasswordResetRepository(ctrl *gomock.Controller) *MockPasswordResetRepository {
+	mock := &MockPasswordResetRepository{ctrl: ctrl}
+	mock.recorder = &MockPasswordResetRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPasswordResetRepository) EXPECT() *MockPasswordResetRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreatePasswordResetToken mocks base method.
+func (m *MockPasswordResetRepository) CreatePasswordResetToken(ctx context.Context, token *entity.PasswordResetToken) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePasswordResetToken", ctx, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreatePasswordResetToken indicates an expected call of CreatePasswordResetToken.
+func (mr *MockPasswordResetRepositoryMockRecorder) CreatePasswordResetToken(ctx, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePasswordResetToken", reflect.TypeOf((*MockPasswordResetRepository)(nil).CreatePasswordResetToken), ctx, token)
+}
+
+// GetPasswordResetTokenByHash mocks base method.
+func (m *MockPasswordResetRepository) GetPasswordResetTokenByHash(ctx context.Context, tokenHash string) (*entity.PasswordResetToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPasswordResetTokenByHash", ctx, tokenHash)
+	ret0, _ := ret[0].(*entity.PasswordResetToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPasswordResetTokenByHash indicates an expected call of GetPasswordResetTokenByHash.
+func (mr *MockPasswordResetRepositoryMockRecorder) GetPasswordResetTokenByHash(ctx, tokenHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPasswordResetTokenByHash", reflect.TypeOf((*MockPasswordResetRepository)(nil).GetPasswordResetTokenByHash), ctx, tokenHash)
+}
+
+// InvalidatePasswordResetTokens mocks base method.
+func (m *MockPasswordResetRepository) InvalidatePasswordResetTokens(ctx context.Context, userID uuid.UUID, usedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InvalidatePasswordResetTokens", ctx, userID, usedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InvalidatePasswordResetTokens indicates an expected call of InvalidatePasswordResetTokens.
+func (mr *MockPasswordResetRepositoryMockRecorder) InvalidatePasswordResetTokens(ctx, userID, usedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InvalidatePasswordResetTokens", reflect.TypeOf((*MockPasswordResetRepository)(nil).InvalidatePasswordResetTokens), ctx, userID, usedAt)
+}
+
 // MockPVZRepository is a mock of PVZRepository interface.
 type MockPVZRepository struct {
 	ctrl     *gomock.Controller
@@ -119,18 +272,78 @@ func (mr *MockPVZRepositoryMockRecorder) CreatePVZ(ctx, pvz interface{}) *gomock
 }
 
 // GetAllPVZ mocks base method.
-func (m *MockPVZRepository) GetAllPVZ(ctx context.Context) ([]entity.PVZ, error) {
+func (m *MockPVZRepository) GetAllPVZ(ctx context.Context, includeClosed bool) ([]entity.PVZ, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAllPVZ", ctx)
+	ret := m.ctrl.Call(m, "GetAllPVZ", ctx, includeClosed)
 	ret0, _ := ret[0].([]entity.PVZ)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetAllPVZ indicates an expected call of GetAllPVZ.
-func (mr *MockPVZRepositoryMockRecorder) GetAllPVZ(ctx interface{}) *gomock.Call {
+func (mr *MockPVZRepositoryMockRecorder) GetAllPVZ(ctx, includeClosed interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllPVZ", reflect.TypeOf((*MockPVZRepository)(nil).GetAllPVZ), ctx, includeClosed)
+}
+
+// GetNearbyPVZ mocks base method.
+func (m *MockPVZRepository) GetNearbyPVZ(ctx context.Context, filter entity.NearbyFilter) ([]entity.NearbyPVZ, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNearbyPVZ", ctx, filter)
+	ret0, _ := ret[0].([]entity.NearbyPVZ)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNearbyPVZ indicates an expected call of GetNearbyPVZ.
+func (mr *MockPVZRepositoryMockRecorder) GetNearbyPVZ(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNearbyPVZ", reflect.TypeOf((*MockPVZRepository)(nil).GetNearbyPVZ), ctx, filter)
+}
+
+// GetPVZByID mocks base method.
+func (m *MockPVZRepository) GetPVZByID(ctx context.Context, pvzID uuid.UUID) (*entity.PVZ, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPVZByID", ctx, pvzID)
+	ret0, _ := ret[0].(*entity.PVZ)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPVZByID indicates an expected call of GetPVZByID.
+func (mr *MockPVZRepositoryMockRecorder) GetPVZByID(ctx, pvzID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPVZByID", reflect.TypeOf((*MockPVZRepository)(nil).GetPVZByID), ctx, pvzID)
+}
+
+// GetPVZByIDForUpdate mocks base method.
+func (m *MockPVZRepository) GetPVZByIDForUpdate(ctx context.Context, pvzID uuid.UUID) (*entity.PVZ, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPVZByIDForUpdate", ctx, pvzID)
+	ret0, _ := ret[0].(*entity.PVZ)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPVZByIDForUpdate indicates an expected call of GetPVZByIDForUpdate.
+func (mr *MockPVZRepositoryMockRecorder) GetPVZByIDForUpdate(ctx, pvzID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPVZByIDForUpdate", reflect.TypeOf((*MockPVZRepository)(nil).GetPVZByIDForUpdate), ctx, pvzID)
+}
+
+// GetPVZStatus mocks base method.
+func (m *MockPVZRepository) GetPVZStatus(ctx context.Context, pvzID uuid.UUID) (entity.PVZStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPVZStatus", ctx, pvzID)
+	ret0, _ := ret[0].(entity.PVZStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPVZStatus indicates an expected call of GetPVZStatus.
+func (mr *MockPVZRepositoryMockRecorder) GetPVZStatus(ctx, pvzID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllPVZ", reflect.TypeOf((*MockPVZRepository)(nil).GetAllPVZ), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPVZStatus", reflect.TypeOf((*MockPVZRepository)(nil).GetPVZStatus), ctx, pvzID)
 }
 
 // IsPVZExists mocks base method.
@@ -148,6 +361,101 @@ func (mr *MockPVZRepositoryMockRecorder) IsPVZExists(ctx, pvzID interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsPVZExists", reflect.TypeOf((*MockPVZRepository)(nil).IsPVZExists), ctx, pvzID)
 }
 
+// UpdatePVZDetails mocks base method.
+func (m *MockPVZRepository) UpdatePVZDetails(ctx context.Context, pvz *entity.PVZ) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePVZDetails", ctx, pvz)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePVZDetails indicates an expected call of UpdatePVZDetails.
+func (mr *MockPVZRepositoryMockRecorder) UpdatePVZDetails(ctx, pvz interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePVZDetails", reflect.TypeOf((*MockPVZRepository)(nil).UpdatePVZDetails), ctx, pvz)
+}
+
+// UpdatePVZStatus mocks base method.
+func (m *MockPVZRepository) UpdatePVZStatus(ctx context.Context, pvz *entity.PVZ) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePVZStatus", ctx, pvz)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePVZStatus indicates an expected call of UpdatePVZStatus.
+func (mr *MockPVZRepositoryMockRecorder) UpdatePVZStatus(ctx, pvz interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePVZStatus", reflect.TypeOf((*MockPVZRepository)(nil).UpdatePVZStatus), ctx, pvz)
+}
+
+// MockCapacityRepository is a mock of CapacityRepository interface.
+type MockCapacityRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockCapacityRepositoryMockRecorder
+}
+
+// MockCapacityRepositoryMockRecorder is the mock recorder for MockCapacityRepository.
+type MockCapacityRepositoryMockRecorder struct {
+	mock *MockCapacityRepository
+}
+
+// NewMockCapacityRepository creates a new mock instance.
+func NewMockCapacityRepository(ctrl *gomock.Controller) *MockCapacityRepository {
+	mock := &MockCapacityRepository{ctrl: ctrl}
+	mock.recorder = &MockCapacityRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCapacityRepository) EXPECT() *MockCapacityRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetPVZCapacity mocks base method.
+func (m *MockCapacityRepository) GetPVZCapacity(ctx context.Context, pvzID uuid.UUID) (*entity.PVZCapacity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPVZCapacity", ctx, pvzID)
+	ret0, _ := ret[0].(*entity.PVZCapacity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPVZCapacity indicates an expected call of GetPVZCapacity.
+func (mr *MockCapacityRepositoryMockRecorder) GetPVZCapacity(ctx, pvzID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPVZCapacity", reflect.TypeOf((*MockCapacityRepository)(nil).GetPVZCapacity), ctx, pvzID)
+}
+
+// GetPVZOccupancy mocks base method.
+func (m *MockCapacityRepository) GetPVZOccupancy(ctx context.Context, pvzID uuid.UUID) (map[entity.ProductType]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPVZOccupancy", ctx, pvzID)
+	ret0, _ := ret[0].(map[entity.ProductType]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPVZOccupancy indicates an expected call of GetPVZOccupancy.
+func (mr *MockCapacityRepositoryMockRecorder) GetPVZOccupancy(ctx, pvzID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPVZOccupancy", reflect.TypeOf((*MockCapacityRepository)(nil).GetPVZOccupancy), ctx, pvzID)
+}
+
+// ReplacePVZCapacity mocks base method.
+func (m *MockCapacityRepository) ReplacePVZCapacity(ctx context.Context, capacity entity.PVZCapacity) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplacePVZCapacity", ctx, capacity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplacePVZCapacity indicates an expected call of ReplacePVZCapacity.
+func (mr *MockCapacityRepositoryMockRecorder) ReplacePVZCapacity(ctx, capacity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplacePVZCapacity", reflect.TypeOf((*MockCapacityRepository)(nil).ReplacePVZCapacity), ctx, capacity)
+}
+
 // MockReceptionRepository is a mock of ReceptionRepository interface.
 type MockReceptionRepository struct {
 	ctrl     *gomock.Controller
@@ -199,6 +507,20 @@ func (mr *MockReceptionRepositoryMockRecorder) CreateReception(ctx, reception in
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateReception", reflect.TypeOf((*MockReceptionRepository)(nil).CreateReception), ctx, reception)
 }
 
+// CreateReturn mocks base method.
+func (m *MockReceptionRepository) CreateReturn(ctx context.Context, ret *entity.CustomerReturn) error {
+	m.ctrl.T.Helper()
+	ret_2 := m.ctrl.Call(m, "CreateReturn", ctx, ret)
+	ret0, _ := ret_2[0].(error)
+	return ret0
+}
+
+// CreateReturn indicates an expected call of CreateReturn.
+func (mr *MockReceptionRepositoryMockRecorder) CreateReturn(ctx, ret interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateReturn", reflect.TypeOf((*MockReceptionRepository)(nil).CreateReturn), ctx, ret)
+}
+
 // GetOpenReception mocks base method.
 func (m *MockReceptionRepository) GetOpenReception(ctx context.Context, pvzID uuid.UUID) (*entity.Reception, error) {
 	m.ctrl.T.Helper()
@@ -214,6 +536,21 @@ func (mr *MockReceptionRepositoryMockRecorder) GetOpenReception(ctx, pvzID inter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOpenReception", reflect.TypeOf((*MockReceptionRepository)(nil).GetOpenReception), ctx, pvzID)
 }
 
+// GetReceptionByID mocks base method.
+func (m *MockReceptionRepository) GetReceptionByID(ctx context.Context, receptionID uuid.UUID) (*entity.Reception, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReceptionByID", ctx, receptionID)
+	ret0, _ := ret[0].(*entity.Reception)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReceptionByID indicates an expected call of GetReceptionByID.
+func (mr *MockReceptionRepositoryMockRecorder) GetReceptionByID(ctx, receptionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReceptionByID", reflect.TypeOf((*MockReceptionRepository)(nil).GetReceptionByID), ctx, receptionID)
+}
+
 // GetReceptionsByPVZIDs mocks base method.
 func (m *MockReceptionRepository) GetReceptionsByPVZIDs(ctx context.Context, pvzIDs []uuid.UUID) ([]entity.Reception, error) {
 	m.ctrl.T.Helper()
@@ -229,6 +566,36 @@ func (mr *MockReceptionRepositoryMockRecorder) GetReceptionsByPVZIDs(ctx, pvzIDs
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReceptionsByPVZIDs", reflect.TypeOf((*MockReceptionRepository)(nil).GetReceptionsByPVZIDs), ctx, pvzIDs)
 }
 
+// GetReturnByID mocks base method.
+func (m *MockReceptionRepository) GetReturnByID(ctx context.Context, returnID uuid.UUID) (*entity.CustomerReturn, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReturnByID", ctx, returnID)
+	ret0, _ := ret[0].(*entity.CustomerReturn)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReturnByID indicates an expected call of GetReturnByID.
+func (mr *MockReceptionRepositoryMockRecorder) GetReturnByID(ctx, returnID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReturnByID", reflect.TypeOf((*MockReceptionRepository)(nil).GetReturnByID), ctx, returnID)
+}
+
+// GetReturnsByPVZIDs mocks base method.
+func (m *MockReceptionRepository) GetReturnsByPVZIDs(ctx context.Context, pvzIDs []uuid.UUID) ([]entity.CustomerReturn, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReturnsByPVZIDs", ctx, pvzIDs)
+	ret0, _ := ret[0].([]entity.CustomerReturn)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReturnsByPVZIDs indicates an expected call of GetReturnsByPVZIDs.
+func (mr *MockReceptionRepositoryMockRecorder) GetReturnsByPVZIDs(ctx, pvzIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReturnsByPVZIDs", reflect.TypeOf((*MockReceptionRepository)(nil).GetReturnsByPVZIDs), ctx, pvzIDs)
+}
+
 // IsReceptionOpenExists mocks base method.
 func (m *MockReceptionRepository) IsReceptionOpenExists(ctx context.Context, pvzID uuid.UUID) (bool, error) {
 	m.ctrl.T.Helper()
@@ -244,6 +611,20 @@ func (mr *MockReceptionRepositoryMockRecorder) IsReceptionOpenExists(ctx, pvzID
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsReceptionOpenExists", reflect.TypeOf((*MockReceptionRepository)(nil).IsReceptionOpenExists), ctx, pvzID)
 }
 
+// UpdateReturnStatus mocks base method.
+func (m *MockReceptionRepository) UpdateReturnStatus(ctx context.Context, ret *entity.CustomerReturn) error {
+	m.ctrl.T.Helper()
+	ret_2 := m.ctrl.Call(m, "UpdateReturnStatus", ctx, ret)
+	ret0, _ := ret_2[0].(error)
+	return ret0
+}
+
+// UpdateReturnStatus indicates an expected call of UpdateReturnStatus.
+func (mr *MockReceptionRepositoryMockRecorder) UpdateReturnStatus(ctx, ret interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateReturnStatus", reflect.TypeOf((*MockReceptionRepository)(nil).UpdateReturnStatus), ctx, ret)
+}
+
 // MockProductRepository is a mock of ProductRepository interface.
 type MockProductRepository struct {
 	ctrl     *gomock.Controller
@@ -267,6 +648,49 @@ func (m *MockProductRepository) EXPECT() *MockProductRepositoryMockRecorder {
 	return m.recorder
 }
 
+// AddPickupCodeAttempt mocks base method.
+func (m *MockProductRepository) AddPickupCodeAttempt(ctx context.Context, productID uuid.UUID) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddPickupCodeAttempt", ctx, productID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddPickupCodeAttempt indicates an expected call of AddPickupCodeAttempt.
+func (mr *MockProductRepositoryMockRecorder) AddPickupCodeAttempt(ctx, productID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPickupCodeAttempt", reflect.TypeOf((*MockProductRepository)(nil).AddPickupCodeAttempt), ctx, productID)
+}
+
+// AddProductStatusChanges mocks base method.
+func (m *MockProductRepository) AddProductStatusChanges(ctx context.Context, changes []entity.ProductStatusChange) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddProductStatusChanges", ctx, changes)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddProductStatusChanges indicates an expected call of AddProductStatusChanges.
+func (mr *MockProductRepositoryMockRecorder) AddProductStatusChanges(ctx, changes interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddProductStatusChanges", reflect.TypeOf((*MockProductRepository)(nil).AddProductStatusChanges), ctx, changes)
+}
+
+// AssignProductOwner mocks base method.
+func (m *MockProductRepository) AssignProductOwner(ctx context.Context, productID, ownerID uuid.UUID, pickupCodeHash string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AssignProductOwner", ctx, productID, ownerID, pickupCodeHash)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AssignProductOwner indicates an expected call of AssignProductOwner.
+func (mr *MockProductRepositoryMockRecorder) AssignProductOwner(ctx, productID, ownerID, pickupCodeHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignProductOwner", reflect.TypeOf((*MockProductRepository)(nil).AssignProductOwner), ctx, productID, ownerID, pickupCodeHash)
+}
+
 // CreateProduct mocks base method.
 func (m *MockProductRepository) CreateProduct(ctx context.Context, product *entity.Product) error {
 	m.ctrl.T.Helper()
@@ -281,6 +705,20 @@ func (mr *MockProductRepositoryMockRecorder) CreateProduct(ctx, product interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProduct", reflect.TypeOf((*MockProductRepository)(nil).CreateProduct), ctx, product)
 }
 
+// CreateProducts mocks base method.
+func (m *MockProductRepository) CreateProducts(ctx context.Context, products []entity.Product) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateProducts", ctx, products)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateProducts indicates an expected call of CreateProducts.
+func (mr *MockProductRepositoryMockRecorder) CreateProducts(ctx, products interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProducts", reflect.TypeOf((*MockProductRepository)(nil).CreateProducts), ctx, products)
+}
+
 // DeleteLastProduct mocks base method.
 func (m *MockProductRepository) DeleteLastProduct(ctx context.Context, receptionID uuid.UUID) (*uuid.UUID, error) {
 	m.ctrl.T.Helper()
@@ -296,17 +734,819 @@ func (mr *MockProductRepositoryMockRecorder) DeleteLastProduct(ctx, receptionID
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLastProduct", reflect.TypeOf((*MockProductRepository)(nil).DeleteLastProduct), ctx, receptionID)
 }
 
-// GetProductsByReceptionIDs mocks base method.
-func (m *MockProductRepository) GetProductsByReceptionIDs(ctx context.Context, receptionIDs []uuid.UUID) ([]entity.Product, error) {
+// FlagOverdueProducts mocks base method.
+func (m *MockProductRepository) FlagOverdueProducts(ctx context.Context, productType entity.ProductType, readyBefore time.Time, period time.Duration, queuedAt time.Time) ([]entity.OverdueProduct, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetProductsByReceptionIDs", ctx, receptionIDs)
-	ret0, _ := ret[0].([]entity.Product)
+	ret := m.ctrl.Call(m, "FlagOverdueProducts", ctx, productType, readyBefore, period, queuedAt)
+	ret0, _ := ret[0].([]entity.OverdueProduct)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetProductsByReceptionIDs indicates an expected call of GetProductsByReceptionIDs.
-func (mr *MockProductRepositoryMockRecorder) GetProductsByReceptionIDs(ctx, receptionIDs interface{}) *gomock.Call {
+// FlagOverdueProducts indicates an expected call of FlagOverdueProducts.
+func (mr *MockProductRepositoryMockRecorder) FlagOverdueProducts(ctx, productType, readyBefore, period, queuedAt interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductsByReceptionIDs", reflect.TypeOf((*MockProductRepository)(nil).GetProductsByReceptionIDs), ctx, receptionIDs)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FlagOverdueProducts", reflect.TypeOf((*MockProductRepository)(nil).FlagOverdueProducts), ctx, productType, readyBefore, period, queuedAt)
+}
+
+// GetOverdueProducts mocks base method.
+func (m *MockProductRepository) GetOverdueProducts(ctx context.Context, pvzID uuid.UUID) ([]entity.OverdueProduct, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOverdueProducts", ctx, pvzID)
+	ret0, _ := ret[0].([]entity.OverdueProduct)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOverdueProducts indicates an expected call of GetOverdueProducts.
+func (mr *MockProductRepositoryMockRecorder) GetOverdueProducts(ctx, pvzID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOverdueProducts", reflect.TypeOf((*MockProductRepository)(nil).GetOverdueProducts), ctx, pvzID)
+}
+
+// GetParcelsByOwner mocks base method.
+func (m *MockProductRepository) GetParcelsByOwner(ctx context.Context, ownerID uuid.UUID) ([]entity.Parcel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetParcelsByOwner", ctx, ownerID)
+	ret0, _ := ret[0].([]entity.Parcel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetParcelsByOwner indicates an expected call of GetParcelsByOwner.
+func (mr *MockProductRepositoryMockRecorder) GetParcelsByOwner(ctx, ownerID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetParcelsByOwner", reflect.TypeOf((*MockProductRepository)(nil).GetParcelsByOwner), ctx, ownerID)
+}
+
+// GetProductByID mocks base method.
+func (m *MockProductRepository) GetProductByID(ctx context.Context, productID uuid.UUID) (*entity.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductByID", ctx, productID)
+	ret0, _ := ret[0].(*entity.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProductByID indicates an expected call of GetProductByID.
+func (mr *MockProductRepositoryMockRecorder) GetProductByID(ctx, productID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductByID", reflect.TypeOf((*MockProductRepository)(nil).GetProductByID), ctx, productID)
+}
+
+// GetProductLocations mocks base method.
+func (m *MockProductRepository) GetProductLocations(ctx context.Context, productIDs []uuid.UUID) ([]entity.ProductLocation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductLocations", ctx, productIDs)
+	ret0, _ := ret[0].([]entity.ProductLocation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProductLocations indicates an expected call of GetProductLocations.
+func (mr *MockProductRepositoryMockRecorder) GetProductLocations(ctx, productIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductLocations", reflect.TypeOf((*MockProductRepository)(nil).GetProductLocations), ctx, productIDs)
+}
+
+// GetProductStatusHistory mocks base method.
+func (m *MockProductRepository) GetProductStatusHistory(ctx context.Context, productID uuid.UUID) ([]entity.ProductStatusChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductStatusHistory", ctx, productID)
+	ret0, _ := ret[0].([]entity.ProductStatusChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProductStatusHistory indicates an expected call of GetProductStatusHistory.
+func (mr *MockProductRepositoryMockRecorder) GetProductStatusHistory(ctx, productID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductStatusHistory", reflect.TypeOf((*MockProductRepository)(nil).GetProductStatusHistory), ctx, productID)
+}
+
+// GetProductsByReceptionIDs mocks base method.
+func (m *MockProductRepository) GetProductsByReceptionIDs(ctx context.Context, receptionIDs []uuid.UUID) ([]entity.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductsByReceptionIDs", ctx, receptionIDs)
+	ret0, _ := ret[0].([]entity.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProductsByReceptionIDs indicates an expected call of GetProductsByReceptionIDs.
+func (mr *MockProductRepositoryMockRecorder) GetProductsByReceptionIDs(ctx, receptionIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductsByReceptionIDs", reflect.TypeOf((*MockProductRepository)(nil).GetProductsByReceptionIDs), ctx, receptionIDs)
+}
+
+// MarkProductIssued mocks base method.
+func (m *MockProductRepository) MarkProductIssued(ctx context.Context, productID uuid.UUID, issuedAt time.Time, issuedBy *uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkProductIssued", ctx, productID, issuedAt, issuedBy)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkProductIssued indicates an expected call of MarkProductIssued.
+func (mr *MockProductRepositoryMockRecorder) MarkProductIssued(ctx, productID, issuedAt, issuedBy interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkProductIssued", reflect.TypeOf((*MockProductRepository)(nil).MarkProductIssued), ctx, productID, issuedAt, issuedBy)
+}
+
+// MarkProductReturned mocks base method.
+func (m *MockProductRepository) MarkProductReturned(ctx context.Context, productID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkProductReturned", ctx, productID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkProductReturned indicates an expected call of MarkProductReturned.
+func (mr *MockProductRepositoryMockRecorder) MarkProductReturned(ctx, productID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkProductReturned", reflect.TypeOf((*MockProductRepository)(nil).MarkProductReturned), ctx, productID)
+}
+
+// MoveProductsToReception mocks base method.
+func (m *MockProductRepository) MoveProductsToReception(ctx context.Context, productIDs []uuid.UUID, receptionID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveProductsToReception", ctx, productIDs, receptionID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MoveProductsToReception indicates an expected call of MoveProductsToReception.
+func (mr *MockProductRepositoryMockRecorder) MoveProductsToReception(ctx, productIDs, receptionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveProductsToReception", reflect.TypeOf((*MockProductRepository)(nil).MoveProductsToReception), ctx, productIDs, receptionID)
+}
+
+// SetProductsInTransit mocks base method.
+func (m *MockProductRepository) SetProductsInTransit(ctx context.Context, productIDs []uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetProductsInTransit", ctx, productIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetProductsInTransit indicates an expected call of SetProductsInTransit.
+func (mr *MockProductRepositoryMockRecorder) SetProductsInTransit(ctx, productIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetProductsInTransit", reflect.TypeOf((*MockProductRepository)(nil).SetProductsInTransit), ctx, productIDs)
+}
+
+// ShipOverdueProducts mocks base method.
+func (m *MockProductRepository) ShipOverdueProducts(ctx context.Context, pvzID uuid.UUID, productIDs []uuid.UUID, shippedAt time.Time) ([]uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ShipOverdueProducts", ctx, pvzID, productIDs, shippedAt)
+	ret0, _ := ret[0].([]uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ShipOverdueProducts indicates an expected call of ShipOverdueProducts.
+func (mr *MockProductRepositoryMockRecorder) ShipOverdueProducts(ctx, pvzID, productIDs, shippedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ShipOverdueProducts", reflect.TypeOf((*MockProductRepository)(nil).ShipOverdueProducts), ctx, pvzID, productIDs, shippedAt)
+}
+
+// MockStocktakeRepository is a mock of StocktakeRepository interface.
+type MockStocktakeRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockStocktakeRepositoryMockRecorder
+}
+
+// MockStocktakeRepositoryMockRecorder is the mock recorder for MockStocktakeRepository.
+type MockStocktakeRepositoryMockRecorder struct {
+	mock *MockStocktakeRepository
+}
+
+// NewMockStocktakeRepository creates a new mock instance.
+func NewMockStocktakeRepository(ctrl *gomock.Controller) *MockStocktakeRepository {
+	mock := &MockStocktakeRepository{ctrl: ctrl}
+	mock.recorder = &MockStocktakeRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStocktakeRepository) EXPECT() *MockStocktakeRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddStocktakeScans mocks base method.
+func (m *MockStocktakeRepository) AddStocktakeScans(ctx context.Context, stocktakeID uuid.UUID, productIDs []uuid.UUID, scannedAt time.Time, scannedBy *uuid.UUID) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddStocktakeScans", ctx, stocktakeID, productIDs, scannedAt, scannedBy)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddStocktakeScans indicates an expected call of AddStocktakeScans.
+func (mr *MockStocktakeRepositoryMockRecorder) AddStocktakeScans(ctx, stocktakeID, productIDs, scannedAt, scannedBy interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddStocktakeScans", reflect.TypeOf((*MockStocktakeRepository)(nil).AddStocktakeScans), ctx, stocktakeID, productIDs, scannedAt, scannedBy)
+}
+
+// CompleteStocktake mocks base method.
+func (m *MockStocktakeRepository) CompleteStocktake(ctx context.Context, stocktake *entity.Stocktake) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteStocktake", ctx, stocktake)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CompleteStocktake indicates an expected call of CompleteStocktake.
+func (mr *MockStocktakeRepositoryMockRecorder) CompleteStocktake(ctx, stocktake interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteStocktake", reflect.TypeOf((*MockStocktakeRepository)(nil).CompleteStocktake), ctx, stocktake)
+}
+
+// CreateStocktake mocks base method.
+func (m *MockStocktakeRepository) CreateStocktake(ctx context.Context, stocktake *entity.Stocktake) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateStocktake", ctx, stocktake)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateStocktake indicates an expected call of CreateStocktake.
+func (mr *MockStocktakeRepositoryMockRecorder) CreateStocktake(ctx, stocktake interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateStocktake", reflect.TypeOf((*MockStocktakeRepository)(nil).CreateStocktake), ctx, stocktake)
+}
+
+// GetExpectedStock mocks base method.
+func (m *MockStocktakeRepository) GetExpectedStock(ctx context.Context, pvzID uuid.UUID) ([]uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExpectedStock", ctx, pvzID)
+	ret0, _ := ret[0].([]uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExpectedStock indicates an expected call of GetExpectedStock.
+func (mr *MockStocktakeRepositoryMockRecorder) GetExpectedStock(ctx, pvzID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExpectedStock", reflect.TypeOf((*MockStocktakeRepository)(nil).GetExpectedStock), ctx, pvzID)
+}
+
+// GetOpenStocktake mocks base method.
+func (m *MockStocktakeRepository) GetOpenStocktake(ctx context.Context, pvzID uuid.UUID) (*entity.Stocktake, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOpenStocktake", ctx, pvzID)
+	ret0, _ := ret[0].(*entity.Stocktake)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOpenStocktake indicates an expected call of GetOpenStocktake.
+func (mr *MockStocktakeRepositoryMockRecorder) GetOpenStocktake(ctx, pvzID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOpenStocktake", reflect.TypeOf((*MockStocktakeRepository)(nil).GetOpenStocktake), ctx, pvzID)
+}
+
+// GetStocktakeByID mocks base method.
+func (m *MockStocktakeRepository) GetStocktakeByID(ctx context.Context, stocktakeID uuid.UUID) (*entity.Stocktake, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStocktakeByID", ctx, stocktakeID)
+	ret0, _ := ret[0].(*entity.Stocktake)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStocktakeByID indicates an expected call of GetStocktakeByID.
+func (mr *MockStocktakeRepositoryMockRecorder) GetStocktakeByID(ctx, stocktakeID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStocktakeByID", reflect.TypeOf((*MockStocktakeRepository)(nil).GetStocktakeByID), ctx, stocktakeID)
+}
+
+// GetStocktakeByIDForUpdate mocks base method.
+func (m *MockStocktakeRepository) GetStocktakeByIDForUpdate(ctx context.Context, stocktakeID uuid.UUID) (*entity.Stocktake, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStocktakeByIDForUpdate", ctx, stocktakeID)
+	ret0, _ := ret[0].(*entity.Stocktake)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStocktakeByIDForUpdate indicates an expected call of GetStocktakeByIDForUpdate.
+func (mr *MockStocktakeRepositoryMockRecorder) GetStocktakeByIDForUpdate(ctx, stocktakeID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStocktakeByIDForUpdate", reflect.TypeOf((*MockStocktakeRepository)(nil).GetStocktakeByIDForUpdate), ctx, stocktakeID)
+}
+
+// GetStocktakeItems mocks base method.
+func (m *MockStocktakeRepository) GetStocktakeItems(ctx context.Context, stocktakeID uuid.UUID) ([]entity.StocktakeItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStocktakeItems", ctx, stocktakeID)
+	ret0, _ := ret[0].([]entity.StocktakeItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStocktakeItems indicates an expected call of GetStocktakeItems.
+func (mr *MockStocktakeRepositoryMockRecorder) GetStocktakeItems(ctx, stocktakeID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStocktakeItems", reflect.TypeOf((*MockStocktakeRepository)(nil).GetStocktakeItems), ctx, stocktakeID)
+}
+
+// GetStocktakeScans mocks base method.
+func (m *MockStocktakeRepository) GetStocktakeScans(ctx context.Context, stocktakeID uuid.UUID) ([]uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStocktakeScans", ctx, stocktakeID)
+	ret0, _ := ret[0].([]uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStocktakeScans indicates an expected call of GetStocktakeScans.
+func (mr *MockStocktakeRepositoryMockRecorder) GetStocktakeScans(ctx, stocktakeID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStocktakeScans", reflect.TypeOf((*MockStocktakeRepository)(nil).GetStocktakeScans), ctx, stocktakeID)
+}
+
+// MockTransferRepository is a mock of TransferRepository interface.
+type MockTransferRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockTransferRepositoryMockRecorder
+}
+
+// MockTransferRepositoryMockRecorder is the mock recorder for MockTransferRepository.
+type MockTransferRepositoryMockRecorder struct {
+	mock *MockTransferRepository
+}
+
+// NewMockTransferRepository creates a new mock instance.
+func NewMockTransferRepository(ctrl *gomock.Controller) *MockTransferRepository {
+	mock := &MockTransferRepository{ctrl: ctrl}
+	mock.recorder = &MockTransferRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTransferRepository) EXPECT() *MockTransferRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateTransfer mocks base method.
+func (m *MockTransferRepository) CreateTransfer(ctx context.Context, order *entity.TransferOrder) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTransfer", ctx, order)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateTransfer indicates an expected call of CreateTransfer.
+func (mr *MockTransferRepositoryMockRecorder) CreateTransfer(ctx, order interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTransfer", reflect.TypeOf((*MockTransferRepository)(nil).CreateTransfer), ctx, order)
+}
+
+// GetProductTransferHops mocks base method.
+func (m *MockTransferRepository) GetProductTransferHops(ctx context.Context, productID uuid.UUID) ([]entity.ProductTransferHop, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductTransferHops", ctx, productID)
+	ret0, _ := ret[0].([]entity.ProductTransferHop)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProductTransferHops indicates an expected call of GetProductTransferHops.
+func (mr *MockTransferRepositoryMockRecorder) GetProductTransferHops(ctx, productID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductTransferHops", reflect.TypeOf((*MockTransferRepository)(nil).GetProductTransferHops), ctx, productID)
+}
+
+// GetTransferByID mocks base method.
+func (m *MockTransferRepository) GetTransferByID(ctx context.Context, transferID uuid.UUID) (*entity.TransferOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransferByID", ctx, transferID)
+	ret0, _ := ret[0].(*entity.TransferOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTransferByID indicates an expected call of GetTransferByID.
+func (mr *MockTransferRepositoryMockRecorder) GetTransferByID(ctx, transferID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransferByID", reflect.TypeOf((*MockTransferRepository)(nil).GetTransferByID), ctx, transferID)
+}
+
+// MarkTransferReceived mocks base method.
+func (m *MockTransferRepository) MarkTransferReceived(ctx context.Context, order *entity.TransferOrder) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkTransferReceived", ctx, order)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkTransferReceived indicates an expected call of MarkTransferReceived.
+func (mr *MockTransferRepositoryMockRecorder) MarkTransferReceived(ctx, order interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkTransferReceived", reflect.TypeOf((*MockTransferRepository)(nil).MarkTransferReceived), ctx, order)
+}
+
+// MockAnalyticsRepository is a mock of AnalyticsRepository interface.
+type MockAnalyticsRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAnalyticsRepositoryMockRecorder
+}
+
+// MockAnalyticsRepositoryMockRecorder is the mock recorder for MockAnalyticsRepository.
+type MockAnalyticsRepositoryMockRecorder struct {
+	mock *MockAnalyticsRepository
+}
+
+// NewMockAnalyticsRepository creates a new mock instance.
+func NewMockAnalyticsRepository(ctrl *gomock.Controller) *MockAnalyticsRepository {
+	mock := &MockAnalyticsRepository{ctrl: ctrl}
+	mock.recorder = &MockAnalyticsRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAnalyticsRepository) EXPECT() *MockAnalyticsRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetProductMix mocks base method.
+func (m *MockAnalyticsRepository) GetProductMix(ctx context.Context, filter entity.AnalyticsFilter) ([]entity.ProductMix, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductMix", ctx, filter)
+	ret0, _ := ret[0].([]entity.ProductMix)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProductMix indicates an expected call of GetProductMix.
+func (mr *MockAnalyticsRepositoryMockRecorder) GetProductMix(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductMix", reflect.TypeOf((*MockAnalyticsRepository)(nil).GetProductMix), ctx, filter)
+}
+
+// GetReceptionThroughput mocks base method.
+func (m *MockAnalyticsRepository) GetReceptionThroughput(ctx context.Context, filter entity.AnalyticsFilter) ([]entity.ReceptionThroughput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReceptionThroughput", ctx, filter)
+	ret0, _ := ret[0].([]entity.ReceptionThroughput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReceptionThroughput indicates an expected call of GetReceptionThroughput.
+func (mr *MockAnalyticsRepositoryMockRecorder) GetReceptionThroughput(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReceptionThroughput", reflect.TypeOf((*MockAnalyticsRepository)(nil).GetReceptionThroughput), ctx, filter)
+}
+
+// MockExportRepository is a mock of ExportRepository interface.
+type MockExportRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockExportRepositoryMockRecorder
+}
+
+// MockExportRepositoryMockRecorder is the mock recorder for MockExportRepository.
+type MockExportRepositoryMockRecorder struct {
+	mock *MockExportRepository
+}
+
+// NewMockExportRepository creates a new mock instance.
+func NewMockExportRepository(ctrl *gomock.Controller) *MockExportRepository {
+	mock := &MockExportRepository{ctrl: ctrl}
+	mock.recorder = &MockExportRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockExportRepository) EXPECT() *MockExportRepositoryMockRecorder {
+	return m.recorder
+}
+
+// StreamPVZ mocks base method.
+func (m *MockExportRepository) StreamPVZ(ctx context.Context, filter entity.ExportFilter, fn func(entity.PVZExportRow) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StreamPVZ", ctx, filter, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StreamPVZ indicates an expected call of StreamPVZ.
+func (mr *MockExportRepositoryMockRecorder) StreamPVZ(ctx, filter, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamPVZ", reflect.TypeOf((*MockExportRepository)(nil).StreamPVZ), ctx, filter, fn)
+}
+
+// StreamProducts mocks base method.
+func (m *MockExportRepository) StreamProducts(ctx context.Context, filter entity.ExportFilter, fn func(entity.ProductExportRow) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StreamProducts", ctx, filter, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StreamProducts indicates an expected call of StreamProducts.
+func (mr *MockExportRepositoryMockRecorder) StreamProducts(ctx, filter, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamProducts", reflect.TypeOf((*MockExportRepository)(nil).StreamProducts), ctx, filter, fn)
+}
+
+// StreamReceptions mocks base method.
+func (m *MockExportRepository) StreamReceptions(ctx context.Context, filter entity.ExportFilter, fn func(entity.ReceptionExportRow) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StreamReceptions", ctx, filter, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StreamReceptions indicates an expected call of StreamReceptions.
+func (mr *MockExportRepositoryMockRecorder) StreamReceptions(ctx, filter, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamReceptions", reflect.TypeOf((*MockExportRepository)(nil).StreamReceptions), ctx, filter, fn)
+}
+
+// MockReportJobRepository is a mock of ReportJobRepository interface.
+type MockReportJobRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockReportJobRepositoryMockRecorder
+}
+
+// MockReportJobRepositoryMockRecorder is the mock recorder for MockReportJobRepository.
+type MockReportJobRepositoryMockRecorder struct {
+	mock *MockReportJobRepository
+}
+
+// NewMockReportJobRepository creates a new mock instance.
+func NewMockReportJobRepository(ctrl *gomock.Controller) *MockReportJobRepository {
+	mock := &MockReportJobRepository{ctrl: ctrl}
+	mock.recorder = &MockReportJobRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReportJobRepository) EXPECT() *MockReportJobRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ClaimReportJob mocks base method.
+func (m *MockReportJobRepository) ClaimReportJob(ctx context.Context, now time.Time) (*entity.ReportJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimReportJob", ctx, now)
+	ret0, _ := ret[0].(*entity.ReportJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimReportJob indicates an expected call of ClaimReportJob.
+func (mr *MockReportJobRepositoryMockRecorder) ClaimReportJob(ctx, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimReportJob", reflect.TypeOf((*MockReportJobRepository)(nil).ClaimReportJob), ctx, now)
+}
+
+// CompleteReportJob mocks base method.
+func (m *MockReportJobRepository) CompleteReportJob(ctx context.Context, jobID uuid.UUID, attempt, progress int, artifact entity.ReportArtifact, now time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteReportJob", ctx, jobID, attempt, progress, artifact, now)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CompleteReportJob indicates an expected call of CompleteReportJob.
+func (mr *MockReportJobRepositoryMockRecorder) CompleteReportJob(ctx, jobID, attempt, progress, artifact, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteReportJob", reflect.TypeOf((*MockReportJobRepository)(nil).CompleteReportJob), ctx, jobID, attempt, progress, artifact, now)
+}
+
+// CreateReportJob mocks base method.
+func (m *MockReportJobRepository) CreateReportJob(ctx context.Context, job *entity.ReportJob) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateReportJob", ctx, job)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateReportJob indicates an expected call of CreateReportJob.
+func (mr *MockReportJobRepositoryMockRecorder) CreateReportJob(ctx, job interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateReportJob", reflect.TypeOf((*MockReportJobRepository)(nil).CreateReportJob), ctx, job)
+}
+
+// FailReportJob mocks base method.
+func (m *MockReportJobRepository) FailReportJob(ctx context.Context, jobID uuid.UUID, attempt int, reason string, now time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FailReportJob", ctx, jobID, attempt, reason, now)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FailReportJob indicates an expected call of FailReportJob.
+func (mr *MockReportJobRepositoryMockRecorder) FailReportJob(ctx, jobID, attempt, reason, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FailReportJob", reflect.TypeOf((*MockReportJobRepository)(nil).FailReportJob), ctx, jobID, attempt, reason, now)
+}
+
+// GetReportArtifact mocks base method.
+func (m *MockReportJobRepository) GetReportArtifact(ctx context.Context, jobID uuid.UUID) (*entity.ReportArtifact, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReportArtifact", ctx, jobID)
+	ret0, _ := ret[0].(*entity.ReportArtifact)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReportArtifact indicates an expected call of GetReportArtifact.
+func (mr *MockReportJobRepositoryMockRecorder) GetReportArtifact(ctx, jobID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReportArtifact", reflect.TypeOf((*MockReportJobRepository)(nil).GetReportArtifact), ctx, jobID)
+}
+
+// GetReportJob mocks base method.
+func (m *MockReportJobRepository) GetReportJob(ctx context.Context, jobID uuid.UUID) (*entity.ReportJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReportJob", ctx, jobID)
+	ret0, _ := ret[0].(*entity.ReportJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReportJob indicates an expected call of GetReportJob.
+func (mr *MockReportJobRepositoryMockRecorder) GetReportJob(ctx, jobID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReportJob", reflect.TypeOf((*MockReportJobRepository)(nil).GetReportJob), ctx, jobID)
+}
+
+// RecoverStaleReportJobs mocks base method.
+func (m *MockReportJobRepository) RecoverStaleReportJobs(ctx context.Context, staleBefore, now time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecoverStaleReportJobs", ctx, staleBefore, now)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecoverStaleReportJobs indicates an expected call of RecoverStaleReportJobs.
+func (mr *MockReportJobRepositoryMockRecorder) RecoverStaleReportJobs(ctx, staleBefore, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecoverStaleReportJobs", reflect.TypeOf((*MockReportJobRepository)(nil).RecoverStaleReportJobs), ctx, staleBefore, now)
+}
+
+// UpdateReportJobProgress mocks base method.
+func (m *MockReportJobRepository) UpdateReportJobProgress(ctx context.Context, jobID uuid.UUID, attempt, progress int, now time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateReportJobProgress", ctx, jobID, attempt, progress, now)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateReportJobProgress indicates an expected call of UpdateReportJobProgress.
+func (mr *MockReportJobRepositoryMockRecorder) UpdateReportJobProgress(ctx, jobID, attempt, progress, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateReportJobProgress", reflect.TypeOf((*MockReportJobRepository)(nil).UpdateReportJobProgress), ctx, jobID, attempt, progress, now)
+}
+
+// MockSchemaRepository is a mock of SchemaRepository interface.
+type MockSchemaRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockSchemaRepositoryMockRecorder
+}
+
+// MockSchemaRepositoryMockRecorder is the mock recorder for MockSchemaRepository.
+type MockSchemaRepositoryMockRecorder struct {
+	mock *MockSchemaRepository
+}
+
+// NewMockSchemaRepository creates a new mock instance.
+func NewMockSchemaRepository(ctrl *gomock.Controller) *MockSchemaRepository {
+	mock := &MockSchemaRepository{ctrl: ctrl}
+	mock.recorder = &MockSchemaRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSchemaRepository) EXPECT() *MockSchemaRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetSchemaVersion mocks base method.
+func (m *MockSchemaRepository) GetSchemaVersion(ctx context.Context) (*entity.SchemaVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSchemaVersion", ctx)
+	ret0, _ := ret[0].(*entity.SchemaVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSchemaVersion indicates an expected call of GetSchemaVersion.
+func (mr *MockSchemaRepositoryMockRecorder) GetSchemaVersion(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSchemaVersion", reflect.TypeOf((*MockSchemaRepository)(nil).GetSchemaVersion), ctx)
+}
+
+// Ping mocks base method.
+func (m *MockSchemaRepository) Ping(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Ping", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Ping indicates an expected call of Ping.
+func (mr *MockSchemaRepositoryMockRecorder) Ping(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ping", reflect.TypeOf((*MockSchemaRepository)(nil).Ping), ctx)
+}
+
+// MockAPIKeyRepository is a mock of APIKeyRepository interface.
+type MockAPIKeyRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAPIKeyRepositoryMockRecorder
+}
+
+// MockAPIKeyRepositoryMockRecorder is the mock recorder for MockAPIKeyRepository.
+type MockAPIKeyRepositoryMockRecorder struct {
+	mock *MockAPIKeyRepository
+}
+
+// NewMockAPIKeyRepository creates a new mock instance.
+func NewMockAPIKeyRepository(ctrl *gomock.Controller) *MockAPIKeyRepository {
+	mock := &MockAPIKeyRepository{ctrl: ctrl}
+	mock.recorder = &MockAPIKeyRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAPIKeyRepository) EXPECT() *MockAPIKeyRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateAPIKey mocks base method.
+func (m *MockAPIKeyRepository) CreateAPIKey(ctx context.Context, key *entity.APIKey) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAPIKey", ctx, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateAPIKey indicates an expected call of CreateAPIKey.
+func (mr *MockAPIKeyRepositoryMockRecorder) CreateAPIKey(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAPIKey", reflect.TypeOf((*MockAPIKeyRepository)(nil).CreateAPIKey), ctx, key)
+}
+
+// GetAPIKeyByHash mocks base method.
+func (m *MockAPIKeyRepository) GetAPIKeyByHash(ctx context.Context, keyHash string) (*entity.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAPIKeyByHash", ctx, keyHash)
+	ret0, _ := ret[0].(*entity.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAPIKeyByHash indicates an expected call of GetAPIKeyByHash.
+func (mr *MockAPIKeyRepositoryMockRecorder) GetAPIKeyByHash(ctx, keyHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAPIKeyByHash", reflect.TypeOf((*MockAPIKeyRepository)(nil).GetAPIKeyByHash), ctx, keyHash)
+}
+
+// GetAllAPIKeys mocks base method.
+func (m *MockAPIKeyRepository) GetAllAPIKeys(ctx context.Context) ([]entity.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllAPIKeys", ctx)
+	ret0, _ := ret[0].([]entity.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllAPIKeys indicates an expected call of GetAllAPIKeys.
+func (mr *MockAPIKeyRepositoryMockRecorder) GetAllAPIKeys(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllAPIKeys", reflect.TypeOf((*MockAPIKeyRepository)(nil).GetAllAPIKeys), ctx)
+}
+
+// RevokeAPIKey mocks base method.
+func (m *MockAPIKeyRepository) RevokeAPIKey(ctx context.Context, keyID uuid.UUID, revokedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAPIKey", ctx, keyID, revokedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeAPIKey indicates an expected call of RevokeAPIKey.
+func (mr *MockAPIKeyRepositoryMockRecorder) RevokeAPIKey(ctx, keyID, revokedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAPIKey", reflect.TypeOf((*MockAPIKeyRepository)(nil).RevokeAPIKey), ctx, keyID, revokedAt)
+}
+
+// TouchAPIKey mocks base method.
+func (m *MockAPIKeyRepository) TouchAPIKey(ctx context.Context, keyID uuid.UUID, usedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TouchAPIKey", ctx, keyID, usedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TouchAPIKey indicates an expected call of TouchAPIKey.
+func (mr *MockAPIKeyRepositoryMockRecorder) TouchAPIKey(ctx, keyID, usedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TouchAPIKey", reflect.TypeOf((*MockAPIKeyRepository)(nil).TouchAPIKey), ctx, keyID, usedAt)
 }
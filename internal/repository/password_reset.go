@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	trmsqlx "github.com/avito-tech/go-transaction-manager/drivers/sqlx/v2"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/senyabanana/pvz-service/internal/entity"
+)
+
+type PasswordResetPostgres struct {
+	db     *sqlx.DB
+	getter *trmsqlx.CtxGetter
+}
+
+func NewPasswordResetPostgres(db *sqlx.DB) *PasswordResetPostgres {
+	return &PasswordResetPostgres{
+		db:     db,
+		getter: trmsqlx.DefaultCtxGetter,
+	}
+}
+
+func (r *PasswordResetPostgres) CreatePasswordResetToken(ctx context.Context, token *entity.PasswordResetToken) error {
+	token.ID = uuid.New()
+	query := `INSERT INTO password_reset_tokens (id, user_id, token_hash, expires_at, created_at) VALUES ($1, $2, $3, $4, $5)`
+	_, err := r.getter.DefaultTrOrDB(ctx, r.db).
+		ExecContext(ctx, query, token.ID, token.UserID, token.TokenHash, token.ExpiresAt, token.CreatedAt)
+
+	return err
+}
+
+func (r *PasswordResetPostgres) GetPasswordResetTokenByHash(ctx context.Context, tokenHash string) (*entity.PasswordResetToken, error) {
+	var token entity.PasswordResetToken
+	query := `
+		SELECT id, user_id, token_hash, expires_at, used_at, created_at
+		FROM password_reset_tokens WHERE token_hash = $1
+		FOR UPDATE
+		`
+	err := r.getter.DefaultTrOrDB(ctx, r.db).GetContext(ctx, &token, query, tokenHash)
+	if err != nil {
+		return nil, err
+	}
+
+	return &token, nil
+}
+
+func (r *PasswordResetPostgres) InvalidatePasswordResetTokens(ctx context.Context, userID uuid.UUID, usedAt time.Time) error {
+	query := `UPDATE password_reset_tokens SET used_at = $2 WHERE user_id = $1 AND used_at IS NULL`
+	_, err := r.getter.DefaultTrOrDB(ctx, r.db).ExecContext(ctx, query, userID, usedAt)
+
+	return err
+}
@@ -0,0 +1,178 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/senyabanana/pvz-service/internal/entity"
+)
+
+func TestPasswordResetPostgres_CreatePasswordResetToken(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, testDriverName)
+	repo := NewPasswordResetPostgres(sqlxDB)
+
+	now := time.Now()
+	token := &entity.PasswordResetToken{
+		UserID:    uuid.New(),
+		TokenHash: "hash",
+		ExpiresAt: now.Add(30 * time.Minute),
+		CreatedAt: now,
+	}
+
+	tests := []struct {
+		name      string
+		setupMock func()
+		wantErr   bool
+	}{
+		{
+			name: "success",
+			setupMock: func() {
+				mock.ExpectExec(`INSERT INTO password_reset_tokens`).
+					WithArgs(sqlmock.AnyArg(), token.UserID, token.TokenHash, token.ExpiresAt, token.CreatedAt).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+			wantErr: false,
+		},
+		{
+			name: "db error",
+			setupMock: func() {
+				mock.ExpectExec(`INSERT INTO password_reset_tokens`).
+					WillReturnError(errors.New("insert error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+			err := repo.CreatePasswordResetToken(context.Background(), token)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.NotEqual(t, uuid.Nil, token.ID)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestPasswordResetPostgres_GetPasswordResetTokenByHash(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, testDriverName)
+	repo := NewPasswordResetPostgres(sqlxDB)
+
+	id := uuid.New()
+	userID := uuid.New()
+	now := time.Now()
+
+	tests := []struct {
+		name      string
+		setupMock func()
+		wantErr   bool
+	}{
+		{
+			name: "success",
+			setupMock: func() {
+				rows := sqlmock.NewRows([]string{"id", "user_id", "token_hash", "expires_at", "used_at", "created_at"}).
+					AddRow(id, userID, "hash", now.Add(time.Hour), nil, now)
+				mock.ExpectQuery(`SELECT id, user_id, token_hash, expires_at, used_at, created_at\s+FROM password_reset_tokens WHERE token_hash = \$1\s+FOR UPDATE`).
+					WithArgs("hash").
+					WillReturnRows(rows)
+			},
+			wantErr: false,
+		},
+		{
+			name: "not found",
+			setupMock: func() {
+				mock.ExpectQuery(`SELECT id, user_id, token_hash`).
+					WithArgs("hash").
+					WillReturnError(sql.ErrNoRows)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+			token, err := repo.GetPasswordResetTokenByHash(context.Background(), "hash")
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, token)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, userID, token.UserID)
+				assert.Nil(t, token.UsedAt)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestPasswordResetPostgres_InvalidatePasswordResetTokens(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, testDriverName)
+	repo := NewPasswordResetPostgres(sqlxDB)
+
+	userID := uuid.New()
+	now := time.Now()
+
+	tests := []struct {
+		name      string
+		setupMock func()
+		wantErr   bool
+	}{
+		{
+			name: "success",
+			setupMock: func() {
+				mock.ExpectExec(`UPDATE password_reset_tokens SET used_at = \$2 WHERE user_id = \$1 AND used_at IS NULL`).
+					WithArgs(userID, now).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+			},
+			wantErr: false,
+		},
+		{
+			name: "db error",
+			setupMock: func() {
+				mock.ExpectExec(`UPDATE password_reset_tokens`).
+					WithArgs(userID, now).
+					WillReturnError(errors.New("update error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+			err := repo.InvalidatePasswordResetTokens(context.Background(), userID, now)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -4,10 +4,12 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"time"
 
 	trmsqlx "github.com/avito-tech/go-transaction-manager/drivers/sqlx/v2"
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 
 	"github.com/senyabanana/pvz-service/internal/entity"
 )
@@ -26,13 +28,58 @@ func NewProductPostgres(db *sqlx.DB) *ProductPostgres {
 
 func (r *ProductPostgres) CreateProduct(ctx context.Context, product *entity.Product) error {
 	product.ID = uuid.New()
-	query := `INSERT INTO products (id, date_time, type, reception_id) VALUES ($1, $2, $3, $4)`
+	query := `INSERT INTO products (id, date_time, type, reception_id, received_reception_id) VALUES ($1, $2, $3, $4, $4)`
 	_, err := r.getter.DefaultTrOrDB(ctx, r.db).
 		ExecContext(ctx, query, product.ID, product.DateTime, product.Type, product.ReceptionID)
 
 	return err
 }
 
+// CreateProducts inserts products in one statement and assigns their IDs. Issued products keep their
+// IssuedAt; the rest are inserted as received.
+func (r *ProductPostgres) CreateProducts(ctx context.Context, products []entity.Product) error {
+	if len(products) == 0 {
+		return nil
+	}
+
+	ids := make([]uuid.UUID, 0, len(products))
+	dateTimes := make([]string, 0, len(products))
+	types := make([]entity.ProductType, 0, len(products))
+	receptionIDs := make([]uuid.UUID, 0, len(products))
+	statuses := make([]entity.ProductStatus, 0, len(products))
+	issuedAt := make([]sql.NullString, 0, len(products))
+	for i := range products {
+		product := &products[i]
+		product.ID = uuid.New()
+		if product.Status == "" {
+			product.Status = entity.ProductStatusReceived
+		}
+
+		ids = append(ids, product.ID)
+		dateTimes = append(dateTimes, product.DateTime.Format(time.RFC3339Nano))
+		types = append(types, product.Type)
+		receptionIDs = append(receptionIDs, product.ReceptionID)
+		statuses = append(statuses, product.Status)
+		if product.IssuedAt != nil {
+			issuedAt = append(issuedAt, sql.NullString{String: product.IssuedAt.Format(time.RFC3339Nano), Valid: true})
+		} else {
+			issuedAt = append(issuedAt, sql.NullString{})
+		}
+	}
+
+	query := `
+		INSERT INTO products (id, date_time, type, reception_id, received_reception_id, status, issued_at)
+		SELECT id, date_time, type, reception_id, reception_id, status, issued_at
+		FROM unnest($1::uuid[], $2::timestamptz[], $3::text[], $4::uuid[], $5::text[], $6::timestamptz[])
+		    AS t (id, date_time, type, reception_id, status, issued_at)
+		`
+	_, err := r.getter.DefaultTrOrDB(ctx, r.db).ExecContext(ctx, query,
+		pq.Array(ids), pq.Array(dateTimes), pq.Array(types), pq.Array(receptionIDs),
+		pq.Array(statuses), pq.Array(issuedAt))
+
+	return err
+}
+
 func (r *ProductPostgres) DeleteLastProduct(ctx context.Context, receptionID uuid.UUID) (*uuid.UUID, error) {
 	var productID uuid.UUID
 	query := `
@@ -50,6 +97,10 @@ func (r *ProductPostgres) DeleteLastProduct(ctx context.Context, receptionID uui
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, nil
 		}
+		// Transfers and returns keep referencing the product, e.g. one that arrived by transfer.
+		if hasPGCode(err, pgForeignKeyViolation) {
+			return nil, entity.ErrProductReferenced
+		}
 
 		return nil, err
 	}
@@ -65,7 +116,7 @@ func (r *ProductPostgres) GetProductsByReceptionIDs(ctx context.Context, recepti
 	}
 
 	query, args, err := sqlx.In(`
-			SELECT id, date_time, type, reception_id
+			SELECT id, date_time, type, reception_id, status, owner_id, issued_at, issued_by
 			FROM products
 			WHERE reception_id IN (?)`, receptionIDs)
 	if err != nil {
@@ -80,3 +131,284 @@ func (r *ProductPostgres) GetProductsByReceptionIDs(ctx context.Context, recepti
 
 	return products, nil
 }
+
+func (r *ProductPostgres) GetProductByID(ctx context.Context, productID uuid.UUID) (*entity.Product, error) {
+	var product entity.Product
+	query := `
+		SELECT id, date_time, type, reception_id, status, owner_id, pickup_code_hash, pickup_code_attempts,
+		       issued_at, issued_by
+		FROM products WHERE id = $1
+		FOR UPDATE
+		`
+	err := r.getter.DefaultTrOrDB(ctx, r.db).GetContext(ctx, &product, query, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &product, nil
+}
+
+// AssignProductOwner stores the owner and the hash of a new pickup code and clears failed attempts.
+func (r *ProductPostgres) AssignProductOwner(ctx context.Context, productID, ownerID uuid.UUID, pickupCodeHash string) error {
+	query := `
+		UPDATE products SET owner_id = $2, pickup_code_hash = $3, pickup_code_attempts = 0
+		WHERE id = $1
+		`
+	_, err := r.getter.DefaultTrOrDB(ctx, r.db).ExecContext(ctx, query, productID, ownerID, pickupCodeHash)
+
+	return err
+}
+
+// AddPickupCodeAttempt counts a wrong pickup code and returns the number of failed attempts so far.
+func (r *ProductPostgres) AddPickupCodeAttempt(ctx context.Context, productID uuid.UUID) (int, error) {
+	var attempts int
+	query := `UPDATE products SET pickup_code_attempts = pickup_code_attempts + 1 WHERE id = $1 RETURNING pickup_code_attempts`
+	err := r.getter.DefaultTrOrDB(ctx, r.db).GetContext(ctx, &attempts, query, productID)
+
+	return attempts, err
+}
+
+func (r *ProductPostgres) MarkProductIssued(ctx context.Context, productID uuid.UUID, issuedAt time.Time, issuedBy *uuid.UUID) error {
+	query := `
+		UPDATE products SET status = 'issued', issued_at = $2, issued_by = $3
+		WHERE id = $1 AND status = 'received'
+		`
+	res, err := r.getter.DefaultTrOrDB(ctx, r.db).ExecContext(ctx, query, productID, issuedAt, issuedBy)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return entity.ErrProductAlreadyIssued
+	}
+
+	return nil
+}
+
+func (r *ProductPostgres) MarkProductReturned(ctx context.Context, productID uuid.UUID) error {
+	query := `UPDATE products SET status = 'returned' WHERE id = $1 AND status = 'issued'`
+	res, err := r.getter.DefaultTrOrDB(ctx, r.db).ExecContext(ctx, query, productID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return entity.ErrProductNotIssued
+	}
+
+	return nil
+}
+
+func (r *ProductPostgres) GetParcelsByOwner(ctx context.Context, ownerID uuid.UUID) ([]entity.Parcel, error) {
+	var parcels []entity.Parcel
+	query := `
+		SELECT p.id AS product_id, p.type, p.date_time AS received_at, p.pickup_code_attempts,
+		       v.id AS pvz_id, v.city, v.address, v.phone, v.working_hours
+		FROM products p
+		JOIN receptions r ON r.id = p.reception_id
+		JOIN pvz v ON v.id = r.pvz_id
+		WHERE p.owner_id = $1 AND p.status = 'received' AND r.status = 'close'
+		ORDER BY p.date_time
+		`
+	err := r.getter.DefaultTrOrDB(ctx, r.db).SelectContext(ctx, &parcels, query, ownerID)
+	if err != nil {
+		return nil, err
+	}
+
+	return parcels, nil
+}
+
+// FlagOverdueProducts moves products of the given type that became ready for pickup before readyBefore
+// to return_to_sender and queues them, in a single statement so a product is never flagged without
+// being queued. period is the storage period used to compute the reported deadline.
+func (r *ProductPostgres) FlagOverdueProducts(
+	ctx context.Context, productType entity.ProductType, readyBefore time.Time, period time.Duration, queuedAt time.Time,
+) ([]entity.OverdueProduct, error) {
+	var overdue []entity.OverdueProduct
+	query := `
+		WITH flagged AS (
+			UPDATE products p SET status = 'return_to_sender'
+			FROM receptions r
+			WHERE r.id = p.reception_id AND r.status = 'close' AND r.closed_at < $2
+			  AND p.status = 'received' AND p.type = $1
+			RETURNING p.id, r.pvz_id, p.reception_id, p.type, p.owner_id, r.closed_at
+		), queued AS (
+			INSERT INTO return_to_sender_queue (product_id, pvz_id, ready_at, deadline, queued_at)
+			SELECT id, pvz_id, closed_at, closed_at + $3 * INTERVAL '1 second', $4 FROM flagged
+		)
+		SELECT id AS product_id, pvz_id, reception_id, type, owner_id, closed_at AS ready_at,
+		       closed_at + $3 * INTERVAL '1 second' AS deadline, $4::timestamptz AS queued_at
+		FROM flagged
+		`
+	err := r.getter.DefaultTrOrDB(ctx, r.db).SelectContext(ctx, &overdue, query,
+		productType, readyBefore, period.Seconds(), queuedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return overdue, nil
+}
+
+func (r *ProductPostgres) GetOverdueProducts(ctx context.Context, pvzID uuid.UUID) ([]entity.OverdueProduct, error) {
+	var overdue []entity.OverdueProduct
+	query := `
+		SELECT q.product_id, q.pvz_id, p.reception_id, p.type, p.owner_id, q.ready_at, q.deadline, q.queued_at
+		FROM return_to_sender_queue q
+		JOIN products p ON p.id = q.product_id
+		WHERE q.pvz_id = $1 AND q.shipped_at IS NULL
+		ORDER BY q.deadline
+		`
+	err := r.getter.DefaultTrOrDB(ctx, r.db).SelectContext(ctx, &overdue, query, pvzID)
+	if err != nil {
+		return nil, err
+	}
+
+	return overdue, nil
+}
+
+// ShipOverdueProducts marks queued products of the PVZ as shipped and moves them to shipped_to_sender
+// in a single statement. It returns the products that were actually shipped; products that are not
+// queued at the PVZ or were shipped already are skipped.
+func (r *ProductPostgres) ShipOverdueProducts(
+	ctx context.Context, pvzID uuid.UUID, productIDs []uuid.UUID, shippedAt time.Time,
+) ([]uuid.UUID, error) {
+	var shipped []uuid.UUID
+	query := `
+		WITH shipped AS (
+			UPDATE return_to_sender_queue SET shipped_at = $3
+			WHERE pvz_id = $1 AND product_id = ANY($2::uuid[]) AND shipped_at IS NULL
+			RETURNING product_id
+		)
+		UPDATE products p SET status = 'shipped_to_sender'
+		FROM shipped s
+		WHERE p.id = s.product_id AND p.status = 'return_to_sender'
+		RETURNING p.id
+		`
+	err := r.getter.DefaultTrOrDB(ctx, r.db).SelectContext(ctx, &shipped, query, pvzID, pq.Array(productIDs), shippedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return shipped, nil
+}
+
+// GetProductLocations locks the given products and returns the reception and PVZ each one is stored at.
+func (r *ProductPostgres) GetProductLocations(ctx context.Context, productIDs []uuid.UUID) ([]entity.ProductLocation, error) {
+	var locations []entity.ProductLocation
+	query := `
+		SELECT p.id AS product_id, p.type, p.status, p.reception_id, r.status AS reception_status, r.pvz_id
+		FROM products p
+		JOIN receptions r ON r.id = p.reception_id
+		WHERE p.id = ANY($1::uuid[])
+		FOR UPDATE OF p
+		`
+	err := r.getter.DefaultTrOrDB(ctx, r.db).SelectContext(ctx, &locations, query, pq.Array(productIDs))
+	if err != nil {
+		return nil, err
+	}
+
+	return locations, nil
+}
+
+func (r *ProductPostgres) SetProductsInTransit(ctx context.Context, productIDs []uuid.UUID) error {
+	query := `UPDATE products SET status = 'in_transit' WHERE id = ANY($1::uuid[]) AND status = 'received'`
+	res, err := r.getter.DefaultTrOrDB(ctx, r.db).ExecContext(ctx, query, pq.Array(productIDs))
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected != int64(len(productIDs)) {
+		return entity.ErrProductInTransit
+	}
+
+	return nil
+}
+
+// MoveProductsToReception places products that arrived by transfer into a reception at the destination PVZ.
+// It fails with ErrProductNotInTransit unless every product was still in transit.
+func (r *ProductPostgres) MoveProductsToReception(ctx context.Context, productIDs []uuid.UUID, receptionID uuid.UUID) error {
+	query := `
+		UPDATE products SET status = 'received', reception_id = $2
+		WHERE id = ANY($1::uuid[]) AND status = 'in_transit'
+		`
+	res, err := r.getter.DefaultTrOrDB(ctx, r.db).ExecContext(ctx, query, pq.Array(productIDs), receptionID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected != int64(len(productIDs)) {
+		return entity.ErrProductNotInTransit
+	}
+
+	return nil
+}
+
+// AddProductStatusChanges appends entries to the product status history in a single statement.
+func (r *ProductPostgres) AddProductStatusChanges(ctx context.Context, changes []entity.ProductStatusChange) error {
+	if len(changes) == 0 {
+		return nil
+	}
+
+	productIDs := make([]uuid.UUID, 0, len(changes))
+	from := make([]*entity.ProductStatus, 0, len(changes))
+	to := make([]entity.ProductStatus, 0, len(changes))
+	pvzIDs := make([]*uuid.UUID, 0, len(changes))
+	changedAt := make([]string, 0, len(changes))
+	changedBy := make([]*uuid.UUID, 0, len(changes))
+	comments := make([]string, 0, len(changes))
+	for _, change := range changes {
+		productIDs = append(productIDs, change.ProductID)
+		from = append(from, change.From)
+		to = append(to, change.To)
+		pvzIDs = append(pvzIDs, change.PVZID)
+		changedAt = append(changedAt, change.ChangedAt.Format(time.RFC3339Nano))
+		changedBy = append(changedBy, change.ChangedBy)
+		comments = append(comments, change.Comment)
+	}
+
+	query := `
+		INSERT INTO product_status_history (product_id, from_status, to_status, pvz_id, changed_at, changed_by, comment)
+		SELECT * FROM unnest($1::uuid[], $2::text[], $3::text[], $4::uuid[], $5::timestamptz[], $6::uuid[], $7::text[])
+		`
+	_, err := r.getter.DefaultTrOrDB(ctx, r.db).ExecContext(ctx, query,
+		pq.Array(productIDs), pq.Array(from), pq.Array(to), pq.Array(pvzIDs),
+		pq.Array(changedAt), pq.Array(changedBy), pq.Array(comments))
+
+	return err
+}
+
+func (r *ProductPostgres) GetProductStatusHistory(ctx context.Context, productID uuid.UUID) ([]entity.ProductStatusChange, error) {
+	var history []entity.ProductStatusChange
+	query := `
+		SELECT id, product_id, from_status, to_status, pvz_id, changed_at, changed_by, comment
+		FROM product_status_history
+		WHERE product_id = $1
+		ORDER BY changed_at, id
+		`
+	err := r.getter.DefaultTrOrDB(ctx, r.db).SelectContext(ctx, &history, query, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	return history, nil
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/senyabanana/pvz-service/internal/entity"
@@ -31,7 +32,7 @@ func TestProductPostgres_CreateProduct(t *testing.T) {
 		{
 			name: "success",
 			setup: func() {
-				mock.ExpectExec(`INSERT INTO products`).
+				mock.ExpectExec(`INSERT INTO products \(id, date_time, type, reception_id, received_reception_id\) VALUES \(\$1, \$2, \$3, \$4, \$4\)`).
 					WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), "электроника", sqlmock.AnyArg()).
 					WillReturnResult(sqlmock.NewResult(1, 1))
 			},
@@ -72,6 +73,31 @@ func TestProductPostgres_CreateProduct(t *testing.T) {
 	}
 }
 
+func TestProductPostgres_CreateProducts(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, testDriverName)
+	repo := NewProductPostgres(sqlxDB)
+
+	issuedAt := time.Now()
+	products := []entity.Product{
+		{DateTime: time.Now(), Type: entity.ProductElectronics, ReceptionID: uuid.New()},
+		{DateTime: time.Now(), Type: entity.ProductShoes, ReceptionID: uuid.New(), Status: entity.ProductStatusIssued, IssuedAt: &issuedAt},
+	}
+
+	mock.ExpectExec(`INSERT INTO products \(id, date_time, type, reception_id, received_reception_id, status, issued_at\)\s+SELECT id, date_time, type, reception_id, reception_id, status, issued_at\s+FROM unnest`).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	assert.NoError(t, repo.CreateProducts(context.Background(), products))
+	assert.NotEqual(t, uuid.Nil, products[0].ID)
+	assert.Equal(t, entity.ProductStatusReceived, products[0].Status)
+	assert.NoError(t, repo.CreateProducts(context.Background(), nil))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestProductPostgres_DeleteLastProduct(t *testing.T) {
 	db, mock, _ := sqlmock.New()
 	defer db.Close()
@@ -87,6 +113,7 @@ func TestProductPostgres_DeleteLastProduct(t *testing.T) {
 		setup    func()
 		expected *uuid.UUID
 		wantErr  bool
+		wantIs   error
 	}{
 		{
 			name: "success",
@@ -118,6 +145,17 @@ func TestProductPostgres_DeleteLastProduct(t *testing.T) {
 			expected: nil,
 			wantErr:  true,
 		},
+		{
+			name: "referenced by a transfer",
+			setup: func() {
+				mock.ExpectQuery(`DELETE FROM products`).
+					WithArgs(receptionID).
+					WillReturnError(&pq.Error{Code: "23503"})
+			},
+			expected: nil,
+			wantErr:  true,
+			wantIs:   entity.ErrProductReferenced,
+		},
 	}
 
 	for _, tt := range tests {
@@ -126,6 +164,9 @@ func TestProductPostgres_DeleteLastProduct(t *testing.T) {
 			id, err := repo.DeleteLastProduct(context.Background(), receptionID)
 			if tt.wantErr {
 				assert.Error(t, err)
+				if tt.wantIs != nil {
+					assert.ErrorIs(t, err, tt.wantIs)
+				}
 			} else {
 				assert.Equal(t, tt.expected, id)
 			}
@@ -160,10 +201,10 @@ func TestProductPostgres_GetProductsByReceptionIDs(t *testing.T) {
 			name:  "success",
 			input: []uuid.UUID{receptionID},
 			setup: func() {
-				mock.ExpectQuery(`SELECT id, date_time, type, reception_id FROM products`).
+				mock.ExpectQuery(`SELECT id, date_time, type, reception_id, status, owner_id, issued_at, issued_by FROM products`).
 					WithArgs(receptionID).
-					WillReturnRows(sqlmock.NewRows([]string{"id", "date_time", "type", "reception_id"}).
-						AddRow(id, time.Now(), "одежда", receptionID))
+					WillReturnRows(sqlmock.NewRows([]string{"id", "date_time", "type", "reception_id", "status", "owner_id", "issued_at", "issued_by"}).
+						AddRow(id, time.Now(), "одежда", receptionID, "received", nil, nil, nil))
 			},
 			wantErr: false,
 		},
@@ -171,7 +212,7 @@ func TestProductPostgres_GetProductsByReceptionIDs(t *testing.T) {
 			name:  "db error",
 			input: []uuid.UUID{receptionID},
 			setup: func() {
-				mock.ExpectQuery(`SELECT id, date_time, type, reception_id FROM products`).
+				mock.ExpectQuery(`SELECT id, date_time, type, reception_id, status, owner_id, issued_at, issued_by FROM products`).
 					WithArgs(receptionID).
 					WillReturnError(errors.New("db error"))
 			},
@@ -192,3 +233,445 @@ func TestProductPostgres_GetProductsByReceptionIDs(t *testing.T) {
 		})
 	}
 }
+
+func TestProductPostgres_GetProductByID(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, testDriverName)
+	repo := NewProductPostgres(sqlxDB)
+
+	productID := uuid.New()
+	ownerID := uuid.New()
+
+	tests := []struct {
+		name    string
+		setup   func()
+		wantErr bool
+	}{
+		{
+			name: "success",
+			setup: func() {
+				mock.ExpectQuery(`SELECT id, date_time, type, reception_id, status, owner_id, pickup_code_hash, pickup_code_attempts,\s+issued_at, issued_by\s+FROM products WHERE id = \$1\s+FOR UPDATE`).
+					WithArgs(productID).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "date_time", "type", "reception_id", "status", "owner_id", "pickup_code_hash", "pickup_code_attempts", "issued_at", "issued_by"}).
+						AddRow(productID, time.Now(), entity.ProductShoes, uuid.New(), entity.ProductStatusReceived, ownerID, "hash", 2, nil, nil))
+			},
+			wantErr: false,
+		},
+		{
+			name: "not found",
+			setup: func() {
+				mock.ExpectQuery(`SELECT id, date_time, type`).
+					WithArgs(productID).
+					WillReturnError(sql.ErrNoRows)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			product, err := repo.GetProductByID(context.Background(), productID)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, ownerID, *product.OwnerID)
+				assert.Equal(t, "hash", *product.PickupCodeHash)
+				assert.Equal(t, 2, product.PickupCodeAttempts)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestProductPostgres_AssignProductOwner(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, testDriverName)
+	repo := NewProductPostgres(sqlxDB)
+
+	productID, ownerID := uuid.New(), uuid.New()
+
+	mock.ExpectExec(`UPDATE products SET owner_id = \$2, pickup_code_hash = \$3, pickup_code_attempts = 0\s+WHERE id = \$1`).
+		WithArgs(productID, ownerID, "hash").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	assert.NoError(t, repo.AssignProductOwner(context.Background(), productID, ownerID, "hash"))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestProductPostgres_AddPickupCodeAttempt(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, testDriverName)
+	repo := NewProductPostgres(sqlxDB)
+
+	productID := uuid.New()
+
+	mock.ExpectQuery(`UPDATE products SET pickup_code_attempts = pickup_code_attempts \+ 1 WHERE id = \$1 RETURNING pickup_code_attempts`).
+		WithArgs(productID).
+		WillReturnRows(sqlmock.NewRows([]string{"pickup_code_attempts"}).AddRow(3))
+
+	attempts, err := repo.AddPickupCodeAttempt(context.Background(), productID)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, attempts)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestProductPostgres_MarkProductIssued(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, testDriverName)
+	repo := NewProductPostgres(sqlxDB)
+
+	productID := uuid.New()
+	issuedBy := uuid.New()
+	now := time.Now()
+
+	tests := []struct {
+		name    string
+		setup   func()
+		wantErr error
+	}{
+		{
+			name: "success",
+			setup: func() {
+				mock.ExpectExec(`UPDATE products SET status = 'issued', issued_at = \$2, issued_by = \$3\s+WHERE id = \$1 AND status = 'received'`).
+					WithArgs(productID, now, issuedBy).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			name: "already issued",
+			setup: func() {
+				mock.ExpectExec(`UPDATE products SET status = 'issued'`).
+					WithArgs(productID, now, issuedBy).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			wantErr: entity.ErrProductAlreadyIssued,
+		},
+		{
+			name: "db error",
+			setup: func() {
+				mock.ExpectExec(`UPDATE products SET status = 'issued'`).
+					WillReturnError(errors.New("update error"))
+			},
+			wantErr: errors.New("update error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			err := repo.MarkProductIssued(context.Background(), productID, now, &issuedBy)
+			if tt.wantErr != nil {
+				assert.ErrorContains(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestProductPostgres_GetParcelsByOwner(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, testDriverName)
+	repo := NewProductPostgres(sqlxDB)
+
+	ownerID := uuid.New()
+
+	tests := []struct {
+		name     string
+		setup    func()
+		expected int
+		wantErr  bool
+	}{
+		{
+			name: "success",
+			setup: func() {
+				mock.ExpectQuery(`SELECT p.id AS product_id, .* FROM products p\s+JOIN receptions r ON r.id = p.reception_id\s+JOIN pvz v ON v.id = r.pvz_id\s+WHERE p.owner_id = \$1 AND p.status = 'received' AND r.status = 'close'`).
+					WithArgs(ownerID).
+					WillReturnRows(sqlmock.NewRows([]string{"product_id", "type", "received_at", "pickup_code_attempts", "pvz_id", "city", "address", "phone", "working_hours"}).
+						AddRow(uuid.New(), entity.ProductClothing, time.Now(), 0, uuid.New(), entity.CityKazan, "ул. Баумана, 1", "", nil))
+			},
+			expected: 1,
+		},
+		{
+			name: "db error",
+			setup: func() {
+				mock.ExpectQuery(`SELECT p.id AS product_id`).
+					WithArgs(ownerID).
+					WillReturnError(errors.New("query error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			parcels, err := repo.GetParcelsByOwner(context.Background(), ownerID)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, parcels, tt.expected)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestProductPostgres_MarkProductReturned(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, testDriverName)
+	repo := NewProductPostgres(sqlxDB)
+
+	productID := uuid.New()
+
+	mock.ExpectExec(`UPDATE products SET status = 'returned' WHERE id = \$1 AND status = 'issued'`).
+		WithArgs(productID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	assert.NoError(t, repo.MarkProductReturned(context.Background(), productID))
+
+	mock.ExpectExec(`UPDATE products SET status = 'returned'`).
+		WithArgs(productID).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	assert.ErrorIs(t, repo.MarkProductReturned(context.Background(), productID), entity.ErrProductNotIssued)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+var overdueColumns = []string{"product_id", "pvz_id", "reception_id", "type", "owner_id", "ready_at", "deadline", "queued_at"}
+
+func TestProductPostgres_FlagOverdueProducts(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, testDriverName)
+	repo := NewProductPostgres(sqlxDB)
+
+	now := time.Now()
+	readyBefore := now.Add(-72 * time.Hour)
+
+	tests := []struct {
+		name     string
+		setup    func()
+		expected int
+		wantErr  bool
+	}{
+		{
+			name: "success",
+			setup: func() {
+				mock.ExpectQuery(`WITH flagged AS \(\s+UPDATE products p SET status = 'return_to_sender'.*INSERT INTO return_to_sender_queue`).
+					WithArgs(entity.ProductShoes, readyBefore, float64(72*3600), now).
+					WillReturnRows(sqlmock.NewRows(overdueColumns).
+						AddRow(uuid.New(), uuid.New(), uuid.New(), entity.ProductShoes, nil, readyBefore, now, now))
+			},
+			expected: 1,
+		},
+		{
+			name: "db error",
+			setup: func() {
+				mock.ExpectQuery(`WITH flagged AS`).
+					WillReturnError(errors.New("query error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			overdue, err := repo.FlagOverdueProducts(context.Background(), entity.ProductShoes, readyBefore, 72*time.Hour, now)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, overdue, tt.expected)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestProductPostgres_GetOverdueProducts(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, testDriverName)
+	repo := NewProductPostgres(sqlxDB)
+
+	pvzID := uuid.New()
+	now := time.Now()
+
+	mock.ExpectQuery(`SELECT q.product_id, .* FROM return_to_sender_queue q\s+JOIN products p ON p.id = q.product_id\s+WHERE q.pvz_id = \$1 AND q.shipped_at IS NULL`).
+		WithArgs(pvzID).
+		WillReturnRows(sqlmock.NewRows(overdueColumns).
+			AddRow(uuid.New(), pvzID, uuid.New(), entity.ProductClothing, uuid.New(), now, now, now))
+
+	overdue, err := repo.GetOverdueProducts(context.Background(), pvzID)
+	assert.NoError(t, err)
+	assert.Len(t, overdue, 1)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestProductPostgres_ShipOverdueProducts(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, testDriverName)
+	repo := NewProductPostgres(sqlxDB)
+
+	pvzID, productID := uuid.New(), uuid.New()
+	now := time.Now()
+
+	mock.ExpectQuery(`WITH shipped AS \(\s+UPDATE return_to_sender_queue SET shipped_at = \$3\s+WHERE pvz_id = \$1 AND product_id = ANY\(\$2::uuid\[\]\) AND shipped_at IS NULL\s+RETURNING product_id\s+\)\s+UPDATE products p SET status = 'shipped_to_sender'`).
+		WithArgs(pvzID, sqlmock.AnyArg(), now).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(productID))
+
+	shipped, err := repo.ShipOverdueProducts(context.Background(), pvzID, []uuid.UUID{productID, uuid.New()}, now)
+	assert.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{productID}, shipped)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestProductPostgres_GetProductLocations(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, testDriverName)
+	repo := NewProductPostgres(sqlxDB)
+
+	productID := uuid.New()
+
+	mock.ExpectQuery(`SELECT p.id AS product_id, p.type, p.status, p.reception_id, r.status AS reception_status, r.pvz_id\s+FROM products p\s+JOIN receptions r ON r.id = p.reception_id\s+WHERE p.id = ANY\(\$1::uuid\[\]\)\s+FOR UPDATE OF p`).
+		WithArgs(sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"product_id", "type", "status", "reception_id", "reception_status", "pvz_id"}).
+			AddRow(productID, "обувь", "received", uuid.New(), "close", uuid.New()))
+
+	locations, err := repo.GetProductLocations(context.Background(), []uuid.UUID{productID})
+	assert.NoError(t, err)
+	assert.Len(t, locations, 1)
+	assert.Equal(t, entity.ProductShoes, locations[0].Type)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestProductPostgres_SetProductsInTransit(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, testDriverName)
+	repo := NewProductPostgres(sqlxDB)
+
+	productIDs := []uuid.UUID{uuid.New(), uuid.New()}
+
+	mock.ExpectExec(`UPDATE products SET status = 'in_transit' WHERE id = ANY\(\$1::uuid\[\]\) AND status = 'received'`).
+		WithArgs(sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	assert.NoError(t, repo.SetProductsInTransit(context.Background(), productIDs))
+
+	mock.ExpectExec(`UPDATE products SET status = 'in_transit'`).
+		WithArgs(sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	assert.ErrorIs(t, repo.SetProductsInTransit(context.Background(), productIDs), entity.ErrProductInTransit)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestProductPostgres_MoveProductsToReception(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, testDriverName)
+	repo := NewProductPostgres(sqlxDB)
+
+	receptionID := uuid.New()
+
+	mock.ExpectExec(`UPDATE products SET status = 'received', reception_id = \$2\s+WHERE id = ANY\(\$1::uuid\[\]\) AND status = 'in_transit'`).
+		WithArgs(sqlmock.AnyArg(), receptionID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	assert.NoError(t, repo.MoveProductsToReception(context.Background(), []uuid.UUID{uuid.New()}, receptionID))
+
+	mock.ExpectExec(`UPDATE products SET status = 'received'`).
+		WithArgs(sqlmock.AnyArg(), receptionID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = repo.MoveProductsToReception(context.Background(), []uuid.UUID{uuid.New(), uuid.New()}, receptionID)
+	assert.ErrorIs(t, err, entity.ErrProductNotInTransit)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestProductPostgres_AddProductStatusChanges(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, testDriverName)
+	repo := NewProductPostgres(sqlxDB)
+
+	from := entity.ProductStatusReceived
+	changes := []entity.ProductStatusChange{
+		{ProductID: uuid.New(), From: &from, To: entity.ProductStatusInTransit, ChangedAt: time.Now()},
+		{ProductID: uuid.New(), From: &from, To: entity.ProductStatusInTransit, ChangedAt: time.Now()},
+	}
+
+	mock.ExpectExec(`INSERT INTO product_status_history .* SELECT \* FROM unnest\(\$1::uuid\[\], .*\$7::text\[\]\)`).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(),
+			sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	assert.NoError(t, repo.AddProductStatusChanges(context.Background(), changes))
+	assert.NoError(t, repo.AddProductStatusChanges(context.Background(), nil))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestProductPostgres_GetProductStatusHistory(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, testDriverName)
+	repo := NewProductPostgres(sqlxDB)
+
+	productID, pvzID := uuid.New(), uuid.New()
+	now := time.Now()
+	columns := []string{"id", "product_id", "from_status", "to_status", "pvz_id", "changed_at", "changed_by", "comment"}
+
+	mock.ExpectQuery(`SELECT id, product_id, from_status, .* FROM product_status_history\s+WHERE product_id = \$1\s+ORDER BY changed_at, id`).
+		WithArgs(productID).
+		WillReturnRows(sqlmock.NewRows(columns).
+			AddRow(1, productID, nil, entity.ProductStatusReceived, pvzID, now, nil, "").
+			AddRow(2, productID, entity.ProductStatusReceived, entity.ProductStatusIssued, pvzID, now, uuid.New(), ""))
+
+	history, err := repo.GetProductStatusHistory(context.Background(), productID)
+	assert.NoError(t, err)
+	assert.Len(t, history, 2)
+	assert.Nil(t, history[0].From)
+	assert.Equal(t, entity.ProductStatusIssued, history[1].To)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -10,6 +10,9 @@ import (
 	"github.com/senyabanana/pvz-service/internal/entity"
 )
 
+const pvzSelectColumns = `id, registration_date, city, address, latitude, longitude, phone, working_hours,
+		status, status_reason, status_changed_at`
+
 type PVZPostgres struct {
 	db     *sqlx.DB
 	getter *trmsqlx.CtxGetter
@@ -40,13 +43,101 @@ func (r *PVZPostgres) IsPVZExists(ctx context.Context, pvzID uuid.UUID) (bool, e
 	return exists, err
 }
 
-func (r *PVZPostgres) GetAllPVZ(ctx context.Context) ([]entity.PVZ, error) {
+func (r *PVZPostgres) GetAllPVZ(ctx context.Context, includeClosed bool) ([]entity.PVZ, error) {
 	var allPVZ []entity.PVZ
-	query := `SELECT id, registration_date, city FROM pvz ORDER BY registration_date DESC`
-	err := r.getter.DefaultTrOrDB(ctx, r.db).SelectContext(ctx, &allPVZ, query)
+	query := `
+		SELECT ` + pvzSelectColumns + `
+		FROM pvz WHERE $1 OR status <> 'closed'
+		ORDER BY registration_date DESC
+		`
+	err := r.getter.DefaultTrOrDB(ctx, r.db).SelectContext(ctx, &allPVZ, query, includeClosed)
 	if err != nil {
 		return nil, err
 	}
 
 	return allPVZ, nil
 }
+
+func (r *PVZPostgres) GetPVZByID(ctx context.Context, pvzID uuid.UUID) (*entity.PVZ, error) {
+	var pvz entity.PVZ
+	query := `SELECT ` + pvzSelectColumns + ` FROM pvz WHERE id = $1`
+	err := r.getter.DefaultTrOrDB(ctx, r.db).GetContext(ctx, &pvz, query, pvzID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pvz, nil
+}
+
+// GetPVZByIDForUpdate reads the PVZ and locks the row until the transaction ends, so status changes
+// and reception creation (see GetPVZStatus) on the same PVZ are serialized.
+func (r *PVZPostgres) GetPVZByIDForUpdate(ctx context.Context, pvzID uuid.UUID) (*entity.PVZ, error) {
+	var pvz entity.PVZ
+	query := `SELECT ` + pvzSelectColumns + ` FROM pvz WHERE id = $1 FOR UPDATE`
+	err := r.getter.DefaultTrOrDB(ctx, r.db).GetContext(ctx, &pvz, query, pvzID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pvz, nil
+}
+
+func (r *PVZPostgres) UpdatePVZDetails(ctx context.Context, pvz *entity.PVZ) error {
+	query := `
+		UPDATE pvz SET address = $2, latitude = $3, longitude = $4, phone = $5, working_hours = $6
+		WHERE id = $1
+		`
+	_, err := r.getter.DefaultTrOrDB(ctx, r.db).ExecContext(ctx, query,
+		pvz.ID, pvz.Address, pvz.Latitude, pvz.Longitude, pvz.Phone, pvz.WorkingHours)
+
+	return err
+}
+
+func (r *PVZPostgres) GetPVZStatus(ctx context.Context, pvzID uuid.UUID) (entity.PVZStatus, error) {
+	var status entity.PVZStatus
+	query := `SELECT status FROM pvz WHERE id = $1 FOR NO KEY UPDATE`
+	err := r.getter.DefaultTrOrDB(ctx, r.db).GetContext(ctx, &status, query, pvzID)
+
+	return status, err
+}
+
+func (r *PVZPostgres) UpdatePVZStatus(ctx context.Context, pvz *entity.PVZ) error {
+	query := `UPDATE pvz SET status = $2, status_reason = $3, status_changed_at = $4 WHERE id = $1`
+	_, err := r.getter.DefaultTrOrDB(ctx, r.db).ExecContext(ctx, query,
+		pvz.ID, pvz.Status, pvz.StatusReason, pvz.StatusChangedAt)
+
+	return err
+}
+
+// GetNearbyPVZ returns active PVZs with coordinates within filter.RadiusMeters of the given point,
+// closest first. Distance is the haversine great-circle distance on a 6371 km sphere; the
+// latitude range check lets Postgres narrow candidates by index before computing it. Rounding can push
+// the haversine term slightly above 1 for antipodal points, so it is clamped before ASIN.
+func (r *PVZPostgres) GetNearbyPVZ(ctx context.Context, filter entity.NearbyFilter) ([]entity.NearbyPVZ, error) {
+	var nearby []entity.NearbyPVZ
+	query := `
+		SELECT ` + pvzSelectColumns + `, distance
+		FROM (
+			SELECT *, 6371000 * 2 * ASIN(SQRT(LEAST(1,
+				POWER(SIN(RADIANS(latitude - $1::double precision) / 2), 2) +
+				COS(RADIANS($1::double precision)) * COS(RADIANS(latitude)) *
+				POWER(SIN(RADIANS(longitude - $2::double precision) / 2), 2)
+			))) AS distance
+			FROM pvz
+			WHERE status = 'active' AND latitude IS NOT NULL
+			  AND latitude BETWEEN $1::double precision - DEGREES($3::double precision / 6371000)
+			                   AND $1::double precision + DEGREES($3::double precision / 6371000)
+			  AND ($5::uuid IS NULL OR id = $5::uuid)
+		) AS candidates
+		WHERE distance <= $3::double precision
+		ORDER BY distance
+		LIMIT $4
+		`
+	err := r.getter.DefaultTrOrDB(ctx, r.db).SelectContext(ctx, &nearby, query,
+		filter.Latitude, filter.Longitude, filter.RadiusMeters, filter.Limit, filter.PVZID)
+	if err != nil {
+		return nil, err
+	}
+
+	return nearby, nil
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"testing"
 	"time"
@@ -14,6 +15,9 @@ import (
 	"github.com/senyabanana/pvz-service/internal/entity"
 )
 
+var pvzColumns = []string{"id", "registration_date", "city", "address", "latitude", "longitude", "phone", "working_hours",
+	"status", "status_reason", "status_changed_at"}
+
 func TestPVZPostgres_CreatePVZ(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	assert.NoError(t, err)
@@ -153,10 +157,11 @@ func TestPVZPostgres_GetAllPVZ(t *testing.T) {
 		{
 			name: "success",
 			setup: func() {
-				mock.ExpectQuery(`SELECT id, registration_date, city FROM pvz`).
+				mock.ExpectQuery(`SELECT id, registration_date, city, address, latitude, longitude, phone, working_hours,\s+status, status_reason, status_changed_at\s+FROM pvz WHERE \$1 OR status <> 'closed'`).
+					WithArgs(false).
 					WillReturnRows(
-						sqlmock.NewRows([]string{"id", "registration_date", "city"}).
-							AddRow(uuid.New(), now, entity.CityMoscow),
+						sqlmock.NewRows(pvzColumns).
+							AddRow(uuid.New(), now, entity.CityMoscow, "", nil, nil, "", nil, entity.PVZStatusActive, "", nil),
 					)
 			},
 			wantErr: false,
@@ -164,7 +169,7 @@ func TestPVZPostgres_GetAllPVZ(t *testing.T) {
 		{
 			name: "query error",
 			setup: func() {
-				mock.ExpectQuery(`SELECT id, registration_date, city FROM pvz`).
+				mock.ExpectQuery(`SELECT id, registration_date, city`).
 					WillReturnError(errors.New("query error"))
 			},
 			wantErr: true,
@@ -174,7 +179,301 @@ func TestPVZPostgres_GetAllPVZ(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.setup()
-			_, err := repo.GetAllPVZ(context.Background())
+			_, err := repo.GetAllPVZ(context.Background(), false)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestPVZPostgres_GetPVZByIDForUpdate(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, testDriverName)
+	repo := NewPVZPostgres(sqlxDB)
+
+	id := uuid.New()
+	now := time.Now().UTC()
+
+	mock.ExpectQuery(`SELECT id, registration_date, city, .* FROM pvz WHERE id = \$1 FOR UPDATE`).
+		WithArgs(id).
+		WillReturnRows(sqlmock.NewRows(pvzColumns).
+			AddRow(id, now, entity.CityMoscow, "", nil, nil, "", nil, entity.PVZStatusActive, "", nil))
+
+	pvz, err := repo.GetPVZByIDForUpdate(context.Background(), id)
+	assert.NoError(t, err)
+	assert.Equal(t, entity.PVZStatusActive, pvz.Status)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPVZPostgres_GetPVZByID(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, testDriverName)
+	repo := NewPVZPostgres(sqlxDB)
+
+	id := uuid.New()
+	now := time.Now().UTC()
+	hours := `{"weekly":[{"weekday":"monday","open":"09:00","close":"21:00"}],"exceptions":[{"date":"2025-01-01","closed":true}]}`
+
+	tests := []struct {
+		name    string
+		setup   func()
+		wantErr bool
+	}{
+		{
+			name: "success",
+			setup: func() {
+				mock.ExpectQuery(`SELECT id, registration_date, city, address, latitude, longitude, phone, working_hours,\s+status, status_reason, status_changed_at FROM pvz WHERE id = \$1`).
+					WithArgs(id).
+					WillReturnRows(
+						sqlmock.NewRows(pvzColumns).
+							AddRow(id, now, entity.CityKazan, "ул. Баумана, 1", 55.79, 49.12, "+7 843 000-00-00", []byte(hours), entity.PVZStatusSuspended, "ремонт", now),
+					)
+			},
+			wantErr: false,
+		},
+		{
+			name: "not found",
+			setup: func() {
+				mock.ExpectQuery(`SELECT id, registration_date, city`).
+					WithArgs(id).
+					WillReturnError(sql.ErrNoRows)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			pvz, err := repo.GetPVZByID(context.Background(), id)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, 55.79, *pvz.Latitude)
+				assert.Len(t, pvz.WorkingHours.Weekly, 1)
+				assert.True(t, pvz.WorkingHours.Exceptions[0].Closed)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestPVZPostgres_UpdatePVZDetails(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, testDriverName)
+	repo := NewPVZPostgres(sqlxDB)
+
+	lat, lon := 55.75, 37.61
+	pvz := &entity.PVZ{
+		ID:           uuid.New(),
+		Address:      "Тверская, 1",
+		Latitude:     &lat,
+		Longitude:    &lon,
+		Phone:        "+7 495 000-00-00",
+		WorkingHours: &entity.WorkingHours{Weekly: []entity.DailyHours{{Weekday: "monday", Open: "09:00", Close: "21:00"}}},
+	}
+
+	tests := []struct {
+		name    string
+		setup   func()
+		wantErr bool
+	}{
+		{
+			name: "success",
+			setup: func() {
+				mock.ExpectExec(`UPDATE pvz SET address = \$2, latitude = \$3, longitude = \$4, phone = \$5, working_hours = \$6`).
+					WithArgs(pvz.ID, pvz.Address, lat, lon, pvz.Phone, sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			wantErr: false,
+		},
+		{
+			name: "db error",
+			setup: func() {
+				mock.ExpectExec(`UPDATE pvz SET`).
+					WillReturnError(errors.New("update error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			err := repo.UpdatePVZDetails(context.Background(), pvz)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestPVZPostgres_GetNearbyPVZ(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, testDriverName)
+	repo := NewPVZPostgres(sqlxDB)
+
+	now := time.Now().UTC()
+	filter := entity.NearbyFilter{Latitude: 55.75, Longitude: 37.61, RadiusMeters: 3000, Limit: 5}
+
+	tests := []struct {
+		name     string
+		setup    func()
+		wantErr  bool
+		expected int
+	}{
+		{
+			name: "success",
+			setup: func() {
+				mock.ExpectQuery(`SELECT id, registration_date, city, .*status_changed_at, distance\s+FROM \(.*ASIN\(SQRT\(LEAST\(1,.*WHERE status = 'active'`).
+					WithArgs(filter.Latitude, filter.Longitude, filter.RadiusMeters, filter.Limit, nil).
+					WillReturnRows(
+						sqlmock.NewRows(append(pvzColumns, "distance")).
+							AddRow(uuid.New(), now, entity.CityMoscow, "Тверская, 1", 55.757, 37.613, "", nil, entity.PVZStatusActive, "", nil, 812.4).
+							AddRow(uuid.New(), now, entity.CityMoscow, "Арбат, 10", 55.751, 37.592, "", nil, entity.PVZStatusActive, "", nil, 1270.9),
+					)
+			},
+			wantErr:  false,
+			expected: 2,
+		},
+		{
+			name: "db error",
+			setup: func() {
+				mock.ExpectQuery(`SELECT id, registration_date, city`).
+					WillReturnError(errors.New("query error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			nearby, err := repo.GetNearbyPVZ(context.Background(), filter)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, nearby, tt.expected)
+				assert.Equal(t, 812.4, nearby[0].DistanceMeters)
+				assert.Equal(t, "Тверская, 1", nearby[0].Address)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestPVZPostgres_GetPVZStatus(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, testDriverName)
+	repo := NewPVZPostgres(sqlxDB)
+
+	pvzID := uuid.New()
+
+	tests := []struct {
+		name     string
+		setup    func()
+		expected entity.PVZStatus
+		wantErr  bool
+	}{
+		{
+			name: "success",
+			setup: func() {
+				mock.ExpectQuery(`SELECT status FROM pvz WHERE id = \$1 FOR NO KEY UPDATE`).
+					WithArgs(pvzID).
+					WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow("suspended"))
+			},
+			expected: entity.PVZStatusSuspended,
+			wantErr:  false,
+		},
+		{
+			name: "not found",
+			setup: func() {
+				mock.ExpectQuery(`SELECT status FROM pvz`).
+					WithArgs(pvzID).
+					WillReturnError(sql.ErrNoRows)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			status, err := repo.GetPVZStatus(context.Background(), pvzID)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, status)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestPVZPostgres_UpdatePVZStatus(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, testDriverName)
+	repo := NewPVZPostgres(sqlxDB)
+
+	now := time.Now()
+	pvz := &entity.PVZ{ID: uuid.New(), Status: entity.PVZStatusClosed, StatusReason: "ошибочная регистрация", StatusChangedAt: &now}
+
+	tests := []struct {
+		name    string
+		setup   func()
+		wantErr bool
+	}{
+		{
+			name: "success",
+			setup: func() {
+				mock.ExpectExec(`UPDATE pvz SET status = \$2, status_reason = \$3, status_changed_at = \$4 WHERE id = \$1`).
+					WithArgs(pvz.ID, pvz.Status, pvz.StatusReason, now).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			wantErr: false,
+		},
+		{
+			name: "db error",
+			setup: func() {
+				mock.ExpectExec(`UPDATE pvz SET status`).
+					WillReturnError(errors.New("update error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			err := repo.UpdatePVZStatus(context.Background(), pvz)
 			if tt.wantErr {
 				assert.Error(t, err)
 			} else {
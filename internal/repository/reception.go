@@ -56,6 +56,17 @@ func (r *ReceptionPostgres) GetOpenReception(ctx context.Context, pvzID uuid.UUI
 	return &reception, nil
 }
 
+func (r *ReceptionPostgres) GetReceptionByID(ctx context.Context, receptionID uuid.UUID) (*entity.Reception, error) {
+	var reception entity.Reception
+	query := `SELECT id, date_time, pvz_id, status, created_at, closed_at FROM receptions WHERE id = $1`
+	err := r.getter.DefaultTrOrDB(ctx, r.db).GetContext(ctx, &reception, query, receptionID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &reception, nil
+}
+
 func (r *ReceptionPostgres) CloseReceptionByID(ctx context.Context, receptionID uuid.UUID, closedAt time.Time) error {
 	query := `UPDATE receptions SET status = 'close', closed_at = $2 WHERE id = $1 AND status = 'in_progress'`
 	res, err := r.getter.DefaultTrOrDB(ctx, r.db).ExecContext(ctx, query, receptionID, closedAt)
@@ -98,3 +109,67 @@ func (r *ReceptionPostgres) GetReceptionsByPVZIDs(ctx context.Context, pvzIDs []
 
 	return receptions, nil
 }
+
+const returnSelectColumns = `id, pvz_id, product_id, client_id, reason, condition, comment, status,
+		accepted_at, accepted_by, status_changed_at, shipped_at`
+
+func (r *ReceptionPostgres) CreateReturn(ctx context.Context, ret *entity.CustomerReturn) error {
+	ret.ID = uuid.New()
+	query := `
+		INSERT INTO customer_returns (id, pvz_id, product_id, client_id, reason, condition, comment, status,
+		                              accepted_at, accepted_by, status_changed_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		`
+	_, err := r.getter.DefaultTrOrDB(ctx, r.db).ExecContext(ctx, query,
+		ret.ID, ret.PVZID, ret.ProductID, ret.ClientID, ret.Reason, ret.Condition, ret.Comment, ret.Status,
+		ret.AcceptedAt, ret.AcceptedBy, ret.StatusChangedAt)
+	if hasPGCode(err, pgUniqueViolation) {
+		return entity.ErrReturnAlreadyExists
+	}
+
+	return err
+}
+
+func (r *ReceptionPostgres) GetReturnByID(ctx context.Context, returnID uuid.UUID) (*entity.CustomerReturn, error) {
+	var ret entity.CustomerReturn
+	query := `SELECT ` + returnSelectColumns + ` FROM customer_returns WHERE id = $1 FOR UPDATE`
+	err := r.getter.DefaultTrOrDB(ctx, r.db).GetContext(ctx, &ret, query, returnID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ret, nil
+}
+
+func (r *ReceptionPostgres) UpdateReturnStatus(ctx context.Context, ret *entity.CustomerReturn) error {
+	query := `UPDATE customer_returns SET status = $2, status_changed_at = $3, shipped_at = $4 WHERE id = $1`
+	_, err := r.getter.DefaultTrOrDB(ctx, r.db).ExecContext(ctx, query,
+		ret.ID, ret.Status, ret.StatusChangedAt, ret.ShippedAt)
+
+	return err
+}
+
+func (r *ReceptionPostgres) GetReturnsByPVZIDs(ctx context.Context, pvzIDs []uuid.UUID) ([]entity.CustomerReturn, error) {
+	var returns []entity.CustomerReturn
+
+	if len(pvzIDs) == 0 {
+		return nil, nil
+	}
+
+	query, args, err := sqlx.In(`
+			SELECT `+returnSelectColumns+`
+			FROM customer_returns
+			WHERE pvz_id IN (?)
+			ORDER BY accepted_at`, pvzIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	query = r.db.Rebind(query)
+	err = r.getter.DefaultTrOrDB(ctx, r.db).SelectContext(ctx, &returns, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return returns, nil
+}
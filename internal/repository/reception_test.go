@@ -10,6 +10,7 @@ import (
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/senyabanana/pvz-service/internal/entity"
@@ -318,3 +319,203 @@ func TestReceptionPostgres_GetReceptionsByPVZIDs(t *testing.T) {
 		})
 	}
 }
+
+func TestReceptionPostgres_GetReceptionByID(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, testDriverName)
+	repo := NewReceptionPostgres(sqlxDB)
+
+	receptionID := uuid.New()
+	now := time.Now()
+
+	tests := []struct {
+		name      string
+		setupMock func()
+		wantErr   bool
+	}{
+		{
+			name: "success",
+			setupMock: func() {
+				rows := sqlmock.NewRows([]string{"id", "date_time", "pvz_id", "status", "created_at", "closed_at"}).
+					AddRow(receptionID, now, uuid.New(), entity.StatusClosed, now, now)
+
+				mock.ExpectQuery(`SELECT id, date_time, pvz_id, status, created_at, closed_at FROM receptions WHERE id = \$1`).
+					WithArgs(receptionID).
+					WillReturnRows(rows)
+			},
+			wantErr: false,
+		},
+		{
+			name: "not found",
+			setupMock: func() {
+				mock.ExpectQuery("SELECT id, date_time, pvz_id, status").
+					WithArgs(receptionID).
+					WillReturnError(sql.ErrNoRows)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+			_, err := repo.GetReceptionByID(context.Background(), receptionID)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+var returnColumns = []string{
+	"id", "pvz_id", "product_id", "client_id", "reason", "condition", "comment", "status",
+	"accepted_at", "accepted_by", "status_changed_at", "shipped_at",
+}
+
+func TestReceptionPostgres_CreateReturn(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, testDriverName)
+	repo := NewReceptionPostgres(sqlxDB)
+
+	now := time.Now()
+	ret := &entity.CustomerReturn{
+		PVZID:           uuid.New(),
+		ProductID:       uuid.New(),
+		Reason:          entity.ReturnReasonDefective,
+		Condition:       entity.ConditionOpened,
+		Status:          entity.ReturnStatusAccepted,
+		AcceptedAt:      now,
+		StatusChangedAt: now,
+	}
+
+	mock.ExpectExec(`INSERT INTO customer_returns`).
+		WithArgs(sqlmock.AnyArg(), ret.PVZID, ret.ProductID, nil, ret.Reason, ret.Condition, "", ret.Status, now, nil, now).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	assert.NoError(t, repo.CreateReturn(context.Background(), ret))
+	assert.NotEqual(t, uuid.Nil, ret.ID)
+
+	mock.ExpectExec(`INSERT INTO customer_returns`).WillReturnError(&pq.Error{Code: "23505"})
+
+	assert.ErrorIs(t, repo.CreateReturn(context.Background(), ret), entity.ErrReturnAlreadyExists)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestReceptionPostgres_GetReturnByID(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, testDriverName)
+	repo := NewReceptionPostgres(sqlxDB)
+
+	returnID := uuid.New()
+	now := time.Now()
+
+	mock.ExpectQuery(`SELECT id, pvz_id, product_id, .* FROM customer_returns WHERE id = \$1 FOR UPDATE`).
+		WithArgs(returnID).
+		WillReturnRows(sqlmock.NewRows(returnColumns).AddRow(
+			returnID, uuid.New(), uuid.New(), nil, "defective", "opened", "", "accepted", now, nil, now, nil))
+
+	ret, err := repo.GetReturnByID(context.Background(), returnID)
+	assert.NoError(t, err)
+	assert.Equal(t, entity.ReturnStatusAccepted, ret.Status)
+
+	mock.ExpectQuery(`SELECT id, pvz_id, product_id`).
+		WithArgs(returnID).
+		WillReturnError(sql.ErrNoRows)
+
+	_, err = repo.GetReturnByID(context.Background(), returnID)
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestReceptionPostgres_UpdateReturnStatus(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, testDriverName)
+	repo := NewReceptionPostgres(sqlxDB)
+
+	now := time.Now()
+	ret := &entity.CustomerReturn{ID: uuid.New(), Status: entity.ReturnStatusShipped, StatusChangedAt: now, ShippedAt: &now}
+
+	mock.ExpectExec(`UPDATE customer_returns SET status = \$2, status_changed_at = \$3, shipped_at = \$4 WHERE id = \$1`).
+		WithArgs(ret.ID, ret.Status, now, &now).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	assert.NoError(t, repo.UpdateReturnStatus(context.Background(), ret))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestReceptionPostgres_GetReturnsByPVZIDs(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, testDriverName)
+	repo := NewReceptionPostgres(sqlxDB)
+
+	pvzID := uuid.New()
+	now := time.Now()
+
+	tests := []struct {
+		name     string
+		pvzIDs   []uuid.UUID
+		setup    func()
+		expected int
+		wantErr  bool
+	}{
+		{
+			name:   "success",
+			pvzIDs: []uuid.UUID{pvzID},
+			setup: func() {
+				mock.ExpectQuery(`SELECT id, pvz_id, product_id, .* FROM customer_returns\s+WHERE pvz_id IN`).
+					WithArgs(pvzID).
+					WillReturnRows(sqlmock.NewRows(returnColumns).AddRow(
+						uuid.New(), pvzID, uuid.New(), nil, "wrong_item", "new", "", "ready_to_ship", now, nil, now, nil))
+			},
+			expected: 1,
+		},
+		{
+			name:     "empty input",
+			pvzIDs:   nil,
+			setup:    func() {},
+			expected: 0,
+		},
+		{
+			name:   "db error",
+			pvzIDs: []uuid.UUID{pvzID},
+			setup: func() {
+				mock.ExpectQuery(`FROM customer_returns`).
+					WithArgs(pvzID).
+					WillReturnError(errors.New("query error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			returns, err := repo.GetReturnsByPVZIDs(context.Background(), tt.pvzIDs)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, returns, tt.expected)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,176 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	trmsqlx "github.com/avito-tech/go-transaction-manager/drivers/sqlx/v2"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/senyabanana/pvz-service/internal/entity"
+)
+
+const reportJobSelectColumns = `
+	id, kind, params, status, progress, attempts, error, created_by, api_key_id,
+	created_at, started_at, heartbeat_at, finished_at, artifact_name, coalesce(length(artifact), 0) AS artifact_size`
+
+type ReportJobPostgres struct {
+	db     *sqlx.DB
+	getter *trmsqlx.CtxGetter
+}
+
+func NewReportJobPostgres(db *sqlx.DB) *ReportJobPostgres {
+	return &ReportJobPostgres{
+		db:     db,
+		getter: trmsqlx.DefaultCtxGetter,
+	}
+}
+
+func (r *ReportJobPostgres) CreateReportJob(ctx context.Context, job *entity.ReportJob) error {
+	job.ID = uuid.New()
+	query := `
+		INSERT INTO report_jobs (id, kind, params, status, created_by, api_key_id, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		`
+	_, err := r.getter.DefaultTrOrDB(ctx, r.db).ExecContext(ctx, query,
+		job.ID, job.Kind, job.Params, job.Status, job.CreatedBy, job.APIKeyID, job.CreatedAt)
+
+	return err
+}
+
+func (r *ReportJobPostgres) GetReportJob(ctx context.Context, jobID uuid.UUID) (*entity.ReportJob, error) {
+	var job entity.ReportJob
+	query := `SELECT ` + reportJobSelectColumns + ` FROM report_jobs WHERE id = $1`
+	err := r.getter.DefaultTrOrDB(ctx, r.db).GetContext(ctx, &job, query, jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &job, nil
+}
+
+// ClaimReportJob marks the oldest queued job as running and returns it. SKIP LOCKED lets several
+// workers poll the queue at once without picking the same job; sql.ErrNoRows means the queue is empty.
+func (r *ReportJobPostgres) ClaimReportJob(ctx context.Context, now time.Time) (*entity.ReportJob, error) {
+	var job entity.ReportJob
+	query := `
+		UPDATE report_jobs
+		SET status = 'running', started_at = $1, heartbeat_at = $1, attempts = attempts + 1, progress = 0
+		WHERE id = (
+			SELECT id FROM report_jobs
+			WHERE status = 'queued'
+			ORDER BY created_at
+			FOR UPDATE SKIP LOCKED
+			LIMIT 1
+		)
+		RETURNING ` + reportJobSelectColumns
+	err := r.getter.DefaultTrOrDB(ctx, r.db).GetContext(ctx, &job, query, now)
+	if err != nil {
+		return nil, err
+	}
+
+	return &job, nil
+}
+
+// The methods below act on one attempt of a job: once a stale job is requeued and claimed again,
+// a late write from the previous attempt matches no row.
+
+func (r *ReportJobPostgres) UpdateReportJobProgress(
+	ctx context.Context, jobID uuid.UUID, attempt, progress int, now time.Time,
+) error {
+	query := `
+		UPDATE report_jobs SET progress = $3, heartbeat_at = $4
+		WHERE id = $1 AND status = 'running' AND attempts = $2
+		`
+	_, err := r.getter.DefaultTrOrDB(ctx, r.db).ExecContext(ctx, query, jobID, attempt, progress, now)
+
+	return err
+}
+
+// CompleteReportJob stores the artifact of the attempt; ErrReportJobLost means the attempt no longer owns the job.
+func (r *ReportJobPostgres) CompleteReportJob(
+	ctx context.Context, jobID uuid.UUID, attempt, progress int, artifact entity.ReportArtifact, now time.Time,
+) error {
+	query := `
+		UPDATE report_jobs
+		SET status = 'succeeded', progress = $3, finished_at = $4, heartbeat_at = $4,
+		    artifact = $5, artifact_name = $6, artifact_content_type = $7
+		WHERE id = $1 AND status = 'running' AND attempts = $2
+		`
+	res, err := r.getter.DefaultTrOrDB(ctx, r.db).ExecContext(ctx, query,
+		jobID, attempt, progress, now, artifact.Data, artifact.Name, artifact.ContentType)
+	if err != nil {
+		return err
+	}
+
+	return ensureJobUpdated(res)
+}
+
+// FailReportJob records the failure of the attempt; ErrReportJobLost means the attempt no longer owns the job.
+func (r *ReportJobPostgres) FailReportJob(
+	ctx context.Context, jobID uuid.UUID, attempt int, reason string, now time.Time,
+) error {
+	query := `
+		UPDATE report_jobs SET status = 'failed', error = $3, finished_at = $4
+		WHERE id = $1 AND status = 'running' AND attempts = $2
+		`
+	res, err := r.getter.DefaultTrOrDB(ctx, r.db).ExecContext(ctx, query, jobID, attempt, reason, now)
+	if err != nil {
+		return err
+	}
+
+	return ensureJobUpdated(res)
+}
+
+func ensureJobUpdated(res sql.Result) error {
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return entity.ErrReportJobLost
+	}
+
+	return nil
+}
+
+// RecoverStaleReportJobs returns running jobs whose worker stopped sending heartbeats to the queue,
+// or fails them once they have used up their attempts. It returns the number of recovered jobs.
+func (r *ReportJobPostgres) RecoverStaleReportJobs(ctx context.Context, staleBefore, now time.Time) (int, error) {
+	query := `
+		UPDATE report_jobs
+		SET status = CASE WHEN attempts >= $3 THEN 'failed' ELSE 'queued' END,
+		    error = CASE WHEN attempts >= $3 THEN 'worker stopped responding' ELSE error END,
+		    finished_at = CASE WHEN attempts >= $3 THEN $2 ELSE NULL END
+		WHERE status = 'running' AND heartbeat_at < $1
+		`
+	res, err := r.getter.DefaultTrOrDB(ctx, r.db).ExecContext(ctx, query, staleBefore, now, entity.MaxReportJobAttempts)
+	if err != nil {
+		return 0, err
+	}
+
+	recovered, err := res.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	return int(recovered), nil
+}
+
+func (r *ReportJobPostgres) GetReportArtifact(ctx context.Context, jobID uuid.UUID) (*entity.ReportArtifact, error) {
+	var artifact entity.ReportArtifact
+	query := `
+		SELECT artifact_name, artifact_content_type, artifact
+		FROM report_jobs
+		WHERE id = $1 AND status = 'succeeded'
+		`
+	err := r.getter.DefaultTrOrDB(ctx, r.db).GetContext(ctx, &artifact, query, jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &artifact, nil
+}
@@ -0,0 +1,206 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/senyabanana/pvz-service/internal/entity"
+)
+
+var reportJobColumns = []string{
+	"id", "kind", "params", "status", "progress", "attempts", "error", "created_by", "api_key_id",
+	"created_at", "started_at", "heartbeat_at", "finished_at", "artifact_name", "artifact_size",
+}
+
+func TestReportJobPostgres_CreateReportJob(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, testDriverName)
+	repo := NewReportJobPostgres(sqlxDB)
+
+	userID := uuid.New()
+	job := &entity.ReportJob{
+		Kind:      entity.ReportExportReceptions,
+		Params:    entity.ReportParams{Format: entity.ExportFormatCSV},
+		Status:    entity.ReportJobQueued,
+		CreatedBy: &userID,
+		CreatedAt: time.Now(),
+	}
+
+	mock.ExpectExec(`INSERT INTO report_jobs \(id, kind, params, status, created_by, api_key_id, created_at\)`).
+		WithArgs(sqlmock.AnyArg(), job.Kind, []byte(`{"format":"csv"}`), job.Status, job.CreatedBy, nil, job.CreatedAt).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	assert.NoError(t, repo.CreateReportJob(context.Background(), job))
+	assert.NotEqual(t, uuid.Nil, job.ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestReportJobPostgres_ClaimReportJob(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, testDriverName)
+	repo := NewReportJobPostgres(sqlxDB)
+
+	now := time.Now()
+	jobID := uuid.New()
+
+	tests := []struct {
+		name    string
+		setup   func()
+		wantErr error
+	}{
+		{
+			name: "claims oldest queued job",
+			setup: func() {
+				mock.ExpectQuery(`UPDATE report_jobs SET status = 'running', .* WHERE status = 'queued' ORDER BY created_at FOR UPDATE SKIP LOCKED LIMIT 1 \) RETURNING`).
+					WithArgs(now).
+					WillReturnRows(sqlmock.NewRows(reportJobColumns).AddRow(
+						jobID, entity.ReportExportPVZ, []byte(`{"format":"xlsx","includeClosed":true}`), entity.ReportJobRunning,
+						0, 1, "", nil, nil, now, now, now, nil, "", 0))
+			},
+		},
+		{
+			name: "queue is empty",
+			setup: func() {
+				mock.ExpectQuery(`FOR UPDATE SKIP LOCKED`).WithArgs(now).WillReturnError(sql.ErrNoRows)
+			},
+			wantErr: sql.ErrNoRows,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			job, err := repo.ClaimReportJob(context.Background(), now)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, jobID, job.ID)
+				assert.Equal(t, entity.ExportFormatXLSX, job.Params.Format)
+				assert.True(t, job.Params.IncludeClosed)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestReportJobPostgres_CompleteReportJob(t *testing.T) {
+	jobID, now := uuid.New(), time.Now()
+	artifact := entity.ReportArtifact{Name: "receptions.csv", ContentType: "text/csv", Data: []byte("id\n")}
+
+	tests := []struct {
+		name         string
+		rowsAffected int64
+		expectedErr  error
+	}{
+		{name: "success", rowsAffected: 1},
+		{name: "job taken over by another attempt", rowsAffected: 0, expectedErr: entity.ErrReportJobLost},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			sqlxDB := sqlx.NewDb(db, testDriverName)
+			repo := NewReportJobPostgres(sqlxDB)
+
+			mock.ExpectExec(`UPDATE report_jobs SET status = 'succeeded'.* WHERE id = \$1 AND status = 'running' AND attempts = \$2`).
+				WithArgs(jobID, 2, 1, now, artifact.Data, artifact.Name, artifact.ContentType).
+				WillReturnResult(sqlmock.NewResult(0, tt.rowsAffected))
+
+			err = repo.CompleteReportJob(context.Background(), jobID, 2, 1, artifact, now)
+			assert.ErrorIs(t, err, tt.expectedErr)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestReportJobPostgres_FailReportJob(t *testing.T) {
+	jobID, now := uuid.New(), time.Now()
+
+	tests := []struct {
+		name         string
+		rowsAffected int64
+		expectedErr  error
+	}{
+		{name: "success", rowsAffected: 1},
+		{name: "job taken over by another attempt", rowsAffected: 0, expectedErr: entity.ErrReportJobLost},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			sqlxDB := sqlx.NewDb(db, testDriverName)
+			repo := NewReportJobPostgres(sqlxDB)
+
+			mock.ExpectExec(`UPDATE report_jobs SET status = 'failed'.* WHERE id = \$1 AND status = 'running' AND attempts = \$2`).
+				WithArgs(jobID, 1, "boom", now).
+				WillReturnResult(sqlmock.NewResult(0, tt.rowsAffected))
+
+			err = repo.FailReportJob(context.Background(), jobID, 1, "boom", now)
+			assert.ErrorIs(t, err, tt.expectedErr)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestReportJobPostgres_RecoverStaleReportJobs(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, testDriverName)
+	repo := NewReportJobPostgres(sqlxDB)
+
+	now := time.Now()
+	staleBefore := now.Add(-10 * time.Minute)
+
+	mock.ExpectExec(`UPDATE report_jobs SET status = CASE WHEN attempts >= \$3 THEN 'failed' ELSE 'queued' END, .* WHERE status = 'running' AND heartbeat_at < \$1`).
+		WithArgs(staleBefore, now, entity.MaxReportJobAttempts).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	recovered, err := repo.RecoverStaleReportJobs(context.Background(), staleBefore, now)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, recovered)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestReportJobPostgres_GetReportArtifact(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, testDriverName)
+	repo := NewReportJobPostgres(sqlxDB)
+
+	jobID := uuid.New()
+
+	mock.ExpectQuery(`SELECT artifact_name, artifact_content_type, artifact FROM report_jobs WHERE id = \$1 AND status = 'succeeded'`).
+		WithArgs(jobID).
+		WillReturnRows(sqlmock.NewRows([]string{"artifact_name", "artifact_content_type", "artifact"}).
+			AddRow("pvz.csv", "text/csv", []byte("id\n")))
+
+	artifact, err := repo.GetReportArtifact(context.Background(), jobID)
+	assert.NoError(t, err)
+	assert.Equal(t, "pvz.csv", artifact.Name)
+	assert.Equal(t, []byte("id\n"), artifact.Data)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -16,40 +16,162 @@ type UserRepository interface {
 	CreateUser(ctx context.Context, user *entity.User) error
 	IsEmailExists(ctx context.Context, email string) (bool, error)
 	GetUserByEmail(ctx context.Context, email string) (*entity.User, error)
+	GetUserByOIDCIdentity(ctx context.Context, issuer, subject string) (*entity.User, error)
+	UpdateUserRole(ctx context.Context, userID uuid.UUID, role entity.UserRole) error
+	GetUserByID(ctx context.Context, userID uuid.UUID) (*entity.User, error)
+	UpdatePassword(ctx context.Context, userID uuid.UUID, passwordHash string) error
+	GetUserPVZIDs(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error)
+	ReplaceUserPVZIDs(ctx context.Context, userID uuid.UUID, pvzIDs []uuid.UUID) error
+}
+
+type PasswordResetRepository interface {
+	CreatePasswordResetToken(ctx context.Context, token *entity.PasswordResetToken) error
+	GetPasswordResetTokenByHash(ctx context.Context, tokenHash string) (*entity.PasswordResetToken, error)
+	InvalidatePasswordResetTokens(ctx context.Context, userID uuid.UUID, usedAt time.Time) error
 }
 
 type PVZRepository interface {
 	CreatePVZ(ctx context.Context, pvz *entity.PVZ) error
 	IsPVZExists(ctx context.Context, pvzID uuid.UUID) (bool, error)
-	GetAllPVZ(ctx context.Context) ([]entity.PVZ, error)
+	GetAllPVZ(ctx context.Context, includeClosed bool) ([]entity.PVZ, error)
+	GetPVZByID(ctx context.Context, pvzID uuid.UUID) (*entity.PVZ, error)
+	GetPVZByIDForUpdate(ctx context.Context, pvzID uuid.UUID) (*entity.PVZ, error)
+	GetPVZStatus(ctx context.Context, pvzID uuid.UUID) (entity.PVZStatus, error)
+	UpdatePVZDetails(ctx context.Context, pvz *entity.PVZ) error
+	UpdatePVZStatus(ctx context.Context, pvz *entity.PVZ) error
+	GetNearbyPVZ(ctx context.Context, filter entity.NearbyFilter) ([]entity.NearbyPVZ, error)
+}
+
+type CapacityRepository interface {
+	GetPVZCapacity(ctx context.Context, pvzID uuid.UUID) (*entity.PVZCapacity, error)
+	ReplacePVZCapacity(ctx context.Context, capacity entity.PVZCapacity) error
+	GetPVZOccupancy(ctx context.Context, pvzID uuid.UUID) (map[entity.ProductType]int, error)
 }
 
 type ReceptionRepository interface {
 	CreateReception(ctx context.Context, reception *entity.Reception) error
 	IsReceptionOpenExists(ctx context.Context, pvzID uuid.UUID) (bool, error)
 	GetOpenReception(ctx context.Context, pvzID uuid.UUID) (*entity.Reception, error)
+	GetReceptionByID(ctx context.Context, receptionID uuid.UUID) (*entity.Reception, error)
 	CloseReceptionByID(ctx context.Context, receptionID uuid.UUID, closedAt time.Time) error
 	GetReceptionsByPVZIDs(ctx context.Context, pvzIDs []uuid.UUID) ([]entity.Reception, error)
+	CreateReturn(ctx context.Context, ret *entity.CustomerReturn) error
+	GetReturnByID(ctx context.Context, returnID uuid.UUID) (*entity.CustomerReturn, error)
+	UpdateReturnStatus(ctx context.Context, ret *entity.CustomerReturn) error
+	GetReturnsByPVZIDs(ctx context.Context, pvzIDs []uuid.UUID) ([]entity.CustomerReturn, error)
 }
 
 type ProductRepository interface {
 	CreateProduct(ctx context.Context, product *entity.Product) error
+	CreateProducts(ctx context.Context, products []entity.Product) error
 	DeleteLastProduct(ctx context.Context, receptionID uuid.UUID) (*uuid.UUID, error)
 	GetProductsByReceptionIDs(ctx context.Context, receptionIDs []uuid.UUID) ([]entity.Product, error)
+	GetProductByID(ctx context.Context, productID uuid.UUID) (*entity.Product, error)
+	AssignProductOwner(ctx context.Context, productID, ownerID uuid.UUID, pickupCodeHash string) error
+	AddPickupCodeAttempt(ctx context.Context, productID uuid.UUID) (int, error)
+	MarkProductIssued(ctx context.Context, productID uuid.UUID, issuedAt time.Time, issuedBy *uuid.UUID) error
+	MarkProductReturned(ctx context.Context, productID uuid.UUID) error
+	GetParcelsByOwner(ctx context.Context, ownerID uuid.UUID) ([]entity.Parcel, error)
+	FlagOverdueProducts(
+		ctx context.Context, productType entity.ProductType, readyBefore time.Time, period time.Duration, queuedAt time.Time,
+	) ([]entity.OverdueProduct, error)
+	GetOverdueProducts(ctx context.Context, pvzID uuid.UUID) ([]entity.OverdueProduct, error)
+	ShipOverdueProducts(ctx context.Context, pvzID uuid.UUID, productIDs []uuid.UUID, shippedAt time.Time) ([]uuid.UUID, error)
+	GetProductLocations(ctx context.Context, productIDs []uuid.UUID) ([]entity.ProductLocation, error)
+	SetProductsInTransit(ctx context.Context, productIDs []uuid.UUID) error
+	MoveProductsToReception(ctx context.Context, productIDs []uuid.UUID, receptionID uuid.UUID) error
+	AddProductStatusChanges(ctx context.Context, changes []entity.ProductStatusChange) error
+	GetProductStatusHistory(ctx context.Context, productID uuid.UUID) ([]entity.ProductStatusChange, error)
+}
+
+type StocktakeRepository interface {
+	CreateStocktake(ctx context.Context, stocktake *entity.Stocktake) error
+	GetOpenStocktake(ctx context.Context, pvzID uuid.UUID) (*entity.Stocktake, error)
+	GetStocktakeByID(ctx context.Context, stocktakeID uuid.UUID) (*entity.Stocktake, error)
+	GetStocktakeByIDForUpdate(ctx context.Context, stocktakeID uuid.UUID) (*entity.Stocktake, error)
+	AddStocktakeScans(
+		ctx context.Context, stocktakeID uuid.UUID, productIDs []uuid.UUID, scannedAt time.Time, scannedBy *uuid.UUID,
+	) (int, error)
+	GetStocktakeScans(ctx context.Context, stocktakeID uuid.UUID) ([]uuid.UUID, error)
+	GetExpectedStock(ctx context.Context, pvzID uuid.UUID) ([]uuid.UUID, error)
+	CompleteStocktake(ctx context.Context, stocktake *entity.Stocktake) error
+	GetStocktakeItems(ctx context.Context, stocktakeID uuid.UUID) ([]entity.StocktakeItem, error)
+}
+
+type TransferRepository interface {
+	CreateTransfer(ctx context.Context, order *entity.TransferOrder) error
+	GetTransferByID(ctx context.Context, transferID uuid.UUID) (*entity.TransferOrder, error)
+	MarkTransferReceived(ctx context.Context, order *entity.TransferOrder) error
+	GetProductTransferHops(ctx context.Context, productID uuid.UUID) ([]entity.ProductTransferHop, error)
+}
+
+type AnalyticsRepository interface {
+	GetReceptionThroughput(ctx context.Context, filter entity.AnalyticsFilter) ([]entity.ReceptionThroughput, error)
+	GetProductMix(ctx context.Context, filter entity.AnalyticsFilter) ([]entity.ProductMix, error)
+}
+
+type ExportRepository interface {
+	StreamPVZ(ctx context.Context, filter entity.ExportFilter, fn func(entity.PVZExportRow) error) error
+	StreamReceptions(ctx context.Context, filter entity.ExportFilter, fn func(entity.ReceptionExportRow) error) error
+	StreamProducts(ctx context.Context, filter entity.ExportFilter, fn func(entity.ProductExportRow) error) error
+}
+
+type ReportJobRepository interface {
+	CreateReportJob(ctx context.Context, job *entity.ReportJob) error
+	GetReportJob(ctx context.Context, jobID uuid.UUID) (*entity.ReportJob, error)
+	ClaimReportJob(ctx context.Context, now time.Time) (*entity.ReportJob, error)
+	UpdateReportJobProgress(ctx context.Context, jobID uuid.UUID, attempt, progress int, now time.Time) error
+	CompleteReportJob(
+		ctx context.Context, jobID uuid.UUID, attempt, progress int, artifact entity.ReportArtifact, now time.Time,
+	) error
+	FailReportJob(ctx context.Context, jobID uuid.UUID, attempt int, reason string, now time.Time) error
+	RecoverStaleReportJobs(ctx context.Context, staleBefore, now time.Time) (int, error)
+	GetReportArtifact(ctx context.Context, jobID uuid.UUID) (*entity.ReportArtifact, error)
+}
+
+type SchemaRepository interface {
+	Ping(ctx context.Context) error
+	GetSchemaVersion(ctx context.Context) (*entity.SchemaVersion, error)
+}
+
+type APIKeyRepository interface {
+	CreateAPIKey(ctx context.Context, key *entity.APIKey) error
+	GetAPIKeyByHash(ctx context.Context, keyHash string) (*entity.APIKey, error)
+	GetAllAPIKeys(ctx context.Context) ([]entity.APIKey, error)
+	RevokeAPIKey(ctx context.Context, keyID uuid.UUID, revokedAt time.Time) error
+	TouchAPIKey(ctx context.Context, keyID uuid.UUID, usedAt time.Time) error
 }
 
 type Repository struct {
 	UserRepository
 	PVZRepository
+	CapacityRepository
 	ReceptionRepository
 	ProductRepository
+	TransferRepository
+	StocktakeRepository
+	AnalyticsRepository
+	ExportRepository
+	ReportJobRepository
+	SchemaRepository
+	APIKeyRepository
+	PasswordResetRepository
 }
 
 func NewRepository(db *sqlx.DB) *Repository {
 	return &Repository{
-		UserRepository:      NewUserPostgres(db),
-		PVZRepository:       NewPVZPostgres(db),
-		ReceptionRepository: NewReceptionPostgres(db),
-		ProductRepository:   NewProductPostgres(db),
+		UserRepository:          NewUserPostgres(db),
+		PVZRepository:           NewPVZPostgres(db),
+		CapacityRepository:      NewCapacityPostgres(db),
+		ReceptionRepository:     NewReceptionPostgres(db),
+		ProductRepository:       NewProductPostgres(db),
+		TransferRepository:      NewTransferPostgres(db),
+		StocktakeRepository:     NewStocktakePostgres(db),
+		AnalyticsRepository:     NewAnalyticsPostgres(db),
+		ExportRepository:        NewExportPostgres(db),
+		ReportJobRepository:     NewReportJobPostgres(db),
+		SchemaRepository:        NewSchemaPostgres(db),
+		APIKeyRepository:        NewAPIKeyPostgres(db),
+		PasswordResetRepository: NewPasswordResetPostgres(db),
 	}
 }
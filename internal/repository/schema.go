@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	trmsqlx "github.com/avito-tech/go-transaction-manager/drivers/sqlx/v2"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/senyabanana/pvz-service/internal/entity"
+)
+
+type SchemaPostgres struct {
+	db     *sqlx.DB
+	getter *trmsqlx.CtxGetter
+}
+
+func NewSchemaPostgres(db *sqlx.DB) *SchemaPostgres {
+	return &SchemaPostgres{
+		db:     db,
+		getter: trmsqlx.DefaultCtxGetter,
+	}
+}
+
+func (r *SchemaPostgres) Ping(ctx context.Context) error {
+	return r.db.PingContext(ctx)
+}
+
+// GetSchemaVersion reads the golang-migrate version table; sql.ErrNoRows means the schema
+// was never migrated.
+func (r *SchemaPostgres) GetSchemaVersion(ctx context.Context) (*entity.SchemaVersion, error) {
+	var exists bool
+	query := `SELECT to_regclass('schema_migrations') IS NOT NULL`
+	if err := r.getter.DefaultTrOrDB(ctx, r.db).GetContext(ctx, &exists, query); err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, sql.ErrNoRows
+	}
+
+	var version entity.SchemaVersion
+	query = `SELECT version, dirty FROM schema_migrations LIMIT 1`
+	if err := r.getter.DefaultTrOrDB(ctx, r.db).GetContext(ctx, &version, query); err != nil {
+		return nil, err
+	}
+
+	return &version, nil
+}
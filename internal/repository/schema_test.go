@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/senyabanana/pvz-service/internal/entity"
+)
+
+func TestSchemaPostgres_GetSchemaVersion(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, testDriverName)
+	repo := NewSchemaPostgres(sqlxDB)
+
+	tests := []struct {
+		name    string
+		setup   func()
+		want    *entity.SchemaVersion
+		wantErr error
+	}{
+		{
+			name: "migrated",
+			setup: func() {
+				mock.ExpectQuery(`SELECT to_regclass\('schema_migrations'\) IS NOT NULL`).
+					WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+				mock.ExpectQuery(`SELECT version, dirty FROM schema_migrations LIMIT 1`).
+					WillReturnRows(sqlmock.NewRows([]string{"version", "dirty"}).AddRow(17, false))
+			},
+			want: &entity.SchemaVersion{Version: 17},
+		},
+		{
+			name: "never migrated",
+			setup: func() {
+				mock.ExpectQuery(`SELECT to_regclass\('schema_migrations'\) IS NOT NULL`).
+					WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+			},
+			wantErr: sql.ErrNoRows,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			version, err := repo.GetSchemaVersion(context.Background())
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, version)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestSchemaPostgres_Ping(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.MonitorPingsOption(true))
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewSchemaPostgres(sqlx.NewDb(db, testDriverName))
+
+	mock.ExpectPing()
+	assert.NoError(t, repo.Ping(context.Background()))
+
+	mock.ExpectPing().WillReturnError(sql.ErrConnDone)
+	assert.ErrorIs(t, repo.Ping(context.Background()), sql.ErrConnDone)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
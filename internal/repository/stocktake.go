@@ -0,0 +1,178 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	trmsqlx "github.com/avito-tech/go-transaction-manager/drivers/sqlx/v2"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"github.com/senyabanana/pvz-service/internal/entity"
+)
+
+const stocktakeSelectColumns = `
+	s.id, s.pvz_id, s.status, s.started_at, s.started_by, s.completed_at, s.completed_by, s.comment,
+	(SELECT count(*) FROM stocktake_scans sc WHERE sc.stocktake_id = s.id) AS scanned_count,
+	s.expected_count, s.matched_count, s.missing_count, s.unexpected_count`
+
+type StocktakePostgres struct {
+	db     *sqlx.DB
+	getter *trmsqlx.CtxGetter
+}
+
+func NewStocktakePostgres(db *sqlx.DB) *StocktakePostgres {
+	return &StocktakePostgres{
+		db:     db,
+		getter: trmsqlx.DefaultCtxGetter,
+	}
+}
+
+func (r *StocktakePostgres) CreateStocktake(ctx context.Context, stocktake *entity.Stocktake) error {
+	stocktake.ID = uuid.New()
+	query := `INSERT INTO stocktakes (id, pvz_id, status, started_at, started_by) VALUES ($1, $2, $3, $4, $5)`
+	_, err := r.getter.DefaultTrOrDB(ctx, r.db).ExecContext(ctx, query,
+		stocktake.ID, stocktake.PVZID, stocktake.Status, stocktake.StartedAt, stocktake.StartedBy)
+
+	return err
+}
+
+func (r *StocktakePostgres) GetOpenStocktake(ctx context.Context, pvzID uuid.UUID) (*entity.Stocktake, error) {
+	var stocktake entity.Stocktake
+	query := `SELECT ` + stocktakeSelectColumns + ` FROM stocktakes s WHERE s.pvz_id = $1 AND s.status = 'open'`
+	err := r.getter.DefaultTrOrDB(ctx, r.db).GetContext(ctx, &stocktake, query, pvzID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &stocktake, nil
+}
+
+func (r *StocktakePostgres) GetStocktakeByID(ctx context.Context, stocktakeID uuid.UUID) (*entity.Stocktake, error) {
+	var stocktake entity.Stocktake
+	query := `SELECT ` + stocktakeSelectColumns + ` FROM stocktakes s WHERE s.id = $1`
+	err := r.getter.DefaultTrOrDB(ctx, r.db).GetContext(ctx, &stocktake, query, stocktakeID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &stocktake, nil
+}
+
+// GetStocktakeByIDForUpdate locks the stocktake so scans cannot be added while it is being completed.
+func (r *StocktakePostgres) GetStocktakeByIDForUpdate(ctx context.Context, stocktakeID uuid.UUID) (*entity.Stocktake, error) {
+	var stocktake entity.Stocktake
+	query := `SELECT ` + stocktakeSelectColumns + ` FROM stocktakes s WHERE s.id = $1 FOR UPDATE OF s`
+	err := r.getter.DefaultTrOrDB(ctx, r.db).GetContext(ctx, &stocktake, query, stocktakeID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &stocktake, nil
+}
+
+// AddStocktakeScans records scanned products and returns how many were not scanned before.
+func (r *StocktakePostgres) AddStocktakeScans(
+	ctx context.Context, stocktakeID uuid.UUID, productIDs []uuid.UUID, scannedAt time.Time, scannedBy *uuid.UUID,
+) (int, error) {
+	query := `
+		INSERT INTO stocktake_scans (stocktake_id, product_id, scanned_at, scanned_by)
+		SELECT $1, unnest($2::uuid[]), $3, $4
+		ON CONFLICT (stocktake_id, product_id) DO NOTHING
+		`
+	res, err := r.getter.DefaultTrOrDB(ctx, r.db).ExecContext(ctx, query,
+		stocktakeID, pq.Array(productIDs), scannedAt, scannedBy)
+	if err != nil {
+		return 0, err
+	}
+
+	added, err := res.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	return int(added), nil
+}
+
+func (r *StocktakePostgres) GetStocktakeScans(ctx context.Context, stocktakeID uuid.UUID) ([]uuid.UUID, error) {
+	var productIDs []uuid.UUID
+	query := `SELECT product_id FROM stocktake_scans WHERE stocktake_id = $1 ORDER BY scanned_at, product_id`
+	err := r.getter.DefaultTrOrDB(ctx, r.db).SelectContext(ctx, &productIDs, query, stocktakeID)
+	if err != nil {
+		return nil, err
+	}
+
+	return productIDs, nil
+}
+
+// GetExpectedStock returns the products that should physically be at the PVZ: received products and
+// products waiting for return to the sender, plus customer returns accepted there and not yet shipped.
+func (r *StocktakePostgres) GetExpectedStock(ctx context.Context, pvzID uuid.UUID) ([]uuid.UUID, error) {
+	var productIDs []uuid.UUID
+	query := `
+		SELECT p.id
+		FROM products p
+		JOIN receptions r ON r.id = p.reception_id
+		WHERE r.pvz_id = $1 AND p.status IN ('received', 'return_to_sender')
+		UNION
+		SELECT product_id
+		FROM customer_returns
+		WHERE pvz_id = $1 AND status <> 'shipped_to_warehouse'
+		ORDER BY 1
+		`
+	err := r.getter.DefaultTrOrDB(ctx, r.db).SelectContext(ctx, &productIDs, query, pvzID)
+	if err != nil {
+		return nil, err
+	}
+
+	return productIDs, nil
+}
+
+// CompleteStocktake stores the signed-off report: the summary on the stocktake and one row per item.
+func (r *StocktakePostgres) CompleteStocktake(ctx context.Context, stocktake *entity.Stocktake) error {
+	tr := r.getter.DefaultTrOrDB(ctx, r.db)
+
+	query := `
+		UPDATE stocktakes
+		SET status = $2, completed_at = $3, completed_by = $4, comment = $5,
+		    expected_count = $6, matched_count = $7, missing_count = $8, unexpected_count = $9
+		WHERE id = $1
+		`
+	_, err := tr.ExecContext(ctx, query,
+		stocktake.ID, stocktake.Status, stocktake.CompletedAt, stocktake.CompletedBy, stocktake.Comment,
+		stocktake.ExpectedCount, stocktake.MatchedCount, stocktake.MissingCount, stocktake.UnexpectedCount)
+	if err != nil {
+		return err
+	}
+
+	if len(stocktake.Items) == 0 {
+		return nil
+	}
+
+	productIDs := make([]uuid.UUID, 0, len(stocktake.Items))
+	results := make([]entity.StocktakeResult, 0, len(stocktake.Items))
+	for _, item := range stocktake.Items {
+		productIDs = append(productIDs, item.ProductID)
+		results = append(results, item.Result)
+	}
+
+	query = `
+		INSERT INTO stocktake_items (stocktake_id, product_id, result)
+		SELECT $1, unnest($2::uuid[]), unnest($3::text[])
+		`
+	_, err = tr.ExecContext(ctx, query, stocktake.ID, pq.Array(productIDs), pq.Array(results))
+
+	return err
+}
+
+func (r *StocktakePostgres) GetStocktakeItems(ctx context.Context, stocktakeID uuid.UUID) ([]entity.StocktakeItem, error) {
+	var items []entity.StocktakeItem
+	query := `SELECT product_id, result FROM stocktake_items WHERE stocktake_id = $1 ORDER BY result, product_id`
+	err := r.getter.DefaultTrOrDB(ctx, r.db).SelectContext(ctx, &items, query, stocktakeID)
+	if err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
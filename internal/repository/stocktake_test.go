@@ -0,0 +1,224 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/senyabanana/pvz-service/internal/entity"
+)
+
+var stocktakeColumns = []string{
+	"id", "pvz_id", "status", "started_at", "started_by", "completed_at", "completed_by", "comment",
+	"scanned_count", "expected_count", "matched_count", "missing_count", "unexpected_count",
+}
+
+func TestStocktakePostgres_CreateStocktake(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, testDriverName)
+	repo := NewStocktakePostgres(sqlxDB)
+
+	stocktake := &entity.Stocktake{PVZID: uuid.New(), Status: entity.StocktakeStatusOpen, StartedAt: time.Now()}
+
+	mock.ExpectExec(`INSERT INTO stocktakes \(id, pvz_id, status, started_at, started_by\)`).
+		WithArgs(sqlmock.AnyArg(), stocktake.PVZID, stocktake.Status, stocktake.StartedAt, nil).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	assert.NoError(t, repo.CreateStocktake(context.Background(), stocktake))
+	assert.NotEqual(t, uuid.Nil, stocktake.ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestStocktakePostgres_GetStocktakeByID(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, testDriverName)
+	repo := NewStocktakePostgres(sqlxDB)
+
+	stocktakeID, pvzID := uuid.New(), uuid.New()
+
+	tests := []struct {
+		name    string
+		get     func(ctx context.Context, stocktakeID uuid.UUID) (*entity.Stocktake, error)
+		setup   func()
+		wantErr error
+	}{
+		{
+			name: "success",
+			get:  repo.GetStocktakeByID,
+			setup: func() {
+				mock.ExpectQuery(`SELECT .* AS scanned_count, .* FROM stocktakes s WHERE s.id = \$1$`).
+					WithArgs(stocktakeID).
+					WillReturnRows(sqlmock.NewRows(stocktakeColumns).
+						AddRow(stocktakeID, pvzID, entity.StocktakeStatusOpen, time.Now(), nil, nil, nil, "", 3, 0, 0, 0, 0))
+			},
+		},
+		{
+			name: "success for update",
+			get:  repo.GetStocktakeByIDForUpdate,
+			setup: func() {
+				mock.ExpectQuery(`SELECT .* AS scanned_count, .* FROM stocktakes s WHERE s.id = \$1 FOR UPDATE OF s`).
+					WithArgs(stocktakeID).
+					WillReturnRows(sqlmock.NewRows(stocktakeColumns).
+						AddRow(stocktakeID, pvzID, entity.StocktakeStatusOpen, time.Now(), nil, nil, nil, "", 3, 0, 0, 0, 0))
+			},
+		},
+		{
+			name: "not found",
+			get:  repo.GetStocktakeByID,
+			setup: func() {
+				mock.ExpectQuery(`FROM stocktakes s WHERE s.id = \$1`).
+					WithArgs(stocktakeID).
+					WillReturnError(sql.ErrNoRows)
+			},
+			wantErr: sql.ErrNoRows,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			stocktake, err := tt.get(context.Background(), stocktakeID)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, 3, stocktake.ScannedCount)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestStocktakePostgres_AddStocktakeScans(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, testDriverName)
+	repo := NewStocktakePostgres(sqlxDB)
+
+	stocktakeID := uuid.New()
+	scannedAt := time.Now()
+
+	mock.ExpectExec(`INSERT INTO stocktake_scans .* SELECT \$1, unnest\(\$2::uuid\[\]\), \$3, \$4\s+ON CONFLICT \(stocktake_id, product_id\) DO NOTHING`).
+		WithArgs(stocktakeID, sqlmock.AnyArg(), scannedAt, nil).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	added, err := repo.AddStocktakeScans(context.Background(), stocktakeID, []uuid.UUID{uuid.New(), uuid.New()}, scannedAt, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, added)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestStocktakePostgres_GetExpectedStock(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, testDriverName)
+	repo := NewStocktakePostgres(sqlxDB)
+
+	pvzID := uuid.New()
+
+	mock.ExpectQuery(`SELECT p.id FROM products p .* p.status IN \('received', 'return_to_sender'\)\s+UNION\s+SELECT product_id\s+FROM customer_returns`).
+		WithArgs(pvzID).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(uuid.New()).AddRow(uuid.New()))
+
+	expected, err := repo.GetExpectedStock(context.Background(), pvzID)
+	assert.NoError(t, err)
+	assert.Len(t, expected, 2)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestStocktakePostgres_CompleteStocktake(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, testDriverName)
+	repo := NewStocktakePostgres(sqlxDB)
+
+	completedAt, employeeID := time.Now(), uuid.New()
+	stocktake := &entity.Stocktake{
+		ID:            uuid.New(),
+		Status:        entity.StocktakeStatusCompleted,
+		CompletedAt:   &completedAt,
+		CompletedBy:   &employeeID,
+		ExpectedCount: 1,
+		MissingCount:  1,
+		Items:         []entity.StocktakeItem{{ProductID: uuid.New(), Result: entity.StocktakeMissing}},
+	}
+
+	tests := []struct {
+		name    string
+		setup   func()
+		wantErr bool
+	}{
+		{
+			name: "success",
+			setup: func() {
+				mock.ExpectExec(`UPDATE stocktakes\s+SET status = \$2, completed_at = \$3, completed_by = \$4`).
+					WithArgs(stocktake.ID, stocktake.Status, stocktake.CompletedAt, stocktake.CompletedBy, "", 1, 0, 1, 0).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(`INSERT INTO stocktake_items \(stocktake_id, product_id, result\)\s+SELECT \$1, unnest\(\$2::uuid\[\]\), unnest\(\$3::text\[\]\)`).
+					WithArgs(stocktake.ID, sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			name: "update error",
+			setup: func() {
+				mock.ExpectExec(`UPDATE stocktakes`).WillReturnError(errors.New("update error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			err := repo.CompleteStocktake(context.Background(), stocktake)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestStocktakePostgres_GetStocktakeItems(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, testDriverName)
+	repo := NewStocktakePostgres(sqlxDB)
+
+	stocktakeID := uuid.New()
+
+	mock.ExpectQuery(`SELECT product_id, result FROM stocktake_items WHERE stocktake_id = \$1`).
+		WithArgs(stocktakeID).
+		WillReturnRows(sqlmock.NewRows([]string{"product_id", "result"}).
+			AddRow(uuid.New(), entity.StocktakeMatched).
+			AddRow(uuid.New(), entity.StocktakeUnexpected))
+
+	items, err := repo.GetStocktakeItems(context.Background(), stocktakeID)
+	assert.NoError(t, err)
+	assert.Len(t, items, 2)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"context"
+
+	trmsqlx "github.com/avito-tech/go-transaction-manager/drivers/sqlx/v2"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"github.com/senyabanana/pvz-service/internal/entity"
+)
+
+type TransferPostgres struct {
+	db     *sqlx.DB
+	getter *trmsqlx.CtxGetter
+}
+
+func NewTransferPostgres(db *sqlx.DB) *TransferPostgres {
+	return &TransferPostgres{
+		db:     db,
+		getter: trmsqlx.DefaultCtxGetter,
+	}
+}
+
+// CreateTransfer stores the order and one item per product, remembering the reception each product leaves.
+func (r *TransferPostgres) CreateTransfer(ctx context.Context, order *entity.TransferOrder) error {
+	tr := r.getter.DefaultTrOrDB(ctx, r.db)
+	order.ID = uuid.New()
+
+	query := `
+		INSERT INTO transfer_orders (id, source_pvz_id, destination_pvz_id, status, created_at, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		`
+	_, err := tr.ExecContext(ctx, query,
+		order.ID, order.SourcePVZID, order.DestinationPVZID, order.Status, order.CreatedAt, order.CreatedBy)
+	if err != nil {
+		return err
+	}
+
+	query = `
+		INSERT INTO transfer_items (transfer_id, product_id, source_reception_id)
+		SELECT $1, id, reception_id FROM products WHERE id = ANY($2::uuid[])
+		`
+	_, err = tr.ExecContext(ctx, query, order.ID, pq.Array(order.ProductIDs))
+
+	return err
+}
+
+func (r *TransferPostgres) GetTransferByID(ctx context.Context, transferID uuid.UUID) (*entity.TransferOrder, error) {
+	tr := r.getter.DefaultTrOrDB(ctx, r.db)
+
+	var order entity.TransferOrder
+	query := `
+		SELECT id, source_pvz_id, destination_pvz_id, status, created_at, created_by,
+		       received_at, received_by, destination_reception_id
+		FROM transfer_orders WHERE id = $1 FOR UPDATE
+		`
+	if err := tr.GetContext(ctx, &order, query, transferID); err != nil {
+		return nil, err
+	}
+
+	query = `SELECT product_id FROM transfer_items WHERE transfer_id = $1 ORDER BY product_id`
+	if err := tr.SelectContext(ctx, &order.ProductIDs, query, transferID); err != nil {
+		return nil, err
+	}
+
+	return &order, nil
+}
+
+func (r *TransferPostgres) MarkTransferReceived(ctx context.Context, order *entity.TransferOrder) error {
+	query := `
+		UPDATE transfer_orders SET status = $2, received_at = $3, received_by = $4, destination_reception_id = $5
+		WHERE id = $1
+		`
+	_, err := r.getter.DefaultTrOrDB(ctx, r.db).ExecContext(ctx, query,
+		order.ID, order.Status, order.ReceivedAt, order.ReceivedBy, order.DestinationReceptionID)
+
+	return err
+}
+
+func (r *TransferPostgres) GetProductTransferHops(ctx context.Context, productID uuid.UUID) ([]entity.ProductTransferHop, error) {
+	var hops []entity.ProductTransferHop
+	query := `
+		SELECT t.id AS transfer_id, t.source_pvz_id, i.source_reception_id, t.destination_pvz_id,
+		       t.destination_reception_id, t.status, t.created_at, t.created_by, t.received_at, t.received_by
+		FROM transfer_items i
+		JOIN transfer_orders t ON t.id = i.transfer_id
+		WHERE i.product_id = $1
+		ORDER BY t.created_at
+		`
+	err := r.getter.DefaultTrOrDB(ctx, r.db).SelectContext(ctx, &hops, query, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	return hops, nil
+}
@@ -0,0 +1,177 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/senyabanana/pvz-service/internal/entity"
+)
+
+func TestTransferPostgres_CreateTransfer(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, testDriverName)
+	repo := NewTransferPostgres(sqlxDB)
+
+	order := &entity.TransferOrder{
+		SourcePVZID:      uuid.New(),
+		DestinationPVZID: uuid.New(),
+		Status:           entity.TransferStatusInTransit,
+		CreatedAt:        time.Now(),
+		ProductIDs:       []uuid.UUID{uuid.New(), uuid.New()},
+	}
+
+	tests := []struct {
+		name    string
+		setup   func()
+		wantErr bool
+	}{
+		{
+			name: "success",
+			setup: func() {
+				mock.ExpectExec(`INSERT INTO transfer_orders`).
+					WithArgs(sqlmock.AnyArg(), order.SourcePVZID, order.DestinationPVZID, order.Status, order.CreatedAt, nil).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec(`INSERT INTO transfer_items \(transfer_id, product_id, source_reception_id\)\s+SELECT \$1, id, reception_id FROM products WHERE id = ANY\(\$2::uuid\[\]\)`).
+					WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+			},
+		},
+		{
+			name: "order insert error",
+			setup: func() {
+				mock.ExpectExec(`INSERT INTO transfer_orders`).
+					WillReturnError(errors.New("insert error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			err := repo.CreateTransfer(context.Background(), order)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.NotEqual(t, uuid.Nil, order.ID)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestTransferPostgres_GetTransferByID(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, testDriverName)
+	repo := NewTransferPostgres(sqlxDB)
+
+	transferID, productID := uuid.New(), uuid.New()
+	columns := []string{
+		"id", "source_pvz_id", "destination_pvz_id", "status", "created_at", "created_by",
+		"received_at", "received_by", "destination_reception_id",
+	}
+
+	tests := []struct {
+		name    string
+		setup   func()
+		wantErr error
+	}{
+		{
+			name: "success",
+			setup: func() {
+				mock.ExpectQuery(`SELECT id, source_pvz_id, destination_pvz_id, .* FROM transfer_orders WHERE id = \$1 FOR UPDATE`).
+					WithArgs(transferID).
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow(transferID, uuid.New(), uuid.New(), "in_transit", time.Now(), nil, nil, nil, nil))
+				mock.ExpectQuery(`SELECT product_id FROM transfer_items WHERE transfer_id = \$1`).
+					WithArgs(transferID).
+					WillReturnRows(sqlmock.NewRows([]string{"product_id"}).AddRow(productID))
+			},
+		},
+		{
+			name: "not found",
+			setup: func() {
+				mock.ExpectQuery(`FROM transfer_orders`).
+					WithArgs(transferID).
+					WillReturnError(sql.ErrNoRows)
+			},
+			wantErr: sql.ErrNoRows,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			order, err := repo.GetTransferByID(context.Background(), transferID)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, []uuid.UUID{productID}, order.ProductIDs)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestTransferPostgres_MarkTransferReceived(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, testDriverName)
+	repo := NewTransferPostgres(sqlxDB)
+
+	now := time.Now()
+	receptionID := uuid.New()
+	order := &entity.TransferOrder{
+		ID: uuid.New(), Status: entity.TransferStatusReceived, ReceivedAt: &now, DestinationReceptionID: &receptionID,
+	}
+
+	mock.ExpectExec(`UPDATE transfer_orders SET status = \$2, received_at = \$3, received_by = \$4, destination_reception_id = \$5\s+WHERE id = \$1`).
+		WithArgs(order.ID, order.Status, &now, nil, &receptionID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	assert.NoError(t, repo.MarkTransferReceived(context.Background(), order))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTransferPostgres_GetProductTransferHops(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, testDriverName)
+	repo := NewTransferPostgres(sqlxDB)
+
+	productID := uuid.New()
+	columns := []string{
+		"transfer_id", "source_pvz_id", "source_reception_id", "destination_pvz_id", "destination_reception_id",
+		"status", "created_at", "created_by", "received_at", "received_by",
+	}
+
+	mock.ExpectQuery(`FROM transfer_items i\s+JOIN transfer_orders t ON t.id = i.transfer_id\s+WHERE i.product_id = \$1\s+ORDER BY t.created_at`).
+		WithArgs(productID).
+		WillReturnRows(sqlmock.NewRows(columns).
+			AddRow(uuid.New(), uuid.New(), uuid.New(), uuid.New(), nil, "in_transit", time.Now(), nil, nil, nil))
+
+	hops, err := repo.GetProductTransferHops(context.Background(), productID)
+	assert.NoError(t, err)
+	assert.Len(t, hops, 1)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -6,6 +6,7 @@ import (
 	trmsqlx "github.com/avito-tech/go-transaction-manager/drivers/sqlx/v2"
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 
 	"github.com/senyabanana/pvz-service/internal/entity"
 )
@@ -24,16 +25,34 @@ func NewUserPostgres(db *sqlx.DB) *UserPostgres {
 
 func (r *UserPostgres) CreateUser(ctx context.Context, user *entity.User) error {
 	user.ID = uuid.New()
-	query := `INSERT INTO users (id, email, password_hash, role, created_at) VALUES ($1, $2, $3, $4, $5)`
-	_, err := r.getter.DefaultTrOrDB(ctx, r.db).
-		ExecContext(ctx, query, user.ID, user.Email, user.Password, user.Role, user.CreatedAt)
+	query := `
+		INSERT INTO users (id, email, password_hash, role, auth_provider, created_at, oidc_issuer, oidc_subject)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		`
+	_, err := r.getter.DefaultTrOrDB(ctx, r.db).ExecContext(ctx, query,
+		user.ID, user.Email, user.Password, user.Role, user.AuthProvider, user.CreatedAt, user.OIDCIssuer, user.OIDCSubject)
 
 	return err
 }
 
+func (r *UserPostgres) GetUserByOIDCIdentity(ctx context.Context, issuer, subject string) (*entity.User, error) {
+	var user entity.User
+	query := `
+		SELECT id, email, password_hash, role, auth_provider, created_at, oidc_issuer, oidc_subject
+		FROM users
+		WHERE oidc_issuer = $1 AND oidc_subject = $2
+		`
+	err := r.getter.DefaultTrOrDB(ctx, r.db).GetContext(ctx, &user, query, issuer, subject)
+	if err != nil {
+		return nil, err
+	}
+
+	return &user, nil
+}
+
 func (r *UserPostgres) IsEmailExists(ctx context.Context, email string) (bool, error) {
 	var count int
-	query := `SELECT COUNT(*) FROM users WHERE email = $1`
+	query := `SELECT COUNT(*) FROM users WHERE lower(email) = lower($1)`
 	err := r.getter.DefaultTrOrDB(ctx, r.db).GetContext(ctx, &count, query, email)
 
 	return count > 0, err
@@ -41,7 +60,7 @@ func (r *UserPostgres) IsEmailExists(ctx context.Context, email string) (bool, e
 
 func (r *UserPostgres) GetUserByEmail(ctx context.Context, email string) (*entity.User, error) {
 	var user entity.User
-	query := `SELECT id, email, password_hash, role, created_at FROM users WHERE email = $1`
+	query := `SELECT id, email, password_hash, role, auth_provider, created_at FROM users WHERE lower(email) = lower($1)`
 	err := r.getter.DefaultTrOrDB(ctx, r.db).GetContext(ctx, &user, query, email)
 	if err != nil {
 		return nil, err
@@ -49,3 +68,53 @@ func (r *UserPostgres) GetUserByEmail(ctx context.Context, email string) (*entit
 
 	return &user, nil
 }
+
+func (r *UserPostgres) UpdateUserRole(ctx context.Context, userID uuid.UUID, role entity.UserRole) error {
+	query := `UPDATE users SET role = $2 WHERE id = $1`
+	_, err := r.getter.DefaultTrOrDB(ctx, r.db).ExecContext(ctx, query, userID, role)
+
+	return err
+}
+
+func (r *UserPostgres) GetUserByID(ctx context.Context, userID uuid.UUID) (*entity.User, error) {
+	var user entity.User
+	query := `SELECT id, email, password_hash, role, auth_provider, created_at FROM users WHERE id = $1`
+	err := r.getter.DefaultTrOrDB(ctx, r.db).GetContext(ctx, &user, query, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+func (r *UserPostgres) UpdatePassword(ctx context.Context, userID uuid.UUID, passwordHash string) error {
+	query := `UPDATE users SET password_hash = $2 WHERE id = $1`
+	_, err := r.getter.DefaultTrOrDB(ctx, r.db).ExecContext(ctx, query, userID, passwordHash)
+
+	return err
+}
+
+func (r *UserPostgres) GetUserPVZIDs(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error) {
+	var pvzIDs []uuid.UUID
+	query := `SELECT pvz_id FROM user_pvz_assignments WHERE user_id = $1 ORDER BY assigned_at, pvz_id`
+	err := r.getter.DefaultTrOrDB(ctx, r.db).SelectContext(ctx, &pvzIDs, query, userID)
+
+	return pvzIDs, err
+}
+
+func (r *UserPostgres) ReplaceUserPVZIDs(ctx context.Context, userID uuid.UUID, pvzIDs []uuid.UUID) error {
+	tr := r.getter.DefaultTrOrDB(ctx, r.db)
+
+	if _, err := tr.ExecContext(ctx, `DELETE FROM user_pvz_assignments WHERE user_id = $1`, userID); err != nil {
+		return err
+	}
+
+	if len(pvzIDs) == 0 {
+		return nil
+	}
+
+	query := `INSERT INTO user_pvz_assignments (user_id, pvz_id) SELECT $1, unnest($2::uuid[])`
+	_, err := tr.ExecContext(ctx, query, userID, pq.Array(pvzIDs))
+
+	return err
+}
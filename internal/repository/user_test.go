@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"testing"
 	"time"
@@ -35,13 +36,14 @@ func TestUserPostgres_CreateUser(t *testing.T) {
 			name: "success",
 			setupMock: func() {
 				mock.ExpectExec(`INSERT INTO users`).
-					WithArgs(sqlmock.AnyArg(), "test@example.com", "hashedpassword", entity.RoleClient, sqlmock.AnyArg()).
+					WithArgs(sqlmock.AnyArg(), "test@example.com", "hashedpassword", entity.RoleClient, entity.AuthProviderLocal, sqlmock.AnyArg(), nil, nil).
 					WillReturnResult(sqlmock.NewResult(1, 1))
 			},
 			inputUser: &entity.User{
-				Email:    "test@example.com",
-				Password: "hashedpassword",
-				Role:     entity.RoleClient,
+				Email:        "test@example.com",
+				Password:     "hashedpassword",
+				Role:         entity.RoleClient,
+				AuthProvider: entity.AuthProviderLocal,
 			},
 			wantErr: false,
 		},
@@ -49,13 +51,14 @@ func TestUserPostgres_CreateUser(t *testing.T) {
 			name: "db error",
 			setupMock: func() {
 				mock.ExpectExec(`INSERT INTO users`).
-					WithArgs(sqlmock.AnyArg(), "test@example.com", "hashedpassword", entity.RoleClient, sqlmock.AnyArg()).
+					WithArgs(sqlmock.AnyArg(), "test@example.com", "hashedpassword", entity.RoleClient, entity.AuthProviderLocal, sqlmock.AnyArg(), nil, nil).
 					WillReturnError(errors.New("db failure"))
 			},
 			inputUser: &entity.User{
-				Email:    "test@example.com",
-				Password: "hashedpassword",
-				Role:     entity.RoleClient,
+				Email:        "test@example.com",
+				Password:     "hashedpassword",
+				Role:         entity.RoleClient,
+				AuthProvider: entity.AuthProviderLocal,
 			},
 			wantErr: true,
 		},
@@ -93,7 +96,7 @@ func TestUserPostgres_IsEmailExists(t *testing.T) {
 		{
 			name: "email exists",
 			setupMock: func() {
-				mock.ExpectQuery(`SELECT COUNT\(\*\) FROM users WHERE email = \$1`).
+				mock.ExpectQuery(`SELECT COUNT\(\*\) FROM users WHERE lower\(email\) = lower\(\$1\)`).
 					WithArgs("exists@example.com").
 					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
 			},
@@ -104,7 +107,7 @@ func TestUserPostgres_IsEmailExists(t *testing.T) {
 		{
 			name: "email does not exist",
 			setupMock: func() {
-				mock.ExpectQuery(`SELECT COUNT\(\*\) FROM users WHERE email = \$1`).
+				mock.ExpectQuery(`SELECT COUNT\(\*\) FROM users WHERE lower\(email\) = lower\(\$1\)`).
 					WithArgs("notfound@example.com").
 					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
 			},
@@ -115,7 +118,7 @@ func TestUserPostgres_IsEmailExists(t *testing.T) {
 		{
 			name: "db error",
 			setupMock: func() {
-				mock.ExpectQuery(`SELECT COUNT\(\*\) FROM users WHERE email = \$1`).
+				mock.ExpectQuery(`SELECT COUNT\(\*\) FROM users WHERE lower\(email\) = lower\(\$1\)`).
 					WithArgs("fail@example.com").
 					WillReturnError(errors.New("query error"))
 			},
@@ -141,6 +144,36 @@ func TestUserPostgres_IsEmailExists(t *testing.T) {
 	}
 }
 
+func TestUserPostgres_GetUserByOIDCIdentity(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, testDriverName)
+	repo := NewUserPostgres(sqlxDB)
+
+	id := uuid.New()
+	columns := []string{"id", "email", "password_hash", "role", "auth_provider", "created_at", "oidc_issuer", "oidc_subject"}
+
+	mock.ExpectQuery(`SELECT .* FROM users\s+WHERE oidc_issuer = \$1 AND oidc_subject = \$2`).
+		WithArgs("https://idp.example.com", "sub").
+		WillReturnRows(sqlmock.NewRows(columns).
+			AddRow(id, "staff@example.com", "", entity.RoleEmployee, entity.AuthProviderOIDC, time.Now(), "https://idp.example.com", "sub"))
+
+	user, err := repo.GetUserByOIDCIdentity(context.Background(), "https://idp.example.com", "sub")
+	assert.NoError(t, err)
+	assert.Equal(t, id, user.ID)
+	assert.Equal(t, "sub", *user.OIDCSubject)
+
+	mock.ExpectQuery(`SELECT .* FROM users\s+WHERE oidc_issuer = \$1 AND oidc_subject = \$2`).
+		WithArgs("https://idp.example.com", "unknown").
+		WillReturnError(sql.ErrNoRows)
+
+	_, err = repo.GetUserByOIDCIdentity(context.Background(), "https://idp.example.com", "unknown")
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestUserPostgres_GetUserByEmail(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	require.NoError(t, err)
@@ -161,10 +194,10 @@ func TestUserPostgres_GetUserByEmail(t *testing.T) {
 		{
 			name: "success",
 			setupMock: func() {
-				mock.ExpectQuery(`SELECT id, email, password_hash, role, created_at FROM users WHERE email = \$1`).
+				mock.ExpectQuery(`SELECT id, email, password_hash, role, auth_provider, created_at FROM users WHERE lower\(email\) = lower\(\$1\)`).
 					WithArgs("test@example.com").
-					WillReturnRows(sqlmock.NewRows([]string{"id", "email", "password_hash", "role", "created_at"}).
-						AddRow(id, "test@example.com", "hashed", entity.RoleClient, now))
+					WillReturnRows(sqlmock.NewRows([]string{"id", "email", "password_hash", "role", "auth_provider", "created_at"}).
+						AddRow(id, "test@example.com", "hashed", entity.RoleClient, entity.AuthProviderLocal, now))
 			},
 			email:     "test@example.com",
 			expectErr: false,
@@ -172,7 +205,7 @@ func TestUserPostgres_GetUserByEmail(t *testing.T) {
 		{
 			name: "query error",
 			setupMock: func() {
-				mock.ExpectQuery(`SELECT id, email, password_hash, role, created_at FROM users WHERE email = \$1`).
+				mock.ExpectQuery(`SELECT id, email, password_hash, role, auth_provider, created_at FROM users WHERE lower\(email\) = lower\(\$1\)`).
 					WithArgs("fail@example.com").
 					WillReturnError(errors.New("db error"))
 			},
@@ -197,3 +230,273 @@ func TestUserPostgres_GetUserByEmail(t *testing.T) {
 		})
 	}
 }
+
+func TestUserPostgres_UpdateUserRole(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, testDriverName)
+	repo := NewUserPostgres(sqlxDB)
+
+	id := uuid.New()
+
+	tests := []struct {
+		name      string
+		setupMock func()
+		wantErr   bool
+	}{
+		{
+			name: "success",
+			setupMock: func() {
+				mock.ExpectExec(`UPDATE users SET role = \$2 WHERE id = \$1`).
+					WithArgs(id, entity.RoleModerator).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			wantErr: false,
+		},
+		{
+			name: "db error",
+			setupMock: func() {
+				mock.ExpectExec(`UPDATE users SET role = \$2 WHERE id = \$1`).
+					WithArgs(id, entity.RoleModerator).
+					WillReturnError(errors.New("update error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+			err := repo.UpdateUserRole(context.Background(), id, entity.RoleModerator)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestUserPostgres_GetUserByID(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, testDriverName)
+	repo := NewUserPostgres(sqlxDB)
+
+	id := uuid.New()
+	now := time.Now()
+
+	tests := []struct {
+		name      string
+		setupMock func()
+		wantErr   bool
+	}{
+		{
+			name: "success",
+			setupMock: func() {
+				rows := sqlmock.NewRows([]string{"id", "email", "password_hash", "role", "auth_provider", "created_at"}).
+					AddRow(id, "test@example.com", "hash", entity.RoleClient, entity.AuthProviderLocal, now)
+				mock.ExpectQuery(`SELECT id, email, password_hash, role, auth_provider, created_at FROM users WHERE id = \$1`).
+					WithArgs(id).
+					WillReturnRows(rows)
+			},
+			wantErr: false,
+		},
+		{
+			name: "not found",
+			setupMock: func() {
+				mock.ExpectQuery(`SELECT id, email, password_hash, role, auth_provider, created_at FROM users WHERE id = \$1`).
+					WithArgs(id).
+					WillReturnError(sql.ErrNoRows)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+			user, err := repo.GetUserByID(context.Background(), id)
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, user)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, id, user.ID)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestUserPostgres_UpdatePassword(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, testDriverName)
+	repo := NewUserPostgres(sqlxDB)
+
+	id := uuid.New()
+
+	tests := []struct {
+		name      string
+		setupMock func()
+		wantErr   bool
+	}{
+		{
+			name: "success",
+			setupMock: func() {
+				mock.ExpectExec(`UPDATE users SET password_hash = \$2 WHERE id = \$1`).
+					WithArgs(id, "new-hash").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			wantErr: false,
+		},
+		{
+			name: "db error",
+			setupMock: func() {
+				mock.ExpectExec(`UPDATE users SET password_hash = \$2 WHERE id = \$1`).
+					WithArgs(id, "new-hash").
+					WillReturnError(errors.New("update error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+			err := repo.UpdatePassword(context.Background(), id, "new-hash")
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestUserPostgres_GetUserPVZIDs(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, testDriverName)
+	repo := NewUserPostgres(sqlxDB)
+
+	userID := uuid.New()
+	pvzID := uuid.New()
+
+	tests := []struct {
+		name      string
+		setupMock func()
+		want      []uuid.UUID
+		wantErr   bool
+	}{
+		{
+			name: "success",
+			setupMock: func() {
+				mock.ExpectQuery(`SELECT pvz_id FROM user_pvz_assignments WHERE user_id = \$1`).
+					WithArgs(userID).
+					WillReturnRows(sqlmock.NewRows([]string{"pvz_id"}).AddRow(pvzID))
+			},
+			want:    []uuid.UUID{pvzID},
+			wantErr: false,
+		},
+		{
+			name: "db error",
+			setupMock: func() {
+				mock.ExpectQuery(`SELECT pvz_id FROM user_pvz_assignments`).
+					WithArgs(userID).
+					WillReturnError(errors.New("select error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+			got, err := repo.GetUserPVZIDs(context.Background(), userID)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestUserPostgres_ReplaceUserPVZIDs(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, testDriverName)
+	repo := NewUserPostgres(sqlxDB)
+
+	userID := uuid.New()
+
+	tests := []struct {
+		name      string
+		pvzIDs    []uuid.UUID
+		setupMock func()
+		wantErr   bool
+	}{
+		{
+			name:   "replace",
+			pvzIDs: []uuid.UUID{uuid.New()},
+			setupMock: func() {
+				mock.ExpectExec(`DELETE FROM user_pvz_assignments WHERE user_id = \$1`).
+					WithArgs(userID).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectExec(`INSERT INTO user_pvz_assignments \(user_id, pvz_id\) SELECT \$1, unnest\(\$2::uuid\[\]\)`).
+					WithArgs(userID, sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			wantErr: false,
+		},
+		{
+			name:   "clear",
+			pvzIDs: nil,
+			setupMock: func() {
+				mock.ExpectExec(`DELETE FROM user_pvz_assignments WHERE user_id = \$1`).
+					WithArgs(userID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			wantErr: false,
+		},
+		{
+			name:   "delete error",
+			pvzIDs: nil,
+			setupMock: func() {
+				mock.ExpectExec(`DELETE FROM user_pvz_assignments`).
+					WithArgs(userID).
+					WillReturnError(errors.New("delete error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+			err := repo.ReplaceUserPVZIDs(context.Background(), userID, tt.pvzIDs)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
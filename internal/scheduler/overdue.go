@@ -0,0 +1,54 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/senyabanana/pvz-service/internal/service"
+)
+
+// OverdueScheduler periodically queues products whose storage period has expired for return to the sender.
+type OverdueScheduler struct {
+	storage  service.StorageOperations
+	interval time.Duration
+	log      *logrus.Logger
+}
+
+func NewOverdueScheduler(storage service.StorageOperations, interval time.Duration, log *logrus.Logger) *OverdueScheduler {
+	return &OverdueScheduler{
+		storage:  storage,
+		interval: interval,
+		log:      log,
+	}
+}
+
+// Run checks for overdue products once immediately and then every interval until ctx is cancelled.
+func (s *OverdueScheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	s.log.Infof("overdue scheduler started, interval=%s", s.interval)
+
+	for {
+		s.runOnce(ctx)
+
+		select {
+		case <-ctx.Done():
+			s.log.Info("overdue scheduler stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *OverdueScheduler) runOnce(ctx context.Context) {
+	if ctx.Err() != nil {
+		return
+	}
+
+	if _, err := s.storage.FlagOverdueProducts(ctx); err != nil && ctx.Err() == nil {
+		s.log.Errorf("overdue check failed: %v", err)
+	}
+}
@@ -0,0 +1,43 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+
+	"github.com/senyabanana/pvz-service/internal/entity"
+	mocks "github.com/senyabanana/pvz-service/internal/service/mocks"
+)
+
+func TestOverdueScheduler_Run(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := mocks.NewMockStorageOperations(ctrl)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	gomock.InOrder(
+		mockStorage.EXPECT().FlagOverdueProducts(gomock.Any()).Return(nil, errors.New("db down")),
+		mockStorage.EXPECT().FlagOverdueProducts(gomock.Any()).
+			DoAndReturn(func(context.Context) ([]entity.OverdueProduct, error) {
+				cancel()
+				return nil, nil
+			}),
+	)
+
+	done := make(chan struct{})
+	go func() {
+		NewOverdueScheduler(mockStorage, time.Millisecond, logrus.New()).Run(ctx)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("scheduler did not stop after context cancellation")
+	}
+}
@@ -0,0 +1,60 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/senyabanana/pvz-service/internal/service"
+)
+
+type ServingStatusSetter interface {
+	SetServing(serving bool)
+}
+
+// ReadinessWatcher periodically runs the readiness checks and mirrors the result into the gRPC
+// health service, so gRPC clients see the same status as GET /readyz.
+type ReadinessWatcher struct {
+	health   service.HealthOperations
+	target   ServingStatusSetter
+	interval time.Duration
+	log      *logrus.Logger
+}
+
+func NewReadinessWatcher(
+	health service.HealthOperations, target ServingStatusSetter, interval time.Duration, log *logrus.Logger,
+) *ReadinessWatcher {
+	return &ReadinessWatcher{
+		health:   health,
+		target:   target,
+		interval: interval,
+		log:      log,
+	}
+}
+
+// Run checks readiness once immediately and then every interval until ctx is cancelled.
+func (w *ReadinessWatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	ready := false
+	first := true
+
+	for {
+		if ctx.Err() == nil {
+			serving := w.health.CheckReadiness(ctx).IsReady()
+			if first || serving != ready {
+				w.log.Infof("readiness changed: ready=%t", serving)
+				w.target.SetServing(serving)
+				ready, first = serving, false
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/senyabanana/pvz-service/internal/entity"
+	mocks "github.com/senyabanana/pvz-service/internal/service/mocks"
+)
+
+type servingRecorder struct {
+	statuses []bool
+}
+
+func (r *servingRecorder) SetServing(serving bool) {
+	r.statuses = append(r.statuses, serving)
+}
+
+func TestReadinessWatcher_Run(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockHealth := mocks.NewMockHealthOperations(ctrl)
+	recorder := &servingRecorder{}
+	ctx, cancel := context.WithCancel(context.Background())
+
+	up := entity.Readiness{Status: entity.HealthStatusUp}
+	down := entity.Readiness{Status: entity.HealthStatusDown}
+
+	gomock.InOrder(
+		mockHealth.EXPECT().CheckReadiness(gomock.Any()).Return(up),
+		mockHealth.EXPECT().CheckReadiness(gomock.Any()).Return(up),
+		mockHealth.EXPECT().CheckReadiness(gomock.Any()).
+			DoAndReturn(func(context.Context) entity.Readiness {
+				cancel()
+				return down
+			}),
+	)
+
+	done := make(chan struct{})
+	go func() {
+		NewReadinessWatcher(mockHealth, recorder, time.Millisecond, logrus.New()).Run(ctx)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("watcher did not stop after context cancellation")
+	}
+
+	assert.Equal(t, []bool{true, false}, recorder.statuses)
+}
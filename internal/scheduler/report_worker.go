@@ -0,0 +1,95 @@
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/senyabanana/pvz-service/internal/service"
+)
+
+// ReportWorkerPool runs queued report jobs. Each worker drains the queue and then polls it every
+// pollInterval; the pool also requeues jobs abandoned by crashed workers.
+type ReportWorkerPool struct {
+	jobs         service.ReportJobOperations
+	workers      int
+	pollInterval time.Duration
+	log          *logrus.Logger
+}
+
+func NewReportWorkerPool(jobs service.ReportJobOperations, workers int, pollInterval time.Duration, log *logrus.Logger) *ReportWorkerPool {
+	if workers < 1 {
+		workers = 1
+	}
+
+	return &ReportWorkerPool{
+		jobs:         jobs,
+		workers:      workers,
+		pollInterval: pollInterval,
+		log:          log,
+	}
+}
+
+// Run starts the workers and blocks until ctx is cancelled and every worker has returned.
+func (p *ReportWorkerPool) Run(ctx context.Context) {
+	p.log.Infof("report worker pool started, workers=%d, poll interval=%s", p.workers, p.pollInterval)
+
+	var wg sync.WaitGroup
+	for i := 0; i < p.workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			p.work(ctx)
+		}()
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		p.recover(ctx)
+	}()
+
+	wg.Wait()
+	p.log.Info("report worker pool stopped")
+}
+
+func (p *ReportWorkerPool) work(ctx context.Context) {
+	ticker := time.NewTicker(p.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		for ctx.Err() == nil {
+			ran, err := p.jobs.RunNextReportJob(ctx)
+			if err != nil && ctx.Err() == nil {
+				p.log.Errorf("report job run failed: %v", err)
+			}
+			if !ran || err != nil {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (p *ReportWorkerPool) recover(ctx context.Context) {
+	ticker := time.NewTicker(p.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := p.jobs.RecoverStaleReportJobs(ctx); err != nil && ctx.Err() == nil {
+				p.log.Errorf("report job recovery failed: %v", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+
+	mocks "github.com/senyabanana/pvz-service/internal/service/mocks"
+)
+
+func TestReportWorkerPool_Run(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockJobs := mocks.NewMockReportJobOperations(ctrl)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	gomock.InOrder(
+		mockJobs.EXPECT().RunNextReportJob(gomock.Any()).Return(true, nil),
+		mockJobs.EXPECT().RunNextReportJob(gomock.Any()).Return(false, errors.New("db down")),
+		mockJobs.EXPECT().RunNextReportJob(gomock.Any()).
+			DoAndReturn(func(context.Context) (bool, error) {
+				cancel()
+				return false, nil
+			}),
+	)
+	mockJobs.EXPECT().RecoverStaleReportJobs(gomock.Any()).Return(0, nil).AnyTimes()
+
+	done := make(chan struct{})
+	go func() {
+		NewReportWorkerPool(mockJobs, 1, time.Millisecond, logrus.New()).Run(ctx)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("worker pool did not stop after context cancellation")
+	}
+}
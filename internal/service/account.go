@@ -0,0 +1,139 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/avito-tech/go-transaction-manager/trm/v2/manager"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+
+	"github.com/senyabanana/pvz-service/internal/entity"
+	"github.com/senyabanana/pvz-service/internal/infrastructure/jwtutil"
+	"github.com/senyabanana/pvz-service/internal/infrastructure/logger"
+	"github.com/senyabanana/pvz-service/internal/repository"
+)
+
+type AccountService struct {
+	userRepo  repository.UserRepository
+	pvzRepo   repository.PVZRepository
+	trManager *manager.Manager
+	JWTSecret string
+	log       *logrus.Logger
+}
+
+func NewAccountService(
+	userRepo repository.UserRepository, pvzRepo repository.PVZRepository, trManager *manager.Manager, secretKey string, log *logrus.Logger,
+) *AccountService {
+	return &AccountService{
+		userRepo:  userRepo,
+		pvzRepo:   pvzRepo,
+		trManager: trManager,
+		JWTSecret: secretKey,
+		log:       log,
+	}
+}
+
+func (s *AccountService) GetCurrentUser(ctx context.Context, claims entity.TokenClaims) (*entity.UserProfile, error) {
+	ctx, span := tracer.Start(ctx, "AccountService.GetCurrentUser")
+	defer span.End()
+	log := logger.FromContext(ctx, s.log)
+
+	userID, err := uuid.Parse(claims.UserID)
+	if err != nil {
+		log.Warnf("token with invalid user id: %s", claims.UserID)
+		return nil, entity.ErrInvalidToken
+	}
+
+	if claims.Dummy {
+		return &entity.UserProfile{
+			User:           entity.User{ID: userID, Role: claims.Role},
+			PVZIDs:         []uuid.UUID{},
+			Dummy:          true,
+			TokenExpiresAt: claims.ExpiresAt,
+		}, nil
+	}
+
+	user, err := s.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			log.Warnf("current user not found: id=%s", userID)
+			return nil, entity.ErrUserNotFound
+		}
+		log.Errorf("failed to get user: %v", err)
+		return nil, err
+	}
+
+	pvzIDs, err := s.userRepo.GetUserPVZIDs(ctx, userID)
+	if err != nil {
+		log.Errorf("failed to get user pvz assignments: %v", err)
+		return nil, err
+	}
+
+	return &entity.UserProfile{
+		User:           *user,
+		PVZIDs:         pvzIDs,
+		TokenExpiresAt: claims.ExpiresAt,
+	}, nil
+}
+
+func (s *AccountService) IntrospectToken(ctx context.Context, token string) (*entity.TokenClaims, error) {
+	ctx, span := tracer.Start(ctx, "AccountService.IntrospectToken")
+	defer span.End()
+	log := logger.FromContext(ctx, s.log)
+
+	claims, err := jwtutil.ParseToken(token, s.JWTSecret)
+	if err != nil {
+		log.Infof("introspected token is inactive: %v", err)
+		return nil, entity.ErrInvalidToken
+	}
+
+	result := claims.ToEntity()
+	return &result, nil
+}
+
+func (s *AccountService) AssignUserPVZ(ctx context.Context, userID uuid.UUID, pvzIDs []uuid.UUID) error {
+	ctx, span := tracer.Start(ctx, "AccountService.AssignUserPVZ")
+	defer span.End()
+	log := logger.FromContext(ctx, s.log)
+
+	return s.trManager.Do(ctx, func(ctx context.Context) error {
+		if _, err := s.userRepo.GetUserByID(ctx, userID); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return entity.ErrUserNotFound
+			}
+			log.Errorf("failed to get user: %v", err)
+			return err
+		}
+
+		unique := make([]uuid.UUID, 0, len(pvzIDs))
+		seen := make(map[uuid.UUID]struct{}, len(pvzIDs))
+		for _, pvzID := range pvzIDs {
+			if _, ok := seen[pvzID]; ok {
+				continue
+			}
+			seen[pvzID] = struct{}{}
+
+			exists, err := s.pvzRepo.IsPVZExists(ctx, pvzID)
+			if err != nil {
+				log.Errorf("failed to check pvz existence: %v", err)
+				return err
+			}
+			if !exists {
+				log.Warnf("assignment to unknown pvz: %s", pvzID)
+				return entity.ErrPVZNotFound
+			}
+
+			unique = append(unique, pvzID)
+		}
+
+		if err := s.userRepo.ReplaceUserPVZIDs(ctx, userID, unique); err != nil {
+			log.Errorf("failed to update user pvz assignments: %v", err)
+			return err
+		}
+
+		log.Infof("user pvz assignments updated: id=%s, pvz=%v", userID, unique)
+		return nil
+	})
+}
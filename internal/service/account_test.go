@@ -0,0 +1,209 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	trmsqlx "github.com/avito-tech/go-transaction-manager/drivers/sqlx/v2"
+	"github.com/avito-tech/go-transaction-manager/trm/v2/manager"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/senyabanana/pvz-service/internal/entity"
+	"github.com/senyabanana/pvz-service/internal/infrastructure/jwtutil"
+	mocks "github.com/senyabanana/pvz-service/internal/repository/mocks"
+)
+
+func TestAccountService_GetCurrentUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserRepo := mocks.NewMockUserRepository(ctrl)
+	mockLog := logrus.New()
+
+	svc := NewAccountService(mockUserRepo, nil, nil, testJWTSecret, mockLog)
+
+	userID := uuid.New()
+	pvzID := uuid.New()
+	expiresAt := time.Now().Add(time.Hour)
+
+	tests := []struct {
+		name      string
+		claims    entity.TokenClaims
+		setup     func()
+		wantErr   error
+		wantDummy bool
+		wantPVZ   int
+	}{
+		{
+			name:   "stored user",
+			claims: entity.TokenClaims{UserID: userID.String(), Role: entity.RoleEmployee, ExpiresAt: expiresAt},
+			setup: func() {
+				mockUserRepo.EXPECT().GetUserByID(gomock.Any(), userID).
+					Return(&entity.User{ID: userID, Email: "e@example.com", Role: entity.RoleEmployee}, nil)
+				mockUserRepo.EXPECT().GetUserPVZIDs(gomock.Any(), userID).Return([]uuid.UUID{pvzID}, nil)
+			},
+			wantPVZ: 1,
+		},
+		{
+			name:      "dummy token",
+			claims:    entity.TokenClaims{UserID: userID.String(), Role: entity.RoleClient, Dummy: true, ExpiresAt: expiresAt},
+			setup:     func() {},
+			wantDummy: true,
+		},
+		{
+			name:    "invalid user id",
+			claims:  entity.TokenClaims{UserID: "123", Role: entity.RoleClient},
+			setup:   func() {},
+			wantErr: entity.ErrInvalidToken,
+		},
+		{
+			name:   "user not found",
+			claims: entity.TokenClaims{UserID: userID.String(), Role: entity.RoleClient},
+			setup: func() {
+				mockUserRepo.EXPECT().GetUserByID(gomock.Any(), userID).Return(nil, sql.ErrNoRows)
+			},
+			wantErr: entity.ErrUserNotFound,
+		},
+		{
+			name:   "assignments error",
+			claims: entity.TokenClaims{UserID: userID.String(), Role: entity.RoleEmployee},
+			setup: func() {
+				mockUserRepo.EXPECT().GetUserByID(gomock.Any(), userID).Return(&entity.User{ID: userID}, nil)
+				mockUserRepo.EXPECT().GetUserPVZIDs(gomock.Any(), userID).Return(nil, errors.New("db error"))
+			},
+			wantErr: errors.New("db error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			profile, err := svc.GetCurrentUser(context.Background(), tt.claims)
+			if tt.wantErr != nil {
+				assert.ErrorContains(t, err, tt.wantErr.Error())
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, userID, profile.ID)
+			assert.Equal(t, tt.wantDummy, profile.Dummy)
+			assert.Len(t, profile.PVZIDs, tt.wantPVZ)
+			assert.Equal(t, expiresAt, profile.TokenExpiresAt)
+		})
+	}
+}
+
+func TestAccountService_IntrospectToken(t *testing.T) {
+	mockLog := logrus.New()
+	svc := NewAccountService(nil, nil, nil, testJWTSecret, mockLog)
+
+	valid, _ := jwtutil.GenerateToken("user-1", "employee", testJWTSecret, time.Hour)
+	dummy, _ := jwtutil.GenerateDummyToken("user-2", "client", testJWTSecret, time.Hour)
+	expired, _ := jwtutil.GenerateToken("user-1", "employee", testJWTSecret, -time.Minute)
+	foreign, _ := jwtutil.GenerateToken("user-1", "employee", "other-secret", time.Hour)
+
+	tests := []struct {
+		name      string
+		token     string
+		wantErr   bool
+		wantDummy bool
+	}{
+		{name: "active", token: valid},
+		{name: "active dummy", token: dummy, wantDummy: true},
+		{name: "expired", token: expired, wantErr: true},
+		{name: "wrong signature", token: foreign, wantErr: true},
+		{name: "garbage", token: "not-a-jwt", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims, err := svc.IntrospectToken(context.Background(), tt.token)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, entity.ErrInvalidToken)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantDummy, claims.Dummy)
+			assert.False(t, claims.ExpiresAt.IsZero())
+		})
+	}
+}
+
+func TestAccountService_AssignUserPVZ(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserRepo := mocks.NewMockUserRepository(ctrl)
+	mockPVZRepo := mocks.NewMockPVZRepository(ctrl)
+	db, mock, _ := sqlmock.New()
+	mockDB := sqlx.NewDb(db, testDriverName)
+	mockTrManager := manager.Must(trmsqlx.NewDefaultFactory(mockDB))
+	mockLog := logrus.New()
+
+	svc := NewAccountService(mockUserRepo, mockPVZRepo, mockTrManager, testJWTSecret, mockLog)
+
+	userID := uuid.New()
+	pvzID := uuid.New()
+
+	tests := []struct {
+		name    string
+		pvzIDs  []uuid.UUID
+		setup   func()
+		wantErr error
+	}{
+		{
+			name:   "success with duplicates",
+			pvzIDs: []uuid.UUID{pvzID, pvzID},
+			setup: func() {
+				mock.ExpectBegin()
+				mockUserRepo.EXPECT().GetUserByID(gomock.Any(), userID).Return(&entity.User{ID: userID}, nil)
+				mockPVZRepo.EXPECT().IsPVZExists(gomock.Any(), pvzID).Return(true, nil)
+				mockUserRepo.EXPECT().ReplaceUserPVZIDs(gomock.Any(), userID, []uuid.UUID{pvzID}).Return(nil)
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:   "user not found",
+			pvzIDs: []uuid.UUID{pvzID},
+			setup: func() {
+				mock.ExpectBegin()
+				mockUserRepo.EXPECT().GetUserByID(gomock.Any(), userID).Return(nil, sql.ErrNoRows)
+				mock.ExpectRollback()
+			},
+			wantErr: entity.ErrUserNotFound,
+		},
+		{
+			name:   "pvz not found",
+			pvzIDs: []uuid.UUID{pvzID},
+			setup: func() {
+				mock.ExpectBegin()
+				mockUserRepo.EXPECT().GetUserByID(gomock.Any(), userID).Return(&entity.User{ID: userID}, nil)
+				mockPVZRepo.EXPECT().IsPVZExists(gomock.Any(), pvzID).Return(false, nil)
+				mock.ExpectRollback()
+			},
+			wantErr: entity.ErrPVZNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			err := svc.AssignUserPVZ(context.Background(), userID, tt.pvzIDs)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
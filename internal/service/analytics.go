@@ -0,0 +1,97 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/senyabanana/pvz-service/internal/entity"
+	"github.com/senyabanana/pvz-service/internal/infrastructure/logger"
+	"github.com/senyabanana/pvz-service/internal/repository"
+)
+
+type AnalyticsService struct {
+	analyticsRepo repository.AnalyticsRepository
+	log           *logrus.Logger
+}
+
+func NewAnalyticsService(analyticsRepo repository.AnalyticsRepository, log *logrus.Logger) *AnalyticsService {
+	return &AnalyticsService{
+		analyticsRepo: analyticsRepo,
+		log:           log,
+	}
+}
+
+func (s *AnalyticsService) GetReceptionThroughput(
+	ctx context.Context, filter entity.AnalyticsFilter,
+) ([]entity.ReceptionThroughput, error) {
+	ctx, span := tracer.Start(ctx, "AnalyticsService.GetReceptionThroughput")
+	defer span.End()
+	log := logger.FromContext(ctx, s.log)
+
+	filter, err := normalizeAnalyticsFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.analyticsRepo.GetReceptionThroughput(ctx, filter)
+	if err != nil {
+		log.Errorf("failed to get reception throughput: %v", err)
+		return nil, err
+	}
+
+	return rows, nil
+}
+
+func (s *AnalyticsService) GetProductMix(ctx context.Context, filter entity.AnalyticsFilter) ([]entity.ProductMix, error) {
+	ctx, span := tracer.Start(ctx, "AnalyticsService.GetProductMix")
+	defer span.End()
+	log := logger.FromContext(ctx, s.log)
+
+	filter, err := normalizeAnalyticsFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.analyticsRepo.GetProductMix(ctx, filter)
+	if err != nil {
+		log.Errorf("failed to get product mix: %v", err)
+		return nil, err
+	}
+
+	return rows, nil
+}
+
+// normalizeAnalyticsFilter fills in the default grouping (by PVZ, per day) and rejects empty,
+// inverted or too long date ranges.
+func normalizeAnalyticsFilter(filter entity.AnalyticsFilter) (entity.AnalyticsFilter, error) {
+	if filter.GroupBy == "" {
+		filter.GroupBy = entity.AnalyticsGroupByPVZ
+	}
+	if filter.Period == "" {
+		filter.Period = entity.AnalyticsPeriodDay
+	}
+
+	switch filter.GroupBy {
+	case entity.AnalyticsGroupByPVZ, entity.AnalyticsGroupByCity:
+	default:
+		return filter, fmt.Errorf("%w: unknown grouping %q", entity.ErrInvalidAnalyticsFilter, filter.GroupBy)
+	}
+
+	switch filter.Period {
+	case entity.AnalyticsPeriodDay, entity.AnalyticsPeriodWeek, entity.AnalyticsPeriodMonth:
+	default:
+		return filter, fmt.Errorf("%w: unknown period %q", entity.ErrInvalidAnalyticsFilter, filter.Period)
+	}
+
+	if filter.StartDate.IsZero() || filter.EndDate.IsZero() || !filter.EndDate.After(filter.StartDate) {
+		return filter, fmt.Errorf("%w: endDate must be after startDate", entity.ErrInvalidAnalyticsFilter)
+	}
+	if filter.EndDate.Sub(filter.StartDate) > entity.MaxAnalyticsRange {
+		return filter, fmt.Errorf("%w: date range is limited to %d days",
+			entity.ErrInvalidAnalyticsFilter, int(entity.MaxAnalyticsRange.Hours()/24))
+	}
+
+	return filter, nil
+}
@@ -0,0 +1,139 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/senyabanana/pvz-service/internal/entity"
+	mocks "github.com/senyabanana/pvz-service/internal/repository/mocks"
+)
+
+func TestAnalyticsService_GetReceptionThroughput(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockAnalyticsRepository(ctrl)
+	mockLog := logrus.New()
+
+	svc := NewAnalyticsService(mockRepo, mockLog)
+
+	start := time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)
+	dbErr := errors.New("db error")
+
+	tests := []struct {
+		name    string
+		filter  entity.AnalyticsFilter
+		setup   func()
+		wantErr error
+	}{
+		{
+			name:   "defaults to pvz and day",
+			filter: entity.AnalyticsFilter{StartDate: start, EndDate: start.AddDate(0, 0, 7)},
+			setup: func() {
+				mockRepo.EXPECT().GetReceptionThroughput(gomock.Any(), entity.AnalyticsFilter{
+					StartDate: start, EndDate: start.AddDate(0, 0, 7),
+					GroupBy: entity.AnalyticsGroupByPVZ, Period: entity.AnalyticsPeriodDay,
+				}).Return([]entity.ReceptionThroughput{{City: "Казань", ReceptionsOpened: 1}}, nil)
+			},
+		},
+		{
+			name:    "end before start",
+			filter:  entity.AnalyticsFilter{StartDate: start, EndDate: start.AddDate(0, 0, -1)},
+			setup:   func() {},
+			wantErr: entity.ErrInvalidAnalyticsFilter,
+		},
+		{
+			name:    "range too long",
+			filter:  entity.AnalyticsFilter{StartDate: start, EndDate: start.AddDate(2, 0, 0)},
+			setup:   func() {},
+			wantErr: entity.ErrInvalidAnalyticsFilter,
+		},
+		{
+			name:    "unknown period",
+			filter:  entity.AnalyticsFilter{StartDate: start, EndDate: start.AddDate(0, 0, 1), Period: "year"},
+			setup:   func() {},
+			wantErr: entity.ErrInvalidAnalyticsFilter,
+		},
+		{
+			name:   "repository error",
+			filter: entity.AnalyticsFilter{StartDate: start, EndDate: start.AddDate(0, 0, 1)},
+			setup: func() {
+				mockRepo.EXPECT().GetReceptionThroughput(gomock.Any(), gomock.Any()).Return(nil, dbErr)
+			},
+			wantErr: dbErr,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			rows, err := svc.GetReceptionThroughput(context.Background(), tt.filter)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, rows)
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, rows, 1)
+			}
+		})
+	}
+}
+
+func TestAnalyticsService_GetProductMix(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockAnalyticsRepository(ctrl)
+	mockLog := logrus.New()
+
+	svc := NewAnalyticsService(mockRepo, mockLog)
+
+	start := time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)
+	pvzID := uuid.New()
+	filter := entity.AnalyticsFilter{
+		StartDate: start, EndDate: start.AddDate(0, 1, 0),
+		GroupBy: entity.AnalyticsGroupByCity, Period: entity.AnalyticsPeriodWeek, PVZID: &pvzID,
+	}
+
+	tests := []struct {
+		name    string
+		filter  entity.AnalyticsFilter
+		setup   func()
+		wantErr bool
+	}{
+		{
+			name:   "success",
+			filter: filter,
+			setup: func() {
+				mockRepo.EXPECT().GetProductMix(gomock.Any(), filter).
+					Return([]entity.ProductMix{{City: "Казань", ProductType: entity.ProductShoes, Items: 2, Share: 1}}, nil)
+			},
+		},
+		{
+			name:    "unknown grouping",
+			filter:  entity.AnalyticsFilter{StartDate: start, EndDate: start.AddDate(0, 0, 1), GroupBy: "region"},
+			setup:   func() {},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			rows, err := svc.GetProductMix(context.Background(), tt.filter)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, entity.ErrInvalidAnalyticsFilter)
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, rows, 1)
+			}
+		})
+	}
+}
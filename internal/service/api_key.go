@@ -0,0 +1,136 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+
+	"github.com/senyabanana/pvz-service/internal/entity"
+	"github.com/senyabanana/pvz-service/internal/infrastructure/logger"
+	"github.com/senyabanana/pvz-service/internal/infrastructure/security"
+	"github.com/senyabanana/pvz-service/internal/repository"
+)
+
+type APIKeyService struct {
+	apiKeyRepo repository.APIKeyRepository
+	pvzRepo    repository.PVZRepository
+	log        *logrus.Logger
+}
+
+func NewAPIKeyService(apiKeyRepo repository.APIKeyRepository, pvzRepo repository.PVZRepository, log *logrus.Logger) *APIKeyService {
+	return &APIKeyService{
+		apiKeyRepo: apiKeyRepo,
+		pvzRepo:    pvzRepo,
+		log:        log,
+	}
+}
+
+func (s *APIKeyService) CreateAPIKey(ctx context.Context, key *entity.APIKey) (string, error) {
+	ctx, span := tracer.Start(ctx, "APIKeyService.CreateAPIKey")
+	defer span.End()
+	log := logger.FromContext(ctx, s.log)
+
+	if len(key.Scopes) == 0 {
+		log.Warn("attempt to create api key without scopes")
+		return "", entity.ErrInvalidAPIKeyScope
+	}
+
+	for _, scope := range key.Scopes {
+		if !entity.IsValidAPIKeyScope(scope) {
+			log.Warnf("attempt to create api key with invalid scope: %s", scope)
+			return "", entity.ErrInvalidAPIKeyScope
+		}
+	}
+
+	now := time.Now()
+	if key.ExpiresAt != nil && !key.ExpiresAt.After(now) {
+		log.Warnf("attempt to create api key with expiry in the past: %s", key.ExpiresAt)
+		return "", entity.ErrInvalidAPIKeyExpiry
+	}
+
+	if key.PVZID != nil {
+		exists, err := s.pvzRepo.IsPVZExists(ctx, *key.PVZID)
+		if err != nil {
+			log.Errorf("failed to check pvz existence: %v", err)
+			return "", err
+		}
+
+		if !exists {
+			log.Warnf("api key restricted to unknown pvz: %s", *key.PVZID)
+			return "", entity.ErrPVZNotFound
+		}
+	}
+
+	rawKey, prefix, err := security.GenerateAPIKey()
+	if err != nil {
+		log.Errorf("failed to generate api key: %v", err)
+		return "", err
+	}
+
+	key.Prefix = prefix
+	key.KeyHash = security.HashAPIKey(rawKey)
+	key.CreatedAt = now
+
+	if err := s.apiKeyRepo.CreateAPIKey(ctx, key); err != nil {
+		log.Errorf("failed to create api key: %v", err)
+		return "", err
+	}
+
+	log.Infof("api key created: id=%s, name=%s, scopes=%v", key.ID, key.Name, key.Scopes)
+	return rawKey, nil
+}
+
+func (s *APIKeyService) GetAllAPIKeys(ctx context.Context) ([]entity.APIKey, error) {
+	ctx, span := tracer.Start(ctx, "APIKeyService.GetAllAPIKeys")
+	defer span.End()
+	log := logger.FromContext(ctx, s.log)
+
+	log.Info("fetching all api keys")
+	return s.apiKeyRepo.GetAllAPIKeys(ctx)
+}
+
+func (s *APIKeyService) RevokeAPIKey(ctx context.Context, keyID uuid.UUID) error {
+	ctx, span := tracer.Start(ctx, "APIKeyService.RevokeAPIKey")
+	defer span.End()
+	log := logger.FromContext(ctx, s.log)
+
+	if err := s.apiKeyRepo.RevokeAPIKey(ctx, keyID, time.Now()); err != nil {
+		log.Warnf("failed to revoke api key %s: %v", keyID, err)
+		return err
+	}
+
+	log.Infof("api key revoked: id=%s", keyID)
+	return nil
+}
+
+func (s *APIKeyService) AuthenticateAPIKey(ctx context.Context, rawKey string) (*entity.APIKey, error) {
+	ctx, span := tracer.Start(ctx, "APIKeyService.AuthenticateAPIKey")
+	defer span.End()
+	log := logger.FromContext(ctx, s.log)
+
+	key, err := s.apiKeyRepo.GetAPIKeyByHash(ctx, security.HashAPIKey(rawKey))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			log.Warn("api key not found")
+			return nil, entity.ErrInvalidAPIKey
+		}
+		log.Errorf("failed to get api key: %v", err)
+		return nil, err
+	}
+
+	now := time.Now()
+	if !key.IsActive(now) {
+		log.Warnf("inactive api key used: id=%s", key.ID)
+		return nil, entity.ErrInvalidAPIKey
+	}
+
+	if err := s.apiKeyRepo.TouchAPIKey(ctx, key.ID, now); err != nil {
+		log.Errorf("failed to update api key last usage: id=%s: %v", key.ID, err)
+	}
+
+	return key, nil
+}
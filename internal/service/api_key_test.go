@@ -0,0 +1,185 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/senyabanana/pvz-service/internal/entity"
+	"github.com/senyabanana/pvz-service/internal/infrastructure/security"
+	mocks "github.com/senyabanana/pvz-service/internal/repository/mocks"
+)
+
+func TestAPIKeyService_CreateAPIKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAPIKeyRepo := mocks.NewMockAPIKeyRepository(ctrl)
+	mockPVZRepo := mocks.NewMockPVZRepository(ctrl)
+	mockLog := logrus.New()
+
+	svc := NewAPIKeyService(mockAPIKeyRepo, mockPVZRepo, mockLog)
+
+	pvzID := uuid.New()
+	past := time.Now().Add(-time.Hour)
+
+	tests := []struct {
+		name    string
+		key     *entity.APIKey
+		setup   func()
+		wantErr error
+	}{
+		{
+			name: "success",
+			key: &entity.APIKey{
+				Name:   "sorting-center",
+				Scopes: []entity.APIKeyScope{entity.ScopeReceptionsManage, entity.ScopePVZRead},
+				PVZID:  &pvzID,
+			},
+			setup: func() {
+				mockPVZRepo.EXPECT().IsPVZExists(gomock.Any(), pvzID).Return(true, nil)
+				mockAPIKeyRepo.EXPECT().CreateAPIKey(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			wantErr: nil,
+		},
+		{
+			name:    "no scopes",
+			key:     &entity.APIKey{Name: "empty"},
+			setup:   func() {},
+			wantErr: entity.ErrInvalidAPIKeyScope,
+		},
+		{
+			name:    "invalid scope",
+			key:     &entity.APIKey{Name: "admin", Scopes: []entity.APIKeyScope{"admin"}},
+			setup:   func() {},
+			wantErr: entity.ErrInvalidAPIKeyScope,
+		},
+		{
+			name:    "expiry in the past",
+			key:     &entity.APIKey{Name: "old", Scopes: []entity.APIKeyScope{entity.ScopePVZRead}, ExpiresAt: &past},
+			setup:   func() {},
+			wantErr: entity.ErrInvalidAPIKeyExpiry,
+		},
+		{
+			name: "unknown pvz",
+			key:  &entity.APIKey{Name: "ghost", Scopes: []entity.APIKeyScope{entity.ScopePVZRead}, PVZID: &pvzID},
+			setup: func() {
+				mockPVZRepo.EXPECT().IsPVZExists(gomock.Any(), pvzID).Return(false, nil)
+			},
+			wantErr: entity.ErrPVZNotFound,
+		},
+		{
+			name: "repo error",
+			key:  &entity.APIKey{Name: "broken", Scopes: []entity.APIKeyScope{entity.ScopePVZManage}},
+			setup: func() {
+				mockAPIKeyRepo.EXPECT().CreateAPIKey(gomock.Any(), gomock.Any()).Return(errors.New("insert failed"))
+			},
+			wantErr: errors.New("insert failed"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			rawKey, err := svc.CreateAPIKey(context.Background(), tt.key)
+			if tt.wantErr != nil {
+				assert.ErrorContains(t, err, tt.wantErr.Error())
+				assert.Empty(t, rawKey)
+			} else {
+				assert.NoError(t, err)
+				assert.True(t, strings.HasPrefix(rawKey, "pvz_"+tt.key.Prefix+"_"))
+				assert.Equal(t, security.HashAPIKey(rawKey), tt.key.KeyHash)
+			}
+		})
+	}
+}
+
+func TestAPIKeyService_AuthenticateAPIKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAPIKeyRepo := mocks.NewMockAPIKeyRepository(ctrl)
+	mockLog := logrus.New()
+
+	svc := NewAPIKeyService(mockAPIKeyRepo, nil, mockLog)
+
+	const rawKey = "pvz_abcd1234_secret"
+	past := time.Now().Add(-time.Hour)
+	future := time.Now().Add(time.Hour)
+	dbErr := errors.New("db error")
+
+	tests := []struct {
+		name    string
+		setup   func()
+		wantErr error
+	}{
+		{
+			name: "success",
+			setup: func() {
+				key := &entity.APIKey{ID: uuid.New(), ExpiresAt: &future}
+				mockAPIKeyRepo.EXPECT().GetAPIKeyByHash(gomock.Any(), security.HashAPIKey(rawKey)).Return(key, nil)
+				mockAPIKeyRepo.EXPECT().TouchAPIKey(gomock.Any(), key.ID, gomock.Any()).Return(nil)
+			},
+			wantErr: nil,
+		},
+		{
+			name: "touch failure does not reject key",
+			setup: func() {
+				key := &entity.APIKey{ID: uuid.New()}
+				mockAPIKeyRepo.EXPECT().GetAPIKeyByHash(gomock.Any(), gomock.Any()).Return(key, nil)
+				mockAPIKeyRepo.EXPECT().TouchAPIKey(gomock.Any(), key.ID, gomock.Any()).Return(errors.New("update failed"))
+			},
+			wantErr: nil,
+		},
+		{
+			name: "unknown key",
+			setup: func() {
+				mockAPIKeyRepo.EXPECT().GetAPIKeyByHash(gomock.Any(), gomock.Any()).Return(nil, sql.ErrNoRows)
+			},
+			wantErr: entity.ErrInvalidAPIKey,
+		},
+		{
+			name: "db failure",
+			setup: func() {
+				mockAPIKeyRepo.EXPECT().GetAPIKeyByHash(gomock.Any(), gomock.Any()).Return(nil, dbErr)
+			},
+			wantErr: dbErr,
+		},
+		{
+			name: "expired key",
+			setup: func() {
+				mockAPIKeyRepo.EXPECT().GetAPIKeyByHash(gomock.Any(), gomock.Any()).Return(&entity.APIKey{ExpiresAt: &past}, nil)
+			},
+			wantErr: entity.ErrInvalidAPIKey,
+		},
+		{
+			name: "revoked key",
+			setup: func() {
+				mockAPIKeyRepo.EXPECT().GetAPIKeyByHash(gomock.Any(), gomock.Any()).Return(&entity.APIKey{RevokedAt: &past}, nil)
+			},
+			wantErr: entity.ErrInvalidAPIKey,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			key, err := svc.AuthenticateAPIKey(context.Background(), rawKey)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, key)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, key)
+			}
+		})
+	}
+}
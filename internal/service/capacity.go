@@ -0,0 +1,139 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/avito-tech/go-transaction-manager/trm/v2/manager"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+
+	"github.com/senyabanana/pvz-service/internal/entity"
+	"github.com/senyabanana/pvz-service/internal/infrastructure/logger"
+	"github.com/senyabanana/pvz-service/internal/infrastructure/monitoring"
+	"github.com/senyabanana/pvz-service/internal/repository"
+)
+
+type CapacityService struct {
+	capacityRepo repository.CapacityRepository
+	trManager    *manager.Manager
+	log          *logrus.Logger
+}
+
+func NewCapacityService(capacityRepo repository.CapacityRepository, trManager *manager.Manager, log *logrus.Logger) *CapacityService {
+	return &CapacityService{
+		capacityRepo: capacityRepo,
+		trManager:    trManager,
+		log:          log,
+	}
+}
+
+func (s *CapacityService) GetPVZOccupancy(ctx context.Context, pvzID uuid.UUID) (*entity.PVZOccupancy, error) {
+	ctx, span := tracer.Start(ctx, "CapacityService.GetPVZOccupancy")
+	defer span.End()
+	log := logger.FromContext(ctx, s.log).WithField(logger.FieldPVZID, pvzID)
+
+	var occupancy *entity.PVZOccupancy
+
+	err := s.trManager.Do(ctx, func(ctx context.Context) error {
+		var err error
+		occupancy, err = loadOccupancy(ctx, s.capacityRepo, pvzID)
+		return err
+	})
+	if err != nil {
+		if !errors.Is(err, entity.ErrPVZNotFound) {
+			log.Errorf("failed to get PVZ %s occupancy: %v", pvzID, err)
+		}
+		return nil, err
+	}
+
+	return occupancy, nil
+}
+
+func (s *CapacityService) SetPVZCapacity(ctx context.Context, capacity entity.PVZCapacity) (*entity.PVZOccupancy, error) {
+	ctx, span := tracer.Start(ctx, "CapacityService.SetPVZCapacity")
+	defer span.End()
+	log := logger.FromContext(ctx, s.log)
+
+	if err := capacity.Validate(); err != nil {
+		log.Warnf("invalid capacity for PVZ %s: %v", capacity.PVZID, err)
+		return nil, err
+	}
+
+	var occupancy *entity.PVZOccupancy
+
+	err := s.trManager.Do(ctx, func(ctx context.Context) error {
+		if _, err := s.capacityRepo.GetPVZCapacity(ctx, capacity.PVZID); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return entity.ErrPVZNotFound
+			}
+			return err
+		}
+
+		if err := s.capacityRepo.ReplacePVZCapacity(ctx, capacity); err != nil {
+			return err
+		}
+
+		var err error
+		occupancy, err = loadOccupancy(ctx, s.capacityRepo, capacity.PVZID)
+		return err
+	})
+	if err != nil {
+		if !errors.Is(err, entity.ErrPVZNotFound) {
+			log.Errorf("failed to set PVZ %s capacity: %v", capacity.PVZID, err)
+		}
+		return nil, err
+	}
+
+	log.Infof("PVZ capacity updated: id=%s, total=%v, byType=%v", capacity.PVZID, capacity.Total, capacity.ByType)
+
+	return occupancy, nil
+}
+
+func loadOccupancy(ctx context.Context, capacityRepo repository.CapacityRepository, pvzID uuid.UUID) (*entity.PVZOccupancy, error) {
+	capacity, err := capacityRepo.GetPVZCapacity(ctx, pvzID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, entity.ErrPVZNotFound
+		}
+		return nil, err
+	}
+
+	occupied, err := capacityRepo.GetPVZOccupancy(ctx, pvzID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.PVZOccupancy{
+		Capacity: *capacity,
+		Occupied: occupied,
+	}, nil
+}
+
+// enforceCapacity checks that the incoming products fit into the PVZ and applies policy when they don't.
+// Callers hold the PVZ row lock taken by ensurePVZActive, so concurrent additions cannot both see
+// the last free slot.
+func enforceCapacity(
+	ctx context.Context, capacityRepo repository.CapacityRepository, policy entity.CapacityPolicy,
+	pvzID uuid.UUID, incoming map[entity.ProductType]int, log *logrus.Entry,
+) error {
+	occupancy, err := loadOccupancy(ctx, capacityRepo, pvzID)
+	if err != nil {
+		log.Errorf("failed to load occupancy for pvz %s: %v", pvzID, err)
+		return err
+	}
+
+	if occupancy.CanAcceptAll(incoming) {
+		return nil
+	}
+
+	monitoring.CapacityExceededCounter.WithLabelValues(string(policy)).Inc()
+	if policy == entity.CapacityPolicyWarn {
+		log.Warnf("pvz %s is over capacity, accepting %v anyway: occupied=%d", pvzID, incoming, occupancy.TotalOccupied())
+		return nil
+	}
+
+	log.Warnf("pvz %s has no capacity left for %v: occupied=%d", pvzID, incoming, occupancy.TotalOccupied())
+	return entity.ErrCapacityExceeded
+}
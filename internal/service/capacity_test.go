@@ -0,0 +1,173 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	trmsqlx "github.com/avito-tech/go-transaction-manager/drivers/sqlx/v2"
+	"github.com/avito-tech/go-transaction-manager/trm/v2/manager"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/senyabanana/pvz-service/internal/entity"
+	mocks "github.com/senyabanana/pvz-service/internal/repository/mocks"
+)
+
+func TestCapacityService_GetPVZOccupancy(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCapacityRepo := mocks.NewMockCapacityRepository(ctrl)
+	db, mock, _ := sqlmock.New()
+	mockDB := sqlx.NewDb(db, testDriverName)
+	trManager := manager.Must(trmsqlx.NewDefaultFactory(mockDB))
+	mockLog := logrus.New()
+
+	svc := NewCapacityService(mockCapacityRepo, trManager, mockLog)
+
+	pvzID := uuid.New()
+	total := 100
+
+	tests := []struct {
+		name    string
+		setup   func()
+		wantErr error
+	}{
+		{
+			name: "success",
+			setup: func() {
+				mock.ExpectBegin()
+				mockCapacityRepo.EXPECT().GetPVZCapacity(gomock.Any(), pvzID).Return(&entity.PVZCapacity{PVZID: pvzID, Total: &total}, nil)
+				mockCapacityRepo.EXPECT().GetPVZOccupancy(gomock.Any(), pvzID).Return(map[entity.ProductType]int{entity.ProductClothing: 40}, nil)
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "pvz not found",
+			setup: func() {
+				mock.ExpectBegin()
+				mockCapacityRepo.EXPECT().GetPVZCapacity(gomock.Any(), pvzID).Return(nil, sql.ErrNoRows)
+				mock.ExpectRollback()
+			},
+			wantErr: entity.ErrPVZNotFound,
+		},
+		{
+			name: "occupancy error",
+			setup: func() {
+				mock.ExpectBegin()
+				mockCapacityRepo.EXPECT().GetPVZCapacity(gomock.Any(), pvzID).Return(&entity.PVZCapacity{PVZID: pvzID}, nil)
+				mockCapacityRepo.EXPECT().GetPVZOccupancy(gomock.Any(), pvzID).Return(nil, errors.New("query failed"))
+				mock.ExpectRollback()
+			},
+			wantErr: errors.New("query failed"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			occupancy, err := svc.GetPVZOccupancy(context.Background(), pvzID)
+			if tt.wantErr != nil {
+				assert.ErrorContains(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, 40, occupancy.TotalOccupied())
+				assert.True(t, occupancy.CanAccept(entity.ProductShoes))
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestCapacityService_SetPVZCapacity(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCapacityRepo := mocks.NewMockCapacityRepository(ctrl)
+	db, mock, _ := sqlmock.New()
+	mockDB := sqlx.NewDb(db, testDriverName)
+	trManager := manager.Must(trmsqlx.NewDefaultFactory(mockDB))
+	mockLog := logrus.New()
+
+	svc := NewCapacityService(mockCapacityRepo, trManager, mockLog)
+
+	pvzID := uuid.New()
+	total := 50
+	negative := -1
+
+	tests := []struct {
+		name     string
+		capacity entity.PVZCapacity
+		setup    func()
+		wantErr  error
+	}{
+		{
+			name: "success",
+			capacity: entity.PVZCapacity{
+				PVZID:  pvzID,
+				Total:  &total,
+				ByType: map[entity.ProductType]int{entity.ProductElectronics: 10},
+			},
+			setup: func() {
+				mock.ExpectBegin()
+				mockCapacityRepo.EXPECT().GetPVZCapacity(gomock.Any(), pvzID).Return(&entity.PVZCapacity{PVZID: pvzID}, nil)
+				mockCapacityRepo.EXPECT().ReplacePVZCapacity(gomock.Any(), gomock.Any()).Return(nil)
+				mockCapacityRepo.EXPECT().GetPVZCapacity(gomock.Any(), pvzID).Return(&entity.PVZCapacity{PVZID: pvzID, Total: &total}, nil)
+				mockCapacityRepo.EXPECT().GetPVZOccupancy(gomock.Any(), pvzID).Return(map[entity.ProductType]int{}, nil)
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:     "negative total",
+			capacity: entity.PVZCapacity{PVZID: pvzID, Total: &negative},
+			setup:    func() {},
+			wantErr:  entity.ErrInvalidCapacity,
+		},
+		{
+			name:     "unknown product type",
+			capacity: entity.PVZCapacity{PVZID: pvzID, ByType: map[entity.ProductType]int{"мебель": 5}},
+			setup:    func() {},
+			wantErr:  entity.ErrInvalidCapacity,
+		},
+		{
+			name:     "pvz not found",
+			capacity: entity.PVZCapacity{PVZID: pvzID, Total: &total},
+			setup: func() {
+				mock.ExpectBegin()
+				mockCapacityRepo.EXPECT().GetPVZCapacity(gomock.Any(), pvzID).Return(nil, sql.ErrNoRows)
+				mock.ExpectRollback()
+			},
+			wantErr: entity.ErrPVZNotFound,
+		},
+		{
+			name:     "replace error",
+			capacity: entity.PVZCapacity{PVZID: pvzID, Total: &total},
+			setup: func() {
+				mock.ExpectBegin()
+				mockCapacityRepo.EXPECT().GetPVZCapacity(gomock.Any(), pvzID).Return(&entity.PVZCapacity{PVZID: pvzID}, nil)
+				mockCapacityRepo.EXPECT().ReplacePVZCapacity(gomock.Any(), gomock.Any()).Return(errors.New("update failed"))
+				mock.ExpectRollback()
+			},
+			wantErr: errors.New("update failed"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			_, err := svc.SetPVZCapacity(context.Background(), tt.capacity)
+			if tt.wantErr != nil {
+				assert.ErrorContains(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
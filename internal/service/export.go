@@ -0,0 +1,104 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/senyabanana/pvz-service/internal/entity"
+	"github.com/senyabanana/pvz-service/internal/infrastructure/logger"
+	"github.com/senyabanana/pvz-service/internal/repository"
+)
+
+// RowWriter receives exported rows one at a time; the first row is the header.
+type RowWriter interface {
+	WriteRow(values []interface{}) error
+}
+
+var (
+	pvzExportHeader = []interface{}{
+		"id", "city", "address", "status", "registrationDate", "receptions", "products",
+	}
+	receptionExportHeader = []interface{}{
+		"id", "pvzId", "city", "dateTime", "status", "closedAt", "products",
+	}
+	productExportHeader = []interface{}{
+		"id", "dateTime", "type", "status", "receptionId", "receptionDateTime", "pvzId", "city",
+	}
+)
+
+type ExportService struct {
+	exportRepo repository.ExportRepository
+	log        *logrus.Logger
+}
+
+func NewExportService(exportRepo repository.ExportRepository, log *logrus.Logger) *ExportService {
+	return &ExportService{
+		exportRepo: exportRepo,
+		log:        log,
+	}
+}
+
+// Export streams the dataset to w row by row, starting with the header.
+func (s *ExportService) Export(ctx context.Context, dataset entity.ExportDataset, filter entity.ExportFilter, w RowWriter) error {
+	ctx, span := tracer.Start(ctx, "ExportService.Export")
+	defer span.End()
+	log := logger.FromContext(ctx, s.log)
+
+	var rows int
+	write := func(values []interface{}) error {
+		rows++
+		return w.WriteRow(values)
+	}
+
+	var err error
+	switch dataset {
+	case entity.ExportPVZ:
+		if err = write(pvzExportHeader); err == nil {
+			err = s.exportRepo.StreamPVZ(ctx, filter, func(row entity.PVZExportRow) error {
+				return write([]interface{}{
+					row.ID.String(), string(row.City), row.Address, string(row.Status),
+					formatExportTime(&row.RegistrationDate), row.Receptions, row.Products,
+				})
+			})
+		}
+	case entity.ExportReceptions:
+		if err = write(receptionExportHeader); err == nil {
+			err = s.exportRepo.StreamReceptions(ctx, filter, func(row entity.ReceptionExportRow) error {
+				return write([]interface{}{
+					row.ID.String(), row.PVZID.String(), string(row.City), formatExportTime(&row.DateTime),
+					string(row.Status), formatExportTime(row.ClosedAt), row.Products,
+				})
+			})
+		}
+	case entity.ExportProducts:
+		if err = write(productExportHeader); err == nil {
+			err = s.exportRepo.StreamProducts(ctx, filter, func(row entity.ProductExportRow) error {
+				return write([]interface{}{
+					row.ID.String(), formatExportTime(&row.DateTime), string(row.Type), string(row.Status),
+					row.ReceptionID.String(), formatExportTime(&row.ReceptionDateTime), row.PVZID.String(), string(row.City),
+				})
+			})
+		}
+	default:
+		return fmt.Errorf("%w: %s", entity.ErrUnknownExport, dataset)
+	}
+
+	if err != nil {
+		log.Errorf("failed to export %s after %d rows: %v", dataset, rows, err)
+		return err
+	}
+
+	log.Infof("exported %s: %d rows", dataset, rows-1)
+	return nil
+}
+
+func formatExportTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+
+	return t.Format(time.RFC3339)
+}
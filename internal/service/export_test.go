@@ -0,0 +1,127 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/senyabanana/pvz-service/internal/entity"
+	mocks "github.com/senyabanana/pvz-service/internal/repository/mocks"
+)
+
+type recordingRowWriter struct {
+	rows [][]interface{}
+	err  error
+}
+
+func (w *recordingRowWriter) WriteRow(values []interface{}) error {
+	if w.err != nil {
+		return w.err
+	}
+	w.rows = append(w.rows, values)
+	return nil
+}
+
+func TestExportService_Export(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockExportRepository(ctrl)
+	mockLog := logrus.New()
+
+	svc := NewExportService(mockRepo, mockLog)
+
+	filter := entity.ExportFilter{IncludeClosed: true}
+	pvzID, receptionID := uuid.New(), uuid.New()
+	dateTime := time.Date(2025, 4, 1, 10, 0, 0, 0, time.UTC)
+	closedAt := dateTime.Add(time.Hour)
+	writeErr := errors.New("client went away")
+	dbErr := errors.New("db error")
+
+	tests := []struct {
+		name     string
+		dataset  entity.ExportDataset
+		writer   *recordingRowWriter
+		setup    func()
+		wantRows [][]interface{}
+		wantErr  error
+	}{
+		{
+			name:    "receptions",
+			dataset: entity.ExportReceptions,
+			writer:  &recordingRowWriter{},
+			setup: func() {
+				mockRepo.EXPECT().StreamReceptions(gomock.Any(), filter, gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ entity.ExportFilter, fn func(entity.ReceptionExportRow) error) error {
+						return fn(entity.ReceptionExportRow{
+							ID: receptionID, PVZID: pvzID, City: entity.CityKazan, DateTime: dateTime,
+							Status: entity.StatusClosed, ClosedAt: &closedAt, Products: 3,
+						})
+					})
+			},
+			wantRows: [][]interface{}{
+				receptionExportHeader,
+				{receptionID.String(), pvzID.String(), "Казань", "2025-04-01T10:00:00Z", "close", "2025-04-01T11:00:00Z", 3},
+			},
+		},
+		{
+			name:    "pvz without receptions",
+			dataset: entity.ExportPVZ,
+			writer:  &recordingRowWriter{},
+			setup: func() {
+				mockRepo.EXPECT().StreamPVZ(gomock.Any(), filter, gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ entity.ExportFilter, fn func(entity.PVZExportRow) error) error {
+						return fn(entity.PVZExportRow{
+							ID: pvzID, City: entity.CityMoscow, Status: entity.PVZStatusActive, RegistrationDate: dateTime,
+						})
+					})
+			},
+			wantRows: [][]interface{}{
+				pvzExportHeader,
+				{pvzID.String(), "Москва", "", "active", "2025-04-01T10:00:00Z", 0, 0},
+			},
+		},
+		{
+			name:    "products repository error",
+			dataset: entity.ExportProducts,
+			writer:  &recordingRowWriter{},
+			setup: func() {
+				mockRepo.EXPECT().StreamProducts(gomock.Any(), filter, gomock.Any()).Return(dbErr)
+			},
+			wantErr: dbErr,
+		},
+		{
+			name:    "writer error",
+			dataset: entity.ExportProducts,
+			writer:  &recordingRowWriter{err: writeErr},
+			setup:   func() {},
+			wantErr: writeErr,
+		},
+		{
+			name:    "unknown dataset",
+			dataset: "users",
+			writer:  &recordingRowWriter{},
+			setup:   func() {},
+			wantErr: entity.ErrUnknownExport,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			err := svc.Export(context.Background(), tt.dataset, filter, tt.writer)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantRows, tt.writer.rows)
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/senyabanana/pvz-service/internal/entity"
+	"github.com/senyabanana/pvz-service/internal/infrastructure/logger"
+	"github.com/senyabanana/pvz-service/internal/repository"
+)
+
+type HealthService struct {
+	schemaRepo          repository.SchemaRepository
+	latestSchemaVersion int64
+	timeout             time.Duration
+	log                 *logrus.Logger
+}
+
+func NewHealthService(
+	schemaRepo repository.SchemaRepository, latestSchemaVersion int64, timeout time.Duration, log *logrus.Logger,
+) *HealthService {
+	return &HealthService{
+		schemaRepo:          schemaRepo,
+		latestSchemaVersion: latestSchemaVersion,
+		timeout:             timeout,
+		log:                 log,
+	}
+}
+
+// CheckReadiness pings the database and compares the applied schema with the embedded migrations.
+// The service is ready when no check is down; every check shares the same timeout.
+func (s *HealthService) CheckReadiness(ctx context.Context) entity.Readiness {
+	ctx, span := tracer.Start(ctx, "HealthService.CheckReadiness")
+	defer span.End()
+	log := logger.FromContext(ctx, s.log)
+
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	readiness := entity.Readiness{
+		Status: entity.HealthStatusUp,
+		Checks: map[string]entity.HealthCheck{
+			entity.HealthCheckDatabase:   s.checkDatabase(ctx),
+			entity.HealthCheckMigrations: s.checkMigrations(ctx),
+		},
+	}
+
+	for name, check := range readiness.Checks {
+		if check.Status == entity.HealthStatusDown {
+			log.Warnf("readiness check %s failed: %s", name, check.Message)
+			readiness.Status = entity.HealthStatusDown
+		}
+	}
+
+	return readiness
+}
+
+func (s *HealthService) checkDatabase(ctx context.Context) entity.HealthCheck {
+	if err := s.schemaRepo.Ping(ctx); err != nil {
+		return entity.HealthCheck{Status: entity.HealthStatusDown, Message: err.Error()}
+	}
+
+	return entity.HealthCheck{Status: entity.HealthStatusUp}
+}
+
+// checkMigrations fails while migrations are pending or a migration stopped halfway. A schema newer than
+// the binary is fine: that is an old replica during a rolling deploy.
+func (s *HealthService) checkMigrations(ctx context.Context) entity.HealthCheck {
+	schema, err := s.schemaRepo.GetSchemaVersion(ctx)
+	if errors.Is(err, sql.ErrNoRows) {
+		return entity.HealthCheck{Status: entity.HealthStatusUnknown, Message: "schema is not managed by migrate"}
+	}
+	if err != nil {
+		return entity.HealthCheck{Status: entity.HealthStatusDown, Message: err.Error()}
+	}
+
+	if schema.Dirty {
+		return entity.HealthCheck{
+			Status:  entity.HealthStatusDown,
+			Message: fmt.Sprintf("schema version %d is dirty", schema.Version),
+		}
+	}
+
+	if schema.Version < s.latestSchemaVersion {
+		return entity.HealthCheck{
+			Status:  entity.HealthStatusDown,
+			Message: fmt.Sprintf("schema version %d is behind %d", schema.Version, s.latestSchemaVersion),
+		}
+	}
+
+	return entity.HealthCheck{Status: entity.HealthStatusUp, Message: fmt.Sprintf("version %d", schema.Version)}
+}
@@ -0,0 +1,108 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/senyabanana/pvz-service/internal/entity"
+	mocks "github.com/senyabanana/pvz-service/internal/repository/mocks"
+)
+
+func TestHealthService_CheckReadiness(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockSchemaRepository(ctrl)
+	mockLog := logrus.New()
+
+	svc := NewHealthService(mockRepo, 17, time.Second, mockLog)
+
+	dbErr := errors.New("connection refused")
+
+	tests := []struct {
+		name           string
+		setup          func()
+		wantStatus     entity.HealthStatus
+		wantDatabase   entity.HealthStatus
+		wantMigrations entity.HealthStatus
+	}{
+		{
+			name: "ready",
+			setup: func() {
+				mockRepo.EXPECT().Ping(gomock.Any()).Return(nil)
+				mockRepo.EXPECT().GetSchemaVersion(gomock.Any()).Return(&entity.SchemaVersion{Version: 17}, nil)
+			},
+			wantStatus:     entity.HealthStatusUp,
+			wantDatabase:   entity.HealthStatusUp,
+			wantMigrations: entity.HealthStatusUp,
+		},
+		{
+			name: "schema ahead of binary",
+			setup: func() {
+				mockRepo.EXPECT().Ping(gomock.Any()).Return(nil)
+				mockRepo.EXPECT().GetSchemaVersion(gomock.Any()).Return(&entity.SchemaVersion{Version: 18}, nil)
+			},
+			wantStatus:     entity.HealthStatusUp,
+			wantDatabase:   entity.HealthStatusUp,
+			wantMigrations: entity.HealthStatusUp,
+		},
+		{
+			name: "schema not managed by migrate",
+			setup: func() {
+				mockRepo.EXPECT().Ping(gomock.Any()).Return(nil)
+				mockRepo.EXPECT().GetSchemaVersion(gomock.Any()).Return(nil, sql.ErrNoRows)
+			},
+			wantStatus:     entity.HealthStatusUp,
+			wantDatabase:   entity.HealthStatusUp,
+			wantMigrations: entity.HealthStatusUnknown,
+		},
+		{
+			name: "pending migrations",
+			setup: func() {
+				mockRepo.EXPECT().Ping(gomock.Any()).Return(nil)
+				mockRepo.EXPECT().GetSchemaVersion(gomock.Any()).Return(&entity.SchemaVersion{Version: 16}, nil)
+			},
+			wantStatus:     entity.HealthStatusDown,
+			wantDatabase:   entity.HealthStatusUp,
+			wantMigrations: entity.HealthStatusDown,
+		},
+		{
+			name: "dirty schema",
+			setup: func() {
+				mockRepo.EXPECT().Ping(gomock.Any()).Return(nil)
+				mockRepo.EXPECT().GetSchemaVersion(gomock.Any()).Return(&entity.SchemaVersion{Version: 17, Dirty: true}, nil)
+			},
+			wantStatus:     entity.HealthStatusDown,
+			wantDatabase:   entity.HealthStatusUp,
+			wantMigrations: entity.HealthStatusDown,
+		},
+		{
+			name: "database unreachable",
+			setup: func() {
+				mockRepo.EXPECT().Ping(gomock.Any()).Return(dbErr)
+				mockRepo.EXPECT().GetSchemaVersion(gomock.Any()).Return(nil, dbErr)
+			},
+			wantStatus:     entity.HealthStatusDown,
+			wantDatabase:   entity.HealthStatusDown,
+			wantMigrations: entity.HealthStatusDown,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+
+			readiness := svc.CheckReadiness(context.Background())
+			assert.Equal(t, tt.wantStatus, readiness.Status)
+			assert.Equal(t, tt.wantDatabase, readiness.Checks[entity.HealthCheckDatabase].Status)
+			assert.Equal(t, tt.wantMigrations, readiness.Checks[entity.HealthCheckMigrations].Status)
+		})
+	}
+}
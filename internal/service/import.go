@@ -0,0 +1,300 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/avito-tech/go-transaction-manager/trm/v2/manager"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+
+	"github.com/senyabanana/pvz-service/internal/entity"
+	"github.com/senyabanana/pvz-service/internal/infrastructure/importer"
+	"github.com/senyabanana/pvz-service/internal/infrastructure/logger"
+	"github.com/senyabanana/pvz-service/internal/repository"
+)
+
+type ImportService struct {
+	pvzRepo       repository.PVZRepository
+	receptionRepo repository.ReceptionRepository
+	productRepo   repository.ProductRepository
+	trManager     *manager.Manager
+	log           *logrus.Logger
+}
+
+func NewImportService(
+	pvzRepo repository.PVZRepository,
+	receptionRepo repository.ReceptionRepository,
+	productRepo repository.ProductRepository,
+	trManager *manager.Manager,
+	log *logrus.Logger,
+) *ImportService {
+	return &ImportService{
+		pvzRepo:       pvzRepo,
+		receptionRepo: receptionRepo,
+		productRepo:   productRepo,
+		trManager:     trManager,
+		log:           log,
+	}
+}
+
+// ImportData validates an import file and, unless dryRun is set, creates its PVZs, receptions and
+// products in one transaction. A file with any invalid row is rejected as a whole with
+// ErrImportRejected; the returned report lists every row error either way.
+func (s *ImportService) ImportData(
+	ctx context.Context, format entity.ImportFormat, r io.Reader, dryRun bool,
+) (*entity.ImportReport, error) {
+	ctx, span := tracer.Start(ctx, "ImportService.ImportData")
+	defer span.End()
+	log := logger.FromContext(ctx, s.log)
+
+	batch, rowErrs, err := importer.Parse(format, r)
+	if err != nil {
+		log.Warnf("failed to parse import file: %v", err)
+		return nil, err
+	}
+
+	now := time.Now()
+	rowErrs = append(rowErrs, validateImport(batch, now)...)
+	sort.SliceStable(rowErrs, func(i, j int) bool { return rowErrs[i].Row < rowErrs[j].Row })
+
+	report := countImport(batch)
+	report.DryRun = dryRun
+	report.Errors = rowErrs
+
+	if len(rowErrs) > 0 {
+		log.Warnf("import file rejected: %d row errors", len(rowErrs))
+		if dryRun {
+			return report, nil
+		}
+		return report, entity.ErrImportRejected
+	}
+	if dryRun {
+		return report, nil
+	}
+
+	err = s.trManager.Do(ctx, func(ctx context.Context) error {
+		for i := range batch.PVZ {
+			if err := s.importPVZ(ctx, &batch.PVZ[i], now); err != nil {
+				return fmt.Errorf("pvz %q: %w", batch.PVZ[i].Ref, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		log.Errorf("failed to import data: %v", err)
+		return nil, err
+	}
+
+	log.Infof("data imported: pvz=%d, receptions=%d, products=%d", report.PVZ, report.Receptions, report.Products)
+	return report, nil
+}
+
+func (s *ImportService) importPVZ(ctx context.Context, item *entity.ImportPVZ, now time.Time) error {
+	pvz := &item.PVZ
+	pvz.Status = entity.PVZStatusActive
+	if pvz.RegistrationDate.IsZero() {
+		pvz.RegistrationDate = now
+	}
+
+	if err := s.pvzRepo.CreatePVZ(ctx, pvz); err != nil {
+		return err
+	}
+
+	if pvz.Address != "" || pvz.Phone != "" || pvz.Latitude != nil || pvz.WorkingHours != nil {
+		if err := s.pvzRepo.UpdatePVZDetails(ctx, pvz); err != nil {
+			return err
+		}
+	}
+
+	for _, item := range item.Receptions {
+		if err := s.importReception(ctx, pvz.ID, item); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *ImportService) importReception(ctx context.Context, pvzID uuid.UUID, item entity.ImportReception) error {
+	reception := &entity.Reception{
+		DateTime:  item.DateTime,
+		PVZID:     pvzID,
+		Status:    entity.StatusInProgress,
+		CreatedAt: item.DateTime,
+	}
+	if err := s.receptionRepo.CreateReception(ctx, reception); err != nil {
+		return err
+	}
+
+	// Only the products of the open reception are still in the PVZ; the rest were handed out long ago
+	// and must not count towards capacity, storage periods or stocktakes.
+	status, issuedAt := entity.ProductStatusReceived, (*time.Time)(nil)
+	if item.ClosedAt != nil {
+		status, issuedAt = entity.ProductStatusIssued, item.ClosedAt
+	}
+
+	var products []entity.Product
+	for _, productType := range sortedProductTypes(item.Products) {
+		for i := 0; i < item.Products[productType]; i++ {
+			products = append(products, entity.Product{
+				DateTime:    item.DateTime,
+				Type:        productType,
+				ReceptionID: reception.ID,
+				Status:      status,
+				IssuedAt:    issuedAt,
+			})
+		}
+	}
+	if err := s.importProducts(ctx, pvzID, products, item.DateTime); err != nil {
+		return err
+	}
+
+	if item.ClosedAt != nil {
+		return s.receptionRepo.CloseReceptionByID(ctx, reception.ID, *item.ClosedAt)
+	}
+
+	return nil
+}
+
+// importProducts inserts products in one batch and writes their history: received at receivedAt
+// and, for issued ones, issued at IssuedAt.
+func (s *ImportService) importProducts(
+	ctx context.Context, pvzID uuid.UUID, products []entity.Product, receivedAt time.Time,
+) error {
+	if len(products) == 0 {
+		return nil
+	}
+	if err := s.productRepo.CreateProducts(ctx, products); err != nil {
+		return err
+	}
+
+	changes := make([]entity.ProductStatusChange, 0, 2*len(products))
+	for _, product := range products {
+		changes = append(changes, entity.ProductStatusChange{
+			ProductID: product.ID,
+			To:        entity.ProductStatusReceived,
+			PVZID:     &pvzID,
+			ChangedAt: receivedAt,
+			Comment:   "imported",
+		})
+		if product.IssuedAt != nil {
+			from := entity.ProductStatusReceived
+			changes = append(changes, entity.ProductStatusChange{
+				ProductID: product.ID,
+				From:      &from,
+				To:        entity.ProductStatusIssued,
+				PVZID:     &pvzID,
+				ChangedAt: *product.IssuedAt,
+				Comment:   "imported",
+			})
+		}
+	}
+	return s.productRepo.AddProductStatusChanges(ctx, changes)
+}
+
+// validateImport checks what the parser cannot: references to the domain and the order of receptions.
+// Receptions of each PVZ are sorted by date; they must not overlap and only the latest may stay open.
+func validateImport(batch *entity.ImportBatch, now time.Time) []entity.ImportRowError {
+	var rowErrs []entity.ImportRowError
+	fail := func(row int, field, message string) {
+		rowErrs = append(rowErrs, entity.ImportRowError{Row: row, Field: field, Message: message})
+	}
+
+	fileProducts := 0
+	seenRefs := make(map[string]int, len(batch.PVZ))
+	for i := range batch.PVZ {
+		item := &batch.PVZ[i]
+
+		if firstRow, ok := seenRefs[item.Ref]; ok && item.Ref != "" {
+			fail(item.Row, "pvz_ref", fmt.Sprintf("duplicates the pvz in row %d", firstRow))
+		}
+		seenRefs[item.Ref] = item.Row
+
+		if !entity.IsValidCity(string(item.PVZ.City)) {
+			fail(item.Row, "city", entity.ErrInvalidCity.Error())
+		}
+		if err := item.PVZ.ValidateDetails(); err != nil {
+			fail(item.Row, "", err.Error())
+		}
+		if item.PVZ.RegistrationDate.After(now) {
+			fail(item.Row, "registration_date", "is in the future")
+		}
+
+		sort.SliceStable(item.Receptions, func(a, b int) bool {
+			return item.Receptions[a].DateTime.Before(item.Receptions[b].DateTime)
+		})
+
+		for j, reception := range item.Receptions {
+			if reception.DateTime.After(now) {
+				fail(reception.Row, "reception_date", "is in the future")
+			}
+			if !item.PVZ.RegistrationDate.IsZero() && reception.DateTime.Before(item.PVZ.RegistrationDate) {
+				fail(reception.Row, "reception_date", "is before the pvz registration date")
+			}
+
+			last := j == len(item.Receptions)-1
+			switch {
+			case reception.ClosedAt == nil && !last:
+				fail(reception.Row, "closed_at", "only the latest reception of a pvz may stay open")
+			case reception.ClosedAt != nil && reception.ClosedAt.Before(reception.DateTime):
+				fail(reception.Row, "closed_at", "is before the reception date")
+			case reception.ClosedAt != nil && reception.ClosedAt.After(now):
+				fail(reception.Row, "closed_at", "is in the future")
+			case reception.ClosedAt != nil && !last && reception.ClosedAt.After(item.Receptions[j+1].DateTime):
+				fail(reception.Row, "closed_at", "overlaps the next reception")
+			}
+
+			total := 0
+			for productType, count := range reception.Products {
+				if !entity.IsValidProductType(productType) {
+					fail(reception.Row, "products", fmt.Sprintf("unknown product type %q", productType))
+				}
+				if count <= 0 {
+					fail(reception.Row, "products", fmt.Sprintf("count of %q must be positive", productType))
+				}
+				total += count
+			}
+			if total > entity.MaxImportedProducts {
+				fail(reception.Row, "products", fmt.Sprintf("more than %d products", entity.MaxImportedProducts))
+			}
+
+			// Reported once, at the reception that crosses the limit.
+			if fileProducts <= entity.MaxImportFileProducts && fileProducts+total > entity.MaxImportFileProducts {
+				fail(reception.Row, "products", fmt.Sprintf("the file has more than %d products", entity.MaxImportFileProducts))
+			}
+			fileProducts += total
+		}
+	}
+
+	return rowErrs
+}
+
+func countImport(batch *entity.ImportBatch) *entity.ImportReport {
+	report := &entity.ImportReport{PVZ: len(batch.PVZ)}
+	for _, item := range batch.PVZ {
+		report.Receptions += len(item.Receptions)
+		for _, reception := range item.Receptions {
+			for _, count := range reception.Products {
+				if count > 0 {
+					report.Products += count
+				}
+			}
+		}
+	}
+
+	return report
+}
+
+func sortedProductTypes(products map[entity.ProductType]int) []entity.ProductType {
+	types := make([]entity.ProductType, 0, len(products))
+	for productType := range products {
+		types = append(types, productType)
+	}
+	sort.Slice(types, func(i, j int) bool { return types[i] < types[j] })
+
+	return types
+}
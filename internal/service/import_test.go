@@ -0,0 +1,182 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	trmsqlx "github.com/avito-tech/go-transaction-manager/drivers/sqlx/v2"
+	"github.com/avito-tech/go-transaction-manager/trm/v2/manager"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/senyabanana/pvz-service/internal/entity"
+	mocks "github.com/senyabanana/pvz-service/internal/repository/mocks"
+)
+
+const importCSV = `pvz_ref,city,registration_date,address,reception_date,closed_at,products
+A1,Москва,2024-01-10T09:00:00Z,"ул. Ленина, 1",2024-02-01T10:00:00Z,2024-02-01T18:00:00Z,"электроника=2,обувь=1"
+A1,,,,2024-03-01T10:00:00Z,,одежда=1
+B2,Казань,2024-01-15T09:00:00Z,,,,
+`
+
+// importFileWithProducts returns a JSON file with one PVZ and the given number of closed daily receptions.
+func importFileWithProducts(receptions, productsEach int) string {
+	items := make([]string, 0, receptions)
+	for i := 0; i < receptions; i++ {
+		day := time.Date(2024, 2, 1+i, 10, 0, 0, 0, time.UTC)
+		items = append(items, fmt.Sprintf(`{"dateTime":%q,"closedAt":%q,"products":{"одежда":%d}}`,
+			day.Format(time.RFC3339), day.Add(8*time.Hour).Format(time.RFC3339), productsEach))
+	}
+
+	return `{"pvz":[{"ref":"A1","city":"Москва","registrationDate":"2024-01-10T09:00:00Z","receptions":[` +
+		strings.Join(items, ",") + `]}]}`
+}
+
+func TestImportService_ImportData(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPVZRepo := mocks.NewMockPVZRepository(ctrl)
+	mockReceptionRepo := mocks.NewMockReceptionRepository(ctrl)
+	mockProductRepo := mocks.NewMockProductRepository(ctrl)
+	db, mock, _ := sqlmock.New()
+	mockDB := sqlx.NewDb(db, testDriverName)
+	trManager := manager.Must(trmsqlx.NewDefaultFactory(mockDB))
+	mockLog := logrus.New()
+
+	svc := NewImportService(mockPVZRepo, mockReceptionRepo, mockProductRepo, trManager, mockLog)
+
+	dbErr := errors.New("db error")
+	setID := func(_ context.Context, pvz *entity.PVZ) error {
+		pvz.ID = uuid.New()
+		return nil
+	}
+
+	tests := []struct {
+		name       string
+		format     entity.ImportFormat
+		file       string
+		dryRun     bool
+		setup      func()
+		wantReport entity.ImportReport
+		wantErrors int
+		wantErr    error
+	}{
+		{
+			name:   "csv import",
+			format: entity.ImportFormatCSV,
+			file:   importCSV,
+			setup: func() {
+				mock.ExpectBegin()
+				mockPVZRepo.EXPECT().CreatePVZ(gomock.Any(), gomock.Any()).DoAndReturn(setID)
+				mockPVZRepo.EXPECT().UpdatePVZDetails(gomock.Any(), gomock.Any()).Return(nil)
+				mockReceptionRepo.EXPECT().CreateReception(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+				// The closed reception is history: its products are imported as issued.
+				mockProductRepo.EXPECT().CreateProducts(gomock.Any(), gomock.Len(3)).
+					DoAndReturn(func(_ context.Context, products []entity.Product) error {
+						for _, product := range products {
+							assert.Equal(t, entity.ProductStatusIssued, product.Status)
+							assert.NotNil(t, product.IssuedAt)
+						}
+						return nil
+					})
+				mockProductRepo.EXPECT().AddProductStatusChanges(gomock.Any(), gomock.Len(6)).Return(nil)
+				mockReceptionRepo.EXPECT().CloseReceptionByID(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				mockProductRepo.EXPECT().CreateProducts(gomock.Any(), gomock.Len(1)).
+					DoAndReturn(func(_ context.Context, products []entity.Product) error {
+						assert.Equal(t, entity.ProductStatusReceived, products[0].Status)
+						assert.Nil(t, products[0].IssuedAt)
+						return nil
+					})
+				mockProductRepo.EXPECT().AddProductStatusChanges(gomock.Any(), gomock.Len(1)).Return(nil)
+				mockPVZRepo.EXPECT().CreatePVZ(gomock.Any(), gomock.Any()).DoAndReturn(setID)
+				mock.ExpectCommit()
+			},
+			wantReport: entity.ImportReport{PVZ: 2, Receptions: 2, Products: 4},
+		},
+		{
+			name:       "dry run writes nothing",
+			format:     entity.ImportFormatCSV,
+			file:       importCSV,
+			dryRun:     true,
+			setup:      func() {},
+			wantReport: entity.ImportReport{DryRun: true, PVZ: 2, Receptions: 2, Products: 4},
+		},
+		{
+			name:   "dry run reports every invalid row",
+			format: entity.ImportFormatCSV,
+			file: `pvz_ref,city,reception_date,closed_at,products
+A1,Тверь,2024-02-01T10:00:00Z,,мебель=1
+A1,,2024-03-01T10:00:00Z,2024-02-01T10:00:00Z,
+,Москва,,,
+`,
+			dryRun:     true,
+			setup:      func() {},
+			wantReport: entity.ImportReport{DryRun: true, PVZ: 1, Receptions: 2, Products: 1},
+			wantErrors: 5,
+		},
+		{
+			name:   "invalid rows reject the whole file",
+			format: entity.ImportFormatJSON,
+			file: `{"pvz":[{"ref":"A1","city":"Москва"},{"ref":"A1","city":"Казань",
+				"receptions":[{"dateTime":"2024-02-01T10:00:00Z","products":{"одежда":0}}]}]}`,
+			setup:      func() {},
+			wantReport: entity.ImportReport{PVZ: 2, Receptions: 1},
+			wantErrors: 2,
+			wantErr:    entity.ErrImportRejected,
+		},
+		{
+			name:       "too many products in the file",
+			format:     entity.ImportFormatJSON,
+			file:       importFileWithProducts(11, entity.MaxImportedProducts),
+			dryRun:     true,
+			setup:      func() {},
+			wantReport: entity.ImportReport{DryRun: true, PVZ: 1, Receptions: 11, Products: 11 * entity.MaxImportedProducts},
+			wantErrors: 1,
+		},
+		{
+			name:    "malformed file",
+			format:  entity.ImportFormatCSV,
+			file:    "ref;city\n",
+			setup:   func() {},
+			wantErr: entity.ErrInvalidImportFile,
+		},
+		{
+			name:   "repository error rolls back",
+			format: entity.ImportFormatJSON,
+			file:   `{"pvz":[{"ref":"A1","city":"Москва"}]}`,
+			setup: func() {
+				mock.ExpectBegin()
+				mockPVZRepo.EXPECT().CreatePVZ(gomock.Any(), gomock.Any()).Return(dbErr)
+				mock.ExpectRollback()
+			},
+			wantErr: dbErr,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			report, err := svc.ImportData(context.Background(), tt.format, strings.NewReader(tt.file), tt.dryRun)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			if report != nil {
+				assert.Len(t, report.Errors, tt.wantErrors)
+				report.Errors = nil
+				assert.Equal(t, tt.wantReport, *report)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
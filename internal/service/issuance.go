@@ -0,0 +1,273 @@
+package service
+
+import (
+	"context"
+	"crypto/subtle"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/avito-tech/go-transaction-manager/trm/v2/manager"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+
+	"github.com/senyabanana/pvz-service/internal/entity"
+	"github.com/senyabanana/pvz-service/internal/infrastructure/logger"
+	"github.com/senyabanana/pvz-service/internal/infrastructure/security"
+	"github.com/senyabanana/pvz-service/internal/repository"
+)
+
+type IssuanceService struct {
+	productRepo   repository.ProductRepository
+	receptionRepo repository.ReceptionRepository
+	userRepo      repository.UserRepository
+	trManager     *manager.Manager
+	log           *logrus.Logger
+}
+
+func NewIssuanceService(
+	productRepo repository.ProductRepository,
+	receptionRepo repository.ReceptionRepository,
+	userRepo repository.UserRepository,
+	trManager *manager.Manager,
+	log *logrus.Logger,
+) *IssuanceService {
+	return &IssuanceService{
+		productRepo:   productRepo,
+		receptionRepo: receptionRepo,
+		userRepo:      userRepo,
+		trManager:     trManager,
+		log:           log,
+	}
+}
+
+// AssignProductOwner binds a received product to a client and returns a freshly generated pickup code.
+// Reassigning an owner rotates the code, so a previously issued code stops working.
+func (s *IssuanceService) AssignProductOwner(ctx context.Context, pvzID, productID, ownerID uuid.UUID) (string, error) {
+	ctx, span := tracer.Start(ctx, "IssuanceService.AssignProductOwner")
+	defer span.End()
+	log := logger.FromContext(ctx, s.log)
+
+	var code string
+
+	err := s.trManager.Do(ctx, func(ctx context.Context) error {
+		product, _, err := s.getPVZProduct(ctx, pvzID, productID)
+		if err != nil {
+			return err
+		}
+
+		if err := checkIssuable(product); err != nil {
+			return err
+		}
+
+		owner, err := s.userRepo.GetUserByID(ctx, ownerID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return entity.ErrUserNotFound
+			}
+			return err
+		}
+
+		if owner.Role != entity.RoleClient {
+			return entity.ErrOwnerNotClient
+		}
+
+		code, err = s.rotatePickupCode(ctx, productID, ownerID)
+		return err
+	})
+	if err != nil {
+		log.Warnf("failed to assign owner %s to product %s: %v", ownerID, productID, err)
+		return "", err
+	}
+
+	log.Infof("product owner assigned: product=%s, owner=%s", productID, ownerID)
+	return code, nil
+}
+
+// RenewPickupCode gives the owner a new pickup code, for example after the old one was locked.
+// Products of other clients are reported as not found.
+func (s *IssuanceService) RenewPickupCode(ctx context.Context, ownerID, productID uuid.UUID) (string, error) {
+	ctx, span := tracer.Start(ctx, "IssuanceService.RenewPickupCode")
+	defer span.End()
+	log := logger.FromContext(ctx, s.log)
+
+	var code string
+
+	err := s.trManager.Do(ctx, func(ctx context.Context) error {
+		product, err := s.productRepo.GetProductByID(ctx, productID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return entity.ErrProductNotFound
+			}
+			return err
+		}
+
+		if product.OwnerID == nil || *product.OwnerID != ownerID {
+			return entity.ErrProductNotFound
+		}
+
+		if err := checkIssuable(product); err != nil {
+			return err
+		}
+
+		code, err = s.rotatePickupCode(ctx, productID, ownerID)
+		return err
+	})
+	if err != nil {
+		log.Warnf("failed to renew pickup code of product %s: %v", productID, err)
+		return "", err
+	}
+
+	log.Infof("pickup code renewed: product=%s, owner=%s", productID, ownerID)
+	return code, nil
+}
+
+// rotatePickupCode stores the hash of a new code, which also clears failed attempts, and returns the code.
+func (s *IssuanceService) rotatePickupCode(ctx context.Context, productID, ownerID uuid.UUID) (string, error) {
+	code, err := security.GenerateNumericCode(entity.PickupCodeLength)
+	if err != nil {
+		return "", err
+	}
+
+	if err := s.productRepo.AssignProductOwner(ctx, productID, ownerID, security.HashPickupCode(productID, code)); err != nil {
+		return "", err
+	}
+
+	return code, nil
+}
+
+// IssueProduct hands a product over to its owner once the employee has checked the pickup code.
+// issuedBy is nil when the request is made with an API key rather than an employee account.
+// Wrong codes are counted; after entity.MaxPickupCodeAttempts the code is locked until a new one is issued.
+func (s *IssuanceService) IssueProduct(
+	ctx context.Context, pvzID, productID uuid.UUID, pickupCode string, issuedBy *uuid.UUID,
+) (*entity.Product, error) {
+	ctx, span := tracer.Start(ctx, "IssuanceService.IssueProduct")
+	defer span.End()
+	log := logger.FromContext(ctx, s.log)
+
+	var result *entity.Product
+	var codeErr error
+
+	err := s.trManager.Do(ctx, func(ctx context.Context) error {
+		product, reception, err := s.getPVZProduct(ctx, pvzID, productID)
+		if err != nil {
+			return err
+		}
+
+		if err := checkIssuable(product); err != nil {
+			return err
+		}
+
+		if err := checkProductTransition(product.Status, entity.ProductStatusIssued); err != nil {
+			return err
+		}
+
+		if reception.Status != entity.StatusClosed {
+			return entity.ErrProductNotReady
+		}
+
+		if product.OwnerID == nil || product.PickupCodeHash == nil {
+			return entity.ErrProductHasNoOwner
+		}
+
+		if product.PickupCodeAttempts >= entity.MaxPickupCodeAttempts {
+			return entity.ErrPickupCodeLocked
+		}
+
+		codeHash := security.HashPickupCode(productID, pickupCode)
+		if subtle.ConstantTimeCompare([]byte(*product.PickupCodeHash), []byte(codeHash)) != 1 {
+			attempts, err := s.productRepo.AddPickupCodeAttempt(ctx, productID)
+			if err != nil {
+				return err
+			}
+
+			codeErr = entity.ErrInvalidPickupCode
+			if attempts >= entity.MaxPickupCodeAttempts {
+				codeErr = entity.ErrPickupCodeLocked
+			}
+
+			// The failed attempt has to be committed, so the transaction ends without an error.
+			return nil
+		}
+
+		issuedAt := time.Now()
+		if err := s.productRepo.MarkProductIssued(ctx, productID, issuedAt, issuedBy); err != nil {
+			return err
+		}
+
+		changes := statusChanges([]uuid.UUID{productID}, product.Status, entity.ProductStatusIssued, pvzID, issuedBy, issuedAt, "")
+		if err := s.productRepo.AddProductStatusChanges(ctx, changes); err != nil {
+			return err
+		}
+
+		product.Status = entity.ProductStatusIssued
+		product.IssuedAt = &issuedAt
+		product.IssuedBy = issuedBy
+		result = product
+		return nil
+	})
+	if err == nil {
+		err = codeErr
+	}
+	if err != nil {
+		log.Warnf("failed to issue product %s: %v", productID, err)
+		return nil, err
+	}
+
+	log.Infof("product issued: product=%s, owner=%s", result.ID, result.OwnerID)
+	return result, nil
+}
+
+func (s *IssuanceService) GetMyParcels(ctx context.Context, ownerID uuid.UUID) ([]entity.Parcel, error) {
+	ctx, span := tracer.Start(ctx, "IssuanceService.GetMyParcels")
+	defer span.End()
+	log := logger.FromContext(ctx, s.log)
+
+	parcels, err := s.productRepo.GetParcelsByOwner(ctx, ownerID)
+	if err != nil {
+		log.Errorf("failed to get parcels for user %s: %v", ownerID, err)
+		return nil, err
+	}
+
+	return parcels, nil
+}
+
+// checkIssuable reports why a product can no longer be handed to a client, if it can't.
+func checkIssuable(product *entity.Product) error {
+	switch product.Status {
+	case entity.ProductStatusIssued, entity.ProductStatusReturned:
+		return entity.ErrProductAlreadyIssued
+	case entity.ProductStatusReturnToSender, entity.ProductStatusShippedToSender:
+		return entity.ErrProductOverdue
+	case entity.ProductStatusInTransit:
+		return entity.ErrProductInTransit
+	default:
+		return nil
+	}
+}
+
+// getPVZProduct locks the product and makes sure it was received at the given PVZ;
+// a product from another PVZ is reported as not found.
+func (s *IssuanceService) getPVZProduct(
+	ctx context.Context, pvzID, productID uuid.UUID,
+) (*entity.Product, *entity.Reception, error) {
+	product, err := s.productRepo.GetProductByID(ctx, productID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil, entity.ErrProductNotFound
+		}
+		return nil, nil, err
+	}
+
+	reception, err := s.receptionRepo.GetReceptionByID(ctx, product.ReceptionID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if reception.PVZID != pvzID {
+		return nil, nil, entity.ErrProductNotFound
+	}
+
+	return product, reception, nil
+}
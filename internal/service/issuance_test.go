@@ -0,0 +1,403 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	trmsqlx "github.com/avito-tech/go-transaction-manager/drivers/sqlx/v2"
+	"github.com/avito-tech/go-transaction-manager/trm/v2/manager"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/senyabanana/pvz-service/internal/entity"
+	"github.com/senyabanana/pvz-service/internal/infrastructure/security"
+	mocks "github.com/senyabanana/pvz-service/internal/repository/mocks"
+)
+
+func TestIssuanceService_AssignProductOwner(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockProductRepo := mocks.NewMockProductRepository(ctrl)
+	mockReceptionRepo := mocks.NewMockReceptionRepository(ctrl)
+	mockUserRepo := mocks.NewMockUserRepository(ctrl)
+	db, mock, _ := sqlmock.New()
+	mockDB := sqlx.NewDb(db, testDriverName)
+	trManager := manager.Must(trmsqlx.NewDefaultFactory(mockDB))
+	mockLog := logrus.New()
+
+	svc := NewIssuanceService(mockProductRepo, mockReceptionRepo, mockUserRepo, trManager, mockLog)
+
+	pvzID, productID, ownerID, receptionID := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	product := func(status entity.ProductStatus) *entity.Product {
+		return &entity.Product{ID: productID, ReceptionID: receptionID, Status: status}
+	}
+	reception := &entity.Reception{ID: receptionID, PVZID: pvzID, Status: entity.StatusInProgress}
+	var storedHash string
+
+	tests := []struct {
+		name    string
+		setup   func()
+		wantErr error
+	}{
+		{
+			name: "success",
+			setup: func() {
+				mock.ExpectBegin()
+				mockProductRepo.EXPECT().GetProductByID(gomock.Any(), productID).Return(product(entity.ProductStatusReceived), nil)
+				mockReceptionRepo.EXPECT().GetReceptionByID(gomock.Any(), receptionID).Return(reception, nil)
+				mockUserRepo.EXPECT().GetUserByID(gomock.Any(), ownerID).Return(&entity.User{ID: ownerID, Role: entity.RoleClient}, nil)
+				mockProductRepo.EXPECT().AssignProductOwner(gomock.Any(), productID, ownerID, gomock.Any()).
+					DoAndReturn(func(_ context.Context, _, _ uuid.UUID, codeHash string) error {
+						storedHash = codeHash
+						return nil
+					})
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "product not found",
+			setup: func() {
+				mock.ExpectBegin()
+				mockProductRepo.EXPECT().GetProductByID(gomock.Any(), productID).Return(nil, sql.ErrNoRows)
+				mock.ExpectRollback()
+			},
+			wantErr: entity.ErrProductNotFound,
+		},
+		{
+			name: "product from another pvz",
+			setup: func() {
+				mock.ExpectBegin()
+				mockProductRepo.EXPECT().GetProductByID(gomock.Any(), productID).Return(product(entity.ProductStatusReceived), nil)
+				mockReceptionRepo.EXPECT().GetReceptionByID(gomock.Any(), receptionID).
+					Return(&entity.Reception{ID: receptionID, PVZID: uuid.New()}, nil)
+				mock.ExpectRollback()
+			},
+			wantErr: entity.ErrProductNotFound,
+		},
+		{
+			name: "already issued",
+			setup: func() {
+				mock.ExpectBegin()
+				mockProductRepo.EXPECT().GetProductByID(gomock.Any(), productID).Return(product(entity.ProductStatusIssued), nil)
+				mockReceptionRepo.EXPECT().GetReceptionByID(gomock.Any(), receptionID).Return(reception, nil)
+				mock.ExpectRollback()
+			},
+			wantErr: entity.ErrProductAlreadyIssued,
+		},
+		{
+			name: "in transit",
+			setup: func() {
+				mock.ExpectBegin()
+				mockProductRepo.EXPECT().GetProductByID(gomock.Any(), productID).Return(product(entity.ProductStatusInTransit), nil)
+				mockReceptionRepo.EXPECT().GetReceptionByID(gomock.Any(), receptionID).Return(reception, nil)
+				mock.ExpectRollback()
+			},
+			wantErr: entity.ErrProductInTransit,
+		},
+		{
+			name: "owner not found",
+			setup: func() {
+				mock.ExpectBegin()
+				mockProductRepo.EXPECT().GetProductByID(gomock.Any(), productID).Return(product(entity.ProductStatusReceived), nil)
+				mockReceptionRepo.EXPECT().GetReceptionByID(gomock.Any(), receptionID).Return(reception, nil)
+				mockUserRepo.EXPECT().GetUserByID(gomock.Any(), ownerID).Return(nil, sql.ErrNoRows)
+				mock.ExpectRollback()
+			},
+			wantErr: entity.ErrUserNotFound,
+		},
+		{
+			name: "owner is not a client",
+			setup: func() {
+				mock.ExpectBegin()
+				mockProductRepo.EXPECT().GetProductByID(gomock.Any(), productID).Return(product(entity.ProductStatusReceived), nil)
+				mockReceptionRepo.EXPECT().GetReceptionByID(gomock.Any(), receptionID).Return(reception, nil)
+				mockUserRepo.EXPECT().GetUserByID(gomock.Any(), ownerID).Return(&entity.User{ID: ownerID, Role: entity.RoleEmployee}, nil)
+				mock.ExpectRollback()
+			},
+			wantErr: entity.ErrOwnerNotClient,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storedHash = ""
+			tt.setup()
+			code, err := svc.AssignProductOwner(context.Background(), pvzID, productID, ownerID)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Empty(t, code)
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, code, entity.PickupCodeLength)
+				assert.Equal(t, security.HashPickupCode(productID, code), storedHash)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestIssuanceService_IssueProduct(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockProductRepo := mocks.NewMockProductRepository(ctrl)
+	mockReceptionRepo := mocks.NewMockReceptionRepository(ctrl)
+	mockUserRepo := mocks.NewMockUserRepository(ctrl)
+	db, mock, _ := sqlmock.New()
+	mockDB := sqlx.NewDb(db, testDriverName)
+	trManager := manager.Must(trmsqlx.NewDefaultFactory(mockDB))
+	mockLog := logrus.New()
+
+	svc := NewIssuanceService(mockProductRepo, mockReceptionRepo, mockUserRepo, trManager, mockLog)
+
+	pvzID, productID, ownerID, receptionID, employeeID := uuid.New(), uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	code := "123456"
+	codeHash := security.HashPickupCode(productID, code)
+	owned := func() *entity.Product {
+		return &entity.Product{
+			ID: productID, ReceptionID: receptionID, Status: entity.ProductStatusReceived, OwnerID: &ownerID, PickupCodeHash: &codeHash,
+		}
+	}
+	closed := &entity.Reception{ID: receptionID, PVZID: pvzID, Status: entity.StatusClosed}
+
+	tests := []struct {
+		name    string
+		code    string
+		setup   func()
+		wantErr error
+	}{
+		{
+			name: "success",
+			code: code,
+			setup: func() {
+				mock.ExpectBegin()
+				mockProductRepo.EXPECT().GetProductByID(gomock.Any(), productID).Return(owned(), nil)
+				mockReceptionRepo.EXPECT().GetReceptionByID(gomock.Any(), receptionID).Return(closed, nil)
+				mockProductRepo.EXPECT().MarkProductIssued(gomock.Any(), productID, gomock.Any(), &employeeID).Return(nil)
+				mockProductRepo.EXPECT().AddProductStatusChanges(gomock.Any(), gomock.Any()).Return(nil)
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "reception in progress",
+			code: code,
+			setup: func() {
+				mock.ExpectBegin()
+				mockProductRepo.EXPECT().GetProductByID(gomock.Any(), productID).Return(owned(), nil)
+				mockReceptionRepo.EXPECT().GetReceptionByID(gomock.Any(), receptionID).
+					Return(&entity.Reception{ID: receptionID, PVZID: pvzID, Status: entity.StatusInProgress}, nil)
+				mock.ExpectRollback()
+			},
+			wantErr: entity.ErrProductNotReady,
+		},
+		{
+			name: "no owner",
+			code: code,
+			setup: func() {
+				mock.ExpectBegin()
+				mockProductRepo.EXPECT().GetProductByID(gomock.Any(), productID).
+					Return(&entity.Product{ID: productID, ReceptionID: receptionID, Status: entity.ProductStatusReceived}, nil)
+				mockReceptionRepo.EXPECT().GetReceptionByID(gomock.Any(), receptionID).Return(closed, nil)
+				mock.ExpectRollback()
+			},
+			wantErr: entity.ErrProductHasNoOwner,
+		},
+		{
+			name: "storage period expired",
+			code: code,
+			setup: func() {
+				overdue := owned()
+				overdue.Status = entity.ProductStatusReturnToSender
+				mock.ExpectBegin()
+				mockProductRepo.EXPECT().GetProductByID(gomock.Any(), productID).Return(overdue, nil)
+				mockReceptionRepo.EXPECT().GetReceptionByID(gomock.Any(), receptionID).Return(closed, nil)
+				mock.ExpectRollback()
+			},
+			wantErr: entity.ErrProductOverdue,
+		},
+		{
+			name: "product in transit",
+			code: code,
+			setup: func() {
+				inTransit := owned()
+				inTransit.Status = entity.ProductStatusInTransit
+				mock.ExpectBegin()
+				mockProductRepo.EXPECT().GetProductByID(gomock.Any(), productID).Return(inTransit, nil)
+				mockReceptionRepo.EXPECT().GetReceptionByID(gomock.Any(), receptionID).Return(closed, nil)
+				mock.ExpectRollback()
+			},
+			wantErr: entity.ErrProductInTransit,
+		},
+		{
+			name: "wrong code",
+			code: "000000",
+			setup: func() {
+				mock.ExpectBegin()
+				mockProductRepo.EXPECT().GetProductByID(gomock.Any(), productID).Return(owned(), nil)
+				mockReceptionRepo.EXPECT().GetReceptionByID(gomock.Any(), receptionID).Return(closed, nil)
+				mockProductRepo.EXPECT().AddPickupCodeAttempt(gomock.Any(), productID).Return(1, nil)
+				mock.ExpectCommit()
+			},
+			wantErr: entity.ErrInvalidPickupCode,
+		},
+		{
+			name: "last wrong code locks",
+			code: "000000",
+			setup: func() {
+				mock.ExpectBegin()
+				mockProductRepo.EXPECT().GetProductByID(gomock.Any(), productID).Return(owned(), nil)
+				mockReceptionRepo.EXPECT().GetReceptionByID(gomock.Any(), receptionID).Return(closed, nil)
+				mockProductRepo.EXPECT().AddPickupCodeAttempt(gomock.Any(), productID).Return(entity.MaxPickupCodeAttempts, nil)
+				mock.ExpectCommit()
+			},
+			wantErr: entity.ErrPickupCodeLocked,
+		},
+		{
+			name: "locked code",
+			code: code,
+			setup: func() {
+				locked := owned()
+				locked.PickupCodeAttempts = entity.MaxPickupCodeAttempts
+				mock.ExpectBegin()
+				mockProductRepo.EXPECT().GetProductByID(gomock.Any(), productID).Return(locked, nil)
+				mockReceptionRepo.EXPECT().GetReceptionByID(gomock.Any(), receptionID).Return(closed, nil)
+				mock.ExpectRollback()
+			},
+			wantErr: entity.ErrPickupCodeLocked,
+		},
+		{
+			name: "concurrently issued",
+			code: code,
+			setup: func() {
+				mock.ExpectBegin()
+				mockProductRepo.EXPECT().GetProductByID(gomock.Any(), productID).Return(owned(), nil)
+				mockReceptionRepo.EXPECT().GetReceptionByID(gomock.Any(), receptionID).Return(closed, nil)
+				mockProductRepo.EXPECT().MarkProductIssued(gomock.Any(), productID, gomock.Any(), &employeeID).
+					Return(entity.ErrProductAlreadyIssued)
+				mock.ExpectRollback()
+			},
+			wantErr: entity.ErrProductAlreadyIssued,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			product, err := svc.IssueProduct(context.Background(), pvzID, productID, tt.code, &employeeID)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, product)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, entity.ProductStatusIssued, product.Status)
+				assert.NotNil(t, product.IssuedAt)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestIssuanceService_GetMyParcels(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockProductRepo := mocks.NewMockProductRepository(ctrl)
+	svc := NewIssuanceService(mockProductRepo, nil, nil, nil, logrus.New())
+
+	ownerID := uuid.New()
+
+	mockProductRepo.EXPECT().GetParcelsByOwner(gomock.Any(), ownerID).
+		Return([]entity.Parcel{{ProductID: uuid.New(), CodeAttempts: 1}}, nil)
+	parcels, err := svc.GetMyParcels(context.Background(), ownerID)
+	assert.NoError(t, err)
+	assert.Len(t, parcels, 1)
+
+	mockProductRepo.EXPECT().GetParcelsByOwner(gomock.Any(), ownerID).Return(nil, errors.New("db error"))
+	_, err = svc.GetMyParcels(context.Background(), ownerID)
+	assert.Error(t, err)
+}
+
+func TestIssuanceService_RenewPickupCode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockProductRepo := mocks.NewMockProductRepository(ctrl)
+	db, mock, _ := sqlmock.New()
+	mockDB := sqlx.NewDb(db, testDriverName)
+	trManager := manager.Must(trmsqlx.NewDefaultFactory(mockDB))
+
+	svc := NewIssuanceService(mockProductRepo, nil, nil, trManager, logrus.New())
+
+	productID, ownerID := uuid.New(), uuid.New()
+	owned := func(status entity.ProductStatus) *entity.Product {
+		return &entity.Product{ID: productID, Status: status, OwnerID: &ownerID, PickupCodeAttempts: entity.MaxPickupCodeAttempts}
+	}
+
+	tests := []struct {
+		name    string
+		setup   func()
+		wantErr error
+	}{
+		{
+			name: "success",
+			setup: func() {
+				mock.ExpectBegin()
+				mockProductRepo.EXPECT().GetProductByID(gomock.Any(), productID).Return(owned(entity.ProductStatusReceived), nil)
+				mockProductRepo.EXPECT().AssignProductOwner(gomock.Any(), productID, ownerID, gomock.Any()).Return(nil)
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "product not found",
+			setup: func() {
+				mock.ExpectBegin()
+				mockProductRepo.EXPECT().GetProductByID(gomock.Any(), productID).Return(nil, sql.ErrNoRows)
+				mock.ExpectRollback()
+			},
+			wantErr: entity.ErrProductNotFound,
+		},
+		{
+			name: "product of another client",
+			setup: func() {
+				other := owned(entity.ProductStatusReceived)
+				otherID := uuid.New()
+				other.OwnerID = &otherID
+				mock.ExpectBegin()
+				mockProductRepo.EXPECT().GetProductByID(gomock.Any(), productID).Return(other, nil)
+				mock.ExpectRollback()
+			},
+			wantErr: entity.ErrProductNotFound,
+		},
+		{
+			name: "already issued",
+			setup: func() {
+				mock.ExpectBegin()
+				mockProductRepo.EXPECT().GetProductByID(gomock.Any(), productID).Return(owned(entity.ProductStatusIssued), nil)
+				mock.ExpectRollback()
+			},
+			wantErr: entity.ErrProductAlreadyIssued,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			code, err := svc.RenewPickupCode(context.Background(), ownerID, productID)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Empty(t, code)
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, code, entity.PickupCodeLength)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/senyabanana/pvz-service/internal/entity"
+	"github.com/senyabanana/pvz-service/internal/infrastructure/logger"
+)
+
+// LogLevelService changes the level of the service logger at runtime. The change is not persisted:
+// a restart, or SIGHUP, brings back LOG_LEVEL from the config.
+type LogLevelService struct {
+	log *logrus.Logger
+}
+
+func NewLogLevelService(log *logrus.Logger) *LogLevelService {
+	return &LogLevelService{log: log}
+}
+
+func (s *LogLevelService) GetLogLevel(ctx context.Context) string {
+	_, span := tracer.Start(ctx, "LogLevelService.GetLogLevel")
+	defer span.End()
+
+	return s.log.GetLevel().String()
+}
+
+func (s *LogLevelService) SetLogLevel(ctx context.Context, level string) error {
+	ctx, span := tracer.Start(ctx, "LogLevelService.SetLogLevel")
+	defer span.End()
+	log := logger.FromContext(ctx, s.log)
+
+	parsed, err := logger.ParseLevel(level)
+	if err != nil {
+		return fmt.Errorf("%w: %s", entity.ErrInvalidLogLevel, level)
+	}
+
+	previous := s.log.GetLevel()
+	s.log.SetLevel(parsed)
+	log.Warnf("log level changed: %s -> %s", previous, parsed)
+
+	return nil
+}
@@ -0,0 +1,41 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/senyabanana/pvz-service/internal/entity"
+)
+
+func TestLogLevelService_SetLogLevel(t *testing.T) {
+	mockLog := logrus.New()
+	mockLog.SetLevel(logrus.InfoLevel)
+
+	svc := NewLogLevelService(mockLog)
+
+	tests := []struct {
+		name      string
+		level     string
+		wantLevel string
+		wantErr   error
+	}{
+		{name: "debug", level: "debug", wantLevel: "debug"},
+		{name: "warning alias", level: "warning", wantLevel: "warning"},
+		{name: "unknown level", level: "verbose", wantLevel: "warning", wantErr: entity.ErrInvalidLogLevel},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := svc.SetLogLevel(context.Background(), tt.level)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantLevel, svc.GetLogLevel(context.Background()))
+		})
+	}
+}
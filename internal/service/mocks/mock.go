@@ -6,12 +6,13 @@ package mock_service
 
 import (
 	context "context"
+	io "io"
 	reflect "reflect"
-	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	uuid "github.com/google/uuid"
 	entity "github.com/senyabanana/pvz-service/internal/entity"
+	service "github.com/senyabanana/pvz-service/internal/service"
 )
 
 // MockAuthorization is a mock of Authorization interface.
@@ -66,6 +67,191 @@ func (mr *MockAuthorizationMockRecorder) RegisterUser(ctx, user interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterUser", reflect.TypeOf((*MockAuthorization)(nil).RegisterUser), ctx, user)
 }
 
+// MockOIDCAuthorization is a mock of OIDCAuthorization interface.
+type MockOIDCAuthorization struct {
+	ctrl     *gomock.Controller
+	recorder *MockOIDCAuthorizationMockRecorder
+}
+
+// MockOIDCAuthorizationMockRecorder is the mock recorder for MockOIDCAuthorization.
+type MockOIDCAuthorizationMockRecorder struct {
+	mock *MockOIDCAuthorization
+}
+
+// NewMockOIDCAuthorization creates a new mock instance.
+func NewMockOIDCAuthorization(ctrl *gomock.Controller) *MockOIDCAuthorization {
+	mock := &MockOIDCAuthorization{ctrl: ctrl}
+	mock.recorder = &MockOIDCAuthorizationMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOIDCAuthorization) EXPECT() *MockOIDCAuthorizationMockRecorder {
+	return m.recorder
+}
+
+// LoginOIDC mocks base method.
+func (m *MockOIDCAuthorization) LoginOIDC(ctx context.Context, code, nonce string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoginOIDC", ctx, code, nonce)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoginOIDC indicates an expected call of LoginOIDC.
+func (mr *MockOIDCAuthorizationMockRecorder) LoginOIDC(ctx, code, nonce interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoginOIDC", reflect.TypeOf((*MockOIDCAuthorization)(nil).LoginOIDC), ctx, code, nonce)
+}
+
+// OIDCAuthURL mocks base method.
+func (m *MockOIDCAuthorization) OIDCAuthURL(state, nonce string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OIDCAuthURL", state, nonce)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OIDCAuthURL indicates an expected call of OIDCAuthURL.
+func (mr *MockOIDCAuthorizationMockRecorder) OIDCAuthURL(state, nonce interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OIDCAuthURL", reflect.TypeOf((*MockOIDCAuthorization)(nil).OIDCAuthURL), state, nonce)
+}
+
+// MockPasswordOperations is a mock of PasswordOperations interface.
+type MockPasswordOperations struct {
+	ctrl     *gomock.Controller
+	recorder *MockPasswordOperationsMockRecorder
+}
+
+// MockPasswordOperationsMockRecorder is the mock recorder for MockPasswordOperations.
+type MockPasswordOperationsMockRecorder struct {
+	mock *MockPasswordOperations
+}
+
+// NewMockPasswordOperations creates a new mock instance.
+func NewMockPasswordOperations(ctrl *gomock.Controller) *MockPasswordOperations {
+	mock := &MockPasswordOperations{ctrl: ctrl}
+	mock.recorder = &MockPasswordOperationsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPasswordOperations) EXPECT() *MockPasswordOperationsMockRecorder {
+	return m.recorder
+}
+
+// ChangePassword mocks base method.
+func (m *MockPasswordOperations) ChangePassword(ctx context.Context, userID uuid.UUID, currentPassword, newPassword string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangePassword", ctx, userID, currentPassword, newPassword)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ChangePassword indicates an expected call of ChangePassword.
+func (mr *MockPasswordOperationsMockRecorder) ChangePassword(ctx, userID, currentPassword, newPassword interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangePassword", reflect.TypeOf((*MockPasswordOperations)(nil).ChangePassword), ctx, userID, currentPassword, newPassword)
+}
+
+// RequestPasswordReset mocks base method.
+func (m *MockPasswordOperations) RequestPasswordReset(ctx context.Context, email string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestPasswordReset", ctx, email)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RequestPasswordReset indicates an expected call of RequestPasswordReset.
+func (mr *MockPasswordOperationsMockRecorder) RequestPasswordReset(ctx, email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestPasswordReset", reflect.TypeOf((*MockPasswordOperations)(nil).RequestPasswordReset), ctx, email)
+}
+
+// ResetPassword mocks base method.
+func (m *MockPasswordOperations) ResetPassword(ctx context.Context, token, newPassword string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetPassword", ctx, token, newPassword)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetPassword indicates an expected call of ResetPassword.
+func (mr *MockPasswordOperationsMockRecorder) ResetPassword(ctx, token, newPassword interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetPassword", reflect.TypeOf((*MockPasswordOperations)(nil).ResetPassword), ctx, token, newPassword)
+}
+
+// MockAccountOperations is a mock of AccountOperations interface.
+type MockAccountOperations struct {
+	ctrl     *gomock.Controller
+	recorder *MockAccountOperationsMockRecorder
+}
+
+// MockAccountOperationsMockRecorder is the mock recorder for MockAccountOperations.
+type MockAccountOperationsMockRecorder struct {
+	mock *MockAccountOperations
+}
+
+// NewMockAccountOperations creates a new mock instance.
+func NewMockAccountOperations(ctrl *gomock.Controller) *MockAccountOperations {
+	mock := &MockAccountOperations{ctrl: ctrl}
+	mock.recorder = &MockAccountOperationsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAccountOperations) EXPECT() *MockAccountOperationsMockRecorder {
+	return m.recorder
+}
+
+// AssignUserPVZ mocks base method.
+func (m *MockAccountOperations) AssignUserPVZ(ctx context.Context, userID uuid.UUID, pvzIDs []uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AssignUserPVZ", ctx, userID, pvzIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AssignUserPVZ indicates an expected call of AssignUserPVZ.
+func (mr *MockAccountOperationsMockRecorder) AssignUserPVZ(ctx, userID, pvzIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignUserPVZ", reflect.TypeOf((*MockAccountOperations)(nil).AssignUserPVZ), ctx, userID, pvzIDs)
+}
+
+// GetCurrentUser mocks base method.
+func (m *MockAccountOperations) GetCurrentUser(ctx context.Context, claims entity.TokenClaims) (*entity.UserProfile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCurrentUser", ctx, claims)
+	ret0, _ := ret[0].(*entity.UserProfile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCurrentUser indicates an expected call of GetCurrentUser.
+func (mr *MockAccountOperationsMockRecorder) GetCurrentUser(ctx, claims interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurrentUser", reflect.TypeOf((*MockAccountOperations)(nil).GetCurrentUser), ctx, claims)
+}
+
+// IntrospectToken mocks base method.
+func (m *MockAccountOperations) IntrospectToken(ctx context.Context, token string) (*entity.TokenClaims, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IntrospectToken", ctx, token)
+	ret0, _ := ret[0].(*entity.TokenClaims)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IntrospectToken indicates an expected call of IntrospectToken.
+func (mr *MockAccountOperationsMockRecorder) IntrospectToken(ctx, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IntrospectToken", reflect.TypeOf((*MockAccountOperations)(nil).IntrospectToken), ctx, token)
+}
+
 // MockPVZOperations is a mock of PVZOperations interface.
 type MockPVZOperations struct {
 	ctrl     *gomock.Controller
@@ -89,6 +275,21 @@ func (m *MockPVZOperations) EXPECT() *MockPVZOperationsMockRecorder {
 	return m.recorder
 }
 
+// ChangePVZStatus mocks base method.
+func (m *MockPVZOperations) ChangePVZStatus(ctx context.Context, pvzID uuid.UUID, status entity.PVZStatus, reason string) (*entity.PVZ, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangePVZStatus", ctx, pvzID, status, reason)
+	ret0, _ := ret[0].(*entity.PVZ)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChangePVZStatus indicates an expected call of ChangePVZStatus.
+func (mr *MockPVZOperationsMockRecorder) ChangePVZStatus(ctx, pvzID, status, reason interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangePVZStatus", reflect.TypeOf((*MockPVZOperations)(nil).ChangePVZStatus), ctx, pvzID, status, reason)
+}
+
 // CreatePVZ mocks base method.
 func (m *MockPVZOperations) CreatePVZ(ctx context.Context, city string) (*entity.PVZ, error) {
 	m.ctrl.T.Helper()
@@ -104,34 +305,117 @@ func (mr *MockPVZOperationsMockRecorder) CreatePVZ(ctx, city interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePVZ", reflect.TypeOf((*MockPVZOperations)(nil).CreatePVZ), ctx, city)
 }
 
+// FindNearbyPVZ mocks base method.
+func (m *MockPVZOperations) FindNearbyPVZ(ctx context.Context, filter entity.NearbyFilter) ([]entity.NearbyPVZ, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindNearbyPVZ", ctx, filter)
+	ret0, _ := ret[0].([]entity.NearbyPVZ)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindNearbyPVZ indicates an expected call of FindNearbyPVZ.
+func (mr *MockPVZOperationsMockRecorder) FindNearbyPVZ(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindNearbyPVZ", reflect.TypeOf((*MockPVZOperations)(nil).FindNearbyPVZ), ctx, filter)
+}
+
 // GetAllPVZ mocks base method.
-func (m *MockPVZOperations) GetAllPVZ(ctx context.Context) ([]entity.PVZ, error) {
+func (m *MockPVZOperations) GetAllPVZ(ctx context.Context, includeClosed bool) ([]entity.PVZ, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAllPVZ", ctx)
+	ret := m.ctrl.Call(m, "GetAllPVZ", ctx, includeClosed)
 	ret0, _ := ret[0].([]entity.PVZ)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetAllPVZ indicates an expected call of GetAllPVZ.
-func (mr *MockPVZOperationsMockRecorder) GetAllPVZ(ctx interface{}) *gomock.Call {
+func (mr *MockPVZOperationsMockRecorder) GetAllPVZ(ctx, includeClosed interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllPVZ", reflect.TypeOf((*MockPVZOperations)(nil).GetAllPVZ), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllPVZ", reflect.TypeOf((*MockPVZOperations)(nil).GetAllPVZ), ctx, includeClosed)
 }
 
 // GetFullPVZInfo mocks base method.
-func (m *MockPVZOperations) GetFullPVZInfo(ctx context.Context, startDate, endDate *time.Time, page, limit int) ([]entity.FullPVZInfo, error) {
+func (m *MockPVZOperations) GetFullPVZInfo(ctx context.Context, filter entity.PVZFilter) ([]entity.FullPVZInfo, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetFullPVZInfo", ctx, startDate, endDate, page, limit)
+	ret := m.ctrl.Call(m, "GetFullPVZInfo", ctx, filter)
 	ret0, _ := ret[0].([]entity.FullPVZInfo)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetFullPVZInfo indicates an expected call of GetFullPVZInfo.
-func (mr *MockPVZOperationsMockRecorder) GetFullPVZInfo(ctx, startDate, endDate, page, limit interface{}) *gomock.Call {
+func (mr *MockPVZOperationsMockRecorder) GetFullPVZInfo(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFullPVZInfo", reflect.TypeOf((*MockPVZOperations)(nil).GetFullPVZInfo), ctx, filter)
+}
+
+// UpdatePVZDetails mocks base method.
+func (m *MockPVZOperations) UpdatePVZDetails(ctx context.Context, pvzID uuid.UUID, update entity.PVZDetailsUpdate) (*entity.PVZ, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePVZDetails", ctx, pvzID, update)
+	ret0, _ := ret[0].(*entity.PVZ)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePVZDetails indicates an expected call of UpdatePVZDetails.
+func (mr *MockPVZOperationsMockRecorder) UpdatePVZDetails(ctx, pvzID, update interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFullPVZInfo", reflect.TypeOf((*MockPVZOperations)(nil).GetFullPVZInfo), ctx, startDate, endDate, page, limit)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePVZDetails", reflect.TypeOf((*MockPVZOperations)(nil).UpdatePVZDetails), ctx, pvzID, update)
+}
+
+// MockCapacityOperations is a mock of CapacityOperations interface.
+type MockCapacityOperations struct {
+	ctrl     *gomock.Controller
+	recorder *MockCapacityOperationsMockRecorder
+}
+
+// MockCapacityOperationsMockRecorder is the mock recorder for MockCapacityOperations.
+type MockCapacityOperationsMockRecorder struct {
+	mock *MockCapacityOperations
+}
+
+// NewMockCapacityOperations creates a new mock instance.
+func NewMockCapacityOperations(ctrl *gomock.Controller) *MockCapacityOperations {
+	mock := &MockCapacityOperations{ctrl: ctrl}
+	mock.recorder = &MockCapacityOperationsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCapacityOperations) EXPECT() *MockCapacityOperationsMockRecorder {
+	return m.recorder
+}
+
+// GetPVZOccupancy mocks base method.
+func (m *MockCapacityOperations) GetPVZOccupancy(ctx context.Context, pvzID uuid.UUID) (*entity.PVZOccupancy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPVZOccupancy", ctx, pvzID)
+	ret0, _ := ret[0].(*entity.PVZOccupancy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPVZOccupancy indicates an expected call of GetPVZOccupancy.
+func (mr *MockCapacityOperationsMockRecorder) GetPVZOccupancy(ctx, pvzID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPVZOccupancy", reflect.TypeOf((*MockCapacityOperations)(nil).GetPVZOccupancy), ctx, pvzID)
+}
+
+// SetPVZCapacity mocks base method.
+func (m *MockCapacityOperations) SetPVZCapacity(ctx context.Context, capacity entity.PVZCapacity) (*entity.PVZOccupancy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPVZCapacity", ctx, capacity)
+	ret0, _ := ret[0].(*entity.PVZOccupancy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetPVZCapacity indicates an expected call of SetPVZCapacity.
+func (mr *MockCapacityOperationsMockRecorder) SetPVZCapacity(ctx, capacity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPVZCapacity", reflect.TypeOf((*MockCapacityOperations)(nil).SetPVZCapacity), ctx, capacity)
 }
 
 // MockReceptionOperations is a mock of ReceptionOperations interface.
@@ -238,3 +522,807 @@ func (mr *MockProductOperationsMockRecorder) DeleteLastProduct(ctx, pvzID interf
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLastProduct", reflect.TypeOf((*MockProductOperations)(nil).DeleteLastProduct), ctx, pvzID)
 }
+
+// GetProductHistory mocks base method.
+func (m *MockProductOperations) GetProductHistory(ctx context.Context, productID uuid.UUID) ([]entity.ProductStatusChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductHistory", ctx, productID)
+	ret0, _ := ret[0].([]entity.ProductStatusChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProductHistory indicates an expected call of GetProductHistory.
+func (mr *MockProductOperationsMockRecorder) GetProductHistory(ctx, productID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductHistory", reflect.TypeOf((*MockProductOperations)(nil).GetProductHistory), ctx, productID)
+}
+
+// MockIssuanceOperations is a mock of IssuanceOperations interface.
+type MockIssuanceOperations struct {
+	ctrl     *gomock.Controller
+	recorder *MockIssuanceOperationsMockRecorder
+}
+
+// MockIssuanceOperationsMockRecorder is the mock recorder for MockIssuanceOperations.
+type MockIssuanceOperationsMockRecorder struct {
+	mock *MockIssuanceOperations
+}
+
+// NewMockIssuanceOperations creates a new mock instance.
+func NewMockIssuanceOperations(ctrl *gomock.Controller) *MockIssuanceOperations {
+	mock := &MockIssuanceOperations{ctrl: ctrl}
+	mock.recorder = &MockIssuanceOperationsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIssuanceOperations) EXPECT() *MockIssuanceOperationsMockRecorder {
+	return m.recorder
+}
+
+// AssignProductOwner mocks base method.
+func (m *MockIssuanceOperations) AssignProductOwner(ctx context.Context, pvzID, productID, ownerID uuid.UUID) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AssignProductOwner", ctx, pvzID, productID, ownerID)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AssignProductOwner indicates an expected call of AssignProductOwner.
+func (mr *MockIssuanceOperationsMockRecorder) AssignProductOwner(ctx, pvzID, productID, ownerID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignProductOwner", reflect.TypeOf((*MockIssuanceOperations)(nil).AssignProductOwner), ctx, pvzID, productID, ownerID)
+}
+
+// GetMyParcels mocks base method.
+func (m *MockIssuanceOperations) GetMyParcels(ctx context.Context, ownerID uuid.UUID) ([]entity.Parcel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMyParcels", ctx, ownerID)
+	ret0, _ := ret[0].([]entity.Parcel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMyParcels indicates an expected call of GetMyParcels.
+func (mr *MockIssuanceOperationsMockRecorder) GetMyParcels(ctx, ownerID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMyParcels", reflect.TypeOf((*MockIssuanceOperations)(nil).GetMyParcels), ctx, ownerID)
+}
+
+// IssueProduct mocks base method.
+func (m *MockIssuanceOperations) IssueProduct(ctx context.Context, pvzID, productID uuid.UUID, pickupCode string, issuedBy *uuid.UUID) (*entity.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IssueProduct", ctx, pvzID, productID, pickupCode, issuedBy)
+	ret0, _ := ret[0].(*entity.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IssueProduct indicates an expected call of IssueProduct.
+func (mr *MockIssuanceOperationsMockRecorder) IssueProduct(ctx, pvzID, productID, pickupCode, issuedBy interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IssueProduct", reflect.TypeOf((*MockIssuanceOperations)(nil).IssueProduct), ctx, pvzID, productID, pickupCode, issuedBy)
+}
+
+// RenewPickupCode mocks base method.
+func (m *MockIssuanceOperations) RenewPickupCode(ctx context.Context, ownerID, productID uuid.UUID) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RenewPickupCode", ctx, ownerID, productID)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RenewPickupCode indicates an expected call of RenewPickupCode.
+func (mr *MockIssuanceOperationsMockRecorder) RenewPickupCode(ctx, ownerID, productID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenewPickupCode", reflect.TypeOf((*MockIssuanceOperations)(nil).RenewPickupCode), ctx, ownerID, productID)
+}
+
+// MockReturnOperations is a mock of ReturnOperations interface.
+type MockReturnOperations struct {
+	ctrl     *gomock.Controller
+	recorder *MockReturnOperationsMockRecorder
+}
+
+// MockReturnOperationsMockRecorder is the mock recorder for MockReturnOperations.
+type MockReturnOperationsMockRecorder struct {
+	mock *MockReturnOperations
+}
+
+// NewMockReturnOperations creates a new mock instance.
+func NewMockReturnOperations(ctrl *gomock.Controller) *MockReturnOperations {
+	mock := &MockReturnOperations{ctrl: ctrl}
+	mock.recorder = &MockReturnOperationsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReturnOperations) EXPECT() *MockReturnOperationsMockRecorder {
+	return m.recorder
+}
+
+// AcceptReturn mocks base method.
+func (m *MockReturnOperations) AcceptReturn(ctx context.Context, ret entity.CustomerReturn) (*entity.CustomerReturn, error) {
+	m.ctrl.T.Helper()
+	ret_2 := m.ctrl.Call(m, "AcceptReturn", ctx, ret)
+	ret0, _ := ret_2[0].(*entity.CustomerReturn)
+	ret1, _ := ret_2[1].(error)
+	return ret0, ret1
+}
+
+// AcceptReturn indicates an expected call of AcceptReturn.
+func (mr *MockReturnOperationsMockRecorder) AcceptReturn(ctx, ret interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcceptReturn", reflect.TypeOf((*MockReturnOperations)(nil).AcceptReturn), ctx, ret)
+}
+
+// ChangeReturnStatus mocks base method.
+func (m *MockReturnOperations) ChangeReturnStatus(ctx context.Context, pvzID, returnID uuid.UUID, status entity.ReturnStatus) (*entity.CustomerReturn, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangeReturnStatus", ctx, pvzID, returnID, status)
+	ret0, _ := ret[0].(*entity.CustomerReturn)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChangeReturnStatus indicates an expected call of ChangeReturnStatus.
+func (mr *MockReturnOperationsMockRecorder) ChangeReturnStatus(ctx, pvzID, returnID, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeReturnStatus", reflect.TypeOf((*MockReturnOperations)(nil).ChangeReturnStatus), ctx, pvzID, returnID, status)
+}
+
+// MockStorageOperations is a mock of StorageOperations interface.
+type MockStorageOperations struct {
+	ctrl     *gomock.Controller
+	recorder *MockStorageOperationsMockRecorder
+}
+
+// MockStorageOperationsMockRecorder is the mock recorder for MockStorageOperations.
+type MockStorageOperationsMockRecorder struct {
+	mock *MockStorageOperations
+}
+
+// NewMockStorageOperations creates a new mock instance.
+func NewMockStorageOperations(ctrl *gomock.Controller) *MockStorageOperations {
+	mock := &MockStorageOperations{ctrl: ctrl}
+	mock.recorder = &MockStorageOperationsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStorageOperations) EXPECT() *MockStorageOperationsMockRecorder {
+	return m.recorder
+}
+
+// FlagOverdueProducts mocks base method.
+func (m *MockStorageOperations) FlagOverdueProducts(ctx context.Context) ([]entity.OverdueProduct, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FlagOverdueProducts", ctx)
+	ret0, _ := ret[0].([]entity.OverdueProduct)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FlagOverdueProducts indicates an expected call of FlagOverdueProducts.
+func (mr *MockStorageOperationsMockRecorder) FlagOverdueProducts(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FlagOverdueProducts", reflect.TypeOf((*MockStorageOperations)(nil).FlagOverdueProducts), ctx)
+}
+
+// GetOverdueProducts mocks base method.
+func (m *MockStorageOperations) GetOverdueProducts(ctx context.Context, pvzID uuid.UUID) ([]entity.OverdueProduct, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOverdueProducts", ctx, pvzID)
+	ret0, _ := ret[0].([]entity.OverdueProduct)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOverdueProducts indicates an expected call of GetOverdueProducts.
+func (mr *MockStorageOperationsMockRecorder) GetOverdueProducts(ctx, pvzID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOverdueProducts", reflect.TypeOf((*MockStorageOperations)(nil).GetOverdueProducts), ctx, pvzID)
+}
+
+// ShipOverdueProducts mocks base method.
+func (m *MockStorageOperations) ShipOverdueProducts(ctx context.Context, shipment entity.SenderShipment) (*entity.SenderShipment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ShipOverdueProducts", ctx, shipment)
+	ret0, _ := ret[0].(*entity.SenderShipment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ShipOverdueProducts indicates an expected call of ShipOverdueProducts.
+func (mr *MockStorageOperationsMockRecorder) ShipOverdueProducts(ctx, shipment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ShipOverdueProducts", reflect.TypeOf((*MockStorageOperations)(nil).ShipOverdueProducts), ctx, shipment)
+}
+
+// MockTransferOperations is a mock of TransferOperations interface.
+type MockTransferOperations struct {
+	ctrl     *gomock.Controller
+	recorder *MockTransferOperationsMockRecorder
+}
+
+// MockTransferOperationsMockRecorder is the mock recorder for MockTransferOperations.
+type MockTransferOperationsMockRecorder struct {
+	mock *MockTransferOperations
+}
+
+// NewMockTransferOperations creates a new mock instance.
+func NewMockTransferOperations(ctrl *gomock.Controller) *MockTransferOperations {
+	mock := &MockTransferOperations{ctrl: ctrl}
+	mock.recorder = &MockTransferOperationsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTransferOperations) EXPECT() *MockTransferOperationsMockRecorder {
+	return m.recorder
+}
+
+// CreateTransfer mocks base method.
+func (m *MockTransferOperations) CreateTransfer(ctx context.Context, order entity.TransferOrder) (*entity.TransferOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTransfer", ctx, order)
+	ret0, _ := ret[0].(*entity.TransferOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTransfer indicates an expected call of CreateTransfer.
+func (mr *MockTransferOperationsMockRecorder) CreateTransfer(ctx, order interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTransfer", reflect.TypeOf((*MockTransferOperations)(nil).CreateTransfer), ctx, order)
+}
+
+// GetProductCustody mocks base method.
+func (m *MockTransferOperations) GetProductCustody(ctx context.Context, productID uuid.UUID) ([]entity.CustodyEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductCustody", ctx, productID)
+	ret0, _ := ret[0].([]entity.CustodyEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProductCustody indicates an expected call of GetProductCustody.
+func (mr *MockTransferOperationsMockRecorder) GetProductCustody(ctx, productID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductCustody", reflect.TypeOf((*MockTransferOperations)(nil).GetProductCustody), ctx, productID)
+}
+
+// ReceiveTransfer mocks base method.
+func (m *MockTransferOperations) ReceiveTransfer(ctx context.Context, pvzID, transferID uuid.UUID, receivedBy *uuid.UUID) (*entity.TransferOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReceiveTransfer", ctx, pvzID, transferID, receivedBy)
+	ret0, _ := ret[0].(*entity.TransferOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReceiveTransfer indicates an expected call of ReceiveTransfer.
+func (mr *MockTransferOperationsMockRecorder) ReceiveTransfer(ctx, pvzID, transferID, receivedBy interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReceiveTransfer", reflect.TypeOf((*MockTransferOperations)(nil).ReceiveTransfer), ctx, pvzID, transferID, receivedBy)
+}
+
+// MockStocktakeOperations is a mock of StocktakeOperations interface.
+type MockStocktakeOperations struct {
+	ctrl     *gomock.Controller
+	recorder *MockStocktakeOperationsMockRecorder
+}
+
+// MockStocktakeOperationsMockRecorder is the mock recorder for MockStocktakeOperations.
+type MockStocktakeOperationsMockRecorder struct {
+	mock *MockStocktakeOperations
+}
+
+// NewMockStocktakeOperations creates a new mock instance.
+func NewMockStocktakeOperations(ctrl *gomock.Controller) *MockStocktakeOperations {
+	mock := &MockStocktakeOperations{ctrl: ctrl}
+	mock.recorder = &MockStocktakeOperationsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStocktakeOperations) EXPECT() *MockStocktakeOperationsMockRecorder {
+	return m.recorder
+}
+
+// CompleteStocktake mocks base method.
+func (m *MockStocktakeOperations) CompleteStocktake(ctx context.Context, pvzID, stocktakeID uuid.UUID, completedBy *uuid.UUID, comment string) (*entity.Stocktake, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteStocktake", ctx, pvzID, stocktakeID, completedBy, comment)
+	ret0, _ := ret[0].(*entity.Stocktake)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompleteStocktake indicates an expected call of CompleteStocktake.
+func (mr *MockStocktakeOperationsMockRecorder) CompleteStocktake(ctx, pvzID, stocktakeID, completedBy, comment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteStocktake", reflect.TypeOf((*MockStocktakeOperations)(nil).CompleteStocktake), ctx, pvzID, stocktakeID, completedBy, comment)
+}
+
+// GetStocktake mocks base method.
+func (m *MockStocktakeOperations) GetStocktake(ctx context.Context, pvzID, stocktakeID uuid.UUID) (*entity.Stocktake, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStocktake", ctx, pvzID, stocktakeID)
+	ret0, _ := ret[0].(*entity.Stocktake)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStocktake indicates an expected call of GetStocktake.
+func (mr *MockStocktakeOperationsMockRecorder) GetStocktake(ctx, pvzID, stocktakeID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStocktake", reflect.TypeOf((*MockStocktakeOperations)(nil).GetStocktake), ctx, pvzID, stocktakeID)
+}
+
+// ScanProducts mocks base method.
+func (m *MockStocktakeOperations) ScanProducts(ctx context.Context, pvzID, stocktakeID uuid.UUID, productIDs []uuid.UUID, scannedBy *uuid.UUID) (*entity.Stocktake, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ScanProducts", ctx, pvzID, stocktakeID, productIDs, scannedBy)
+	ret0, _ := ret[0].(*entity.Stocktake)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ScanProducts indicates an expected call of ScanProducts.
+func (mr *MockStocktakeOperationsMockRecorder) ScanProducts(ctx, pvzID, stocktakeID, productIDs, scannedBy interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ScanProducts", reflect.TypeOf((*MockStocktakeOperations)(nil).ScanProducts), ctx, pvzID, stocktakeID, productIDs, scannedBy)
+}
+
+// StartStocktake mocks base method.
+func (m *MockStocktakeOperations) StartStocktake(ctx context.Context, pvzID uuid.UUID, startedBy *uuid.UUID) (*entity.Stocktake, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartStocktake", ctx, pvzID, startedBy)
+	ret0, _ := ret[0].(*entity.Stocktake)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StartStocktake indicates an expected call of StartStocktake.
+func (mr *MockStocktakeOperationsMockRecorder) StartStocktake(ctx, pvzID, startedBy interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartStocktake", reflect.TypeOf((*MockStocktakeOperations)(nil).StartStocktake), ctx, pvzID, startedBy)
+}
+
+// MockAnalyticsOperations is a mock of AnalyticsOperations interface.
+type MockAnalyticsOperations struct {
+	ctrl     *gomock.Controller
+	recorder *MockAnalyticsOperationsMockRecorder
+}
+
+// MockAnalyticsOperationsMockRecorder is the mock recorder for MockAnalyticsOperations.
+type MockAnalyticsOperationsMockRecorder struct {
+	mock *MockAnalyticsOperations
+}
+
+// NewMockAnalyticsOperations creates a new mock instance.
+func NewMockAnalyticsOperations(ctrl *gomock.Controller) *MockAnalyticsOperations {
+	mock := &MockAnalyticsOperations{ctrl: ctrl}
+	mock.recorder = &MockAnalyticsOperationsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAnalyticsOperations) EXPECT() *MockAnalyticsOperationsMockRecorder {
+	return m.recorder
+}
+
+// GetProductMix mocks base method.
+func (m *MockAnalyticsOperations) GetProductMix(ctx context.Context, filter entity.AnalyticsFilter) ([]entity.ProductMix, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductMix", ctx, filter)
+	ret0, _ := ret[0].([]entity.ProductMix)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProductMix indicates an expected call of GetProductMix.
+func (mr *MockAnalyticsOperationsMockRecorder) GetProductMix(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductMix", reflect.TypeOf((*MockAnalyticsOperations)(nil).GetProductMix), ctx, filter)
+}
+
+// GetReceptionThroughput mocks base method.
+func (m *MockAnalyticsOperations) GetReceptionThroughput(ctx context.Context, filter entity.AnalyticsFilter) ([]entity.ReceptionThroughput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReceptionThroughput", ctx, filter)
+	ret0, _ := ret[0].([]entity.ReceptionThroughput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReceptionThroughput indicates an expected call of GetReceptionThroughput.
+func (mr *MockAnalyticsOperationsMockRecorder) GetReceptionThroughput(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReceptionThroughput", reflect.TypeOf((*MockAnalyticsOperations)(nil).GetReceptionThroughput), ctx, filter)
+}
+
+// MockExportOperations is a mock of ExportOperations interface.
+type MockExportOperations struct {
+	ctrl     *gomock.Controller
+	recorder *MockExportOperationsMockRecorder
+}
+
+// MockExportOperationsMockRecorder is the mock recorder for MockExportOperations.
+type MockExportOperationsMockRecorder struct {
+	mock *MockExportOperations
+}
+
+// NewMockExportOperations creates a new mock instance.
+func NewMockExportOperations(ctrl *gomock.Controller) *MockExportOperations {
+	mock := &MockExportOperations{ctrl: ctrl}
+	mock.recorder = &MockExportOperationsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockExportOperations) EXPECT() *MockExportOperationsMockRecorder {
+	return m.recorder
+}
+
+// Export mocks base method.
+func (m *MockExportOperations) Export(ctx context.Context, dataset entity.ExportDataset, filter entity.ExportFilter, w service.RowWriter) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Export", ctx, dataset, filter, w)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Export indicates an expected call of Export.
+func (mr *MockExportOperationsMockRecorder) Export(ctx, dataset, filter, w interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Export", reflect.TypeOf((*MockExportOperations)(nil).Export), ctx, dataset, filter, w)
+}
+
+// MockImportOperations is a mock of ImportOperations interface.
+type MockImportOperations struct {
+	ctrl     *gomock.Controller
+	recorder *MockImportOperationsMockRecorder
+}
+
+// MockImportOperationsMockRecorder is the mock recorder for MockImportOperations.
+type MockImportOperationsMockRecorder struct {
+	mock *MockImportOperations
+}
+
+// NewMockImportOperations creates a new mock instance.
+func NewMockImportOperations(ctrl *gomock.Controller) *MockImportOperations {
+	mock := &MockImportOperations{ctrl: ctrl}
+	mock.recorder = &MockImportOperationsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockImportOperations) EXPECT() *MockImportOperationsMockRecorder {
+	return m.recorder
+}
+
+// ImportData mocks base method.
+func (m *MockImportOperations) ImportData(ctx context.Context, format entity.ImportFormat, r io.Reader, dryRun bool) (*entity.ImportReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportData", ctx, format, r, dryRun)
+	ret0, _ := ret[0].(*entity.ImportReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportData indicates an expected call of ImportData.
+func (mr *MockImportOperationsMockRecorder) ImportData(ctx, format, r, dryRun interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportData", reflect.TypeOf((*MockImportOperations)(nil).ImportData), ctx, format, r, dryRun)
+}
+
+// MockReportJobOperations is a mock of ReportJobOperations interface.
+type MockReportJobOperations struct {
+	ctrl     *gomock.Controller
+	recorder *MockReportJobOperationsMockRecorder
+}
+
+// MockReportJobOperationsMockRecorder is the mock recorder for MockReportJobOperations.
+type MockReportJobOperationsMockRecorder struct {
+	mock *MockReportJobOperations
+}
+
+// NewMockReportJobOperations creates a new mock instance.
+func NewMockReportJobOperations(ctrl *gomock.Controller) *MockReportJobOperations {
+	mock := &MockReportJobOperations{ctrl: ctrl}
+	mock.recorder = &MockReportJobOperationsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReportJobOperations) EXPECT() *MockReportJobOperationsMockRecorder {
+	return m.recorder
+}
+
+// CreateReportJob mocks base method.
+func (m *MockReportJobOperations) CreateReportJob(ctx context.Context, job entity.ReportJob) (*entity.ReportJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateReportJob", ctx, job)
+	ret0, _ := ret[0].(*entity.ReportJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateReportJob indicates an expected call of CreateReportJob.
+func (mr *MockReportJobOperationsMockRecorder) CreateReportJob(ctx, job interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateReportJob", reflect.TypeOf((*MockReportJobOperations)(nil).CreateReportJob), ctx, job)
+}
+
+// GetReportArtifact mocks base method.
+func (m *MockReportJobOperations) GetReportArtifact(ctx context.Context, jobID uuid.UUID) (*entity.ReportArtifact, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReportArtifact", ctx, jobID)
+	ret0, _ := ret[0].(*entity.ReportArtifact)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReportArtifact indicates an expected call of GetReportArtifact.
+func (mr *MockReportJobOperationsMockRecorder) GetReportArtifact(ctx, jobID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReportArtifact", reflect.TypeOf((*MockReportJobOperations)(nil).GetReportArtifact), ctx, jobID)
+}
+
+// GetReportJob mocks base method.
+func (m *MockReportJobOperations) GetReportJob(ctx context.Context, jobID uuid.UUID) (*entity.ReportJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReportJob", ctx, jobID)
+	ret0, _ := ret[0].(*entity.ReportJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReportJob indicates an expected call of GetReportJob.
+func (mr *MockReportJobOperationsMockRecorder) GetReportJob(ctx, jobID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReportJob", reflect.TypeOf((*MockReportJobOperations)(nil).GetReportJob), ctx, jobID)
+}
+
+// RecoverStaleReportJobs mocks base method.
+func (m *MockReportJobOperations) RecoverStaleReportJobs(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecoverStaleReportJobs", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecoverStaleReportJobs indicates an expected call of RecoverStaleReportJobs.
+func (mr *MockReportJobOperationsMockRecorder) RecoverStaleReportJobs(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecoverStaleReportJobs", reflect.TypeOf((*MockReportJobOperations)(nil).RecoverStaleReportJobs), ctx)
+}
+
+// RunNextReportJob mocks base method.
+func (m *MockReportJobOperations) RunNextReportJob(ctx context.Context) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RunNextReportJob", ctx)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RunNextReportJob indicates an expected call of RunNextReportJob.
+func (mr *MockReportJobOperationsMockRecorder) RunNextReportJob(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunNextReportJob", reflect.TypeOf((*MockReportJobOperations)(nil).RunNextReportJob), ctx)
+}
+
+// MockVersionOperations is a mock of VersionOperations interface.
+type MockVersionOperations struct {
+	ctrl     *gomock.Controller
+	recorder *MockVersionOperationsMockRecorder
+}
+
+// MockVersionOperationsMockRecorder is the mock recorder for MockVersionOperations.
+type MockVersionOperationsMockRecorder struct {
+	mock *MockVersionOperations
+}
+
+// NewMockVersionOperations creates a new mock instance.
+func NewMockVersionOperations(ctrl *gomock.Controller) *MockVersionOperations {
+	mock := &MockVersionOperations{ctrl: ctrl}
+	mock.recorder = &MockVersionOperationsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockVersionOperations) EXPECT() *MockVersionOperationsMockRecorder {
+	return m.recorder
+}
+
+// GetVersion mocks base method.
+func (m *MockVersionOperations) GetVersion(ctx context.Context) (*entity.VersionInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVersion", ctx)
+	ret0, _ := ret[0].(*entity.VersionInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVersion indicates an expected call of GetVersion.
+func (mr *MockVersionOperationsMockRecorder) GetVersion(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVersion", reflect.TypeOf((*MockVersionOperations)(nil).GetVersion), ctx)
+}
+
+// MockHealthOperations is a mock of HealthOperations interface.
+type MockHealthOperations struct {
+	ctrl     *gomock.Controller
+	recorder *MockHealthOperationsMockRecorder
+}
+
+// MockHealthOperationsMockRecorder is the mock recorder for MockHealthOperations.
+type MockHealthOperationsMockRecorder struct {
+	mock *MockHealthOperations
+}
+
+// NewMockHealthOperations creates a new mock instance.
+func NewMockHealthOperations(ctrl *gomock.Controller) *MockHealthOperations {
+	mock := &MockHealthOperations{ctrl: ctrl}
+	mock.recorder = &MockHealthOperationsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHealthOperations) EXPECT() *MockHealthOperationsMockRecorder {
+	return m.recorder
+}
+
+// CheckReadiness mocks base method.
+func (m *MockHealthOperations) CheckReadiness(ctx context.Context) entity.Readiness {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckReadiness", ctx)
+	ret0, _ := ret[0].(entity.Readiness)
+	return ret0
+}
+
+// CheckReadiness indicates an expected call of CheckReadiness.
+func (mr *MockHealthOperationsMockRecorder) CheckReadiness(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckReadiness", reflect.TypeOf((*MockHealthOperations)(nil).CheckReadiness), ctx)
+}
+
+// MockLogLevelOperations is a mock of LogLevelOperations interface.
+type MockLogLevelOperations struct {
+	ctrl     *gomock.Controller
+	recorder *MockLogLevelOperationsMockRecorder
+}
+
+// MockLogLevelOperationsMockRecorder is the mock recorder for MockLogLevelOperations.
+type MockLogLevelOperationsMockRecorder struct {
+	mock *MockLogLevelOperations
+}
+
+// NewMockLogLevelOperations creates a new mock instance.
+func NewMockLogLevelOperations(ctrl *gomock.Controller) *MockLogLevelOperations {
+	mock := &MockLogLevelOperations{ctrl: ctrl}
+	mock.recorder = &MockLogLevelOperationsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLogLevelOperations) EXPECT() *MockLogLevelOperationsMockRecorder {
+	return m.recorder
+}
+
+// GetLogLevel mocks base method.
+func (m *MockLogLevelOperations) GetLogLevel(ctx context.Context) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLogLevel", ctx)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetLogLevel indicates an expected call of GetLogLevel.
+func (mr *MockLogLevelOperationsMockRecorder) GetLogLevel(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLogLevel", reflect.TypeOf((*MockLogLevelOperations)(nil).GetLogLevel), ctx)
+}
+
+// SetLogLevel mocks base method.
+func (m *MockLogLevelOperations) SetLogLevel(ctx context.Context, level string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetLogLevel", ctx, level)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetLogLevel indicates an expected call of SetLogLevel.
+func (mr *MockLogLevelOperationsMockRecorder) SetLogLevel(ctx, level interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLogLevel", reflect.TypeOf((*MockLogLevelOperations)(nil).SetLogLevel), ctx, level)
+}
+
+// MockAPIKeyOperations is a mock of APIKeyOperations interface.
+type MockAPIKeyOperations struct {
+	ctrl     *gomock.Controller
+	recorder *MockAPIKeyOperationsMockRecorder
+}
+
+// MockAPIKeyOperationsMockRecorder is the mock recorder for MockAPIKeyOperations.
+type MockAPIKeyOperationsMockRecorder struct {
+	mock *MockAPIKeyOperations
+}
+
+// NewMockAPIKeyOperations creates a new mock instance.
+func NewMockAPIKeyOperations(ctrl *gomock.Controller) *MockAPIKeyOperations {
+	mock := &MockAPIKeyOperations{ctrl: ctrl}
+	mock.recorder = &MockAPIKeyOperationsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAPIKeyOperations) EXPECT() *MockAPIKeyOperationsMockRecorder {
+	return m.recorder
+}
+
+// AuthenticateAPIKey mocks base method.
+func (m *MockAPIKeyOperations) AuthenticateAPIKey(ctx context.Context, rawKey string) (*entity.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthenticateAPIKey", ctx, rawKey)
+	ret0, _ := ret[0].(*entity.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AuthenticateAPIKey indicates an expected call of AuthenticateAPIKey.
+func (mr *MockAPIKeyOperationsMockRecorder) AuthenticateAPIKey(ctx, rawKey interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthenticateAPIKey", reflect.TypeOf((*MockAPIKeyOperations)(nil).AuthenticateAPIKey), ctx, rawKey)
+}
+
+// CreateAPIKey mocks base method.
+func (m *MockAPIKeyOperations) CreateAPIKey(ctx context.Context, key *entity.APIKey) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAPIKey", ctx, key)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAPIKey indicates an expected call of CreateAPIKey.
+func (mr *MockAPIKeyOperationsMockRecorder) CreateAPIKey(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAPIKey", reflect.TypeOf((*MockAPIKeyOperations)(nil).CreateAPIKey), ctx, key)
+}
+
+// GetAllAPIKeys mocks base method.
+func (m *MockAPIKeyOperations) GetAllAPIKeys(ctx context.Context) ([]entity.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllAPIKeys", ctx)
+	ret0, _ := ret[0].([]entity.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllAPIKeys indicates an expected call of GetAllAPIKeys.
+func (mr *MockAPIKeyOperationsMockRecorder) GetAllAPIKeys(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllAPIKeys", reflect.TypeOf((*MockAPIKeyOperations)(nil).GetAllAPIKeys), ctx)
+}
+
+// RevokeAPIKey mocks base method.
+func (m *MockAPIKeyOperations) RevokeAPIKey(ctx context.Context, keyID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAPIKey", ctx, keyID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeAPIKey indicates an expected call of RevokeAPIKey.
+func (mr *MockAPIKeyOperationsMockRecorder) RevokeAPIKey(ctx, keyID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAPIKey", reflect.TypeOf((*MockAPIKeyOperations)(nil).RevokeAPIKey), ctx, keyID)
+}
@@ -0,0 +1,158 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/avito-tech/go-transaction-manager/trm/v2/manager"
+	"github.com/sirupsen/logrus"
+
+	"github.com/senyabanana/pvz-service/internal/entity"
+	"github.com/senyabanana/pvz-service/internal/infrastructure/jwtutil"
+	"github.com/senyabanana/pvz-service/internal/infrastructure/logger"
+	"github.com/senyabanana/pvz-service/internal/repository"
+)
+
+type IdentityProvider interface {
+	AuthCodeURL(state, nonce string) string
+	Exchange(ctx context.Context, code, nonce string) (*entity.ExternalIdentity, error)
+}
+
+type OIDCService struct {
+	repo        repository.UserRepository
+	provider    IdentityProvider
+	roleMapping map[string]entity.UserRole
+	trManager   *manager.Manager
+	JWTSecret   string
+	log         *logrus.Logger
+}
+
+func NewOIDCService(
+	repo repository.UserRepository, provider IdentityProvider, roleMapping map[string]entity.UserRole,
+	trManager *manager.Manager, secretKey string, log *logrus.Logger,
+) *OIDCService {
+	return &OIDCService{
+		repo:        repo,
+		provider:    provider,
+		roleMapping: roleMapping,
+		trManager:   trManager,
+		JWTSecret:   secretKey,
+		log:         log,
+	}
+}
+
+func (s *OIDCService) OIDCAuthURL(state, nonce string) (string, error) {
+	if s.provider == nil {
+		return "", entity.ErrOIDCDisabled
+	}
+
+	return s.provider.AuthCodeURL(state, nonce), nil
+}
+
+func (s *OIDCService) LoginOIDC(ctx context.Context, code, nonce string) (string, error) {
+	ctx, span := tracer.Start(ctx, "OIDCService.LoginOIDC")
+	defer span.End()
+	log := logger.FromContext(ctx, s.log)
+
+	if s.provider == nil {
+		return "", entity.ErrOIDCDisabled
+	}
+
+	identity, err := s.provider.Exchange(ctx, code, nonce)
+	if err != nil {
+		log.Warnf("oidc code exchange failed: %v", err)
+		return "", fmt.Errorf("%w: %v", entity.ErrOIDCAuthFailed, err)
+	}
+
+	role, ok := s.mapGroupsToRole(identity.Groups)
+	if !ok {
+		log.Warnf("oidc login rejected: no role mapped for subject=%s, groups=%v", identity.Subject, identity.Groups)
+		return "", entity.ErrOIDCNoRole
+	}
+
+	if !identity.EmailVerified {
+		log.Warnf("oidc login rejected: email of subject=%s is not verified", identity.Subject)
+		return "", fmt.Errorf("%w: %v", entity.ErrOIDCAuthFailed, entity.ErrOIDCEmailNotVerified)
+	}
+
+	var user *entity.User
+	err = s.trManager.Do(ctx, func(ctx context.Context) error {
+		// Accounts are linked by issuer and subject only: anyone controlling an IdP identity with a
+		// matching email must not be able to take over an existing account.
+		existing, err := s.repo.GetUserByOIDCIdentity(ctx, identity.Issuer, identity.Subject)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			log.Errorf("failed to get user by oidc identity: %v", err)
+			return err
+		}
+
+		if existing == nil {
+			taken, err := s.repo.IsEmailExists(ctx, identity.Email)
+			if err != nil {
+				log.Errorf("failed to check email: %v", err)
+				return err
+			}
+			if taken {
+				log.Warnf("oidc login rejected: email of subject=%s belongs to another account", identity.Subject)
+				return entity.ErrOIDCAccountConflict
+			}
+
+			user = &entity.User{
+				Email:        identity.Email,
+				Role:         role,
+				AuthProvider: entity.AuthProviderOIDC,
+				CreatedAt:    time.Now(),
+				OIDCIssuer:   &identity.Issuer,
+				OIDCSubject:  &identity.Subject,
+			}
+
+			if err := s.repo.CreateUser(ctx, user); err != nil {
+				log.Errorf("failed to provision oidc user: email=%s: %v", identity.Email, err)
+				return err
+			}
+
+			log.Infof("oidc user provisioned: id=%s, role=%s", user.ID, user.Role)
+			return nil
+		}
+
+		// The IdP only manages roles of the accounts it created.
+		if existing.AuthProvider == entity.AuthProviderOIDC && existing.Role != role {
+			if err := s.repo.UpdateUserRole(ctx, existing.ID, role); err != nil {
+				log.Errorf("failed to sync oidc user role: id=%s: %v", existing.ID, err)
+				return err
+			}
+
+			log.Infof("oidc user role synced: id=%s, role=%s -> %s", existing.ID, existing.Role, role)
+			existing.Role = role
+		}
+
+		user = existing
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	token, err := jwtutil.GenerateToken(user.ID.String(), string(user.Role), s.JWTSecret, 2*time.Hour)
+	if err != nil {
+		log.Warnf("failed to generate JWT: %v", err)
+		return "", err
+	}
+
+	log.Infof("user logged in via oidc: id=%s", user.ID)
+	return token, nil
+}
+
+func (s *OIDCService) mapGroupsToRole(groups []string) (entity.UserRole, bool) {
+	var result entity.UserRole
+	for _, group := range groups {
+		role, ok := s.roleMapping[group]
+		if ok && entity.RolePriority(role) > entity.RolePriority(result) {
+			result = role
+		}
+	}
+
+	return result, result != ""
+}
@@ -0,0 +1,179 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	trmsqlx "github.com/avito-tech/go-transaction-manager/drivers/sqlx/v2"
+	"github.com/avito-tech/go-transaction-manager/trm/v2/manager"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/senyabanana/pvz-service/internal/entity"
+	mocks "github.com/senyabanana/pvz-service/internal/repository/mocks"
+)
+
+type fakeIdentityProvider struct {
+	identity *entity.ExternalIdentity
+	err      error
+}
+
+func (p *fakeIdentityProvider) AuthCodeURL(state, nonce string) string {
+	return "https://idp.example.com/auth?state=" + state + "&nonce=" + nonce
+}
+
+func (p *fakeIdentityProvider) Exchange(ctx context.Context, code, nonce string) (*entity.ExternalIdentity, error) {
+	return p.identity, p.err
+}
+
+func TestOIDCService_OIDCAuthURL(t *testing.T) {
+	mockLog := logrus.New()
+
+	disabled := NewOIDCService(nil, nil, nil, nil, testJWTSecret, mockLog)
+	_, err := disabled.OIDCAuthURL("state", "nonce")
+	assert.ErrorIs(t, err, entity.ErrOIDCDisabled)
+
+	enabled := NewOIDCService(nil, &fakeIdentityProvider{}, nil, nil, testJWTSecret, mockLog)
+	url, err := enabled.OIDCAuthURL("state", "nonce")
+	assert.NoError(t, err)
+	assert.Contains(t, url, "state=state")
+}
+
+func TestOIDCService_LoginOIDC(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepository(ctrl)
+	db, mock, _ := sqlmock.New()
+	mockDB := sqlx.NewDb(db, testDriverName)
+	mockTrManager := manager.Must(trmsqlx.NewDefaultFactory(mockDB))
+	mockLog := logrus.New()
+
+	roleMapping := map[string]entity.UserRole{
+		"pvz-employees":  entity.RoleEmployee,
+		"pvz-moderators": entity.RoleModerator,
+	}
+
+	userID := uuid.New()
+	identity := &entity.ExternalIdentity{
+		Issuer:        "https://idp.example.com",
+		Subject:       "sub",
+		Email:         "staff@example.com",
+		EmailVerified: true,
+		Groups:        []string{"pvz-employees", "pvz-moderators"},
+	}
+
+	tests := []struct {
+		name     string
+		provider *fakeIdentityProvider
+		setup    func()
+		wantErr  error
+	}{
+		{
+			name:     "provision new user",
+			provider: &fakeIdentityProvider{identity: identity},
+			setup: func() {
+				mock.ExpectBegin()
+				mockRepo.EXPECT().GetUserByOIDCIdentity(gomock.Any(), "https://idp.example.com", "sub").Return(nil, sql.ErrNoRows)
+				mockRepo.EXPECT().IsEmailExists(gomock.Any(), "staff@example.com").Return(false, nil)
+				mockRepo.EXPECT().CreateUser(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, user *entity.User) error {
+						assert.Equal(t, entity.RoleModerator, user.Role)
+						assert.Equal(t, entity.AuthProviderOIDC, user.AuthProvider)
+						assert.Equal(t, "sub", *user.OIDCSubject)
+						user.ID = userID
+						return nil
+					})
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:     "sync role of existing user",
+			provider: &fakeIdentityProvider{identity: identity},
+			setup: func() {
+				mock.ExpectBegin()
+				mockRepo.EXPECT().GetUserByOIDCIdentity(gomock.Any(), "https://idp.example.com", "sub").Return(&entity.User{
+					ID:           userID,
+					Email:        "staff@example.com",
+					Role:         entity.RoleEmployee,
+					AuthProvider: entity.AuthProviderOIDC,
+				}, nil)
+				mockRepo.EXPECT().UpdateUserRole(gomock.Any(), userID, entity.RoleModerator).Return(nil)
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:     "email of an existing account is not linked",
+			provider: &fakeIdentityProvider{identity: identity},
+			setup: func() {
+				// A local moderator registered with the same email must neither be logged into
+				// nor have their role changed by the identity provider.
+				mock.ExpectBegin()
+				mockRepo.EXPECT().GetUserByOIDCIdentity(gomock.Any(), "https://idp.example.com", "sub").Return(nil, sql.ErrNoRows)
+				mockRepo.EXPECT().IsEmailExists(gomock.Any(), "staff@example.com").Return(true, nil)
+				mockRepo.EXPECT().UpdateUserRole(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+				mock.ExpectRollback()
+			},
+			wantErr: entity.ErrOIDCAccountConflict,
+		},
+		{
+			name: "email not verified",
+			provider: &fakeIdentityProvider{identity: &entity.ExternalIdentity{
+				Issuer:  "https://idp.example.com",
+				Subject: "sub",
+				Email:   "staff@example.com",
+				Groups:  []string{"pvz-employees"},
+			}},
+			setup:   func() {},
+			wantErr: entity.ErrOIDCEmailNotVerified,
+		},
+		{
+			name:     "exchange failed",
+			provider: &fakeIdentityProvider{err: errors.New("invalid_grant")},
+			setup:    func() {},
+			wantErr:  entity.ErrOIDCAuthFailed,
+		},
+		{
+			name: "no mapped role",
+			provider: &fakeIdentityProvider{identity: &entity.ExternalIdentity{
+				Subject: "sub",
+				Email:   "staff@example.com",
+				Groups:  []string{"marketing"},
+			}},
+			setup:   func() {},
+			wantErr: entity.ErrOIDCNoRole,
+		},
+		{
+			name:     "repo error",
+			provider: &fakeIdentityProvider{identity: identity},
+			setup: func() {
+				mock.ExpectBegin()
+				mockRepo.EXPECT().GetUserByOIDCIdentity(gomock.Any(), "https://idp.example.com", "sub").Return(nil, errors.New("db error"))
+				mock.ExpectRollback()
+			},
+			wantErr: errors.New("db error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+
+			svc := NewOIDCService(mockRepo, tt.provider, roleMapping, mockTrManager, testJWTSecret, mockLog)
+			token, err := svc.LoginOIDC(context.Background(), "code", "nonce")
+			if tt.wantErr != nil {
+				assert.ErrorContains(t, err, tt.wantErr.Error())
+				assert.Empty(t, token)
+			} else {
+				assert.NoError(t, err)
+				assert.NotEmpty(t, token)
+			}
+		})
+	}
+}
@@ -0,0 +1,215 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/avito-tech/go-transaction-manager/trm/v2/manager"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+
+	"github.com/senyabanana/pvz-service/internal/entity"
+	"github.com/senyabanana/pvz-service/internal/infrastructure/logger"
+	"github.com/senyabanana/pvz-service/internal/infrastructure/security"
+	"github.com/senyabanana/pvz-service/internal/repository"
+)
+
+const resetTokenBytes = 32
+
+type Notifier interface {
+	SendPasswordReset(ctx context.Context, email, token string, expiresAt time.Time) error
+}
+
+type PasswordService struct {
+	userRepo  repository.UserRepository
+	resetRepo repository.PasswordResetRepository
+	hasher    *security.PasswordHasher
+	policy    security.PasswordPolicy
+	notifier  Notifier
+	resetTTL  time.Duration
+	trManager *manager.Manager
+	log       *logrus.Logger
+}
+
+func NewPasswordService(
+	userRepo repository.UserRepository, resetRepo repository.PasswordResetRepository, hasher *security.PasswordHasher,
+	policy security.PasswordPolicy, notifier Notifier, resetTTL time.Duration, trManager *manager.Manager, log *logrus.Logger,
+) *PasswordService {
+	return &PasswordService{
+		userRepo:  userRepo,
+		resetRepo: resetRepo,
+		hasher:    hasher,
+		policy:    policy,
+		notifier:  notifier,
+		resetTTL:  resetTTL,
+		trManager: trManager,
+		log:       log,
+	}
+}
+
+func (s *PasswordService) ChangePassword(ctx context.Context, userID uuid.UUID, currentPassword, newPassword string) error {
+	ctx, span := tracer.Start(ctx, "PasswordService.ChangePassword")
+	defer span.End()
+	log := logger.FromContext(ctx, s.log)
+
+	return s.trManager.Do(ctx, func(ctx context.Context) error {
+		user, err := s.userRepo.GetUserByID(ctx, userID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				log.Warnf("password change for unknown user: id=%s", userID)
+				return entity.ErrUserNotFound
+			}
+			log.Errorf("failed to get user: %v", err)
+			return err
+		}
+
+		if user.AuthProvider == entity.AuthProviderOIDC {
+			log.Warnf("password change attempt for sso user: id=%s", userID)
+			return entity.ErrPasswordNotManaged
+		}
+
+		if err := s.hasher.Compare(currentPassword, user.Password); err != nil {
+			log.Warnf("password change rejected: wrong current password: id=%s", userID)
+			return entity.ErrInvalidCredentials
+		}
+
+		if currentPassword == newPassword {
+			return entity.ErrSamePassword
+		}
+
+		if err := s.updatePassword(ctx, userID, newPassword); err != nil {
+			return err
+		}
+
+		log.Infof("password changed: id=%s", userID)
+		return nil
+	})
+}
+
+// RequestPasswordReset issues a one-time reset token and hands it to the notifier.
+// Unknown emails and SSO accounts are ignored silently so the endpoint can't be used to enumerate users.
+func (s *PasswordService) RequestPasswordReset(ctx context.Context, email string) error {
+	ctx, span := tracer.Start(ctx, "PasswordService.RequestPasswordReset")
+	defer span.End()
+	log := logger.FromContext(ctx, s.log)
+
+	rawToken, err := security.GenerateRandomString(resetTokenBytes)
+	if err != nil {
+		log.Errorf("failed to generate reset token: %v", err)
+		return err
+	}
+
+	var user *entity.User
+	now := time.Now()
+	token := &entity.PasswordResetToken{
+		TokenHash: security.HashToken(rawToken),
+		ExpiresAt: now.Add(s.resetTTL),
+		CreatedAt: now,
+	}
+
+	err = s.trManager.Do(ctx, func(ctx context.Context) error {
+		user, err = s.userRepo.GetUserByEmail(ctx, entity.NormalizeEmail(email))
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				log.Infof("password reset requested for unknown email")
+				user = nil
+				return nil
+			}
+			log.Errorf("failed to get user by email: %v", err)
+			return err
+		}
+
+		if user.AuthProvider == entity.AuthProviderOIDC {
+			log.Infof("password reset requested for sso user: id=%s", user.ID)
+			user = nil
+			return nil
+		}
+
+		if err := s.resetRepo.InvalidatePasswordResetTokens(ctx, user.ID, now); err != nil {
+			log.Errorf("failed to invalidate previous reset tokens: %v", err)
+			return err
+		}
+
+		token.UserID = user.ID
+		if err := s.resetRepo.CreatePasswordResetToken(ctx, token); err != nil {
+			log.Errorf("failed to create reset token: %v", err)
+			return err
+		}
+
+		return nil
+	})
+	if err != nil || user == nil {
+		return err
+	}
+
+	if err := s.notifier.SendPasswordReset(ctx, user.Email, rawToken, token.ExpiresAt); err != nil {
+		log.Errorf("failed to deliver password reset: id=%s: %v", user.ID, err)
+		return err
+	}
+
+	log.Infof("password reset token issued: user=%s, expiresAt=%s", user.ID, token.ExpiresAt.Format(time.RFC3339))
+	return nil
+}
+
+func (s *PasswordService) ResetPassword(ctx context.Context, rawToken, newPassword string) error {
+	ctx, span := tracer.Start(ctx, "PasswordService.ResetPassword")
+	defer span.End()
+	log := logger.FromContext(ctx, s.log)
+
+	if err := s.policy.Validate(newPassword); err != nil {
+		return err
+	}
+
+	return s.trManager.Do(ctx, func(ctx context.Context) error {
+		token, err := s.resetRepo.GetPasswordResetTokenByHash(ctx, security.HashToken(rawToken))
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				log.Warn("password reset with unknown token")
+				return entity.ErrInvalidResetToken
+			}
+			log.Errorf("failed to get reset token: %v", err)
+			return err
+		}
+
+		now := time.Now()
+		if !token.IsUsable(now) {
+			log.Warnf("password reset with used or expired token: id=%s", token.ID)
+			return entity.ErrInvalidResetToken
+		}
+
+		if err := s.updatePassword(ctx, token.UserID, newPassword); err != nil {
+			return err
+		}
+
+		if err := s.resetRepo.InvalidatePasswordResetTokens(ctx, token.UserID, now); err != nil {
+			log.Errorf("failed to invalidate reset tokens: %v", err)
+			return err
+		}
+
+		log.Infof("password reset completed: user=%s", token.UserID)
+		return nil
+	})
+}
+
+func (s *PasswordService) updatePassword(ctx context.Context, userID uuid.UUID, password string) error {
+	log := logger.FromContext(ctx, s.log)
+
+	if err := s.policy.Validate(password); err != nil {
+		return err
+	}
+
+	hash, err := s.hasher.Hash(password)
+	if err != nil {
+		log.Errorf("failed to hash password: %v", err)
+		return err
+	}
+
+	if err := s.userRepo.UpdatePassword(ctx, userID, hash); err != nil {
+		log.Errorf("failed to update password: id=%s: %v", userID, err)
+		return err
+	}
+
+	return nil
+}
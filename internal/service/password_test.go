@@ -0,0 +1,319 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	trmsqlx "github.com/avito-tech/go-transaction-manager/drivers/sqlx/v2"
+	"github.com/avito-tech/go-transaction-manager/trm/v2/manager"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/senyabanana/pvz-service/internal/entity"
+	"github.com/senyabanana/pvz-service/internal/infrastructure/security"
+	mocks "github.com/senyabanana/pvz-service/internal/repository/mocks"
+)
+
+type fakeNotifier struct {
+	email string
+	token string
+	err   error
+}
+
+func (n *fakeNotifier) SendPasswordReset(ctx context.Context, email, token string, expiresAt time.Time) error {
+	n.email, n.token = email, token
+	return n.err
+}
+
+func TestPasswordService_ChangePassword(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserRepo := mocks.NewMockUserRepository(ctrl)
+	db, mock, _ := sqlmock.New()
+	mockDB := sqlx.NewDb(db, testDriverName)
+	mockTrManager := manager.Must(trmsqlx.NewDefaultFactory(mockDB))
+	mockLog := logrus.New()
+
+	svc := NewPasswordService(mockUserRepo, nil, testPasswordHasher, testPasswordPolicy, nil, time.Hour, mockTrManager, mockLog)
+
+	userID := uuid.New()
+	hash, _ := testPasswordHasher.Hash("old-s3cret")
+	user := &entity.User{ID: userID, Password: hash, AuthProvider: entity.AuthProviderLocal}
+
+	tests := []struct {
+		name        string
+		current     string
+		newPassword string
+		setup       func()
+		wantErr     error
+	}{
+		{
+			name:        "success",
+			current:     "old-s3cret",
+			newPassword: "new-s3cret",
+			setup: func() {
+				mock.ExpectBegin()
+				mockUserRepo.EXPECT().GetUserByID(gomock.Any(), userID).Return(user, nil)
+				mockUserRepo.EXPECT().UpdatePassword(gomock.Any(), userID, gomock.Any()).Return(nil)
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:        "wrong current password",
+			current:     "wrong-pass1",
+			newPassword: "new-s3cret",
+			setup: func() {
+				mock.ExpectBegin()
+				mockUserRepo.EXPECT().GetUserByID(gomock.Any(), userID).Return(user, nil)
+				mock.ExpectRollback()
+			},
+			wantErr: entity.ErrInvalidCredentials,
+		},
+		{
+			name:        "weak new password",
+			current:     "old-s3cret",
+			newPassword: "short",
+			setup: func() {
+				mock.ExpectBegin()
+				mockUserRepo.EXPECT().GetUserByID(gomock.Any(), userID).Return(user, nil)
+				mock.ExpectRollback()
+			},
+			wantErr: entity.ErrWeakPassword,
+		},
+		{
+			name:        "same password",
+			current:     "old-s3cret",
+			newPassword: "old-s3cret",
+			setup: func() {
+				mock.ExpectBegin()
+				mockUserRepo.EXPECT().GetUserByID(gomock.Any(), userID).Return(user, nil)
+				mock.ExpectRollback()
+			},
+			wantErr: entity.ErrSamePassword,
+		},
+		{
+			name:        "sso user",
+			current:     "old-s3cret",
+			newPassword: "new-s3cret",
+			setup: func() {
+				mock.ExpectBegin()
+				mockUserRepo.EXPECT().GetUserByID(gomock.Any(), userID).
+					Return(&entity.User{ID: userID, AuthProvider: entity.AuthProviderOIDC}, nil)
+				mock.ExpectRollback()
+			},
+			wantErr: entity.ErrPasswordNotManaged,
+		},
+		{
+			name:        "user not found",
+			current:     "old-s3cret",
+			newPassword: "new-s3cret",
+			setup: func() {
+				mock.ExpectBegin()
+				mockUserRepo.EXPECT().GetUserByID(gomock.Any(), userID).Return(nil, sql.ErrNoRows)
+				mock.ExpectRollback()
+			},
+			wantErr: entity.ErrUserNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			err := svc.ChangePassword(context.Background(), userID, tt.current, tt.newPassword)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestPasswordService_RequestPasswordReset(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserRepo := mocks.NewMockUserRepository(ctrl)
+	mockResetRepo := mocks.NewMockPasswordResetRepository(ctrl)
+	db, mock, _ := sqlmock.New()
+	mockDB := sqlx.NewDb(db, testDriverName)
+	mockTrManager := manager.Must(trmsqlx.NewDefaultFactory(mockDB))
+	mockLog := logrus.New()
+
+	userID := uuid.New()
+	user := &entity.User{ID: userID, Email: "test@example.com", AuthProvider: entity.AuthProviderLocal}
+
+	tests := []struct {
+		name       string
+		notifier   *fakeNotifier
+		setup      func()
+		wantErr    bool
+		wantNotify bool
+	}{
+		{
+			name:     "success",
+			notifier: &fakeNotifier{},
+			setup: func() {
+				mock.ExpectBegin()
+				mockUserRepo.EXPECT().GetUserByEmail(gomock.Any(), "test@example.com").Return(user, nil)
+				mockResetRepo.EXPECT().InvalidatePasswordResetTokens(gomock.Any(), userID, gomock.Any()).Return(nil)
+				mockResetRepo.EXPECT().CreatePasswordResetToken(gomock.Any(), gomock.Any()).Return(nil)
+				mock.ExpectCommit()
+			},
+			wantNotify: true,
+		},
+		{
+			name:     "unknown email is ignored",
+			notifier: &fakeNotifier{},
+			setup: func() {
+				mock.ExpectBegin()
+				mockUserRepo.EXPECT().GetUserByEmail(gomock.Any(), "test@example.com").Return(nil, sql.ErrNoRows)
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:     "notifier error",
+			notifier: &fakeNotifier{err: errors.New("smtp down")},
+			setup: func() {
+				mock.ExpectBegin()
+				mockUserRepo.EXPECT().GetUserByEmail(gomock.Any(), "test@example.com").Return(user, nil)
+				mockResetRepo.EXPECT().InvalidatePasswordResetTokens(gomock.Any(), userID, gomock.Any()).Return(nil)
+				mockResetRepo.EXPECT().CreatePasswordResetToken(gomock.Any(), gomock.Any()).Return(nil)
+				mock.ExpectCommit()
+			},
+			wantErr:    true,
+			wantNotify: true,
+		},
+		{
+			name:     "repo error",
+			notifier: &fakeNotifier{},
+			setup: func() {
+				mock.ExpectBegin()
+				mockUserRepo.EXPECT().GetUserByEmail(gomock.Any(), "test@example.com").Return(nil, errors.New("db error"))
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+
+			svc := NewPasswordService(mockUserRepo, mockResetRepo, testPasswordHasher, testPasswordPolicy, tt.notifier, time.Hour, mockTrManager, mockLog)
+			err := svc.RequestPasswordReset(context.Background(), "test@example.com")
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			if tt.wantNotify {
+				assert.Equal(t, "test@example.com", tt.notifier.email)
+				assert.NotEmpty(t, tt.notifier.token)
+			} else {
+				assert.Empty(t, tt.notifier.token)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestPasswordService_ResetPassword(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserRepo := mocks.NewMockUserRepository(ctrl)
+	mockResetRepo := mocks.NewMockPasswordResetRepository(ctrl)
+	db, mock, _ := sqlmock.New()
+	mockDB := sqlx.NewDb(db, testDriverName)
+	mockTrManager := manager.Must(trmsqlx.NewDefaultFactory(mockDB))
+	mockLog := logrus.New()
+
+	svc := NewPasswordService(mockUserRepo, mockResetRepo, testPasswordHasher, testPasswordPolicy, nil, time.Hour, mockTrManager, mockLog)
+
+	userID := uuid.New()
+	now := time.Now()
+	rawToken := "raw-token"
+	tokenHash := security.HashToken(rawToken)
+
+	tests := []struct {
+		name        string
+		newPassword string
+		setup       func()
+		wantErr     error
+	}{
+		{
+			name:        "success",
+			newPassword: "new-s3cret",
+			setup: func() {
+				mock.ExpectBegin()
+				mockResetRepo.EXPECT().GetPasswordResetTokenByHash(gomock.Any(), tokenHash).
+					Return(&entity.PasswordResetToken{ID: uuid.New(), UserID: userID, ExpiresAt: now.Add(time.Hour)}, nil)
+				mockUserRepo.EXPECT().UpdatePassword(gomock.Any(), userID, gomock.Any()).Return(nil)
+				mockResetRepo.EXPECT().InvalidatePasswordResetTokens(gomock.Any(), userID, gomock.Any()).Return(nil)
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:        "weak password",
+			newPassword: "qwerty123",
+			setup:       func() {},
+			wantErr:     entity.ErrWeakPassword,
+		},
+		{
+			name:        "unknown token",
+			newPassword: "new-s3cret",
+			setup: func() {
+				mock.ExpectBegin()
+				mockResetRepo.EXPECT().GetPasswordResetTokenByHash(gomock.Any(), tokenHash).Return(nil, sql.ErrNoRows)
+				mock.ExpectRollback()
+			},
+			wantErr: entity.ErrInvalidResetToken,
+		},
+		{
+			name:        "expired token",
+			newPassword: "new-s3cret",
+			setup: func() {
+				mock.ExpectBegin()
+				mockResetRepo.EXPECT().GetPasswordResetTokenByHash(gomock.Any(), tokenHash).
+					Return(&entity.PasswordResetToken{ID: uuid.New(), UserID: userID, ExpiresAt: now.Add(-time.Minute)}, nil)
+				mock.ExpectRollback()
+			},
+			wantErr: entity.ErrInvalidResetToken,
+		},
+		{
+			name:        "used token",
+			newPassword: "new-s3cret",
+			setup: func() {
+				mock.ExpectBegin()
+				mockResetRepo.EXPECT().GetPasswordResetTokenByHash(gomock.Any(), tokenHash).
+					Return(&entity.PasswordResetToken{ID: uuid.New(), UserID: userID, ExpiresAt: now.Add(time.Hour), UsedAt: &now}, nil)
+				mock.ExpectRollback()
+			},
+			wantErr: entity.ErrInvalidResetToken,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			err := svc.ResetPassword(context.Background(), rawToken, tt.newPassword)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/avito-tech/go-transaction-manager/trm/v2/manager"
@@ -9,43 +10,69 @@ import (
 	"github.com/sirupsen/logrus"
 
 	"github.com/senyabanana/pvz-service/internal/entity"
+	"github.com/senyabanana/pvz-service/internal/infrastructure/logger"
 	"github.com/senyabanana/pvz-service/internal/infrastructure/monitoring"
 	"github.com/senyabanana/pvz-service/internal/repository"
 )
 
 type ProductService struct {
-	productRepo   repository.ProductRepository
-	receptionRepo repository.ReceptionRepository
-	trManager     *manager.Manager
-	log           *logrus.Logger
+	productRepo    repository.ProductRepository
+	receptionRepo  repository.ReceptionRepository
+	pvzRepo        repository.PVZRepository
+	capacityRepo   repository.CapacityRepository
+	capacityPolicy entity.CapacityPolicy
+	trManager      *manager.Manager
+	log            *logrus.Logger
 }
 
 func NewProductService(
-	productRepo repository.ProductRepository, receptionRepo repository.ReceptionRepository, trManager *manager.Manager, log *logrus.Logger,
+	productRepo repository.ProductRepository,
+	receptionRepo repository.ReceptionRepository,
+	pvzRepo repository.PVZRepository,
+	capacityRepo repository.CapacityRepository,
+	capacityPolicy entity.CapacityPolicy,
+	trManager *manager.Manager,
+	log *logrus.Logger,
 ) *ProductService {
 	return &ProductService{
-		receptionRepo: receptionRepo,
-		productRepo:   productRepo,
-		trManager:     trManager,
-		log:           log,
+		receptionRepo:  receptionRepo,
+		productRepo:    productRepo,
+		pvzRepo:        pvzRepo,
+		capacityRepo:   capacityRepo,
+		capacityPolicy: capacityPolicy,
+		trManager:      trManager,
+		log:            log,
 	}
 }
 
 func (s *ProductService) AddProduct(ctx context.Context, pvzID uuid.UUID, productType entity.ProductType) (*entity.Product, error) {
+	ctx, span := tracer.Start(ctx, "ProductService.AddProduct")
+	defer span.End()
+	log := logger.FromContext(ctx, s.log).WithField(logger.FieldPVZID, pvzID)
+
 	if !entity.IsValidProductType(productType) {
-		s.log.Warnf("invalid product type: %s", productType)
+		log.Warnf("invalid product type: %s", productType)
 		return nil, entity.ErrInvalidProductType
 	}
 
 	var result *entity.Product
 
 	err := s.trManager.Do(ctx, func(ctx context.Context) error {
+		if err := ensurePVZActive(ctx, s.pvzRepo, pvzID, log); err != nil {
+			return err
+		}
+
 		reception, err := s.receptionRepo.GetOpenReception(ctx, pvzID)
 		if err != nil {
-			s.log.Warnf("no open reception for pvz: %s, err: %v", pvzID, err)
+			log.Warnf("no open reception for pvz: %s, err: %v", pvzID, err)
 			return entity.ErrNoActiveReception
 		}
 
+		incoming := map[entity.ProductType]int{productType: 1}
+		if err := enforceCapacity(ctx, s.capacityRepo, s.capacityPolicy, pvzID, incoming, log); err != nil {
+			return err
+		}
+
 		product := &entity.Product{
 			DateTime:    time.Now(),
 			Type:        productType,
@@ -53,7 +80,18 @@ func (s *ProductService) AddProduct(ctx context.Context, pvzID uuid.UUID, produc
 		}
 
 		if err := s.productRepo.CreateProduct(ctx, product); err != nil {
-			s.log.Errorf("failed to create product: %v", err)
+			log.Errorf("failed to create product: %v", err)
+			return err
+		}
+
+		received := entity.ProductStatusChange{
+			ProductID: product.ID,
+			To:        entity.ProductStatusReceived,
+			PVZID:     &pvzID,
+			ChangedAt: product.DateTime,
+		}
+		if err := s.productRepo.AddProductStatusChanges(ctx, []entity.ProductStatusChange{received}); err != nil {
+			log.Errorf("failed to record product status: %v", err)
 			return err
 		}
 
@@ -65,35 +103,88 @@ func (s *ProductService) AddProduct(ctx context.Context, pvzID uuid.UUID, produc
 		return nil, err
 	}
 
-	s.log.Infof("product added to reception: type=%s, pvz=%s", result.Type, pvzID)
+	log.Infof("product added to reception: type=%s, pvz=%s", result.Type, pvzID)
 	monitoring.AddedProductsCounter.Inc()
 	return result, nil
 }
 
 func (s *ProductService) DeleteLastProduct(ctx context.Context, pvzID uuid.UUID) error {
+	ctx, span := tracer.Start(ctx, "ProductService.DeleteLastProduct")
+	defer span.End()
+	log := logger.FromContext(ctx, s.log).WithField(logger.FieldPVZID, pvzID)
+
 	return s.trManager.Do(ctx, func(ctx context.Context) error {
 		reception, err := s.receptionRepo.GetOpenReception(ctx, pvzID)
 		if err != nil {
-			s.log.Warnf("no open reception for pvz: %s, err: %v", pvzID, err)
+			log.Warnf("no open reception for pvz: %s, err: %v", pvzID, err)
 			return entity.ErrNoOpenReception
 		}
 
 		productID, err := s.productRepo.DeleteLastProduct(ctx, reception.ID)
 		if err != nil {
-			s.log.Errorf("failed to delete last product: %v", err)
+			log.Errorf("failed to delete last product: %v", err)
 			return err
 		}
 
 		if productID == nil {
-			s.log.Warnf("no products to delete for reception: %s", reception.ID)
+			log.Warnf("no products to delete for reception: %s", reception.ID)
 			return entity.ErrNoProductsToDelete
 		}
 
-		s.log.Infof("product deleted: %s", *productID)
+		log.Infof("product deleted: %s", *productID)
 		return nil
 	})
 }
 
+// GetProductHistory returns the status history of a product, oldest entry first. Every product
+// has at least the entry written when it was received, so an empty history means no such product.
+func (s *ProductService) GetProductHistory(ctx context.Context, productID uuid.UUID) ([]entity.ProductStatusChange, error) {
+	ctx, span := tracer.Start(ctx, "ProductService.GetProductHistory")
+	defer span.End()
+	log := logger.FromContext(ctx, s.log)
+
+	history, err := s.productRepo.GetProductStatusHistory(ctx, productID)
+	if err != nil {
+		log.Errorf("failed to get status history of product %s: %v", productID, err)
+		return nil, err
+	}
+
+	if len(history) == 0 {
+		return nil, entity.ErrProductNotFound
+	}
+
+	return history, nil
+}
+
+// checkProductTransition enforces the product state machine.
+func checkProductTransition(from, to entity.ProductStatus) error {
+	if !from.CanTransitionTo(to) {
+		return fmt.Errorf("%w: %s -> %s", entity.ErrProductStatusTransition, from, to)
+	}
+
+	return nil
+}
+
+// statusChanges builds the history entries for products that moved from one status to another at a PVZ.
+func statusChanges(
+	productIDs []uuid.UUID, from, to entity.ProductStatus, pvzID uuid.UUID, changedBy *uuid.UUID, changedAt time.Time, comment string,
+) []entity.ProductStatusChange {
+	changes := make([]entity.ProductStatusChange, 0, len(productIDs))
+	for _, productID := range productIDs {
+		changes = append(changes, entity.ProductStatusChange{
+			ProductID: productID,
+			From:      &from,
+			To:        to,
+			PVZID:     &pvzID,
+			ChangedAt: changedAt,
+			ChangedBy: changedBy,
+			Comment:   comment,
+		})
+	}
+
+	return changes
+}
+
 func groupProductsByReceptionID(products []entity.Product) map[uuid.UUID][]entity.Product {
 	result := make(map[uuid.UUID][]entity.Product)
 	for _, product := range products {
@@ -24,21 +24,40 @@ func TestProductService_AddProduct(t *testing.T) {
 
 	mockReceptionRepo := mocks.NewMockReceptionRepository(ctrl)
 	mockProductRepo := mocks.NewMockProductRepository(ctrl)
+	mockPVZRepo := mocks.NewMockPVZRepository(ctrl)
+	mockCapacityRepo := mocks.NewMockCapacityRepository(ctrl)
 	db, mock, _ := sqlmock.New()
 	mockDB := sqlx.NewDb(db, testDriverName)
 	trManager := manager.Must(trmsqlx.NewDefaultFactory(mockDB))
 	mockLog := logrus.New()
 
-	svc := NewProductService(mockProductRepo, mockReceptionRepo, trManager, mockLog)
+	svc := NewProductService(mockProductRepo, mockReceptionRepo, mockPVZRepo, mockCapacityRepo, entity.CapacityPolicyReject, trManager, mockLog)
+	warnSvc := NewProductService(mockProductRepo, mockReceptionRepo, mockPVZRepo, mockCapacityRepo, entity.CapacityPolicyWarn, trManager, mockLog)
 
 	validReception := &entity.Reception{
 		ID: uuid.New(),
 	}
 
+	unlimited := func() {
+		mockCapacityRepo.EXPECT().GetPVZCapacity(gomock.Any(), gomock.Any()).Return(&entity.PVZCapacity{}, nil)
+		mockCapacityRepo.EXPECT().GetPVZOccupancy(gomock.Any(), gomock.Any()).Return(map[entity.ProductType]int{}, nil)
+	}
+	full := func() {
+		total := 10
+		mockCapacityRepo.EXPECT().GetPVZCapacity(gomock.Any(), gomock.Any()).Return(&entity.PVZCapacity{
+			Total:  &total,
+			ByType: map[entity.ProductType]int{entity.ProductShoes: 2},
+		}, nil)
+		mockCapacityRepo.EXPECT().GetPVZOccupancy(gomock.Any(), gomock.Any()).Return(map[entity.ProductType]int{
+			entity.ProductShoes: 2,
+		}, nil)
+	}
+
 	tests := []struct {
 		name        string
 		pvzID       uuid.UUID
 		productType entity.ProductType
+		warn        bool
 		setup       func()
 		wantErr     error
 	}{
@@ -48,8 +67,55 @@ func TestProductService_AddProduct(t *testing.T) {
 			productType: entity.ProductClothing,
 			setup: func() {
 				mock.ExpectBegin()
+				mockPVZRepo.EXPECT().GetPVZStatus(gomock.Any(), gomock.Any()).Return(entity.PVZStatusActive, nil)
 				mockReceptionRepo.EXPECT().GetOpenReception(gomock.Any(), gomock.Any()).Return(validReception, nil)
+				unlimited()
 				mockProductRepo.EXPECT().CreateProduct(gomock.Any(), gomock.Any()).Return(nil)
+				mockProductRepo.EXPECT().AddProductStatusChanges(gomock.Any(), gomock.Any()).Return(nil)
+				mock.ExpectCommit()
+			},
+			wantErr: nil,
+		},
+		{
+			name:        "type capacity exceeded",
+			pvzID:       uuid.New(),
+			productType: entity.ProductShoes,
+			setup: func() {
+				mock.ExpectBegin()
+				mockPVZRepo.EXPECT().GetPVZStatus(gomock.Any(), gomock.Any()).Return(entity.PVZStatusActive, nil)
+				mockReceptionRepo.EXPECT().GetOpenReception(gomock.Any(), gomock.Any()).Return(validReception, nil)
+				full()
+				mock.ExpectRollback()
+			},
+			wantErr: entity.ErrCapacityExceeded,
+		},
+		{
+			name:        "other type still fits",
+			pvzID:       uuid.New(),
+			productType: entity.ProductClothing,
+			setup: func() {
+				mock.ExpectBegin()
+				mockPVZRepo.EXPECT().GetPVZStatus(gomock.Any(), gomock.Any()).Return(entity.PVZStatusActive, nil)
+				mockReceptionRepo.EXPECT().GetOpenReception(gomock.Any(), gomock.Any()).Return(validReception, nil)
+				full()
+				mockProductRepo.EXPECT().CreateProduct(gomock.Any(), gomock.Any()).Return(nil)
+				mockProductRepo.EXPECT().AddProductStatusChanges(gomock.Any(), gomock.Any()).Return(nil)
+				mock.ExpectCommit()
+			},
+			wantErr: nil,
+		},
+		{
+			name:        "capacity exceeded with warn policy",
+			pvzID:       uuid.New(),
+			productType: entity.ProductShoes,
+			warn:        true,
+			setup: func() {
+				mock.ExpectBegin()
+				mockPVZRepo.EXPECT().GetPVZStatus(gomock.Any(), gomock.Any()).Return(entity.PVZStatusActive, nil)
+				mockReceptionRepo.EXPECT().GetOpenReception(gomock.Any(), gomock.Any()).Return(validReception, nil)
+				full()
+				mockProductRepo.EXPECT().CreateProduct(gomock.Any(), gomock.Any()).Return(nil)
+				mockProductRepo.EXPECT().AddProductStatusChanges(gomock.Any(), gomock.Any()).Return(nil)
 				mock.ExpectCommit()
 			},
 			wantErr: nil,
@@ -67,18 +133,32 @@ func TestProductService_AddProduct(t *testing.T) {
 			productType: entity.ProductShoes,
 			setup: func() {
 				mock.ExpectBegin()
+				mockPVZRepo.EXPECT().GetPVZStatus(gomock.Any(), gomock.Any()).Return(entity.PVZStatusActive, nil)
 				mockReceptionRepo.EXPECT().GetOpenReception(gomock.Any(), gomock.Any()).Return(nil, errors.New("not found"))
 				mock.ExpectRollback()
 			},
 			wantErr: entity.ErrNoActiveReception,
 		},
+		{
+			name:        "pvz closed",
+			pvzID:       uuid.New(),
+			productType: entity.ProductShoes,
+			setup: func() {
+				mock.ExpectBegin()
+				mockPVZRepo.EXPECT().GetPVZStatus(gomock.Any(), gomock.Any()).Return(entity.PVZStatusClosed, nil)
+				mock.ExpectRollback()
+			},
+			wantErr: entity.ErrPVZNotActive,
+		},
 		{
 			name:        "db error on create product",
 			pvzID:       uuid.New(),
 			productType: entity.ProductElectronics,
 			setup: func() {
 				mock.ExpectBegin()
+				mockPVZRepo.EXPECT().GetPVZStatus(gomock.Any(), gomock.Any()).Return(entity.PVZStatusActive, nil)
 				mockReceptionRepo.EXPECT().GetOpenReception(gomock.Any(), gomock.Any()).Return(validReception, nil)
+				unlimited()
 				mockProductRepo.EXPECT().CreateProduct(gomock.Any(), gomock.Any()).Return(errors.New("insert error"))
 				mock.ExpectRollback()
 			},
@@ -89,7 +169,11 @@ func TestProductService_AddProduct(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.setup()
-			_, err := svc.AddProduct(context.Background(), tt.pvzID, tt.productType)
+			target := svc
+			if tt.warn {
+				target = warnSvc
+			}
+			_, err := target.AddProduct(context.Background(), tt.pvzID, tt.productType)
 			if tt.wantErr != nil {
 				assert.Error(t, err)
 				assert.ErrorContains(t, err, tt.wantErr.Error())
@@ -112,7 +196,7 @@ func TestProductService_DeleteLastProduct(t *testing.T) {
 	trManager := manager.Must(trmsqlx.NewDefaultFactory(mockDB))
 	mockLog := logrus.New()
 
-	svc := NewProductService(mockProductRepo, mockReceptionRepo, trManager, mockLog)
+	svc := NewProductService(mockProductRepo, mockReceptionRepo, nil, nil, entity.CapacityPolicyReject, trManager, mockLog)
 
 	receptionID := uuid.New()
 	reception := &entity.Reception{ID: receptionID}
@@ -181,3 +265,59 @@ func TestProductService_DeleteLastProduct(t *testing.T) {
 		})
 	}
 }
+
+func TestProductService_GetProductHistory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockProductRepo := mocks.NewMockProductRepository(ctrl)
+	svc := NewProductService(mockProductRepo, nil, nil, nil, entity.CapacityPolicyReject, nil, logrus.New())
+
+	productID := uuid.New()
+	received := entity.ProductStatusReceived
+
+	tests := []struct {
+		name    string
+		setup   func()
+		wantLen int
+		wantErr error
+	}{
+		{
+			name: "success",
+			setup: func() {
+				mockProductRepo.EXPECT().GetProductStatusHistory(gomock.Any(), productID).Return([]entity.ProductStatusChange{
+					{ProductID: productID, To: entity.ProductStatusReceived},
+					{ProductID: productID, From: &received, To: entity.ProductStatusIssued},
+				}, nil)
+			},
+			wantLen: 2,
+		},
+		{
+			name: "product not found",
+			setup: func() {
+				mockProductRepo.EXPECT().GetProductStatusHistory(gomock.Any(), productID).Return(nil, nil)
+			},
+			wantErr: entity.ErrProductNotFound,
+		},
+		{
+			name: "db error",
+			setup: func() {
+				mockProductRepo.EXPECT().GetProductStatusHistory(gomock.Any(), productID).Return(nil, errors.New("db error"))
+			},
+			wantErr: errors.New("db error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			history, err := svc.GetProductHistory(context.Background(), productID)
+			if tt.wantErr != nil {
+				assert.ErrorContains(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, history, tt.wantLen)
+			}
+		})
+	}
+}
@@ -2,6 +2,10 @@ package service
 
 import (
 	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/avito-tech/go-transaction-manager/trm/v2/manager"
@@ -9,6 +13,7 @@ import (
 	"github.com/sirupsen/logrus"
 
 	"github.com/senyabanana/pvz-service/internal/entity"
+	"github.com/senyabanana/pvz-service/internal/infrastructure/logger"
 	"github.com/senyabanana/pvz-service/internal/infrastructure/monitoring"
 	"github.com/senyabanana/pvz-service/internal/repository"
 )
@@ -32,62 +37,83 @@ func NewPVZService(pvzRepo repository.PVZRepository, receptionRepo repository.Re
 }
 
 func (s *PVZService) CreatePVZ(ctx context.Context, city string) (*entity.PVZ, error) {
+	ctx, span := tracer.Start(ctx, "PVZService.CreatePVZ")
+	defer span.End()
+	log := logger.FromContext(ctx, s.log)
+
 	if !entity.IsValidCity(city) {
-		s.log.Warnf("attempt to create PVZ in unsupported city: %s", city)
+		log.Warnf("attempt to create PVZ in unsupported city: %s", city)
 		return nil, entity.ErrInvalidCity
 	}
 
 	pvz := &entity.PVZ{
 		RegistrationDate: time.Now(),
 		City:             entity.PVZCity(city),
+		Status:           entity.PVZStatusActive,
 	}
 
 	if err := s.pvzRepo.CreatePVZ(ctx, pvz); err != nil {
-		s.log.Errorf("failed to create PVZ: %v", err)
+		log.Errorf("failed to create PVZ: %v", err)
 		return nil, err
 	}
 
-	s.log.Infof("PVZ created: id=%s, city=%s", pvz.ID, city)
+	log.Infof("PVZ created: id=%s, city=%s", pvz.ID, city)
 	monitoring.CreatedPVZCounter.Inc()
 
 	return pvz, nil
 }
 
-func (s *PVZService) GetFullPVZInfo(ctx context.Context, startDate, endDate *time.Time, page, limit int) ([]entity.FullPVZInfo, error) {
+func (s *PVZService) GetFullPVZInfo(ctx context.Context, filter entity.PVZFilter) ([]entity.FullPVZInfo, error) {
+	ctx, span := tracer.Start(ctx, "PVZService.GetFullPVZInfo")
+	defer span.End()
+	log := logger.FromContext(ctx, s.log)
+
 	var result []entity.FullPVZInfo
 
-	s.log.Infof("get full PVZ info: page=%d, limit=%d, startDate=%v, endDate=%v", page, limit, startDate, endDate)
+	log.Infof("get full PVZ info: page=%d, limit=%d, startDate=%v, endDate=%v, pvzID=%v, includeClosed=%t",
+		filter.Page, filter.Limit, filter.StartDate, filter.EndDate, filter.PVZID, filter.IncludeClosed)
 
 	err := s.trManager.Do(ctx, func(ctx context.Context) error {
-		allPVZ, err := s.pvzRepo.GetAllPVZ(ctx)
+		allPVZ, err := s.pvzRepo.GetAllPVZ(ctx, filter.IncludeClosed)
 		if err != nil {
-			s.log.Errorf("failed to get PVZ list: %v", err)
+			log.Errorf("failed to get PVZ list: %v", err)
 			return err
 		}
 
-		paginated := paginatePVZ(allPVZ, page, limit)
+		if filter.PVZID != nil {
+			allPVZ = filterPVZByID(allPVZ, *filter.PVZID)
+		}
+
+		paginated := paginatePVZ(allPVZ, filter.Page, filter.Limit)
 		pvzIDs := extractPVZIDs(paginated)
 
 		allReceptions, err := s.receptionRepo.GetReceptionsByPVZIDs(ctx, pvzIDs)
 		if err != nil {
-			s.log.Errorf("failed to get receptions: %v", err)
+			log.Errorf("failed to get receptions: %v", err)
 			return err
 		}
 
-		filtered := filterReceptionsByDate(allReceptions, startDate, endDate)
+		filtered := filterReceptionsByDate(allReceptions, filter.StartDate, filter.EndDate)
 		receptionMap := groupReceptionsByPVZ(filtered)
 		receptionIDs := extractReceptionIDs(filtered)
 
 		allProducts, err := s.productRepo.GetProductsByReceptionIDs(ctx, receptionIDs)
 		if err != nil {
-			s.log.Errorf("failed to get products: %v", err)
+			log.Errorf("failed to get products: %v", err)
+			return err
+		}
+
+		allReturns, err := s.receptionRepo.GetReturnsByPVZIDs(ctx, pvzIDs)
+		if err != nil {
+			log.Errorf("failed to get returns: %v", err)
 			return err
 		}
 
 		productMap := groupProductsByReceptionID(allProducts)
-		result = buildFullPVZInfo(paginated, receptionMap, productMap)
+		returnMap := groupReturnsByPVZ(filterReturnsByDate(allReturns, filter.StartDate, filter.EndDate))
+		result = buildFullPVZInfo(paginated, receptionMap, productMap, returnMap)
 
-		s.log.Infof("successfully built full PVZ info, total %d pvz returned", len(result))
+		log.Infof("successfully built full PVZ info, total %d pvz returned", len(result))
 
 		return nil
 	})
@@ -99,9 +125,164 @@ func (s *PVZService) GetFullPVZInfo(ctx context.Context, startDate, endDate *tim
 	return result, nil
 }
 
-func (s *PVZService) GetAllPVZ(ctx context.Context) ([]entity.PVZ, error) {
-	s.log.Info("fetching all PVZ records")
-	return s.pvzRepo.GetAllPVZ(ctx)
+func (s *PVZService) GetAllPVZ(ctx context.Context, includeClosed bool) ([]entity.PVZ, error) {
+	ctx, span := tracer.Start(ctx, "PVZService.GetAllPVZ")
+	defer span.End()
+	log := logger.FromContext(ctx, s.log)
+
+	log.Infof("fetching all PVZ records, includeClosed=%t", includeClosed)
+	return s.pvzRepo.GetAllPVZ(ctx, includeClosed)
+}
+
+func (s *PVZService) ChangePVZStatus(ctx context.Context, pvzID uuid.UUID, status entity.PVZStatus, reason string) (*entity.PVZ, error) {
+	ctx, span := tracer.Start(ctx, "PVZService.ChangePVZStatus")
+	defer span.End()
+	log := logger.FromContext(ctx, s.log).WithField(logger.FieldPVZID, pvzID)
+
+	if !entity.IsValidPVZStatus(string(status)) {
+		return nil, entity.ErrInvalidPVZStatus
+	}
+
+	if status != entity.PVZStatusActive && strings.TrimSpace(reason) == "" {
+		return nil, entity.ErrPVZStatusReason
+	}
+
+	var pvz *entity.PVZ
+
+	err := s.trManager.Do(ctx, func(ctx context.Context) error {
+		var err error
+		// The lock keeps a reception from being opened between the checks below and the update.
+		pvz, err = s.pvzRepo.GetPVZByIDForUpdate(ctx, pvzID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				log.Warnf("change status of unknown PVZ: %s", pvzID)
+				return entity.ErrPVZNotFound
+			}
+			log.Errorf("failed to get PVZ %s: %v", pvzID, err)
+			return err
+		}
+
+		if !pvz.Status.CanTransitionTo(status) {
+			log.Warnf("PVZ %s status transition %s -> %s is not allowed", pvzID, pvz.Status, status)
+			return fmt.Errorf("%w: %s -> %s", entity.ErrPVZStatusTransition, pvz.Status, status)
+		}
+
+		if status == entity.PVZStatusClosed {
+			openExists, err := s.receptionRepo.IsReceptionOpenExists(ctx, pvzID)
+			if err != nil {
+				log.Errorf("failed to check open reception: %v", err)
+				return err
+			}
+			if openExists {
+				log.Warnf("attempt to close PVZ %s with an open reception", pvzID)
+				return entity.ErrPVZHasOpenReception
+			}
+		}
+
+		now := time.Now()
+		pvz.Status = status
+		pvz.StatusReason = strings.TrimSpace(reason)
+		pvz.StatusChangedAt = &now
+
+		if err := s.pvzRepo.UpdatePVZStatus(ctx, pvz); err != nil {
+			log.Errorf("failed to update PVZ %s status: %v", pvzID, err)
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	log.Infof("PVZ status changed: id=%s, status=%s, reason=%q", pvzID, status, pvz.StatusReason)
+
+	return pvz, nil
+}
+
+func (s *PVZService) UpdatePVZDetails(ctx context.Context, pvzID uuid.UUID, update entity.PVZDetailsUpdate) (*entity.PVZ, error) {
+	ctx, span := tracer.Start(ctx, "PVZService.UpdatePVZDetails")
+	defer span.End()
+	log := logger.FromContext(ctx, s.log).WithField(logger.FieldPVZID, pvzID)
+
+	var pvz *entity.PVZ
+
+	err := s.trManager.Do(ctx, func(ctx context.Context) error {
+		var err error
+		pvz, err = s.pvzRepo.GetPVZByID(ctx, pvzID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				log.Warnf("update details of unknown PVZ: %s", pvzID)
+				return entity.ErrPVZNotFound
+			}
+			log.Errorf("failed to get PVZ %s: %v", pvzID, err)
+			return err
+		}
+
+		update.Apply(pvz)
+		if err := pvz.ValidateDetails(); err != nil {
+			log.Warnf("invalid details for PVZ %s: %v", pvzID, err)
+			return err
+		}
+
+		if err := s.pvzRepo.UpdatePVZDetails(ctx, pvz); err != nil {
+			log.Errorf("failed to update PVZ %s details: %v", pvzID, err)
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	log.Infof("PVZ details updated: id=%s", pvzID)
+
+	return pvz, nil
+}
+
+func (s *PVZService) FindNearbyPVZ(ctx context.Context, filter entity.NearbyFilter) ([]entity.NearbyPVZ, error) {
+	ctx, span := tracer.Start(ctx, "PVZService.FindNearbyPVZ")
+	defer span.End()
+	log := logger.FromContext(ctx, s.log)
+
+	if !entity.IsValidCoordinates(filter.Latitude, filter.Longitude) {
+		return nil, entity.ErrInvalidCoordinates
+	}
+
+	if filter.RadiusMeters == 0 {
+		filter.RadiusMeters = entity.DefaultNearbyRadius
+	}
+	if filter.RadiusMeters < 0 || filter.RadiusMeters > entity.MaxNearbyRadius {
+		return nil, entity.ErrInvalidSearchRadius
+	}
+
+	if filter.Limit <= 0 {
+		filter.Limit = entity.DefaultNearbyLimit
+	}
+	if filter.Limit > entity.MaxNearbyLimit {
+		filter.Limit = entity.MaxNearbyLimit
+	}
+
+	log.Infof("search PVZ near (%f, %f): radius=%.0fm, limit=%d", filter.Latitude, filter.Longitude, filter.RadiusMeters, filter.Limit)
+
+	nearby, err := s.pvzRepo.GetNearbyPVZ(ctx, filter)
+	if err != nil {
+		log.Errorf("failed to search nearby PVZ: %v", err)
+		return nil, err
+	}
+
+	return nearby, nil
+}
+
+func filterPVZByID(pvz []entity.PVZ, pvzID uuid.UUID) []entity.PVZ {
+	for _, p := range pvz {
+		if p.ID == pvzID {
+			return []entity.PVZ{p}
+		}
+	}
+
+	return nil
 }
 
 func paginatePVZ(pvz []entity.PVZ, page, limit int) []entity.PVZ {
@@ -128,7 +309,10 @@ func extractPVZIDs(pvz []entity.PVZ) []uuid.UUID {
 }
 
 func buildFullPVZInfo(
-	pvz []entity.PVZ, receptionMap map[uuid.UUID][]entity.Reception, productMap map[uuid.UUID][]entity.Product,
+	pvz []entity.PVZ,
+	receptionMap map[uuid.UUID][]entity.Reception,
+	productMap map[uuid.UUID][]entity.Product,
+	returnMap map[uuid.UUID][]entity.CustomerReturn,
 ) []entity.FullPVZInfo {
 	var result []entity.FullPVZInfo
 	for _, p := range pvz {
@@ -143,6 +327,7 @@ func buildFullPVZInfo(
 		result = append(result, entity.FullPVZInfo{
 			PVZ:        p,
 			Receptions: receptions,
+			Returns:    returnMap[p.ID],
 		})
 	}
 	return result
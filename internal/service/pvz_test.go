@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"testing"
 	"time"
@@ -99,7 +100,7 @@ func TestPVZService_GetFullPVZInfo(t *testing.T) {
 			name: "success",
 			setup: func() {
 				mock.ExpectBegin()
-				mockPVZRepo.EXPECT().GetAllPVZ(gomock.Any()).Return([]entity.PVZ{
+				mockPVZRepo.EXPECT().GetAllPVZ(gomock.Any(), false).Return([]entity.PVZ{
 					{ID: pvzID, RegistrationDate: now, City: "Москва"},
 				}, nil)
 
@@ -114,6 +115,12 @@ func TestPVZService_GetFullPVZInfo(t *testing.T) {
 					Return([]entity.Product{
 						{ID: uuid.New(), Type: entity.ProductClothing, ReceptionID: receptionID, DateTime: now},
 					}, nil)
+
+				mockReceptionRepo.EXPECT().
+					GetReturnsByPVZIDs(gomock.Any(), []uuid.UUID{pvzID}).
+					Return([]entity.CustomerReturn{
+						{ID: uuid.New(), PVZID: pvzID, Status: entity.ReturnStatusAccepted, AcceptedAt: now},
+					}, nil)
 				mock.ExpectCommit()
 			},
 			wantErr:      false,
@@ -123,7 +130,7 @@ func TestPVZService_GetFullPVZInfo(t *testing.T) {
 			name: "fail on GetAllPVZ",
 			setup: func() {
 				mock.ExpectBegin()
-				mockPVZRepo.EXPECT().GetAllPVZ(gomock.Any()).Return(nil, errors.New("db error"))
+				mockPVZRepo.EXPECT().GetAllPVZ(gomock.Any(), false).Return(nil, errors.New("db error"))
 				mock.ExpectRollback()
 			},
 			wantErr: true,
@@ -132,7 +139,7 @@ func TestPVZService_GetFullPVZInfo(t *testing.T) {
 			name: "fail on GetReceptionsByPVZIDs",
 			setup: func() {
 				mock.ExpectBegin()
-				mockPVZRepo.EXPECT().GetAllPVZ(gomock.Any()).Return([]entity.PVZ{
+				mockPVZRepo.EXPECT().GetAllPVZ(gomock.Any(), false).Return([]entity.PVZ{
 					{ID: pvzID, RegistrationDate: now, City: "Казань"},
 				}, nil)
 				mockReceptionRepo.EXPECT().
@@ -146,7 +153,7 @@ func TestPVZService_GetFullPVZInfo(t *testing.T) {
 			name: "fail on GetProductsByReceptionIDs",
 			setup: func() {
 				mock.ExpectBegin()
-				mockPVZRepo.EXPECT().GetAllPVZ(gomock.Any()).Return([]entity.PVZ{
+				mockPVZRepo.EXPECT().GetAllPVZ(gomock.Any(), false).Return([]entity.PVZ{
 					{ID: pvzID, RegistrationDate: now, City: "Казань"},
 				}, nil)
 
@@ -163,18 +170,42 @@ func TestPVZService_GetFullPVZInfo(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "fail on GetReturnsByPVZIDs",
+			setup: func() {
+				mock.ExpectBegin()
+				mockPVZRepo.EXPECT().GetAllPVZ(gomock.Any(), false).Return([]entity.PVZ{
+					{ID: pvzID, RegistrationDate: now, City: "Казань"},
+				}, nil)
+
+				mockReceptionRepo.EXPECT().
+					GetReceptionsByPVZIDs(gomock.Any(), []uuid.UUID{pvzID}).
+					Return(nil, nil)
+
+				mockProductRepo.EXPECT().
+					GetProductsByReceptionIDs(gomock.Any(), gomock.Any()).
+					Return(nil, nil)
+
+				mockReceptionRepo.EXPECT().
+					GetReturnsByPVZIDs(gomock.Any(), []uuid.UUID{pvzID}).
+					Return(nil, errors.New("fail get returns"))
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.setup()
-			resp, err := svc.GetFullPVZInfo(context.Background(), nil, nil, 1, 10)
+			resp, err := svc.GetFullPVZInfo(context.Background(), entity.PVZFilter{Page: 1, Limit: 10})
 
 			if tt.wantErr {
 				assert.Error(t, err)
 			} else {
 				assert.NoError(t, err)
 				assert.Len(t, resp, tt.expectedSize)
+				assert.Len(t, resp[0].Returns, 1)
 			}
 		})
 	}
@@ -204,7 +235,7 @@ func TestPVZService_GetAllPVZ(t *testing.T) {
 			name: "success",
 			setup: func() {
 				mockPVZRepo.EXPECT().
-					GetAllPVZ(gomock.Any()).
+					GetAllPVZ(gomock.Any(), false).
 					Return(expectedPVZ, nil)
 			},
 			wantErr:  nil,
@@ -214,7 +245,7 @@ func TestPVZService_GetAllPVZ(t *testing.T) {
 			name: "repo error",
 			setup: func() {
 				mockPVZRepo.EXPECT().
-					GetAllPVZ(gomock.Any()).
+					GetAllPVZ(gomock.Any(), false).
 					Return(nil, errors.New("db fail"))
 			},
 			wantErr:  errors.New("db fail"),
@@ -226,7 +257,7 @@ func TestPVZService_GetAllPVZ(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.setup()
 
-			result, err := svc.GetAllPVZ(context.Background())
+			result, err := svc.GetAllPVZ(context.Background(), false)
 			if tt.wantErr != nil {
 				assert.ErrorContains(t, err, tt.wantErr.Error())
 			} else {
@@ -236,3 +267,311 @@ func TestPVZService_GetAllPVZ(t *testing.T) {
 		})
 	}
 }
+
+func TestPVZService_UpdatePVZDetails(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPVZRepo := mocks.NewMockPVZRepository(ctrl)
+	db, mock, _ := sqlmock.New()
+	mockDB := sqlx.NewDb(db, testDriverName)
+	trxManager := manager.Must(trmsqlx.NewDefaultFactory(mockDB))
+	mockLog := logrus.New()
+
+	svc := NewPVZService(mockPVZRepo, nil, nil, trxManager, mockLog)
+
+	pvzID := uuid.New()
+	address := "Казань, ул. Баумана, 1"
+	phone := "+7 843 000-00-00"
+	lat, lon := 55.79, 49.12
+	badLat := 120.0
+
+	tests := []struct {
+		name    string
+		update  entity.PVZDetailsUpdate
+		setup   func()
+		wantErr error
+	}{
+		{
+			name: "success",
+			update: entity.PVZDetailsUpdate{
+				Address:   &address,
+				Phone:     &phone,
+				Latitude:  &lat,
+				Longitude: &lon,
+				WorkingHours: &entity.WorkingHours{
+					Weekly: []entity.DailyHours{{Weekday: "monday", Open: "09:00", Close: "21:00"}},
+				},
+			},
+			setup: func() {
+				mock.ExpectBegin()
+				mockPVZRepo.EXPECT().GetPVZByID(gomock.Any(), pvzID).Return(&entity.PVZ{ID: pvzID, City: entity.CityKazan}, nil)
+				mockPVZRepo.EXPECT().UpdatePVZDetails(gomock.Any(), gomock.Any()).Return(nil)
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:   "pvz not found",
+			update: entity.PVZDetailsUpdate{Address: &address},
+			setup: func() {
+				mock.ExpectBegin()
+				mockPVZRepo.EXPECT().GetPVZByID(gomock.Any(), pvzID).Return(nil, sql.ErrNoRows)
+				mock.ExpectRollback()
+			},
+			wantErr: entity.ErrPVZNotFound,
+		},
+		{
+			name:   "latitude without longitude",
+			update: entity.PVZDetailsUpdate{Latitude: &lat},
+			setup: func() {
+				mock.ExpectBegin()
+				mockPVZRepo.EXPECT().GetPVZByID(gomock.Any(), pvzID).Return(&entity.PVZ{ID: pvzID}, nil)
+				mock.ExpectRollback()
+			},
+			wantErr: entity.ErrInvalidCoordinates,
+		},
+		{
+			name:   "coordinates out of range",
+			update: entity.PVZDetailsUpdate{Latitude: &badLat, Longitude: &lon},
+			setup: func() {
+				mock.ExpectBegin()
+				mockPVZRepo.EXPECT().GetPVZByID(gomock.Any(), pvzID).Return(&entity.PVZ{ID: pvzID}, nil)
+				mock.ExpectRollback()
+			},
+			wantErr: entity.ErrInvalidCoordinates,
+		},
+		{
+			name: "invalid working hours",
+			update: entity.PVZDetailsUpdate{WorkingHours: &entity.WorkingHours{
+				Weekly: []entity.DailyHours{{Weekday: "monday", Open: "21:00", Close: "09:00"}},
+			}},
+			setup: func() {
+				mock.ExpectBegin()
+				mockPVZRepo.EXPECT().GetPVZByID(gomock.Any(), pvzID).Return(&entity.PVZ{ID: pvzID}, nil)
+				mock.ExpectRollback()
+			},
+			wantErr: entity.ErrInvalidWorkingHours,
+		},
+		{
+			name:   "update error",
+			update: entity.PVZDetailsUpdate{Phone: &phone},
+			setup: func() {
+				mock.ExpectBegin()
+				mockPVZRepo.EXPECT().GetPVZByID(gomock.Any(), pvzID).Return(&entity.PVZ{ID: pvzID}, nil)
+				mockPVZRepo.EXPECT().UpdatePVZDetails(gomock.Any(), gomock.Any()).Return(errors.New("update failed"))
+				mock.ExpectRollback()
+			},
+			wantErr: errors.New("update failed"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			pvz, err := svc.UpdatePVZDetails(context.Background(), pvzID, tt.update)
+			if tt.wantErr != nil {
+				assert.ErrorContains(t, err, tt.wantErr.Error())
+				assert.Nil(t, pvz)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, address, pvz.Address)
+				assert.Equal(t, lat, *pvz.Latitude)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestPVZService_FindNearbyPVZ(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPVZRepo := mocks.NewMockPVZRepository(ctrl)
+	mockLog := logrus.New()
+
+	svc := NewPVZService(mockPVZRepo, nil, nil, nil, mockLog)
+
+	tests := []struct {
+		name    string
+		filter  entity.NearbyFilter
+		setup   func()
+		wantErr error
+	}{
+		{
+			name:   "defaults applied",
+			filter: entity.NearbyFilter{Latitude: 55.75, Longitude: 37.61},
+			setup: func() {
+				mockPVZRepo.EXPECT().GetNearbyPVZ(gomock.Any(), entity.NearbyFilter{
+					Latitude:     55.75,
+					Longitude:    37.61,
+					RadiusMeters: entity.DefaultNearbyRadius,
+					Limit:        entity.DefaultNearbyLimit,
+				}).Return([]entity.NearbyPVZ{{DistanceMeters: 120}}, nil)
+			},
+		},
+		{
+			name:   "limit capped",
+			filter: entity.NearbyFilter{Latitude: 55.75, Longitude: 37.61, RadiusMeters: 1000, Limit: 500},
+			setup: func() {
+				mockPVZRepo.EXPECT().GetNearbyPVZ(gomock.Any(), entity.NearbyFilter{
+					Latitude:     55.75,
+					Longitude:    37.61,
+					RadiusMeters: 1000,
+					Limit:        entity.MaxNearbyLimit,
+				}).Return(nil, nil)
+			},
+		},
+		{
+			name:    "invalid coordinates",
+			filter:  entity.NearbyFilter{Latitude: 95, Longitude: 37.61},
+			setup:   func() {},
+			wantErr: entity.ErrInvalidCoordinates,
+		},
+		{
+			name:    "radius too large",
+			filter:  entity.NearbyFilter{Latitude: 55.75, Longitude: 37.61, RadiusMeters: entity.MaxNearbyRadius + 1},
+			setup:   func() {},
+			wantErr: entity.ErrInvalidSearchRadius,
+		},
+		{
+			name:   "repo error",
+			filter: entity.NearbyFilter{Latitude: 55.75, Longitude: 37.61},
+			setup: func() {
+				mockPVZRepo.EXPECT().GetNearbyPVZ(gomock.Any(), gomock.Any()).Return(nil, errors.New("query failed"))
+			},
+			wantErr: errors.New("query failed"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			_, err := svc.FindNearbyPVZ(context.Background(), tt.filter)
+			if tt.wantErr != nil {
+				assert.ErrorContains(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestPVZService_ChangePVZStatus(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPVZRepo := mocks.NewMockPVZRepository(ctrl)
+	mockReceptionRepo := mocks.NewMockReceptionRepository(ctrl)
+	db, mock, _ := sqlmock.New()
+	mockDB := sqlx.NewDb(db, testDriverName)
+	trxManager := manager.Must(trmsqlx.NewDefaultFactory(mockDB))
+	mockLog := logrus.New()
+
+	svc := NewPVZService(mockPVZRepo, mockReceptionRepo, nil, trxManager, mockLog)
+
+	pvzID := uuid.New()
+
+	tests := []struct {
+		name    string
+		status  entity.PVZStatus
+		reason  string
+		setup   func()
+		wantErr error
+	}{
+		{
+			name:   "suspend active",
+			status: entity.PVZStatusSuspended,
+			reason: "ремонт",
+			setup: func() {
+				mock.ExpectBegin()
+				mockPVZRepo.EXPECT().GetPVZByIDForUpdate(gomock.Any(), pvzID).Return(&entity.PVZ{ID: pvzID, Status: entity.PVZStatusActive}, nil)
+				mockPVZRepo.EXPECT().UpdatePVZStatus(gomock.Any(), gomock.Any()).Return(nil)
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:   "reactivate suspended without reason",
+			status: entity.PVZStatusActive,
+			setup: func() {
+				mock.ExpectBegin()
+				mockPVZRepo.EXPECT().GetPVZByIDForUpdate(gomock.Any(), pvzID).Return(&entity.PVZ{ID: pvzID, Status: entity.PVZStatusSuspended}, nil)
+				mockPVZRepo.EXPECT().UpdatePVZStatus(gomock.Any(), gomock.Any()).Return(nil)
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:   "close active",
+			status: entity.PVZStatusClosed,
+			reason: "ошибочная регистрация",
+			setup: func() {
+				mock.ExpectBegin()
+				mockPVZRepo.EXPECT().GetPVZByIDForUpdate(gomock.Any(), pvzID).Return(&entity.PVZ{ID: pvzID, Status: entity.PVZStatusActive}, nil)
+				mockReceptionRepo.EXPECT().IsReceptionOpenExists(gomock.Any(), pvzID).Return(false, nil)
+				mockPVZRepo.EXPECT().UpdatePVZStatus(gomock.Any(), gomock.Any()).Return(nil)
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:   "close with open reception",
+			status: entity.PVZStatusClosed,
+			reason: "переезд",
+			setup: func() {
+				mock.ExpectBegin()
+				mockPVZRepo.EXPECT().GetPVZByIDForUpdate(gomock.Any(), pvzID).Return(&entity.PVZ{ID: pvzID, Status: entity.PVZStatusActive}, nil)
+				mockReceptionRepo.EXPECT().IsReceptionOpenExists(gomock.Any(), pvzID).Return(true, nil)
+				mock.ExpectRollback()
+			},
+			wantErr: entity.ErrPVZHasOpenReception,
+		},
+		{
+			name:    "invalid status",
+			status:  "deleted",
+			reason:  "ремонт",
+			setup:   func() {},
+			wantErr: entity.ErrInvalidPVZStatus,
+		},
+		{
+			name:    "reason required",
+			status:  entity.PVZStatusSuspended,
+			reason:  "  ",
+			setup:   func() {},
+			wantErr: entity.ErrPVZStatusReason,
+		},
+		{
+			name:   "closed is final",
+			status: entity.PVZStatusActive,
+			setup: func() {
+				mock.ExpectBegin()
+				mockPVZRepo.EXPECT().GetPVZByIDForUpdate(gomock.Any(), pvzID).Return(&entity.PVZ{ID: pvzID, Status: entity.PVZStatusClosed}, nil)
+				mock.ExpectRollback()
+			},
+			wantErr: entity.ErrPVZStatusTransition,
+		},
+		{
+			name:   "pvz not found",
+			status: entity.PVZStatusSuspended,
+			reason: "ремонт",
+			setup: func() {
+				mock.ExpectBegin()
+				mockPVZRepo.EXPECT().GetPVZByIDForUpdate(gomock.Any(), pvzID).Return(nil, sql.ErrNoRows)
+				mock.ExpectRollback()
+			},
+			wantErr: entity.ErrPVZNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			pvz, err := svc.ChangePVZStatus(context.Background(), pvzID, tt.status, tt.reason)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.status, pvz.Status)
+				assert.NotNil(t, pvz.StatusChangedAt)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
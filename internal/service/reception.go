@@ -2,7 +2,9 @@ package service
 
 import (
 	"context"
+	"database/sql"
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/avito-tech/go-transaction-manager/trm/v2/manager"
@@ -10,6 +12,7 @@ import (
 	"github.com/sirupsen/logrus"
 
 	"github.com/senyabanana/pvz-service/internal/entity"
+	"github.com/senyabanana/pvz-service/internal/infrastructure/logger"
 	"github.com/senyabanana/pvz-service/internal/infrastructure/monitoring"
 	"github.com/senyabanana/pvz-service/internal/repository"
 )
@@ -33,28 +36,25 @@ func NewReceptionService(
 }
 
 func (s *ReceptionService) CreateReception(ctx context.Context, pvzID uuid.UUID) (*entity.Reception, error) {
+	ctx, span := tracer.Start(ctx, "ReceptionService.CreateReception")
+	defer span.End()
+	log := logger.FromContext(ctx, s.log).WithField(logger.FieldPVZID, pvzID)
+
 	var result *entity.Reception
 
 	err := s.trManager.Do(ctx, func(ctx context.Context) error {
-		pvzExists, err := s.pvzRepo.IsPVZExists(ctx, pvzID)
-		if err != nil {
-			s.log.Errorf("failed to check pvz existence: %v", err)
+		if err := ensurePVZActive(ctx, s.pvzRepo, pvzID, log); err != nil {
 			return err
 		}
 
-		if !pvzExists {
-			s.log.Warnf("pvz not found: %s", pvzID)
-			return entity.ErrPVZNotFound
-		}
-
 		openExists, err := s.receptionRepo.IsReceptionOpenExists(ctx, pvzID)
 		if err != nil {
-			s.log.Errorf("failed to check open reception: %v", err)
+			log.Errorf("failed to check open reception: %v", err)
 			return err
 		}
 
 		if openExists {
-			s.log.Infof("reception already exists for pvzID=%s", pvzID)
+			log.Infof("reception already exists for pvzID=%s", pvzID)
 			return entity.ErrReceptionAlreadyExists
 		}
 
@@ -66,7 +66,7 @@ func (s *ReceptionService) CreateReception(ctx context.Context, pvzID uuid.UUID)
 		}
 
 		if err := s.receptionRepo.CreateReception(ctx, reception); err != nil {
-			s.log.Errorf("failed to create reception: %v", err)
+			log.Errorf("failed to create reception: %v", err)
 			return err
 		}
 
@@ -78,29 +78,33 @@ func (s *ReceptionService) CreateReception(ctx context.Context, pvzID uuid.UUID)
 		return nil, err
 	}
 
-	s.log.Infof("reception created: id=%s, pvz=%s", result.ID, result.PVZID)
+	log.Infof("reception created: id=%s, pvz=%s", result.ID, result.PVZID)
 	monitoring.CreatedReceptionsCounter.Inc()
 	return result, nil
 }
 
 func (s *ReceptionService) CloseLastReception(ctx context.Context, pvzID uuid.UUID) (*entity.Reception, error) {
+	ctx, span := tracer.Start(ctx, "ReceptionService.CloseLastReception")
+	defer span.End()
+	log := logger.FromContext(ctx, s.log).WithField(logger.FieldPVZID, pvzID)
+
 	var result *entity.Reception
 
 	err := s.trManager.Do(ctx, func(ctx context.Context) error {
 		reception, err := s.receptionRepo.GetOpenReception(ctx, pvzID)
 		if err != nil {
-			s.log.Warnf("no open reception to close for pvz: %s, err: %v", pvzID, err)
+			log.Warnf("no open reception to close for pvz: %s, err: %v", pvzID, err)
 			return entity.ErrNoOpenReception
 		}
 
 		timeClose := time.Now()
 		if err := s.receptionRepo.CloseReceptionByID(ctx, reception.ID, timeClose); err != nil {
 			if errors.Is(err, entity.ErrReceptionAlreadyClosed) {
-				s.log.Warnf("reception already closed: %s", reception.ID)
+				log.Warnf("reception already closed: %s", reception.ID)
 				return entity.ErrReceptionAlreadyClosed
 			}
 
-			s.log.Errorf("failed to close reception: %v", err)
+			log.Errorf("failed to close reception: %v", err)
 			return err
 		}
 
@@ -108,13 +112,34 @@ func (s *ReceptionService) CloseLastReception(ctx context.Context, pvzID uuid.UU
 		reception.ClosedAt = &timeClose
 		result = reception
 
-		s.log.Infof("reception closed: id=%s", reception.ID)
+		log.Infof("reception closed: id=%s", reception.ID)
 		return nil
 	})
 
 	return result, err
 }
 
+// ensurePVZActive locks the PVZ row for the rest of the transaction, so status changes and
+// capacity checks at the same PVZ are serialised, and fails unless the PVZ exists and is active.
+func ensurePVZActive(ctx context.Context, pvzRepo repository.PVZRepository, pvzID uuid.UUID, log *logrus.Entry) error {
+	status, err := pvzRepo.GetPVZStatus(ctx, pvzID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			log.Warnf("pvz not found: %s", pvzID)
+			return entity.ErrPVZNotFound
+		}
+		log.Errorf("failed to get pvz status: %v", err)
+		return err
+	}
+
+	if status != entity.PVZStatusActive {
+		log.Warnf("pvz %s is %s", pvzID, status)
+		return fmt.Errorf("%w: %s", entity.ErrPVZNotActive, status)
+	}
+
+	return nil
+}
+
 func filterReceptionsByDate(receptions []entity.Reception, start, end *time.Time) []entity.Reception {
 	var filtered []entity.Reception
 	for _, reception := range receptions {
@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"testing"
 
@@ -42,7 +43,7 @@ func TestReceptionService_CreateReception(t *testing.T) {
 			name: "success",
 			setup: func() {
 				mock.ExpectBegin()
-				mockPVZRepo.EXPECT().IsPVZExists(gomock.Any(), pvzID).Return(true, nil)
+				mockPVZRepo.EXPECT().GetPVZStatus(gomock.Any(), pvzID).Return(entity.PVZStatusActive, nil)
 				mockReceptionRepo.EXPECT().IsReceptionOpenExists(gomock.Any(), pvzID).Return(false, nil)
 				mockReceptionRepo.EXPECT().CreateReception(gomock.Any(), gomock.Any()).Return(nil)
 				mock.ExpectCommit()
@@ -53,16 +54,25 @@ func TestReceptionService_CreateReception(t *testing.T) {
 			name: "pvz not found",
 			setup: func() {
 				mock.ExpectBegin()
-				mockPVZRepo.EXPECT().IsPVZExists(gomock.Any(), pvzID).Return(false, nil)
+				mockPVZRepo.EXPECT().GetPVZStatus(gomock.Any(), pvzID).Return(entity.PVZStatus(""), sql.ErrNoRows)
 				mock.ExpectRollback()
 			},
 			wantErr: entity.ErrPVZNotFound,
 		},
+		{
+			name: "pvz suspended",
+			setup: func() {
+				mock.ExpectBegin()
+				mockPVZRepo.EXPECT().GetPVZStatus(gomock.Any(), pvzID).Return(entity.PVZStatusSuspended, nil)
+				mock.ExpectRollback()
+			},
+			wantErr: entity.ErrPVZNotActive,
+		},
 		{
 			name: "reception already exists",
 			setup: func() {
 				mock.ExpectBegin()
-				mockPVZRepo.EXPECT().IsPVZExists(gomock.Any(), pvzID).Return(true, nil)
+				mockPVZRepo.EXPECT().GetPVZStatus(gomock.Any(), pvzID).Return(entity.PVZStatusActive, nil)
 				mockReceptionRepo.EXPECT().IsReceptionOpenExists(gomock.Any(), pvzID).Return(true, nil)
 				mock.ExpectRollback()
 			},
@@ -72,7 +82,7 @@ func TestReceptionService_CreateReception(t *testing.T) {
 			name: "db error on create",
 			setup: func() {
 				mock.ExpectBegin()
-				mockPVZRepo.EXPECT().IsPVZExists(gomock.Any(), pvzID).Return(true, nil)
+				mockPVZRepo.EXPECT().GetPVZStatus(gomock.Any(), pvzID).Return(entity.PVZStatusActive, nil)
 				mockReceptionRepo.EXPECT().IsReceptionOpenExists(gomock.Any(), pvzID).Return(false, nil)
 				mockReceptionRepo.EXPECT().CreateReception(gomock.Any(), gomock.Any()).Return(errors.New("create error"))
 				mock.ExpectRollback()
@@ -0,0 +1,349 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+
+	"github.com/senyabanana/pvz-service/internal/entity"
+	"github.com/senyabanana/pvz-service/internal/infrastructure/export"
+	"github.com/senyabanana/pvz-service/internal/infrastructure/logger"
+	"github.com/senyabanana/pvz-service/internal/infrastructure/monitoring"
+	"github.com/senyabanana/pvz-service/internal/repository"
+)
+
+var (
+	receptionThroughputHeader = []interface{}{
+		"pvzId", "city", "periodStart", "receptionsOpened", "receptionsClosed",
+		"itemsReceived", "avgItemsPerReception", "avgReceptionDurationSec",
+	}
+	productMixHeader = []interface{}{"pvzId", "city", "periodStart", "type", "items", "share"}
+)
+
+type ReportJobService struct {
+	jobRepo    repository.ReportJobRepository
+	exporter   ExportOperations
+	analytics  AnalyticsOperations
+	jobTimeout time.Duration
+	log        *logrus.Logger
+}
+
+func NewReportJobService(
+	jobRepo repository.ReportJobRepository,
+	exporter ExportOperations,
+	analytics AnalyticsOperations,
+	jobTimeout time.Duration,
+	log *logrus.Logger,
+) *ReportJobService {
+	return &ReportJobService{
+		jobRepo:    jobRepo,
+		exporter:   exporter,
+		analytics:  analytics,
+		jobTimeout: jobTimeout,
+		log:        log,
+	}
+}
+
+// CreateReportJob validates the report parameters and queues the job.
+func (s *ReportJobService) CreateReportJob(ctx context.Context, job entity.ReportJob) (*entity.ReportJob, error) {
+	ctx, span := tracer.Start(ctx, "ReportJobService.CreateReportJob")
+	defer span.End()
+	log := logger.FromContext(ctx, s.log)
+
+	if !entity.IsValidReportKind(job.Kind) {
+		return nil, fmt.Errorf("%w: unknown kind %q", entity.ErrInvalidReportJob, job.Kind)
+	}
+
+	if job.Params.Format == "" {
+		job.Params.Format = entity.ExportFormatCSV
+	}
+	if job.Params.Format != entity.ExportFormatCSV && job.Params.Format != entity.ExportFormatXLSX {
+		return nil, fmt.Errorf("%w: unknown format %q", entity.ErrInvalidReportJob, job.Params.Format)
+	}
+
+	if job.Kind.IsAnalytics() {
+		filter, err := normalizeAnalyticsFilter(job.Params.AnalyticsFilter())
+		if err != nil {
+			return nil, err
+		}
+		job.Params.GroupBy, job.Params.Period = filter.GroupBy, filter.Period
+	}
+
+	job.Status = entity.ReportJobQueued
+	job.CreatedAt = time.Now()
+
+	if err := s.jobRepo.CreateReportJob(ctx, &job); err != nil {
+		log.Errorf("failed to create report job: %v", err)
+		return nil, err
+	}
+
+	log.Infof("report job queued: id=%s, kind=%s", job.ID, job.Kind)
+	return &job, nil
+}
+
+func (s *ReportJobService) GetReportJob(ctx context.Context, jobID uuid.UUID) (*entity.ReportJob, error) {
+	ctx, span := tracer.Start(ctx, "ReportJobService.GetReportJob")
+	defer span.End()
+	log := logger.FromContext(ctx, s.log)
+
+	job, err := s.jobRepo.GetReportJob(ctx, jobID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, entity.ErrReportJobNotFound
+		}
+		log.Errorf("failed to get report job %s: %v", jobID, err)
+		return nil, err
+	}
+
+	return job, nil
+}
+
+func (s *ReportJobService) GetReportArtifact(ctx context.Context, jobID uuid.UUID) (*entity.ReportArtifact, error) {
+	ctx, span := tracer.Start(ctx, "ReportJobService.GetReportArtifact")
+	defer span.End()
+	log := logger.FromContext(ctx, s.log)
+
+	artifact, err := s.jobRepo.GetReportArtifact(ctx, jobID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, entity.ErrReportNotReady
+		}
+		log.Errorf("failed to get artifact of report job %s: %v", jobID, err)
+		return nil, err
+	}
+
+	return artifact, nil
+}
+
+// RunNextReportJob claims the oldest queued job and runs it to completion. It returns false when the
+// queue is empty. A job interrupted by ctx cancellation is left running and requeued once it goes stale.
+func (s *ReportJobService) RunNextReportJob(ctx context.Context) (bool, error) {
+	ctx, span := tracer.Start(ctx, "ReportJobService.RunNextReportJob")
+	defer span.End()
+	log := logger.FromContext(ctx, s.log)
+
+	job, err := s.jobRepo.ClaimReportJob(ctx, time.Now())
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	ctx = logger.WithFields(ctx, s.log, logrus.Fields{logger.FieldJobID: job.ID.String()})
+	log = logger.FromContext(ctx, s.log)
+	log.Infof("report job started: id=%s, kind=%s, attempt=%d", job.ID, job.Kind, job.Attempts)
+
+	var rows atomic.Int64
+	heartbeatCtx, stopHeartbeat := context.WithCancel(ctx)
+	heartbeatDone := make(chan struct{})
+	go func() {
+		defer close(heartbeatDone)
+		s.heartbeat(heartbeatCtx, job, &rows)
+	}()
+
+	artifact, err := s.buildReport(ctx, job, &rows)
+	stopHeartbeat()
+	<-heartbeatDone
+
+	if ctx.Err() != nil {
+		log.Warnf("report job %s interrupted, it will be retried", job.ID)
+		return true, ctx.Err()
+	}
+
+	now := time.Now()
+	if err != nil {
+		log.Errorf("report job %s failed: %v", job.ID, err)
+		err = s.jobRepo.FailReportJob(ctx, job.ID, job.Attempts, err.Error(), now)
+		if errors.Is(err, entity.ErrReportJobLost) {
+			log.Warnf("report job %s was requeued while running, result of attempt %d discarded", job.ID, job.Attempts)
+			return true, nil
+		}
+		monitoring.ReportJobsCounter.WithLabelValues(string(job.Kind), string(entity.ReportJobFailed)).Inc()
+		return true, err
+	}
+
+	err = s.jobRepo.CompleteReportJob(ctx, job.ID, job.Attempts, int(rows.Load()), *artifact, now)
+	if errors.Is(err, entity.ErrReportJobLost) {
+		log.Warnf("report job %s was requeued while running, result of attempt %d discarded", job.ID, job.Attempts)
+		return true, nil
+	}
+	if err != nil {
+		return true, err
+	}
+
+	monitoring.ReportJobsCounter.WithLabelValues(string(job.Kind), string(entity.ReportJobSucceeded)).Inc()
+	log.Infof("report job finished: id=%s, rows=%d, size=%d", job.ID, rows.Load(), len(artifact.Data))
+	return true, nil
+}
+
+// RecoverStaleReportJobs requeues jobs whose worker has not reported progress within the job timeout.
+func (s *ReportJobService) RecoverStaleReportJobs(ctx context.Context) (int, error) {
+	ctx, span := tracer.Start(ctx, "ReportJobService.RecoverStaleReportJobs")
+	defer span.End()
+	log := logger.FromContext(ctx, s.log)
+
+	now := time.Now()
+	recovered, err := s.jobRepo.RecoverStaleReportJobs(ctx, now.Add(-s.jobTimeout), now)
+	if err != nil {
+		return 0, err
+	}
+
+	if recovered > 0 {
+		log.Warnf("recovered %d stale report jobs", recovered)
+	}
+	return recovered, nil
+}
+
+func (s *ReportJobService) heartbeat(ctx context.Context, job *entity.ReportJob, rows *atomic.Int64) {
+	log := logger.FromContext(ctx, s.log)
+
+	ticker := time.NewTicker(entity.ReportHeartbeatInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			err := s.jobRepo.UpdateReportJobProgress(ctx, job.ID, job.Attempts, int(rows.Load()), time.Now())
+			if err != nil && ctx.Err() == nil {
+				log.Warnf("failed to save progress of report job %s: %v", job.ID, err)
+			}
+		}
+	}
+}
+
+func (s *ReportJobService) buildReport(ctx context.Context, job *entity.ReportJob, rows *atomic.Int64) (*entity.ReportArtifact, error) {
+	var buf artifactBuffer
+	w, contentType, err := export.NewWriter(job.Params.Format, &buf, string(job.Kind))
+	if err != nil {
+		return nil, err
+	}
+	defer w.Discard()
+
+	counter := &countingRowWriter{next: w, rows: rows}
+
+	switch job.Kind {
+	case entity.ReportExportPVZ:
+		err = s.exporter.Export(ctx, entity.ExportPVZ, job.Params.ExportFilter(), counter)
+	case entity.ReportExportReceptions:
+		err = s.exporter.Export(ctx, entity.ExportReceptions, job.Params.ExportFilter(), counter)
+	case entity.ReportExportProducts:
+		err = s.exporter.Export(ctx, entity.ExportProducts, job.Params.ExportFilter(), counter)
+	case entity.ReportAnalyticsReceptions:
+		err = s.writeReceptionThroughput(ctx, job.Params.AnalyticsFilter(), counter)
+	case entity.ReportAnalyticsProducts:
+		err = s.writeProductMix(ctx, job.Params.AnalyticsFilter(), counter)
+	default:
+		err = fmt.Errorf("%w: unknown kind %q", entity.ErrInvalidReportJob, job.Kind)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+
+	return &entity.ReportArtifact{
+		Name:        fmt.Sprintf("%s_%s.%s", job.Kind, job.ID, job.Params.Format),
+		ContentType: contentType,
+		Data:        buf.Bytes(),
+	}, nil
+}
+
+func (s *ReportJobService) writeReceptionThroughput(ctx context.Context, filter entity.AnalyticsFilter, w RowWriter) error {
+	rows, err := s.analytics.GetReceptionThroughput(ctx, filter)
+	if err != nil {
+		return err
+	}
+
+	if err := w.WriteRow(receptionThroughputHeader); err != nil {
+		return err
+	}
+	for _, row := range rows {
+		var duration interface{}
+		if row.AvgReceptionDurationSec != nil {
+			duration = *row.AvgReceptionDurationSec
+		}
+		err := w.WriteRow([]interface{}{
+			formatOptionalUUID(row.PVZID), row.City, formatExportTime(&row.PeriodStart), row.ReceptionsOpened,
+			row.ReceptionsClosed, row.ItemsReceived, row.AvgItemsPerReception, duration,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *ReportJobService) writeProductMix(ctx context.Context, filter entity.AnalyticsFilter, w RowWriter) error {
+	rows, err := s.analytics.GetProductMix(ctx, filter)
+	if err != nil {
+		return err
+	}
+
+	if err := w.WriteRow(productMixHeader); err != nil {
+		return err
+	}
+	for _, row := range rows {
+		err := w.WriteRow([]interface{}{
+			formatOptionalUUID(row.PVZID), row.City, formatExportTime(&row.PeriodStart),
+			string(row.ProductType), row.Items, row.Share,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// artifactBuffer collects a report in memory and fails once it outgrows entity.MaxReportArtifactSize.
+type artifactBuffer struct {
+	bytes.Buffer
+}
+
+func (b *artifactBuffer) Write(p []byte) (int, error) {
+	if b.Len()+len(p) > entity.MaxReportArtifactSize {
+		return 0, fmt.Errorf("%w of %d bytes", entity.ErrReportTooLarge, entity.MaxReportArtifactSize)
+	}
+
+	return b.Buffer.Write(p)
+}
+
+// countingRowWriter counts data rows, skipping the header, for progress reporting.
+type countingRowWriter struct {
+	next    RowWriter
+	rows    *atomic.Int64
+	started bool
+}
+
+func (w *countingRowWriter) WriteRow(values []interface{}) error {
+	if err := w.next.WriteRow(values); err != nil {
+		return err
+	}
+
+	if w.started {
+		w.rows.Add(1)
+	}
+	w.started = true
+	return nil
+}
+
+func formatOptionalUUID(id *uuid.UUID) string {
+	if id == nil {
+		return ""
+	}
+
+	return id.String()
+}
@@ -0,0 +1,273 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/senyabanana/pvz-service/internal/entity"
+	mocks "github.com/senyabanana/pvz-service/internal/repository/mocks"
+)
+
+func TestReportJobService_CreateReportJob(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockJobRepo := mocks.NewMockReportJobRepository(ctrl)
+	mockLog := logrus.New()
+
+	svc := NewReportJobService(mockJobRepo, nil, nil, time.Minute, mockLog)
+
+	start := time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 0, 7)
+	dbErr := errors.New("db error")
+
+	tests := []struct {
+		name       string
+		job        entity.ReportJob
+		setup      func()
+		wantParams entity.ReportParams
+		wantErr    error
+	}{
+		{
+			name: "export defaults to csv",
+			job:  entity.ReportJob{Kind: entity.ReportExportProducts},
+			setup: func() {
+				mockJobRepo.EXPECT().CreateReportJob(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			wantParams: entity.ReportParams{Format: entity.ExportFormatCSV},
+		},
+		{
+			name: "analytics defaults to pvz and day",
+			job: entity.ReportJob{
+				Kind:   entity.ReportAnalyticsReceptions,
+				Params: entity.ReportParams{Format: entity.ExportFormatXLSX, StartDate: &start, EndDate: &end},
+			},
+			setup: func() {
+				mockJobRepo.EXPECT().CreateReportJob(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			wantParams: entity.ReportParams{
+				Format: entity.ExportFormatXLSX, StartDate: &start, EndDate: &end,
+				GroupBy: entity.AnalyticsGroupByPVZ, Period: entity.AnalyticsPeriodDay,
+			},
+		},
+		{
+			name:    "analytics without date range",
+			job:     entity.ReportJob{Kind: entity.ReportAnalyticsProducts},
+			setup:   func() {},
+			wantErr: entity.ErrInvalidAnalyticsFilter,
+		},
+		{
+			name:    "unknown kind",
+			job:     entity.ReportJob{Kind: "users"},
+			setup:   func() {},
+			wantErr: entity.ErrInvalidReportJob,
+		},
+		{
+			name:    "unknown format",
+			job:     entity.ReportJob{Kind: entity.ReportExportPVZ, Params: entity.ReportParams{Format: "pdf"}},
+			setup:   func() {},
+			wantErr: entity.ErrInvalidReportJob,
+		},
+		{
+			name: "repository error",
+			job:  entity.ReportJob{Kind: entity.ReportExportPVZ},
+			setup: func() {
+				mockJobRepo.EXPECT().CreateReportJob(gomock.Any(), gomock.Any()).Return(dbErr)
+			},
+			wantErr: dbErr,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			job, err := svc.CreateReportJob(context.Background(), tt.job)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, entity.ReportJobQueued, job.Status)
+				assert.Equal(t, tt.wantParams, job.Params)
+			}
+		})
+	}
+}
+
+func TestReportJobService_GetReportJob(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockJobRepo := mocks.NewMockReportJobRepository(ctrl)
+	mockLog := logrus.New()
+
+	svc := NewReportJobService(mockJobRepo, nil, nil, time.Minute, mockLog)
+
+	jobID := uuid.New()
+
+	mockJobRepo.EXPECT().GetReportJob(gomock.Any(), jobID).Return(nil, sql.ErrNoRows)
+	_, err := svc.GetReportJob(context.Background(), jobID)
+	assert.ErrorIs(t, err, entity.ErrReportJobNotFound)
+
+	mockJobRepo.EXPECT().GetReportArtifact(gomock.Any(), jobID).Return(nil, sql.ErrNoRows)
+	_, err = svc.GetReportArtifact(context.Background(), jobID)
+	assert.ErrorIs(t, err, entity.ErrReportNotReady)
+}
+
+func TestReportJobService_RunNextReportJob(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockJobRepo := mocks.NewMockReportJobRepository(ctrl)
+	mockExportRepo := mocks.NewMockExportRepository(ctrl)
+	mockAnalyticsRepo := mocks.NewMockAnalyticsRepository(ctrl)
+	mockLog := logrus.New()
+
+	svc := NewReportJobService(
+		mockJobRepo, NewExportService(mockExportRepo, mockLog), NewAnalyticsService(mockAnalyticsRepo, mockLog), time.Minute, mockLog,
+	)
+
+	jobID := uuid.New()
+	start := time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 0, 7)
+	dbErr := errors.New("db error")
+
+	tests := []struct {
+		name    string
+		setup   func()
+		wantRan bool
+		wantErr error
+	}{
+		{
+			name: "empty queue",
+			setup: func() {
+				mockJobRepo.EXPECT().ClaimReportJob(gomock.Any(), gomock.Any()).Return(nil, sql.ErrNoRows)
+			},
+		},
+		{
+			name: "export succeeded",
+			setup: func() {
+				mockJobRepo.EXPECT().ClaimReportJob(gomock.Any(), gomock.Any()).Return(&entity.ReportJob{
+					ID: jobID, Kind: entity.ReportExportPVZ, Attempts: 1,
+					Params: entity.ReportParams{Format: entity.ExportFormatCSV},
+				}, nil)
+				mockExportRepo.EXPECT().StreamPVZ(gomock.Any(), entity.ExportFilter{}, gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ entity.ExportFilter, fn func(entity.PVZExportRow) error) error {
+						for i := 0; i < 2; i++ {
+							if err := fn(entity.PVZExportRow{ID: uuid.New(), City: entity.CityMoscow}); err != nil {
+								return err
+							}
+						}
+						return nil
+					})
+				mockJobRepo.EXPECT().CompleteReportJob(gomock.Any(), jobID, 1, 2, gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ uuid.UUID, _, _ int, artifact entity.ReportArtifact, _ time.Time) error {
+						assert.Equal(t, "export_pvz_"+jobID.String()+".csv", artifact.Name)
+						assert.Contains(t, string(artifact.Data), "Москва")
+						return nil
+					})
+			},
+			wantRan: true,
+		},
+		{
+			name: "analytics succeeded",
+			setup: func() {
+				mockJobRepo.EXPECT().ClaimReportJob(gomock.Any(), gomock.Any()).Return(&entity.ReportJob{
+					ID: jobID, Kind: entity.ReportAnalyticsProducts, Attempts: 1,
+					Params: entity.ReportParams{
+						Format: entity.ExportFormatCSV, StartDate: &start, EndDate: &end,
+						GroupBy: entity.AnalyticsGroupByCity, Period: entity.AnalyticsPeriodWeek,
+					},
+				}, nil)
+				mockAnalyticsRepo.EXPECT().GetProductMix(gomock.Any(), gomock.Any()).
+					Return([]entity.ProductMix{{City: "Казань", PeriodStart: start, ProductType: entity.ProductShoes, Items: 4, Share: 1}}, nil)
+				mockJobRepo.EXPECT().CompleteReportJob(gomock.Any(), jobID, 1, 1, gomock.Any(), gomock.Any()).Return(nil)
+			},
+			wantRan: true,
+		},
+		{
+			name: "job requeued while running",
+			setup: func() {
+				mockJobRepo.EXPECT().ClaimReportJob(gomock.Any(), gomock.Any()).Return(&entity.ReportJob{
+					ID: jobID, Kind: entity.ReportExportPVZ, Attempts: 1,
+					Params: entity.ReportParams{Format: entity.ExportFormatCSV},
+				}, nil)
+				mockExportRepo.EXPECT().StreamPVZ(gomock.Any(), entity.ExportFilter{}, gomock.Any()).Return(nil)
+				mockJobRepo.EXPECT().CompleteReportJob(gomock.Any(), jobID, 1, 0, gomock.Any(), gomock.Any()).
+					Return(entity.ErrReportJobLost)
+			},
+			wantRan: true,
+		},
+		{
+			name: "report failed",
+			setup: func() {
+				mockJobRepo.EXPECT().ClaimReportJob(gomock.Any(), gomock.Any()).Return(&entity.ReportJob{
+					ID: jobID, Kind: entity.ReportExportProducts, Attempts: 2,
+					Params: entity.ReportParams{Format: entity.ExportFormatXLSX},
+				}, nil)
+				mockExportRepo.EXPECT().StreamProducts(gomock.Any(), gomock.Any(), gomock.Any()).Return(dbErr)
+				mockJobRepo.EXPECT().FailReportJob(gomock.Any(), jobID, 2, dbErr.Error(), gomock.Any()).Return(nil)
+			},
+			wantRan: true,
+		},
+		{
+			name: "claim error",
+			setup: func() {
+				mockJobRepo.EXPECT().ClaimReportJob(gomock.Any(), gomock.Any()).Return(nil, dbErr)
+			},
+			wantErr: dbErr,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			ran, err := svc.RunNextReportJob(context.Background())
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantRan, ran)
+		})
+	}
+}
+
+func TestReportJobService_RecoverStaleReportJobs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockJobRepo := mocks.NewMockReportJobRepository(ctrl)
+	mockLog := logrus.New()
+
+	svc := NewReportJobService(mockJobRepo, nil, nil, 10*time.Minute, mockLog)
+
+	mockJobRepo.EXPECT().RecoverStaleReportJobs(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, staleBefore, now time.Time) (int, error) {
+			assert.Equal(t, 10*time.Minute, now.Sub(staleBefore))
+			return 2, nil
+		})
+
+	recovered, err := svc.RecoverStaleReportJobs(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 2, recovered)
+}
+
+func TestArtifactBuffer_Limit(t *testing.T) {
+	var buf artifactBuffer
+
+	n, err := buf.Write(make([]byte, entity.MaxReportArtifactSize))
+	assert.NoError(t, err)
+	assert.Equal(t, entity.MaxReportArtifactSize, n)
+
+	_, err = buf.Write([]byte{1})
+	assert.ErrorIs(t, err, entity.ErrReportTooLarge)
+	assert.Equal(t, entity.MaxReportArtifactSize, buf.Len())
+}
@@ -0,0 +1,176 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/avito-tech/go-transaction-manager/trm/v2/manager"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+
+	"github.com/senyabanana/pvz-service/internal/entity"
+	"github.com/senyabanana/pvz-service/internal/infrastructure/logger"
+	"github.com/senyabanana/pvz-service/internal/repository"
+)
+
+type ReturnService struct {
+	receptionRepo repository.ReceptionRepository
+	productRepo   repository.ProductRepository
+	pvzRepo       repository.PVZRepository
+	trManager     *manager.Manager
+	log           *logrus.Logger
+}
+
+func NewReturnService(
+	receptionRepo repository.ReceptionRepository,
+	productRepo repository.ProductRepository,
+	pvzRepo repository.PVZRepository,
+	trManager *manager.Manager,
+	log *logrus.Logger,
+) *ReturnService {
+	return &ReturnService{
+		receptionRepo: receptionRepo,
+		productRepo:   productRepo,
+		pvzRepo:       pvzRepo,
+		trManager:     trManager,
+		log:           log,
+	}
+}
+
+// AcceptReturn takes an issued product back from a client at the given PVZ. The product may have
+// been issued at any PVZ; the client is taken from the product owner.
+func (s *ReturnService) AcceptReturn(ctx context.Context, ret entity.CustomerReturn) (*entity.CustomerReturn, error) {
+	ctx, span := tracer.Start(ctx, "ReturnService.AcceptReturn")
+	defer span.End()
+	log := logger.FromContext(ctx, s.log)
+
+	if !entity.IsValidReturnReason(ret.Reason) {
+		return nil, entity.ErrInvalidReturnReason
+	}
+
+	if !entity.IsValidItemCondition(ret.Condition) {
+		return nil, entity.ErrInvalidItemCondition
+	}
+
+	err := s.trManager.Do(ctx, func(ctx context.Context) error {
+		if err := ensurePVZActive(ctx, s.pvzRepo, ret.PVZID, log); err != nil {
+			return err
+		}
+
+		product, err := s.productRepo.GetProductByID(ctx, ret.ProductID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return entity.ErrProductNotFound
+			}
+			return err
+		}
+
+		if product.Status != entity.ProductStatusIssued {
+			return entity.ErrProductNotIssued
+		}
+
+		if err := checkProductTransition(product.Status, entity.ProductStatusReturned); err != nil {
+			return err
+		}
+
+		if err := s.productRepo.MarkProductReturned(ctx, product.ID); err != nil {
+			return err
+		}
+
+		now := time.Now()
+		changes := statusChanges([]uuid.UUID{product.ID}, product.Status, entity.ProductStatusReturned,
+			ret.PVZID, ret.AcceptedBy, now, string(ret.Reason))
+		if err := s.productRepo.AddProductStatusChanges(ctx, changes); err != nil {
+			return err
+		}
+
+		ret.ClientID = product.OwnerID
+		ret.Status = entity.ReturnStatusAccepted
+		ret.AcceptedAt = now
+		ret.StatusChangedAt = now
+
+		return s.receptionRepo.CreateReturn(ctx, &ret)
+	})
+	if err != nil {
+		log.Warnf("failed to accept return of product %s at pvz %s: %v", ret.ProductID, ret.PVZID, err)
+		return nil, err
+	}
+
+	log.Infof("return accepted: id=%s, product=%s, pvz=%s, reason=%s", ret.ID, ret.ProductID, ret.PVZID, ret.Reason)
+	return &ret, nil
+}
+
+func (s *ReturnService) ChangeReturnStatus(
+	ctx context.Context, pvzID, returnID uuid.UUID, status entity.ReturnStatus,
+) (*entity.CustomerReturn, error) {
+	ctx, span := tracer.Start(ctx, "ReturnService.ChangeReturnStatus")
+	defer span.End()
+	log := logger.FromContext(ctx, s.log)
+
+	if !entity.IsValidReturnStatus(status) {
+		return nil, entity.ErrInvalidReturnStatus
+	}
+
+	var result *entity.CustomerReturn
+
+	err := s.trManager.Do(ctx, func(ctx context.Context) error {
+		ret, err := s.receptionRepo.GetReturnByID(ctx, returnID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return entity.ErrReturnNotFound
+			}
+			return err
+		}
+
+		if ret.PVZID != pvzID {
+			return entity.ErrReturnNotFound
+		}
+
+		if !ret.Status.CanTransitionTo(status) {
+			return entity.ErrReturnStatusTransition
+		}
+
+		now := time.Now()
+		ret.Status = status
+		ret.StatusChangedAt = now
+		if status == entity.ReturnStatusShipped {
+			ret.ShippedAt = &now
+		}
+
+		if err := s.receptionRepo.UpdateReturnStatus(ctx, ret); err != nil {
+			return err
+		}
+
+		result = ret
+		return nil
+	})
+	if err != nil {
+		log.Warnf("failed to change return %s status to %s: %v", returnID, status, err)
+		return nil, err
+	}
+
+	log.Infof("return status changed: id=%s, status=%s", result.ID, result.Status)
+	return result, nil
+}
+
+func filterReturnsByDate(returns []entity.CustomerReturn, start, end *time.Time) []entity.CustomerReturn {
+	var filtered []entity.CustomerReturn
+	for _, ret := range returns {
+		if (start == nil || !ret.AcceptedAt.Before(*start)) && (end == nil || !ret.AcceptedAt.After(*end)) {
+			filtered = append(filtered, ret)
+		}
+	}
+
+	return filtered
+}
+
+func groupReturnsByPVZ(returns []entity.CustomerReturn) map[uuid.UUID][]entity.CustomerReturn {
+	result := make(map[uuid.UUID][]entity.CustomerReturn)
+	for _, ret := range returns {
+		result[ret.PVZID] = append(result[ret.PVZID], ret)
+	}
+
+	return result
+}
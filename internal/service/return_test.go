@@ -0,0 +1,223 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	trmsqlx "github.com/avito-tech/go-transaction-manager/drivers/sqlx/v2"
+	"github.com/avito-tech/go-transaction-manager/trm/v2/manager"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/senyabanana/pvz-service/internal/entity"
+	mocks "github.com/senyabanana/pvz-service/internal/repository/mocks"
+)
+
+func TestReturnService_AcceptReturn(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockReceptionRepo := mocks.NewMockReceptionRepository(ctrl)
+	mockProductRepo := mocks.NewMockProductRepository(ctrl)
+	mockPVZRepo := mocks.NewMockPVZRepository(ctrl)
+	db, mock, _ := sqlmock.New()
+	mockDB := sqlx.NewDb(db, testDriverName)
+	trManager := manager.Must(trmsqlx.NewDefaultFactory(mockDB))
+	mockLog := logrus.New()
+
+	svc := NewReturnService(mockReceptionRepo, mockProductRepo, mockPVZRepo, trManager, mockLog)
+
+	pvzID, productID, ownerID := uuid.New(), uuid.New(), uuid.New()
+	valid := entity.CustomerReturn{
+		PVZID: pvzID, ProductID: productID, Reason: entity.ReturnReasonDefective, Condition: entity.ConditionOpened,
+	}
+
+	tests := []struct {
+		name    string
+		input   entity.CustomerReturn
+		setup   func()
+		wantErr error
+	}{
+		{
+			name:  "success",
+			input: valid,
+			setup: func() {
+				mock.ExpectBegin()
+				mockPVZRepo.EXPECT().GetPVZStatus(gomock.Any(), pvzID).Return(entity.PVZStatusActive, nil)
+				mockProductRepo.EXPECT().GetProductByID(gomock.Any(), productID).
+					Return(&entity.Product{ID: productID, Status: entity.ProductStatusIssued, OwnerID: &ownerID}, nil)
+				mockProductRepo.EXPECT().MarkProductReturned(gomock.Any(), productID).Return(nil)
+				mockProductRepo.EXPECT().AddProductStatusChanges(gomock.Any(), gomock.Any()).Return(nil)
+				mockReceptionRepo.EXPECT().CreateReturn(gomock.Any(), gomock.Any()).Return(nil)
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:    "invalid reason",
+			input:   entity.CustomerReturn{PVZID: pvzID, ProductID: productID, Reason: "bored", Condition: entity.ConditionNew},
+			setup:   func() {},
+			wantErr: entity.ErrInvalidReturnReason,
+		},
+		{
+			name:    "invalid condition",
+			input:   entity.CustomerReturn{PVZID: pvzID, ProductID: productID, Reason: entity.ReturnReasonOther, Condition: "mint"},
+			setup:   func() {},
+			wantErr: entity.ErrInvalidItemCondition,
+		},
+		{
+			name:  "pvz suspended",
+			input: valid,
+			setup: func() {
+				mock.ExpectBegin()
+				mockPVZRepo.EXPECT().GetPVZStatus(gomock.Any(), pvzID).Return(entity.PVZStatusSuspended, nil)
+				mock.ExpectRollback()
+			},
+			wantErr: entity.ErrPVZNotActive,
+		},
+		{
+			name:  "product not found",
+			input: valid,
+			setup: func() {
+				mock.ExpectBegin()
+				mockPVZRepo.EXPECT().GetPVZStatus(gomock.Any(), pvzID).Return(entity.PVZStatusActive, nil)
+				mockProductRepo.EXPECT().GetProductByID(gomock.Any(), productID).Return(nil, sql.ErrNoRows)
+				mock.ExpectRollback()
+			},
+			wantErr: entity.ErrProductNotFound,
+		},
+		{
+			name:  "product not issued",
+			input: valid,
+			setup: func() {
+				mock.ExpectBegin()
+				mockPVZRepo.EXPECT().GetPVZStatus(gomock.Any(), pvzID).Return(entity.PVZStatusActive, nil)
+				mockProductRepo.EXPECT().GetProductByID(gomock.Any(), productID).
+					Return(&entity.Product{ID: productID, Status: entity.ProductStatusReturned}, nil)
+				mock.ExpectRollback()
+			},
+			wantErr: entity.ErrProductNotIssued,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			ret, err := svc.AcceptReturn(context.Background(), tt.input)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, ret)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, entity.ReturnStatusAccepted, ret.Status)
+				assert.Equal(t, &ownerID, ret.ClientID)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestReturnService_ChangeReturnStatus(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockReceptionRepo := mocks.NewMockReceptionRepository(ctrl)
+	db, mock, _ := sqlmock.New()
+	mockDB := sqlx.NewDb(db, testDriverName)
+	trManager := manager.Must(trmsqlx.NewDefaultFactory(mockDB))
+	mockLog := logrus.New()
+
+	svc := NewReturnService(mockReceptionRepo, nil, nil, trManager, mockLog)
+
+	pvzID, returnID := uuid.New(), uuid.New()
+	stored := func(status entity.ReturnStatus) *entity.CustomerReturn {
+		return &entity.CustomerReturn{ID: returnID, PVZID: pvzID, Status: status}
+	}
+
+	tests := []struct {
+		name    string
+		status  entity.ReturnStatus
+		setup   func()
+		wantErr error
+	}{
+		{
+			name:   "ready to ship",
+			status: entity.ReturnStatusReadyToShip,
+			setup: func() {
+				mock.ExpectBegin()
+				mockReceptionRepo.EXPECT().GetReturnByID(gomock.Any(), returnID).Return(stored(entity.ReturnStatusAccepted), nil)
+				mockReceptionRepo.EXPECT().UpdateReturnStatus(gomock.Any(), gomock.Any()).Return(nil)
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:   "shipped",
+			status: entity.ReturnStatusShipped,
+			setup: func() {
+				mock.ExpectBegin()
+				mockReceptionRepo.EXPECT().GetReturnByID(gomock.Any(), returnID).Return(stored(entity.ReturnStatusReadyToShip), nil)
+				mockReceptionRepo.EXPECT().UpdateReturnStatus(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, ret *entity.CustomerReturn) error {
+						assert.NotNil(t, ret.ShippedAt)
+						return nil
+					})
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:    "invalid status",
+			status:  "lost",
+			setup:   func() {},
+			wantErr: entity.ErrInvalidReturnStatus,
+		},
+		{
+			name:   "skipping a step",
+			status: entity.ReturnStatusShipped,
+			setup: func() {
+				mock.ExpectBegin()
+				mockReceptionRepo.EXPECT().GetReturnByID(gomock.Any(), returnID).Return(stored(entity.ReturnStatusAccepted), nil)
+				mock.ExpectRollback()
+			},
+			wantErr: entity.ErrReturnStatusTransition,
+		},
+		{
+			name:   "return from another pvz",
+			status: entity.ReturnStatusReadyToShip,
+			setup: func() {
+				mock.ExpectBegin()
+				mockReceptionRepo.EXPECT().GetReturnByID(gomock.Any(), returnID).
+					Return(&entity.CustomerReturn{ID: returnID, PVZID: uuid.New(), Status: entity.ReturnStatusAccepted}, nil)
+				mock.ExpectRollback()
+			},
+			wantErr: entity.ErrReturnNotFound,
+		},
+		{
+			name:   "not found",
+			status: entity.ReturnStatusReadyToShip,
+			setup: func() {
+				mock.ExpectBegin()
+				mockReceptionRepo.EXPECT().GetReturnByID(gomock.Any(), returnID).Return(nil, sql.ErrNoRows)
+				mock.ExpectRollback()
+			},
+			wantErr: entity.ErrReturnNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			ret, err := svc.ChangeReturnStatus(context.Background(), pvzID, returnID, tt.status)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.status, ret.Status)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"io"
 	"time"
 
 	"github.com/avito-tech/go-transaction-manager/trm/v2/manager"
@@ -9,6 +10,7 @@ import (
 	"github.com/sirupsen/logrus"
 
 	"github.com/senyabanana/pvz-service/internal/entity"
+	"github.com/senyabanana/pvz-service/internal/infrastructure/security"
 	"github.com/senyabanana/pvz-service/internal/repository"
 )
 
@@ -19,10 +21,35 @@ type Authorization interface {
 	LoginUser(ctx context.Context, email, password string) (string, error)
 }
 
+type OIDCAuthorization interface {
+	OIDCAuthURL(state, nonce string) (string, error)
+	LoginOIDC(ctx context.Context, code, nonce string) (string, error)
+}
+
+type PasswordOperations interface {
+	ChangePassword(ctx context.Context, userID uuid.UUID, currentPassword, newPassword string) error
+	RequestPasswordReset(ctx context.Context, email string) error
+	ResetPassword(ctx context.Context, token, newPassword string) error
+}
+
+type AccountOperations interface {
+	GetCurrentUser(ctx context.Context, claims entity.TokenClaims) (*entity.UserProfile, error)
+	IntrospectToken(ctx context.Context, token string) (*entity.TokenClaims, error)
+	AssignUserPVZ(ctx context.Context, userID uuid.UUID, pvzIDs []uuid.UUID) error
+}
+
 type PVZOperations interface {
 	CreatePVZ(ctx context.Context, city string) (*entity.PVZ, error)
-	GetFullPVZInfo(ctx context.Context, startDate, endDate *time.Time, page, limit int) ([]entity.FullPVZInfo, error)
-	GetAllPVZ(ctx context.Context) ([]entity.PVZ, error)
+	GetFullPVZInfo(ctx context.Context, filter entity.PVZFilter) ([]entity.FullPVZInfo, error)
+	GetAllPVZ(ctx context.Context, includeClosed bool) ([]entity.PVZ, error)
+	UpdatePVZDetails(ctx context.Context, pvzID uuid.UUID, update entity.PVZDetailsUpdate) (*entity.PVZ, error)
+	FindNearbyPVZ(ctx context.Context, filter entity.NearbyFilter) ([]entity.NearbyPVZ, error)
+	ChangePVZStatus(ctx context.Context, pvzID uuid.UUID, status entity.PVZStatus, reason string) (*entity.PVZ, error)
+}
+
+type CapacityOperations interface {
+	GetPVZOccupancy(ctx context.Context, pvzID uuid.UUID) (*entity.PVZOccupancy, error)
+	SetPVZCapacity(ctx context.Context, capacity entity.PVZCapacity) (*entity.PVZOccupancy, error)
 }
 
 type ReceptionOperations interface {
@@ -33,20 +60,155 @@ type ReceptionOperations interface {
 type ProductOperations interface {
 	AddProduct(ctx context.Context, pvzID uuid.UUID, productType entity.ProductType) (*entity.Product, error)
 	DeleteLastProduct(ctx context.Context, pvzID uuid.UUID) error
+	GetProductHistory(ctx context.Context, productID uuid.UUID) ([]entity.ProductStatusChange, error)
+}
+
+type IssuanceOperations interface {
+	AssignProductOwner(ctx context.Context, pvzID, productID, ownerID uuid.UUID) (string, error)
+	IssueProduct(ctx context.Context, pvzID, productID uuid.UUID, pickupCode string, issuedBy *uuid.UUID) (*entity.Product, error)
+	GetMyParcels(ctx context.Context, ownerID uuid.UUID) ([]entity.Parcel, error)
+	RenewPickupCode(ctx context.Context, ownerID, productID uuid.UUID) (string, error)
+}
+
+type ReturnOperations interface {
+	AcceptReturn(ctx context.Context, ret entity.CustomerReturn) (*entity.CustomerReturn, error)
+	ChangeReturnStatus(ctx context.Context, pvzID, returnID uuid.UUID, status entity.ReturnStatus) (*entity.CustomerReturn, error)
+}
+
+type StorageOperations interface {
+	FlagOverdueProducts(ctx context.Context) ([]entity.OverdueProduct, error)
+	GetOverdueProducts(ctx context.Context, pvzID uuid.UUID) ([]entity.OverdueProduct, error)
+	ShipOverdueProducts(ctx context.Context, shipment entity.SenderShipment) (*entity.SenderShipment, error)
+}
+
+type TransferOperations interface {
+	CreateTransfer(ctx context.Context, order entity.TransferOrder) (*entity.TransferOrder, error)
+	ReceiveTransfer(ctx context.Context, pvzID, transferID uuid.UUID, receivedBy *uuid.UUID) (*entity.TransferOrder, error)
+	GetProductCustody(ctx context.Context, productID uuid.UUID) ([]entity.CustodyEvent, error)
+}
+
+type StocktakeOperations interface {
+	StartStocktake(ctx context.Context, pvzID uuid.UUID, startedBy *uuid.UUID) (*entity.Stocktake, error)
+	ScanProducts(
+		ctx context.Context, pvzID, stocktakeID uuid.UUID, productIDs []uuid.UUID, scannedBy *uuid.UUID,
+	) (*entity.Stocktake, error)
+	CompleteStocktake(
+		ctx context.Context, pvzID, stocktakeID uuid.UUID, completedBy *uuid.UUID, comment string,
+	) (*entity.Stocktake, error)
+	GetStocktake(ctx context.Context, pvzID, stocktakeID uuid.UUID) (*entity.Stocktake, error)
+}
+
+type AnalyticsOperations interface {
+	GetReceptionThroughput(ctx context.Context, filter entity.AnalyticsFilter) ([]entity.ReceptionThroughput, error)
+	GetProductMix(ctx context.Context, filter entity.AnalyticsFilter) ([]entity.ProductMix, error)
+}
+
+type ExportOperations interface {
+	Export(ctx context.Context, dataset entity.ExportDataset, filter entity.ExportFilter, w RowWriter) error
+}
+
+type ImportOperations interface {
+	ImportData(ctx context.Context, format entity.ImportFormat, r io.Reader, dryRun bool) (*entity.ImportReport, error)
+}
+
+type ReportJobOperations interface {
+	CreateReportJob(ctx context.Context, job entity.ReportJob) (*entity.ReportJob, error)
+	GetReportJob(ctx context.Context, jobID uuid.UUID) (*entity.ReportJob, error)
+	GetReportArtifact(ctx context.Context, jobID uuid.UUID) (*entity.ReportArtifact, error)
+	RunNextReportJob(ctx context.Context) (bool, error)
+	RecoverStaleReportJobs(ctx context.Context) (int, error)
+}
+
+type VersionOperations interface {
+	GetVersion(ctx context.Context) (*entity.VersionInfo, error)
+}
+
+type HealthOperations interface {
+	CheckReadiness(ctx context.Context) entity.Readiness
+}
+
+type LogLevelOperations interface {
+	GetLogLevel(ctx context.Context) string
+	SetLogLevel(ctx context.Context, level string) error
+}
+
+type APIKeyOperations interface {
+	CreateAPIKey(ctx context.Context, key *entity.APIKey) (string, error)
+	GetAllAPIKeys(ctx context.Context) ([]entity.APIKey, error)
+	RevokeAPIKey(ctx context.Context, keyID uuid.UUID) error
+	AuthenticateAPIKey(ctx context.Context, rawKey string) (*entity.APIKey, error)
 }
 
 type Service struct {
 	Authorization
+	OIDCAuthorization
+	PasswordOperations
+	AccountOperations
 	PVZOperations
+	CapacityOperations
 	ReceptionOperations
 	ProductOperations
+	IssuanceOperations
+	ReturnOperations
+	StorageOperations
+	TransferOperations
+	StocktakeOperations
+	AnalyticsOperations
+	ExportOperations
+	ImportOperations
+	ReportJobOperations
+	VersionOperations
+	HealthOperations
+	LogLevelOperations
+	APIKeyOperations
+}
+
+type Dependencies struct {
+	Repos            *repository.Repository
+	TrManager        *manager.Manager
+	JWTSecret        string
+	IdentityProvider IdentityProvider
+	OIDCRoleMapping  map[string]entity.UserRole
+	PasswordHasher   *security.PasswordHasher
+	PasswordPolicy   security.PasswordPolicy
+	Notifier         Notifier
+	PasswordResetTTL time.Duration
+	CapacityPolicy   entity.CapacityPolicy
+	StoragePolicy    entity.StoragePolicy
+	Events           EventPublisher
+	ReportJobTimeout time.Duration
+	BuildVersion     string
+	LatestSchema     int64
+	ReadyTimeout     time.Duration
+	Log              *logrus.Logger
 }
 
-func NewService(repos *repository.Repository, trManager *manager.Manager, secretKey string, log *logrus.Logger) *Service {
+func NewService(deps Dependencies) *Service {
+	repos, trManager, log := deps.Repos, deps.TrManager, deps.Log
+	analytics := NewAnalyticsService(repos, log)
+	exporter := NewExportService(repos, log)
+
 	return &Service{
-		Authorization:       NewUserService(repos, trManager, secretKey, log),
+		Authorization:       NewUserService(repos, deps.PasswordHasher, deps.PasswordPolicy, trManager, deps.JWTSecret, log),
+		OIDCAuthorization:   NewOIDCService(repos, deps.IdentityProvider, deps.OIDCRoleMapping, trManager, deps.JWTSecret, log),
+		PasswordOperations:  NewPasswordService(repos, repos, deps.PasswordHasher, deps.PasswordPolicy, deps.Notifier, deps.PasswordResetTTL, trManager, log),
+		AccountOperations:   NewAccountService(repos, repos, trManager, deps.JWTSecret, log),
 		PVZOperations:       NewPVZService(repos, repos, repos, trManager, log),
+		CapacityOperations:  NewCapacityService(repos, trManager, log),
 		ReceptionOperations: NewReceptionService(repos, repos, trManager, log),
-		ProductOperations:   NewProductService(repos, repos, trManager, log),
+		ProductOperations:   NewProductService(repos, repos, repos, repos, deps.CapacityPolicy, trManager, log),
+		IssuanceOperations:  NewIssuanceService(repos, repos, repos, trManager, log),
+		ReturnOperations:    NewReturnService(repos, repos, repos, trManager, log),
+		StorageOperations:   NewStorageService(repos, repos, deps.StoragePolicy, deps.Events, trManager, log),
+		TransferOperations:  NewTransferService(repos, repos, repos, repos, repos, deps.CapacityPolicy, trManager, log),
+		StocktakeOperations: NewStocktakeService(repos, repos, trManager, log),
+		AnalyticsOperations: analytics,
+		ExportOperations:    exporter,
+		ImportOperations:    NewImportService(repos, repos, repos, trManager, log),
+		ReportJobOperations: NewReportJobService(repos, exporter, analytics, deps.ReportJobTimeout, log),
+		VersionOperations:   NewVersionService(repos, deps.BuildVersion, deps.LatestSchema, log),
+		HealthOperations:    NewHealthService(repos, deps.LatestSchema, deps.ReadyTimeout, log),
+		LogLevelOperations:  NewLogLevelService(log),
+		APIKeyOperations:    NewAPIKeyService(repos, repos, log),
 	}
 }
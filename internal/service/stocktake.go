@@ -0,0 +1,270 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/avito-tech/go-transaction-manager/trm/v2/manager"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+
+	"github.com/senyabanana/pvz-service/internal/entity"
+	"github.com/senyabanana/pvz-service/internal/infrastructure/logger"
+	"github.com/senyabanana/pvz-service/internal/infrastructure/monitoring"
+	"github.com/senyabanana/pvz-service/internal/repository"
+)
+
+type StocktakeService struct {
+	stocktakeRepo repository.StocktakeRepository
+	pvzRepo       repository.PVZRepository
+	trManager     *manager.Manager
+	log           *logrus.Logger
+}
+
+func NewStocktakeService(
+	stocktakeRepo repository.StocktakeRepository,
+	pvzRepo repository.PVZRepository,
+	trManager *manager.Manager,
+	log *logrus.Logger,
+) *StocktakeService {
+	return &StocktakeService{
+		stocktakeRepo: stocktakeRepo,
+		pvzRepo:       pvzRepo,
+		trManager:     trManager,
+		log:           log,
+	}
+}
+
+// StartStocktake opens a stocktake session at the PVZ. A suspended PVZ can still be recounted,
+// a closed one cannot; only one session per PVZ may be open at a time.
+func (s *StocktakeService) StartStocktake(ctx context.Context, pvzID uuid.UUID, startedBy *uuid.UUID) (*entity.Stocktake, error) {
+	ctx, span := tracer.Start(ctx, "StocktakeService.StartStocktake")
+	defer span.End()
+	log := logger.FromContext(ctx, s.log).WithField(logger.FieldPVZID, pvzID)
+
+	stocktake := &entity.Stocktake{
+		PVZID:     pvzID,
+		Status:    entity.StocktakeStatusOpen,
+		StartedAt: time.Now(),
+		StartedBy: startedBy,
+	}
+
+	err := s.trManager.Do(ctx, func(ctx context.Context) error {
+		status, err := s.pvzRepo.GetPVZStatus(ctx, pvzID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return entity.ErrPVZNotFound
+			}
+			return err
+		}
+
+		if status == entity.PVZStatusClosed {
+			return fmt.Errorf("%w: %s", entity.ErrPVZNotActive, status)
+		}
+
+		_, err = s.stocktakeRepo.GetOpenStocktake(ctx, pvzID)
+		if err == nil {
+			return entity.ErrStocktakeInProgress
+		}
+		if !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+
+		return s.stocktakeRepo.CreateStocktake(ctx, stocktake)
+	})
+	if err != nil {
+		log.Warnf("failed to start stocktake at pvz %s: %v", pvzID, err)
+		return nil, err
+	}
+
+	log.Infof("stocktake started: id=%s, pvz=%s", stocktake.ID, pvzID)
+	return stocktake, nil
+}
+
+// ScanProducts records products found on the shelves. Scanning the same product twice is harmless;
+// the returned stocktake reports the number of distinct products scanned so far.
+func (s *StocktakeService) ScanProducts(
+	ctx context.Context, pvzID, stocktakeID uuid.UUID, productIDs []uuid.UUID, scannedBy *uuid.UUID,
+) (*entity.Stocktake, error) {
+	ctx, span := tracer.Start(ctx, "StocktakeService.ScanProducts")
+	defer span.End()
+	log := logger.FromContext(ctx, s.log)
+
+	if len(productIDs) == 0 || len(productIDs) > entity.MaxStocktakeScanBatch {
+		return nil, fmt.Errorf("%w: from 1 to %d products per scan", entity.ErrInvalidStocktakeScan, entity.MaxStocktakeScanBatch)
+	}
+
+	var result *entity.Stocktake
+
+	err := s.trManager.Do(ctx, func(ctx context.Context) error {
+		stocktake, err := s.getOpenStocktake(ctx, pvzID, stocktakeID)
+		if err != nil {
+			return err
+		}
+
+		added, err := s.stocktakeRepo.AddStocktakeScans(ctx, stocktakeID, productIDs, time.Now(), scannedBy)
+		if err != nil {
+			return err
+		}
+
+		stocktake.ScannedCount += added
+		result = stocktake
+		return nil
+	})
+	if err != nil {
+		log.Warnf("failed to record scans for stocktake %s: %v", stocktakeID, err)
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// CompleteStocktake compares the scans with the stock the PVZ is expected to hold and stores the report
+// signed off by the completing employee. API keys cannot sign off a stocktake.
+func (s *StocktakeService) CompleteStocktake(
+	ctx context.Context, pvzID, stocktakeID uuid.UUID, completedBy *uuid.UUID, comment string,
+) (*entity.Stocktake, error) {
+	ctx, span := tracer.Start(ctx, "StocktakeService.CompleteStocktake")
+	defer span.End()
+	log := logger.FromContext(ctx, s.log)
+
+	if completedBy == nil {
+		return nil, entity.ErrStocktakeSignOff
+	}
+
+	var result *entity.Stocktake
+
+	err := s.trManager.Do(ctx, func(ctx context.Context) error {
+		stocktake, err := s.getOpenStocktake(ctx, pvzID, stocktakeID)
+		if err != nil {
+			return err
+		}
+
+		expected, err := s.stocktakeRepo.GetExpectedStock(ctx, pvzID)
+		if err != nil {
+			return err
+		}
+
+		scanned, err := s.stocktakeRepo.GetStocktakeScans(ctx, stocktakeID)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		stocktake.Status = entity.StocktakeStatusCompleted
+		stocktake.CompletedAt = &now
+		stocktake.CompletedBy = completedBy
+		stocktake.Comment = comment
+		stocktake.Items = entity.CompareStock(expected, scanned)
+		stocktake.ExpectedCount = len(expected)
+		stocktake.ScannedCount = len(scanned)
+		countStocktakeResults(stocktake)
+
+		if err := s.stocktakeRepo.CompleteStocktake(ctx, stocktake); err != nil {
+			return err
+		}
+
+		result = stocktake
+		return nil
+	})
+	if err != nil {
+		log.Warnf("failed to complete stocktake %s: %v", stocktakeID, err)
+		return nil, err
+	}
+
+	monitoring.StocktakeDiscrepanciesCounter.WithLabelValues(string(entity.StocktakeMissing)).Add(float64(result.MissingCount))
+	monitoring.StocktakeDiscrepanciesCounter.WithLabelValues(string(entity.StocktakeUnexpected)).Add(float64(result.UnexpectedCount))
+
+	log.Infof("stocktake completed: id=%s, pvz=%s, expected=%d, matched=%d, missing=%d, unexpected=%d",
+		result.ID, pvzID, result.ExpectedCount, result.MatchedCount, result.MissingCount, result.UnexpectedCount)
+	return result, nil
+}
+
+// GetStocktake returns a stocktake of the PVZ; a completed one comes with its report items.
+func (s *StocktakeService) GetStocktake(ctx context.Context, pvzID, stocktakeID uuid.UUID) (*entity.Stocktake, error) {
+	ctx, span := tracer.Start(ctx, "StocktakeService.GetStocktake")
+	defer span.End()
+	log := logger.FromContext(ctx, s.log)
+
+	var result *entity.Stocktake
+
+	err := s.trManager.Do(ctx, func(ctx context.Context) error {
+		stocktake, err := s.getStocktake(ctx, pvzID, stocktakeID)
+		if err != nil {
+			return err
+		}
+
+		if stocktake.Status == entity.StocktakeStatusCompleted {
+			stocktake.Items, err = s.stocktakeRepo.GetStocktakeItems(ctx, stocktakeID)
+			if err != nil {
+				return err
+			}
+		}
+
+		result = stocktake
+		return nil
+	})
+	if err != nil {
+		if !errors.Is(err, entity.ErrStocktakeNotFound) {
+			log.Errorf("failed to get stocktake %s: %v", stocktakeID, err)
+		}
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// getStocktake reads the stocktake without locking it.
+func (s *StocktakeService) getStocktake(ctx context.Context, pvzID, stocktakeID uuid.UUID) (*entity.Stocktake, error) {
+	stocktake, err := s.stocktakeRepo.GetStocktakeByID(ctx, stocktakeID)
+	return stocktakeOfPVZ(stocktake, err, pvzID)
+}
+
+// getOpenStocktake locks the stocktake so scans cannot be added while it is being completed.
+func (s *StocktakeService) getOpenStocktake(ctx context.Context, pvzID, stocktakeID uuid.UUID) (*entity.Stocktake, error) {
+	stocktake, err := s.stocktakeRepo.GetStocktakeByIDForUpdate(ctx, stocktakeID)
+	stocktake, err = stocktakeOfPVZ(stocktake, err, pvzID)
+	if err != nil {
+		return nil, err
+	}
+
+	if stocktake.Status != entity.StocktakeStatusOpen {
+		return nil, entity.ErrStocktakeCompleted
+	}
+
+	return stocktake, nil
+}
+
+// stocktakeOfPVZ makes sure the stocktake belongs to the PVZ; a stocktake of another PVZ
+// is reported as not found.
+func stocktakeOfPVZ(stocktake *entity.Stocktake, err error, pvzID uuid.UUID) (*entity.Stocktake, error) {
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, entity.ErrStocktakeNotFound
+		}
+		return nil, err
+	}
+
+	if stocktake.PVZID != pvzID {
+		return nil, entity.ErrStocktakeNotFound
+	}
+
+	return stocktake, nil
+}
+
+func countStocktakeResults(stocktake *entity.Stocktake) {
+	stocktake.MatchedCount, stocktake.MissingCount, stocktake.UnexpectedCount = 0, 0, 0
+	for _, item := range stocktake.Items {
+		switch item.Result {
+		case entity.StocktakeMatched:
+			stocktake.MatchedCount++
+		case entity.StocktakeMissing:
+			stocktake.MissingCount++
+		case entity.StocktakeUnexpected:
+			stocktake.UnexpectedCount++
+		}
+	}
+}
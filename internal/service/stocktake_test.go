@@ -0,0 +1,332 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	trmsqlx "github.com/avito-tech/go-transaction-manager/drivers/sqlx/v2"
+	"github.com/avito-tech/go-transaction-manager/trm/v2/manager"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/senyabanana/pvz-service/internal/entity"
+	mocks "github.com/senyabanana/pvz-service/internal/repository/mocks"
+)
+
+func TestStocktakeService_StartStocktake(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStocktakeRepo := mocks.NewMockStocktakeRepository(ctrl)
+	mockPVZRepo := mocks.NewMockPVZRepository(ctrl)
+	db, mock, _ := sqlmock.New()
+	mockDB := sqlx.NewDb(db, testDriverName)
+	trManager := manager.Must(trmsqlx.NewDefaultFactory(mockDB))
+	mockLog := logrus.New()
+
+	svc := NewStocktakeService(mockStocktakeRepo, mockPVZRepo, trManager, mockLog)
+
+	pvzID, employeeID := uuid.New(), uuid.New()
+
+	tests := []struct {
+		name    string
+		setup   func()
+		wantErr error
+	}{
+		{
+			name: "success",
+			setup: func() {
+				mock.ExpectBegin()
+				mockPVZRepo.EXPECT().GetPVZStatus(gomock.Any(), pvzID).Return(entity.PVZStatusActive, nil)
+				mockStocktakeRepo.EXPECT().GetOpenStocktake(gomock.Any(), pvzID).Return(nil, sql.ErrNoRows)
+				mockStocktakeRepo.EXPECT().CreateStocktake(gomock.Any(), gomock.Any()).Return(nil)
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "suspended pvz can be recounted",
+			setup: func() {
+				mock.ExpectBegin()
+				mockPVZRepo.EXPECT().GetPVZStatus(gomock.Any(), pvzID).Return(entity.PVZStatusSuspended, nil)
+				mockStocktakeRepo.EXPECT().GetOpenStocktake(gomock.Any(), pvzID).Return(nil, sql.ErrNoRows)
+				mockStocktakeRepo.EXPECT().CreateStocktake(gomock.Any(), gomock.Any()).Return(nil)
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "pvz not found",
+			setup: func() {
+				mock.ExpectBegin()
+				mockPVZRepo.EXPECT().GetPVZStatus(gomock.Any(), pvzID).Return(entity.PVZStatus(""), sql.ErrNoRows)
+				mock.ExpectRollback()
+			},
+			wantErr: entity.ErrPVZNotFound,
+		},
+		{
+			name: "pvz closed",
+			setup: func() {
+				mock.ExpectBegin()
+				mockPVZRepo.EXPECT().GetPVZStatus(gomock.Any(), pvzID).Return(entity.PVZStatusClosed, nil)
+				mock.ExpectRollback()
+			},
+			wantErr: entity.ErrPVZNotActive,
+		},
+		{
+			name: "stocktake already open",
+			setup: func() {
+				mock.ExpectBegin()
+				mockPVZRepo.EXPECT().GetPVZStatus(gomock.Any(), pvzID).Return(entity.PVZStatusActive, nil)
+				mockStocktakeRepo.EXPECT().GetOpenStocktake(gomock.Any(), pvzID).Return(&entity.Stocktake{ID: uuid.New()}, nil)
+				mock.ExpectRollback()
+			},
+			wantErr: entity.ErrStocktakeInProgress,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			stocktake, err := svc.StartStocktake(context.Background(), pvzID, &employeeID)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, stocktake)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, entity.StocktakeStatusOpen, stocktake.Status)
+				assert.Equal(t, &employeeID, stocktake.StartedBy)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestStocktakeService_ScanProducts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStocktakeRepo := mocks.NewMockStocktakeRepository(ctrl)
+	db, mock, _ := sqlmock.New()
+	mockDB := sqlx.NewDb(db, testDriverName)
+	trManager := manager.Must(trmsqlx.NewDefaultFactory(mockDB))
+	mockLog := logrus.New()
+
+	svc := NewStocktakeService(mockStocktakeRepo, nil, trManager, mockLog)
+
+	pvzID, stocktakeID := uuid.New(), uuid.New()
+	productIDs := []uuid.UUID{uuid.New(), uuid.New()}
+	stored := func(pvzID uuid.UUID, status entity.StocktakeStatus) *entity.Stocktake {
+		return &entity.Stocktake{ID: stocktakeID, PVZID: pvzID, Status: status, ScannedCount: 3}
+	}
+
+	tests := []struct {
+		name        string
+		productIDs  []uuid.UUID
+		setup       func()
+		wantScanned int
+		wantErr     error
+	}{
+		{
+			name:       "success",
+			productIDs: productIDs,
+			setup: func() {
+				mock.ExpectBegin()
+				mockStocktakeRepo.EXPECT().GetStocktakeByIDForUpdate(gomock.Any(), stocktakeID).
+					Return(stored(pvzID, entity.StocktakeStatusOpen), nil)
+				mockStocktakeRepo.EXPECT().AddStocktakeScans(gomock.Any(), stocktakeID, productIDs, gomock.Any(), nil).Return(1, nil)
+				mock.ExpectCommit()
+			},
+			wantScanned: 4,
+		},
+		{
+			name:       "empty scan",
+			productIDs: nil,
+			setup:      func() {},
+			wantErr:    entity.ErrInvalidStocktakeScan,
+		},
+		{
+			name:       "stocktake of another pvz",
+			productIDs: productIDs,
+			setup: func() {
+				mock.ExpectBegin()
+				mockStocktakeRepo.EXPECT().GetStocktakeByIDForUpdate(gomock.Any(), stocktakeID).
+					Return(stored(uuid.New(), entity.StocktakeStatusOpen), nil)
+				mock.ExpectRollback()
+			},
+			wantErr: entity.ErrStocktakeNotFound,
+		},
+		{
+			name:       "stocktake completed",
+			productIDs: productIDs,
+			setup: func() {
+				mock.ExpectBegin()
+				mockStocktakeRepo.EXPECT().GetStocktakeByIDForUpdate(gomock.Any(), stocktakeID).
+					Return(stored(pvzID, entity.StocktakeStatusCompleted), nil)
+				mock.ExpectRollback()
+			},
+			wantErr: entity.ErrStocktakeCompleted,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			stocktake, err := svc.ScanProducts(context.Background(), pvzID, stocktakeID, tt.productIDs, nil)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantScanned, stocktake.ScannedCount)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestStocktakeService_CompleteStocktake(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStocktakeRepo := mocks.NewMockStocktakeRepository(ctrl)
+	db, mock, _ := sqlmock.New()
+	mockDB := sqlx.NewDb(db, testDriverName)
+	trManager := manager.Must(trmsqlx.NewDefaultFactory(mockDB))
+	mockLog := logrus.New()
+
+	svc := NewStocktakeService(mockStocktakeRepo, nil, trManager, mockLog)
+
+	pvzID, stocktakeID, employeeID := uuid.New(), uuid.New(), uuid.New()
+	onShelf, lost, stray := uuid.New(), uuid.New(), uuid.New()
+
+	tests := []struct {
+		name        string
+		completedBy *uuid.UUID
+		setup       func()
+		wantErr     error
+	}{
+		{
+			name:        "success",
+			completedBy: &employeeID,
+			setup: func() {
+				mock.ExpectBegin()
+				mockStocktakeRepo.EXPECT().GetStocktakeByIDForUpdate(gomock.Any(), stocktakeID).
+					Return(&entity.Stocktake{ID: stocktakeID, PVZID: pvzID, Status: entity.StocktakeStatusOpen}, nil)
+				mockStocktakeRepo.EXPECT().GetExpectedStock(gomock.Any(), pvzID).Return([]uuid.UUID{onShelf, lost}, nil)
+				mockStocktakeRepo.EXPECT().GetStocktakeScans(gomock.Any(), stocktakeID).Return([]uuid.UUID{stray, onShelf}, nil)
+				mockStocktakeRepo.EXPECT().CompleteStocktake(gomock.Any(), gomock.Any()).Return(nil)
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:    "not signed off by an employee",
+			setup:   func() {},
+			wantErr: entity.ErrStocktakeSignOff,
+		},
+		{
+			name:        "not found",
+			completedBy: &employeeID,
+			setup: func() {
+				mock.ExpectBegin()
+				mockStocktakeRepo.EXPECT().GetStocktakeByIDForUpdate(gomock.Any(), stocktakeID).Return(nil, sql.ErrNoRows)
+				mock.ExpectRollback()
+			},
+			wantErr: entity.ErrStocktakeNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			stocktake, err := svc.CompleteStocktake(context.Background(), pvzID, stocktakeID, tt.completedBy, "")
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, stocktake)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, entity.StocktakeStatusCompleted, stocktake.Status)
+				assert.Equal(t, 2, stocktake.ExpectedCount)
+				assert.Equal(t, 2, stocktake.ScannedCount)
+				assert.Equal(t, 1, stocktake.MatchedCount)
+				assert.Equal(t, 1, stocktake.MissingCount)
+				assert.Equal(t, 1, stocktake.UnexpectedCount)
+				assert.Equal(t, []entity.StocktakeItem{
+					{ProductID: onShelf, Result: entity.StocktakeMatched},
+					{ProductID: lost, Result: entity.StocktakeMissing},
+					{ProductID: stray, Result: entity.StocktakeUnexpected},
+				}, stocktake.Items)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestStocktakeService_GetStocktake(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStocktakeRepo := mocks.NewMockStocktakeRepository(ctrl)
+	db, mock, _ := sqlmock.New()
+	mockDB := sqlx.NewDb(db, testDriverName)
+	trManager := manager.Must(trmsqlx.NewDefaultFactory(mockDB))
+	mockLog := logrus.New()
+
+	svc := NewStocktakeService(mockStocktakeRepo, nil, trManager, mockLog)
+
+	pvzID, stocktakeID := uuid.New(), uuid.New()
+
+	tests := []struct {
+		name      string
+		setup     func()
+		wantItems int
+		wantErr   error
+	}{
+		{
+			name: "completed with report",
+			setup: func() {
+				mock.ExpectBegin()
+				mockStocktakeRepo.EXPECT().GetStocktakeByID(gomock.Any(), stocktakeID).
+					Return(&entity.Stocktake{ID: stocktakeID, PVZID: pvzID, Status: entity.StocktakeStatusCompleted}, nil)
+				mockStocktakeRepo.EXPECT().GetStocktakeItems(gomock.Any(), stocktakeID).
+					Return([]entity.StocktakeItem{{ProductID: uuid.New(), Result: entity.StocktakeMatched}}, nil)
+				mock.ExpectCommit()
+			},
+			wantItems: 1,
+		},
+		{
+			name: "open without report",
+			setup: func() {
+				mock.ExpectBegin()
+				mockStocktakeRepo.EXPECT().GetStocktakeByID(gomock.Any(), stocktakeID).
+					Return(&entity.Stocktake{ID: stocktakeID, PVZID: pvzID, Status: entity.StocktakeStatusOpen}, nil)
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "not found",
+			setup: func() {
+				mock.ExpectBegin()
+				mockStocktakeRepo.EXPECT().GetStocktakeByID(gomock.Any(), stocktakeID).Return(nil, sql.ErrNoRows)
+				mock.ExpectRollback()
+			},
+			wantErr: entity.ErrStocktakeNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			stocktake, err := svc.GetStocktake(context.Background(), pvzID, stocktakeID)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, stocktake.Items, tt.wantItems)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
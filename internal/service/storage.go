@@ -0,0 +1,183 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/avito-tech/go-transaction-manager/trm/v2/manager"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+
+	"github.com/senyabanana/pvz-service/internal/entity"
+	"github.com/senyabanana/pvz-service/internal/infrastructure/logger"
+	"github.com/senyabanana/pvz-service/internal/infrastructure/monitoring"
+	"github.com/senyabanana/pvz-service/internal/repository"
+)
+
+// EventPublisher delivers domain events to external consumers.
+type EventPublisher interface {
+	Publish(ctx context.Context, event entity.Event) error
+}
+
+type StorageService struct {
+	productRepo repository.ProductRepository
+	pvzRepo     repository.PVZRepository
+	policy      entity.StoragePolicy
+	publisher   EventPublisher
+	trManager   *manager.Manager
+	log         *logrus.Logger
+}
+
+func NewStorageService(
+	productRepo repository.ProductRepository,
+	pvzRepo repository.PVZRepository,
+	policy entity.StoragePolicy,
+	publisher EventPublisher,
+	trManager *manager.Manager,
+	log *logrus.Logger,
+) *StorageService {
+	return &StorageService{
+		productRepo: productRepo,
+		pvzRepo:     pvzRepo,
+		policy:      policy,
+		publisher:   publisher,
+		trManager:   trManager,
+		log:         log,
+	}
+}
+
+// FlagOverdueProducts queues every product whose storage period has expired for return to the sender.
+// Events are published only after the transaction commits; a failed publish is logged and not retried.
+func (s *StorageService) FlagOverdueProducts(ctx context.Context) ([]entity.OverdueProduct, error) {
+	ctx, span := tracer.Start(ctx, "StorageService.FlagOverdueProducts")
+	defer span.End()
+	log := logger.FromContext(ctx, s.log)
+
+	var flagged []entity.OverdueProduct
+	now := time.Now()
+
+	err := s.trManager.Do(ctx, func(ctx context.Context) error {
+		flagged = nil
+		for _, productType := range entity.ProductTypes() {
+			period := s.policy.Period(productType)
+			overdue, err := s.productRepo.FlagOverdueProducts(ctx, productType, now.Add(-period), period, now)
+			if err != nil {
+				return err
+			}
+			flagged = append(flagged, overdue...)
+		}
+
+		changes := make([]entity.ProductStatusChange, 0, len(flagged))
+		for _, product := range flagged {
+			changes = append(changes, statusChanges([]uuid.UUID{product.ProductID}, entity.ProductStatusReceived,
+				entity.ProductStatusReturnToSender, product.PVZID, nil, now, "storage period expired")...)
+		}
+		return s.productRepo.AddProductStatusChanges(ctx, changes)
+	})
+	if err != nil {
+		log.Errorf("failed to flag overdue products: %v", err)
+		return nil, err
+	}
+
+	for _, product := range flagged {
+		monitoring.OverdueProductsCounter.WithLabelValues(string(product.Type)).Inc()
+
+		event := entity.Event{Type: entity.EventProductOverdue, OccurredAt: now, Payload: product}
+		if err := s.publisher.Publish(ctx, event); err != nil {
+			log.Errorf("failed to publish %s event for product %s: %v", event.Type, product.ProductID, err)
+		}
+	}
+
+	if len(flagged) > 0 {
+		log.Infof("queued %d overdue products for return to sender", len(flagged))
+	}
+
+	return flagged, nil
+}
+
+func (s *StorageService) GetOverdueProducts(ctx context.Context, pvzID uuid.UUID) ([]entity.OverdueProduct, error) {
+	ctx, span := tracer.Start(ctx, "StorageService.GetOverdueProducts")
+	defer span.End()
+	log := logger.FromContext(ctx, s.log).WithField(logger.FieldPVZID, pvzID)
+
+	exists, err := s.pvzRepo.IsPVZExists(ctx, pvzID)
+	if err != nil {
+		log.Errorf("failed to check pvz existence: %v", err)
+		return nil, err
+	}
+
+	if !exists {
+		return nil, entity.ErrPVZNotFound
+	}
+
+	overdue, err := s.productRepo.GetOverdueProducts(ctx, pvzID)
+	if err != nil {
+		log.Errorf("failed to get overdue products for pvz %s: %v", pvzID, err)
+		return nil, err
+	}
+
+	return overdue, nil
+}
+
+// ShipOverdueProducts records that queued products were handed to the courier for the sender. They leave
+// the PVZ: the queue entry gets shipped_at and the products move to shipped_to_sender. Either every product
+// of the shipment is shipped or none is.
+func (s *StorageService) ShipOverdueProducts(
+	ctx context.Context, shipment entity.SenderShipment,
+) (*entity.SenderShipment, error) {
+	ctx, span := tracer.Start(ctx, "StorageService.ShipOverdueProducts")
+	defer span.End()
+	log := logger.FromContext(ctx, s.log).WithField(logger.FieldPVZID, shipment.PVZID)
+
+	if err := validateSenderShipment(shipment); err != nil {
+		return nil, err
+	}
+
+	err := s.trManager.Do(ctx, func(ctx context.Context) error {
+		exists, err := s.pvzRepo.IsPVZExists(ctx, shipment.PVZID)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return entity.ErrPVZNotFound
+		}
+
+		shipment.ShippedAt = time.Now()
+		shipped, err := s.productRepo.ShipOverdueProducts(ctx, shipment.PVZID, shipment.ProductIDs, shipment.ShippedAt)
+		if err != nil {
+			return err
+		}
+
+		if len(shipped) != len(shipment.ProductIDs) {
+			return entity.ErrProductNotOverdue
+		}
+
+		changes := statusChanges(shipment.ProductIDs, entity.ProductStatusReturnToSender, entity.ProductStatusShippedToSender,
+			shipment.PVZID, shipment.ShippedBy, shipment.ShippedAt, "shipped to sender")
+		return s.productRepo.AddProductStatusChanges(ctx, changes)
+	})
+	if err != nil {
+		log.Warnf("failed to ship overdue products to sender: %v", err)
+		return nil, err
+	}
+
+	log.Infof("shipped %d overdue products to sender", len(shipment.ProductIDs))
+	return &shipment, nil
+}
+
+func validateSenderShipment(shipment entity.SenderShipment) error {
+	if len(shipment.ProductIDs) == 0 || len(shipment.ProductIDs) > entity.MaxSenderShipmentProducts {
+		return fmt.Errorf("%w: from 1 to %d products per shipment", entity.ErrInvalidSenderShipment, entity.MaxSenderShipmentProducts)
+	}
+
+	seen := make(map[uuid.UUID]struct{}, len(shipment.ProductIDs))
+	for _, id := range shipment.ProductIDs {
+		if _, ok := seen[id]; ok {
+			return fmt.Errorf("%w: duplicate product %s", entity.ErrInvalidSenderShipment, id)
+		}
+		seen[id] = struct{}{}
+	}
+
+	return nil
+}
@@ -0,0 +1,259 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	trmsqlx "github.com/avito-tech/go-transaction-manager/drivers/sqlx/v2"
+	"github.com/avito-tech/go-transaction-manager/trm/v2/manager"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/senyabanana/pvz-service/internal/entity"
+	mocks "github.com/senyabanana/pvz-service/internal/repository/mocks"
+)
+
+type fakePublisher struct {
+	events []entity.Event
+	err    error
+}
+
+func (p *fakePublisher) Publish(ctx context.Context, event entity.Event) error {
+	p.events = append(p.events, event)
+	return p.err
+}
+
+func TestStorageService_FlagOverdueProducts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockProductRepo := mocks.NewMockProductRepository(ctrl)
+	db, mock, _ := sqlmock.New()
+	mockDB := sqlx.NewDb(db, testDriverName)
+	trManager := manager.Must(trmsqlx.NewDefaultFactory(mockDB))
+	mockLog := logrus.New()
+
+	policy := entity.StoragePolicy{
+		Default: 7 * 24 * time.Hour,
+		ByType:  map[entity.ProductType]time.Duration{entity.ProductElectronics: 14 * 24 * time.Hour},
+	}
+
+	overdueShoes := entity.OverdueProduct{ProductID: uuid.New(), PVZID: uuid.New(), Type: entity.ProductShoes}
+
+	tests := []struct {
+		name       string
+		publisher  *fakePublisher
+		setup      func()
+		wantErr    bool
+		wantEvents int
+	}{
+		{
+			name:      "success",
+			publisher: &fakePublisher{},
+			setup: func() {
+				mock.ExpectBegin()
+				mockProductRepo.EXPECT().
+					FlagOverdueProducts(gomock.Any(), entity.ProductElectronics, gomock.Any(), 14*24*time.Hour, gomock.Any()).
+					Return(nil, nil)
+				mockProductRepo.EXPECT().
+					FlagOverdueProducts(gomock.Any(), entity.ProductClothing, gomock.Any(), 7*24*time.Hour, gomock.Any()).
+					Return(nil, nil)
+				mockProductRepo.EXPECT().
+					FlagOverdueProducts(gomock.Any(), entity.ProductShoes, gomock.Any(), 7*24*time.Hour, gomock.Any()).
+					Return([]entity.OverdueProduct{overdueShoes}, nil)
+				mockProductRepo.EXPECT().AddProductStatusChanges(gomock.Any(), gomock.Len(1)).Return(nil)
+				mock.ExpectCommit()
+			},
+			wantEvents: 1,
+		},
+		{
+			name:      "publish failure does not fail the run",
+			publisher: &fakePublisher{err: errors.New("broker down")},
+			setup: func() {
+				mock.ExpectBegin()
+				mockProductRepo.EXPECT().FlagOverdueProducts(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return([]entity.OverdueProduct{overdueShoes}, nil).Times(3)
+				mockProductRepo.EXPECT().AddProductStatusChanges(gomock.Any(), gomock.Len(3)).Return(nil)
+				mock.ExpectCommit()
+			},
+			wantEvents: 3,
+		},
+		{
+			name:      "repository error rolls back and publishes nothing",
+			publisher: &fakePublisher{},
+			setup: func() {
+				mock.ExpectBegin()
+				mockProductRepo.EXPECT().FlagOverdueProducts(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("db error"))
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewStorageService(mockProductRepo, nil, policy, tt.publisher, trManager, mockLog)
+
+			tt.setup()
+			flagged, err := svc.FlagOverdueProducts(context.Background())
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, flagged, tt.wantEvents)
+			}
+			assert.Len(t, tt.publisher.events, tt.wantEvents)
+			for _, event := range tt.publisher.events {
+				assert.Equal(t, entity.EventProductOverdue, event.Type)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestStorageService_GetOverdueProducts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockProductRepo := mocks.NewMockProductRepository(ctrl)
+	mockPVZRepo := mocks.NewMockPVZRepository(ctrl)
+	svc := NewStorageService(mockProductRepo, mockPVZRepo, entity.StoragePolicy{}, &fakePublisher{}, nil, logrus.New())
+
+	pvzID := uuid.New()
+
+	tests := []struct {
+		name    string
+		setup   func()
+		wantErr error
+	}{
+		{
+			name: "success",
+			setup: func() {
+				mockPVZRepo.EXPECT().IsPVZExists(gomock.Any(), pvzID).Return(true, nil)
+				mockProductRepo.EXPECT().GetOverdueProducts(gomock.Any(), pvzID).
+					Return([]entity.OverdueProduct{{ProductID: uuid.New(), PVZID: pvzID}}, nil)
+			},
+		},
+		{
+			name: "pvz not found",
+			setup: func() {
+				mockPVZRepo.EXPECT().IsPVZExists(gomock.Any(), pvzID).Return(false, nil)
+			},
+			wantErr: entity.ErrPVZNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			overdue, err := svc.GetOverdueProducts(context.Background(), pvzID)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, overdue, 1)
+			}
+		})
+	}
+}
+
+func TestStorageService_ShipOverdueProducts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockProductRepo := mocks.NewMockProductRepository(ctrl)
+	mockPVZRepo := mocks.NewMockPVZRepository(ctrl)
+	db, mock, _ := sqlmock.New()
+	mockDB := sqlx.NewDb(db, testDriverName)
+	trManager := manager.Must(trmsqlx.NewDefaultFactory(mockDB))
+
+	svc := NewStorageService(mockProductRepo, mockPVZRepo, entity.StoragePolicy{}, &fakePublisher{}, trManager, logrus.New())
+
+	pvzID, employeeID := uuid.New(), uuid.New()
+	first, second := uuid.New(), uuid.New()
+
+	tests := []struct {
+		name       string
+		productIDs []uuid.UUID
+		setup      func()
+		wantErr    error
+	}{
+		{
+			name:       "success",
+			productIDs: []uuid.UUID{first, second},
+			setup: func() {
+				mock.ExpectBegin()
+				mockPVZRepo.EXPECT().IsPVZExists(gomock.Any(), pvzID).Return(true, nil)
+				mockProductRepo.EXPECT().ShipOverdueProducts(gomock.Any(), pvzID, []uuid.UUID{first, second}, gomock.Any()).
+					Return([]uuid.UUID{second, first}, nil)
+				mockProductRepo.EXPECT().AddProductStatusChanges(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, changes []entity.ProductStatusChange) error {
+						assert.Len(t, changes, 2)
+						assert.Equal(t, entity.ProductStatusReturnToSender, *changes[0].From)
+						assert.Equal(t, entity.ProductStatusShippedToSender, changes[0].To)
+						assert.Equal(t, &employeeID, changes[0].ChangedBy)
+						return nil
+					})
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:       "no products",
+			productIDs: nil,
+			setup:      func() {},
+			wantErr:    entity.ErrInvalidSenderShipment,
+		},
+		{
+			name:       "duplicate product",
+			productIDs: []uuid.UUID{first, first},
+			setup:      func() {},
+			wantErr:    entity.ErrInvalidSenderShipment,
+		},
+		{
+			name:       "pvz not found",
+			productIDs: []uuid.UUID{first},
+			setup: func() {
+				mock.ExpectBegin()
+				mockPVZRepo.EXPECT().IsPVZExists(gomock.Any(), pvzID).Return(false, nil)
+				mock.ExpectRollback()
+			},
+			wantErr: entity.ErrPVZNotFound,
+		},
+		{
+			name:       "product not queued at pvz",
+			productIDs: []uuid.UUID{first, second},
+			setup: func() {
+				mock.ExpectBegin()
+				mockPVZRepo.EXPECT().IsPVZExists(gomock.Any(), pvzID).Return(true, nil)
+				mockProductRepo.EXPECT().ShipOverdueProducts(gomock.Any(), pvzID, []uuid.UUID{first, second}, gomock.Any()).
+					Return([]uuid.UUID{first}, nil)
+				mock.ExpectRollback()
+			},
+			wantErr: entity.ErrProductNotOverdue,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			shipment := entity.SenderShipment{PVZID: pvzID, ProductIDs: tt.productIDs, ShippedBy: &employeeID}
+			shipped, err := svc.ShipOverdueProducts(context.Background(), shipment)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, shipped)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.productIDs, shipped.ProductIDs)
+				assert.False(t, shipped.ShippedAt.IsZero())
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,6 @@
+package service
+
+import "go.opentelemetry.io/otel"
+
+// tracer starts a span for every exported service method; repository queries become its children.
+var tracer = otel.Tracer("github.com/senyabanana/pvz-service/internal/service")
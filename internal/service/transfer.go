@@ -0,0 +1,319 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/avito-tech/go-transaction-manager/trm/v2/manager"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+
+	"github.com/senyabanana/pvz-service/internal/entity"
+	"github.com/senyabanana/pvz-service/internal/infrastructure/logger"
+	"github.com/senyabanana/pvz-service/internal/repository"
+)
+
+type TransferService struct {
+	transferRepo   repository.TransferRepository
+	productRepo    repository.ProductRepository
+	receptionRepo  repository.ReceptionRepository
+	pvzRepo        repository.PVZRepository
+	capacityRepo   repository.CapacityRepository
+	capacityPolicy entity.CapacityPolicy
+	trManager      *manager.Manager
+	log            *logrus.Logger
+}
+
+func NewTransferService(
+	transferRepo repository.TransferRepository,
+	productRepo repository.ProductRepository,
+	receptionRepo repository.ReceptionRepository,
+	pvzRepo repository.PVZRepository,
+	capacityRepo repository.CapacityRepository,
+	capacityPolicy entity.CapacityPolicy,
+	trManager *manager.Manager,
+	log *logrus.Logger,
+) *TransferService {
+	return &TransferService{
+		transferRepo:   transferRepo,
+		productRepo:    productRepo,
+		receptionRepo:  receptionRepo,
+		pvzRepo:        pvzRepo,
+		capacityRepo:   capacityRepo,
+		capacityPolicy: capacityPolicy,
+		trManager:      trManager,
+		log:            log,
+	}
+}
+
+// CreateTransfer puts products stored at the source PVZ in transit to the destination PVZ.
+// Only the destination row is locked: it must stay active until the order is created, while
+// locking both PVZs could deadlock two transfers going in opposite directions.
+func (s *TransferService) CreateTransfer(ctx context.Context, order entity.TransferOrder) (*entity.TransferOrder, error) {
+	ctx, span := tracer.Start(ctx, "TransferService.CreateTransfer")
+	defer span.End()
+	log := logger.FromContext(ctx, s.log)
+
+	if err := validateTransfer(&order); err != nil {
+		return nil, err
+	}
+
+	err := s.trManager.Do(ctx, func(ctx context.Context) error {
+		exists, err := s.pvzRepo.IsPVZExists(ctx, order.SourcePVZID)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return entity.ErrPVZNotFound
+		}
+
+		if err := ensurePVZActive(ctx, s.pvzRepo, order.DestinationPVZID, log); err != nil {
+			return err
+		}
+
+		locations, err := s.productRepo.GetProductLocations(ctx, order.ProductIDs)
+		if err != nil {
+			return err
+		}
+
+		if len(locations) != len(order.ProductIDs) {
+			return entity.ErrProductNotFound
+		}
+
+		for _, location := range locations {
+			if err := checkTransferable(location, order.SourcePVZID); err != nil {
+				return fmt.Errorf("product %s: %w", location.ProductID, err)
+			}
+		}
+
+		if err := s.productRepo.SetProductsInTransit(ctx, order.ProductIDs); err != nil {
+			return err
+		}
+
+		order.Status = entity.TransferStatusInTransit
+		order.CreatedAt = time.Now()
+
+		if err := s.transferRepo.CreateTransfer(ctx, &order); err != nil {
+			return err
+		}
+
+		changes := statusChanges(order.ProductIDs, entity.ProductStatusReceived, entity.ProductStatusInTransit,
+			order.SourcePVZID, order.CreatedBy, order.CreatedAt, transferComment(order.ID))
+		return s.productRepo.AddProductStatusChanges(ctx, changes)
+	})
+	if err != nil {
+		log.Warnf("failed to create transfer from %s to %s: %v", order.SourcePVZID, order.DestinationPVZID, err)
+		return nil, err
+	}
+
+	log.Infof("transfer created: id=%s, from=%s, to=%s, products=%d",
+		order.ID, order.SourcePVZID, order.DestinationPVZID, len(order.ProductIDs))
+	return &order, nil
+}
+
+// ReceiveTransfer accepts an in-transit order at its destination into the PVZ's open reception.
+// The arriving products are subject to the destination's capacity like any other product.
+func (s *TransferService) ReceiveTransfer(
+	ctx context.Context, pvzID, transferID uuid.UUID, receivedBy *uuid.UUID,
+) (*entity.TransferOrder, error) {
+	ctx, span := tracer.Start(ctx, "TransferService.ReceiveTransfer")
+	defer span.End()
+	log := logger.FromContext(ctx, s.log)
+
+	var result *entity.TransferOrder
+
+	err := s.trManager.Do(ctx, func(ctx context.Context) error {
+		if err := ensurePVZActive(ctx, s.pvzRepo, pvzID, log); err != nil {
+			return err
+		}
+
+		order, err := s.transferRepo.GetTransferByID(ctx, transferID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return entity.ErrTransferNotFound
+			}
+			return err
+		}
+
+		if order.DestinationPVZID != pvzID {
+			return entity.ErrTransferNotFound
+		}
+
+		if order.Status != entity.TransferStatusInTransit {
+			return entity.ErrTransferAlreadyClosed
+		}
+
+		reception, err := s.receptionRepo.GetOpenReception(ctx, pvzID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return entity.ErrNoOpenReception
+			}
+			return err
+		}
+
+		locations, err := s.productRepo.GetProductLocations(ctx, order.ProductIDs)
+		if err != nil {
+			return err
+		}
+
+		incoming := make(map[entity.ProductType]int)
+		for _, location := range locations {
+			incoming[location.Type]++
+		}
+		if err := enforceCapacity(ctx, s.capacityRepo, s.capacityPolicy, pvzID, incoming, log); err != nil {
+			return err
+		}
+
+		if err := s.productRepo.MoveProductsToReception(ctx, order.ProductIDs, reception.ID); err != nil {
+			return err
+		}
+
+		now := time.Now()
+		order.Status = entity.TransferStatusReceived
+		order.ReceivedAt = &now
+		order.ReceivedBy = receivedBy
+		order.DestinationReceptionID = &reception.ID
+
+		if err := s.transferRepo.MarkTransferReceived(ctx, order); err != nil {
+			return err
+		}
+
+		changes := statusChanges(order.ProductIDs, entity.ProductStatusInTransit, entity.ProductStatusReceived,
+			pvzID, receivedBy, now, transferComment(order.ID))
+		if err := s.productRepo.AddProductStatusChanges(ctx, changes); err != nil {
+			return err
+		}
+
+		result = order
+		return nil
+	})
+	if err != nil {
+		log.Warnf("failed to receive transfer %s at pvz %s: %v", transferID, pvzID, err)
+		return nil, err
+	}
+
+	log.Infof("transfer received: id=%s, pvz=%s, reception=%s", result.ID, pvzID, *result.DestinationReceptionID)
+	return result, nil
+}
+
+// GetProductCustody returns the chain of custody of a product: the reception it was first received in,
+// followed by a dispatch and, once received, an arrival for every transfer it has been on.
+func (s *TransferService) GetProductCustody(ctx context.Context, productID uuid.UUID) ([]entity.CustodyEvent, error) {
+	ctx, span := tracer.Start(ctx, "TransferService.GetProductCustody")
+	defer span.End()
+	log := logger.FromContext(ctx, s.log)
+
+	var events []entity.CustodyEvent
+
+	err := s.trManager.Do(ctx, func(ctx context.Context) error {
+		product, err := s.productRepo.GetProductByID(ctx, productID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return entity.ErrProductNotFound
+			}
+			return err
+		}
+
+		hops, err := s.transferRepo.GetProductTransferHops(ctx, productID)
+		if err != nil {
+			return err
+		}
+
+		firstReceptionID := product.ReceptionID
+		if len(hops) > 0 {
+			firstReceptionID = hops[0].SourceReceptionID
+		}
+
+		reception, err := s.receptionRepo.GetReceptionByID(ctx, firstReceptionID)
+		if err != nil {
+			return err
+		}
+
+		events = append(events, entity.CustodyEvent{
+			Kind:        entity.CustodyReceived,
+			PVZID:       reception.PVZID,
+			ReceptionID: &reception.ID,
+			At:          product.DateTime,
+		})
+
+		for _, hop := range hops {
+			transferID, sourceReceptionID := hop.TransferID, hop.SourceReceptionID
+			events = append(events, entity.CustodyEvent{
+				Kind:        entity.CustodyDispatched,
+				PVZID:       hop.SourcePVZID,
+				ReceptionID: &sourceReceptionID,
+				TransferID:  &transferID,
+				At:          hop.CreatedAt,
+				By:          hop.CreatedBy,
+			})
+
+			if hop.Status == entity.TransferStatusReceived && hop.ReceivedAt != nil {
+				events = append(events, entity.CustodyEvent{
+					Kind:        entity.CustodyArrived,
+					PVZID:       hop.DestinationPVZID,
+					ReceptionID: hop.DestinationReceptionID,
+					TransferID:  &transferID,
+					At:          *hop.ReceivedAt,
+					By:          hop.ReceivedBy,
+				})
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		if !errors.Is(err, entity.ErrProductNotFound) {
+			log.Errorf("failed to get custody of product %s: %v", productID, err)
+		}
+		return nil, err
+	}
+
+	return events, nil
+}
+
+func transferComment(transferID uuid.UUID) string {
+	return "transfer " + transferID.String()
+}
+
+func validateTransfer(order *entity.TransferOrder) error {
+	if order.SourcePVZID == order.DestinationPVZID {
+		return fmt.Errorf("%w: source and destination pvz must differ", entity.ErrInvalidTransfer)
+	}
+
+	if len(order.ProductIDs) == 0 || len(order.ProductIDs) > entity.MaxTransferProducts {
+		return fmt.Errorf("%w: from 1 to %d products per transfer", entity.ErrInvalidTransfer, entity.MaxTransferProducts)
+	}
+
+	seen := make(map[uuid.UUID]struct{}, len(order.ProductIDs))
+	for _, id := range order.ProductIDs {
+		if _, ok := seen[id]; ok {
+			return fmt.Errorf("%w: duplicate product %s", entity.ErrInvalidTransfer, id)
+		}
+		seen[id] = struct{}{}
+	}
+
+	return nil
+}
+
+func checkTransferable(location entity.ProductLocation, sourcePVZID uuid.UUID) error {
+	if location.PVZID != sourcePVZID {
+		return entity.ErrProductNotAtPVZ
+	}
+
+	switch location.Status {
+	case entity.ProductStatusInTransit:
+		return entity.ErrProductInTransit
+	case entity.ProductStatusReceived:
+	default:
+		return checkIssuable(&entity.Product{Status: location.Status})
+	}
+
+	if location.ReceptionStatus != entity.StatusClosed {
+		return entity.ErrProductNotReady
+	}
+
+	return nil
+}
@@ -0,0 +1,404 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	trmsqlx "github.com/avito-tech/go-transaction-manager/drivers/sqlx/v2"
+	"github.com/avito-tech/go-transaction-manager/trm/v2/manager"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/senyabanana/pvz-service/internal/entity"
+	mocks "github.com/senyabanana/pvz-service/internal/repository/mocks"
+)
+
+func TestTransferService_CreateTransfer(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTransferRepo := mocks.NewMockTransferRepository(ctrl)
+	mockProductRepo := mocks.NewMockProductRepository(ctrl)
+	mockPVZRepo := mocks.NewMockPVZRepository(ctrl)
+	db, mock, _ := sqlmock.New()
+	mockDB := sqlx.NewDb(db, testDriverName)
+	trManager := manager.Must(trmsqlx.NewDefaultFactory(mockDB))
+	mockLog := logrus.New()
+
+	svc := NewTransferService(mockTransferRepo, mockProductRepo, nil, mockPVZRepo, nil, entity.CapacityPolicyReject, trManager, mockLog)
+
+	sourceID, destinationID, productID, employeeID := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	valid := entity.TransferOrder{
+		SourcePVZID: sourceID, DestinationPVZID: destinationID, ProductIDs: []uuid.UUID{productID}, CreatedBy: &employeeID,
+	}
+	location := func(pvzID uuid.UUID, status entity.ProductStatus, receptionStatus entity.ReceptionStatus) []entity.ProductLocation {
+		return []entity.ProductLocation{{ProductID: productID, Status: status, ReceptionStatus: receptionStatus, PVZID: pvzID}}
+	}
+	locate := func(locations []entity.ProductLocation) {
+		mock.ExpectBegin()
+		mockPVZRepo.EXPECT().IsPVZExists(gomock.Any(), sourceID).Return(true, nil)
+		mockPVZRepo.EXPECT().GetPVZStatus(gomock.Any(), destinationID).Return(entity.PVZStatusActive, nil)
+		mockProductRepo.EXPECT().GetProductLocations(gomock.Any(), []uuid.UUID{productID}).Return(locations, nil)
+	}
+
+	tests := []struct {
+		name    string
+		input   entity.TransferOrder
+		setup   func()
+		wantErr error
+	}{
+		{
+			name:  "success",
+			input: valid,
+			setup: func() {
+				locate(location(sourceID, entity.ProductStatusReceived, entity.StatusClosed))
+				mockProductRepo.EXPECT().SetProductsInTransit(gomock.Any(), []uuid.UUID{productID}).Return(nil)
+				mockTransferRepo.EXPECT().CreateTransfer(gomock.Any(), gomock.Any()).Return(nil)
+				mockProductRepo.EXPECT().AddProductStatusChanges(gomock.Any(), gomock.Len(1)).Return(nil)
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:    "same source and destination",
+			input:   entity.TransferOrder{SourcePVZID: sourceID, DestinationPVZID: sourceID, ProductIDs: []uuid.UUID{productID}},
+			setup:   func() {},
+			wantErr: entity.ErrInvalidTransfer,
+		},
+		{
+			name: "duplicate products",
+			input: entity.TransferOrder{
+				SourcePVZID: sourceID, DestinationPVZID: destinationID, ProductIDs: []uuid.UUID{productID, productID},
+			},
+			setup:   func() {},
+			wantErr: entity.ErrInvalidTransfer,
+		},
+		{
+			name:  "source pvz not found",
+			input: valid,
+			setup: func() {
+				mock.ExpectBegin()
+				mockPVZRepo.EXPECT().IsPVZExists(gomock.Any(), sourceID).Return(false, nil)
+				mock.ExpectRollback()
+			},
+			wantErr: entity.ErrPVZNotFound,
+		},
+		{
+			name:  "destination closed",
+			input: valid,
+			setup: func() {
+				mock.ExpectBegin()
+				mockPVZRepo.EXPECT().IsPVZExists(gomock.Any(), sourceID).Return(true, nil)
+				mockPVZRepo.EXPECT().GetPVZStatus(gomock.Any(), destinationID).Return(entity.PVZStatusClosed, nil)
+				mock.ExpectRollback()
+			},
+			wantErr: entity.ErrPVZNotActive,
+		},
+		{
+			name:  "product not found",
+			input: valid,
+			setup: func() {
+				locate(nil)
+				mock.ExpectRollback()
+			},
+			wantErr: entity.ErrProductNotFound,
+		},
+		{
+			name:  "product at another pvz",
+			input: valid,
+			setup: func() {
+				locate(location(uuid.New(), entity.ProductStatusReceived, entity.StatusClosed))
+				mock.ExpectRollback()
+			},
+			wantErr: entity.ErrProductNotAtPVZ,
+		},
+		{
+			name:  "product already in transit",
+			input: valid,
+			setup: func() {
+				locate(location(sourceID, entity.ProductStatusInTransit, entity.StatusClosed))
+				mock.ExpectRollback()
+			},
+			wantErr: entity.ErrProductInTransit,
+		},
+		{
+			name:  "product already issued",
+			input: valid,
+			setup: func() {
+				locate(location(sourceID, entity.ProductStatusIssued, entity.StatusClosed))
+				mock.ExpectRollback()
+			},
+			wantErr: entity.ErrProductAlreadyIssued,
+		},
+		{
+			name:  "reception still open",
+			input: valid,
+			setup: func() {
+				locate(location(sourceID, entity.ProductStatusReceived, entity.StatusInProgress))
+				mock.ExpectRollback()
+			},
+			wantErr: entity.ErrProductNotReady,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			order, err := svc.CreateTransfer(context.Background(), tt.input)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, order)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, entity.TransferStatusInTransit, order.Status)
+				assert.False(t, order.CreatedAt.IsZero())
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestTransferService_ReceiveTransfer(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTransferRepo := mocks.NewMockTransferRepository(ctrl)
+	mockProductRepo := mocks.NewMockProductRepository(ctrl)
+	mockReceptionRepo := mocks.NewMockReceptionRepository(ctrl)
+	mockPVZRepo := mocks.NewMockPVZRepository(ctrl)
+	mockCapacityRepo := mocks.NewMockCapacityRepository(ctrl)
+	db, mock, _ := sqlmock.New()
+	mockDB := sqlx.NewDb(db, testDriverName)
+	trManager := manager.Must(trmsqlx.NewDefaultFactory(mockDB))
+	mockLog := logrus.New()
+
+	svc := NewTransferService(mockTransferRepo, mockProductRepo, mockReceptionRepo, mockPVZRepo,
+		mockCapacityRepo, entity.CapacityPolicyReject, trManager, mockLog)
+
+	pvzID, transferID, receptionID, employeeID := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	productIDs := []uuid.UUID{uuid.New(), uuid.New()}
+	stored := func(destinationID uuid.UUID, status entity.TransferStatus) *entity.TransferOrder {
+		return &entity.TransferOrder{
+			ID: transferID, SourcePVZID: uuid.New(), DestinationPVZID: destinationID, Status: status, ProductIDs: productIDs,
+		}
+	}
+	locations := []entity.ProductLocation{
+		{ProductID: productIDs[0], Type: entity.ProductElectronics, Status: entity.ProductStatusInTransit},
+		{ProductID: productIDs[1], Type: entity.ProductElectronics, Status: entity.ProductStatusInTransit},
+	}
+	total := func(limit int) *entity.PVZCapacity {
+		return &entity.PVZCapacity{PVZID: pvzID, Total: &limit}
+	}
+
+	tests := []struct {
+		name    string
+		setup   func()
+		wantErr error
+	}{
+		{
+			name: "success",
+			setup: func() {
+				mock.ExpectBegin()
+				mockPVZRepo.EXPECT().GetPVZStatus(gomock.Any(), pvzID).Return(entity.PVZStatusActive, nil)
+				mockTransferRepo.EXPECT().GetTransferByID(gomock.Any(), transferID).Return(stored(pvzID, entity.TransferStatusInTransit), nil)
+				mockReceptionRepo.EXPECT().GetOpenReception(gomock.Any(), pvzID).Return(&entity.Reception{ID: receptionID}, nil)
+				mockProductRepo.EXPECT().GetProductLocations(gomock.Any(), productIDs).Return(locations, nil)
+				mockCapacityRepo.EXPECT().GetPVZCapacity(gomock.Any(), pvzID).Return(total(3), nil)
+				mockCapacityRepo.EXPECT().GetPVZOccupancy(gomock.Any(), pvzID).
+					Return(map[entity.ProductType]int{entity.ProductClothing: 1}, nil)
+				mockProductRepo.EXPECT().MoveProductsToReception(gomock.Any(), productIDs, receptionID).Return(nil)
+				mockTransferRepo.EXPECT().MarkTransferReceived(gomock.Any(), gomock.Any()).Return(nil)
+				mockProductRepo.EXPECT().AddProductStatusChanges(gomock.Any(), gomock.Len(2)).Return(nil)
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "destination over capacity",
+			setup: func() {
+				mock.ExpectBegin()
+				mockPVZRepo.EXPECT().GetPVZStatus(gomock.Any(), pvzID).Return(entity.PVZStatusActive, nil)
+				mockTransferRepo.EXPECT().GetTransferByID(gomock.Any(), transferID).Return(stored(pvzID, entity.TransferStatusInTransit), nil)
+				mockReceptionRepo.EXPECT().GetOpenReception(gomock.Any(), pvzID).Return(&entity.Reception{ID: receptionID}, nil)
+				mockProductRepo.EXPECT().GetProductLocations(gomock.Any(), productIDs).Return(locations, nil)
+				mockCapacityRepo.EXPECT().GetPVZCapacity(gomock.Any(), pvzID).Return(total(3), nil)
+				mockCapacityRepo.EXPECT().GetPVZOccupancy(gomock.Any(), pvzID).
+					Return(map[entity.ProductType]int{entity.ProductClothing: 2}, nil)
+				mock.ExpectRollback()
+			},
+			wantErr: entity.ErrCapacityExceeded,
+		},
+		{
+			name: "product no longer in transit",
+			setup: func() {
+				mock.ExpectBegin()
+				mockPVZRepo.EXPECT().GetPVZStatus(gomock.Any(), pvzID).Return(entity.PVZStatusActive, nil)
+				mockTransferRepo.EXPECT().GetTransferByID(gomock.Any(), transferID).Return(stored(pvzID, entity.TransferStatusInTransit), nil)
+				mockReceptionRepo.EXPECT().GetOpenReception(gomock.Any(), pvzID).Return(&entity.Reception{ID: receptionID}, nil)
+				mockProductRepo.EXPECT().GetProductLocations(gomock.Any(), productIDs).Return(locations, nil)
+				mockCapacityRepo.EXPECT().GetPVZCapacity(gomock.Any(), pvzID).Return(&entity.PVZCapacity{PVZID: pvzID}, nil)
+				mockCapacityRepo.EXPECT().GetPVZOccupancy(gomock.Any(), pvzID).Return(map[entity.ProductType]int{}, nil)
+				mockProductRepo.EXPECT().MoveProductsToReception(gomock.Any(), productIDs, receptionID).
+					Return(entity.ErrProductNotInTransit)
+				mock.ExpectRollback()
+			},
+			wantErr: entity.ErrProductNotInTransit,
+		},
+		{
+			name: "transfer not found",
+			setup: func() {
+				mock.ExpectBegin()
+				mockPVZRepo.EXPECT().GetPVZStatus(gomock.Any(), pvzID).Return(entity.PVZStatusActive, nil)
+				mockTransferRepo.EXPECT().GetTransferByID(gomock.Any(), transferID).Return(nil, sql.ErrNoRows)
+				mock.ExpectRollback()
+			},
+			wantErr: entity.ErrTransferNotFound,
+		},
+		{
+			name: "transfer to another pvz",
+			setup: func() {
+				mock.ExpectBegin()
+				mockPVZRepo.EXPECT().GetPVZStatus(gomock.Any(), pvzID).Return(entity.PVZStatusActive, nil)
+				mockTransferRepo.EXPECT().GetTransferByID(gomock.Any(), transferID).
+					Return(stored(uuid.New(), entity.TransferStatusInTransit), nil)
+				mock.ExpectRollback()
+			},
+			wantErr: entity.ErrTransferNotFound,
+		},
+		{
+			name: "already received",
+			setup: func() {
+				mock.ExpectBegin()
+				mockPVZRepo.EXPECT().GetPVZStatus(gomock.Any(), pvzID).Return(entity.PVZStatusActive, nil)
+				mockTransferRepo.EXPECT().GetTransferByID(gomock.Any(), transferID).Return(stored(pvzID, entity.TransferStatusReceived), nil)
+				mock.ExpectRollback()
+			},
+			wantErr: entity.ErrTransferAlreadyClosed,
+		},
+		{
+			name: "no open reception",
+			setup: func() {
+				mock.ExpectBegin()
+				mockPVZRepo.EXPECT().GetPVZStatus(gomock.Any(), pvzID).Return(entity.PVZStatusActive, nil)
+				mockTransferRepo.EXPECT().GetTransferByID(gomock.Any(), transferID).Return(stored(pvzID, entity.TransferStatusInTransit), nil)
+				mockReceptionRepo.EXPECT().GetOpenReception(gomock.Any(), pvzID).Return(nil, sql.ErrNoRows)
+				mock.ExpectRollback()
+			},
+			wantErr: entity.ErrNoOpenReception,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			order, err := svc.ReceiveTransfer(context.Background(), pvzID, transferID, &employeeID)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, order)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, entity.TransferStatusReceived, order.Status)
+				assert.Equal(t, &receptionID, order.DestinationReceptionID)
+				assert.Equal(t, &employeeID, order.ReceivedBy)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestTransferService_GetProductCustody(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTransferRepo := mocks.NewMockTransferRepository(ctrl)
+	mockProductRepo := mocks.NewMockProductRepository(ctrl)
+	mockReceptionRepo := mocks.NewMockReceptionRepository(ctrl)
+	db, mock, _ := sqlmock.New()
+	mockDB := sqlx.NewDb(db, testDriverName)
+	trManager := manager.Must(trmsqlx.NewDefaultFactory(mockDB))
+	mockLog := logrus.New()
+
+	svc := NewTransferService(mockTransferRepo, mockProductRepo, mockReceptionRepo, nil, nil, entity.CapacityPolicyReject, trManager, mockLog)
+
+	productID, firstPVZ, secondPVZ := uuid.New(), uuid.New(), uuid.New()
+	firstReception, secondReception := uuid.New(), uuid.New()
+	receivedAt := time.Now()
+
+	tests := []struct {
+		name      string
+		setup     func()
+		wantKinds []entity.CustodyEventKind
+		wantErr   error
+	}{
+		{
+			name: "never transferred",
+			setup: func() {
+				mock.ExpectBegin()
+				mockProductRepo.EXPECT().GetProductByID(gomock.Any(), productID).
+					Return(&entity.Product{ID: productID, ReceptionID: firstReception}, nil)
+				mockTransferRepo.EXPECT().GetProductTransferHops(gomock.Any(), productID).Return(nil, nil)
+				mockReceptionRepo.EXPECT().GetReceptionByID(gomock.Any(), firstReception).
+					Return(&entity.Reception{ID: firstReception, PVZID: firstPVZ}, nil)
+				mock.ExpectCommit()
+			},
+			wantKinds: []entity.CustodyEventKind{entity.CustodyReceived},
+		},
+		{
+			name: "received transfer and one in transit",
+			setup: func() {
+				mock.ExpectBegin()
+				mockProductRepo.EXPECT().GetProductByID(gomock.Any(), productID).
+					Return(&entity.Product{ID: productID, ReceptionID: secondReception}, nil)
+				mockTransferRepo.EXPECT().GetProductTransferHops(gomock.Any(), productID).Return([]entity.ProductTransferHop{
+					{
+						TransferID: uuid.New(), SourcePVZID: firstPVZ, SourceReceptionID: firstReception,
+						DestinationPVZID: secondPVZ, DestinationReceptionID: &secondReception,
+						Status: entity.TransferStatusReceived, ReceivedAt: &receivedAt,
+					},
+					{
+						TransferID: uuid.New(), SourcePVZID: secondPVZ, SourceReceptionID: secondReception,
+						DestinationPVZID: firstPVZ, Status: entity.TransferStatusInTransit,
+					},
+				}, nil)
+				mockReceptionRepo.EXPECT().GetReceptionByID(gomock.Any(), firstReception).
+					Return(&entity.Reception{ID: firstReception, PVZID: firstPVZ}, nil)
+				mock.ExpectCommit()
+			},
+			wantKinds: []entity.CustodyEventKind{
+				entity.CustodyReceived, entity.CustodyDispatched, entity.CustodyArrived, entity.CustodyDispatched,
+			},
+		},
+		{
+			name: "product not found",
+			setup: func() {
+				mock.ExpectBegin()
+				mockProductRepo.EXPECT().GetProductByID(gomock.Any(), productID).Return(nil, sql.ErrNoRows)
+				mock.ExpectRollback()
+			},
+			wantErr: entity.ErrProductNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			events, err := svc.GetProductCustody(context.Background(), productID)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+				kinds := make([]entity.CustodyEventKind, 0, len(events))
+				for _, event := range events {
+					kinds = append(kinds, event.Kind)
+				}
+				assert.Equal(t, tt.wantKinds, kinds)
+				assert.Equal(t, firstPVZ, events[0].PVZID)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
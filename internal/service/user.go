@@ -9,20 +9,28 @@ import (
 
 	"github.com/senyabanana/pvz-service/internal/entity"
 	"github.com/senyabanana/pvz-service/internal/infrastructure/jwtutil"
+	"github.com/senyabanana/pvz-service/internal/infrastructure/logger"
 	"github.com/senyabanana/pvz-service/internal/infrastructure/security"
 	"github.com/senyabanana/pvz-service/internal/repository"
 )
 
 type UserService struct {
 	repo      repository.UserRepository
+	hasher    *security.PasswordHasher
+	policy    security.PasswordPolicy
 	trManager *manager.Manager
 	JWTSecret string
 	log       *logrus.Logger
 }
 
-func NewUserService(repo repository.UserRepository, trManager *manager.Manager, secretKey string, log *logrus.Logger) *UserService {
+func NewUserService(
+	repo repository.UserRepository, hasher *security.PasswordHasher, policy security.PasswordPolicy,
+	trManager *manager.Manager, secretKey string, log *logrus.Logger,
+) *UserService {
 	return &UserService{
 		repo:      repo,
+		hasher:    hasher,
+		policy:    policy,
 		trManager: trManager,
 		JWTSecret: secretKey,
 		log:       log,
@@ -30,63 +38,106 @@ func NewUserService(repo repository.UserRepository, trManager *manager.Manager,
 }
 
 func (s *UserService) RegisterUser(ctx context.Context, user *entity.User) error {
+	ctx, span := tracer.Start(ctx, "UserService.RegisterUser")
+	defer span.End()
+	log := logger.FromContext(ctx, s.log)
+
+	user.Email = entity.NormalizeEmail(user.Email)
+
 	if !entity.IsValidUserRole(user.Role) {
-		s.log.Warnf("invalid user role during registration: %s", user.Role)
+		log.Warnf("invalid user role during registration: %s", user.Role)
 		return entity.ErrInvalidUserRole
 	}
 
-	s.log.Infof("attempt to register user: email=%s", user.Email)
+	if err := s.policy.Validate(user.Password); err != nil {
+		log.Warnf("registration blocked: weak password: %v", err)
+		return err
+	}
+
+	log.Infof("attempt to register user: email=%s", user.Email)
 
 	return s.trManager.Do(ctx, func(ctx context.Context) error {
 		exist, err := s.repo.IsEmailExists(ctx, user.Email)
 		if err != nil {
-			s.log.Errorf("failed to check email existence: %v", err)
+			log.Errorf("failed to check email existence: %v", err)
 			return err
 		}
 
 		if exist {
-			s.log.Warnf("registration blocked: email already exists: %s", user.Email)
+			log.Warnf("registration blocked: email already exists: %s", user.Email)
 			return entity.ErrEmailTaken
 		}
 
-		hash, err := security.GeneratePasswordHash(user.Password)
+		hash, err := s.hasher.Hash(user.Password)
 		if err != nil {
-			s.log.Errorf("failed to hash password for email=%s: %v", user.Email, err)
+			log.Errorf("failed to hash password for email=%s: %v", user.Email, err)
 			return err
 		}
 
 		user.Password = hash
+		user.AuthProvider = entity.AuthProviderLocal
 		user.CreatedAt = time.Now()
 
 		if err := s.repo.CreateUser(ctx, user); err != nil {
-			s.log.Errorf("failed to create user: email=%s: %v", user.Email, err)
+			log.Errorf("failed to create user: email=%s: %v", user.Email, err)
 			return err
 		}
 
-		s.log.Infof("user registered successfully: id=%s, email=%s", user.ID.String(), user.Email)
+		log.Infof("user registered successfully: id=%s, email=%s", user.ID.String(), user.Email)
 		return nil
 	})
 }
 
 func (s *UserService) LoginUser(ctx context.Context, email, password string) (string, error) {
-	user, err := s.repo.GetUserByEmail(ctx, email)
+	ctx, span := tracer.Start(ctx, "UserService.LoginUser")
+	defer span.End()
+	log := logger.FromContext(ctx, s.log)
+
+	user, err := s.repo.GetUserByEmail(ctx, entity.NormalizeEmail(email))
 	if err != nil {
-		s.log.Warnf("user not found: %s", err)
+		log.Warnf("user not found: %s", err)
 		return "", entity.ErrInvalidCredentials
 	}
 
-	if err := security.ComparePassword(password, user.Password); err != nil {
-		s.log.Warnf("invalid password for user: %s", email)
+	if user.AuthProvider == entity.AuthProviderOIDC {
+		log.Warnf("password login attempt for sso user: %s", email)
 		return "", entity.ErrInvalidCredentials
 	}
 
-	s.log.Infof("user logged in successfully: id=%s, email=%s", user.ID.String(), user.Email)
+	if err := s.hasher.Compare(password, user.Password); err != nil {
+		log.Warnf("invalid password for user: %s", email)
+		return "", entity.ErrInvalidCredentials
+	}
+
+	if s.hasher.NeedsRehash(user.Password) {
+		s.rehashPassword(ctx, user, password)
+	}
+
+	log.Infof("user logged in successfully: id=%s, email=%s", user.ID.String(), user.Email)
 
 	token, err := jwtutil.GenerateToken(user.ID.String(), string(user.Role), s.JWTSecret, 2*time.Hour)
 	if err != nil {
-		s.log.Warnf("failed to generate JWT: %v", err)
+		log.Warnf("failed to generate JWT: %v", err)
 		return "", err
 	}
 
 	return token, nil
 }
+
+// rehashPassword upgrades a hash produced with outdated parameters. Failures don't affect the login.
+func (s *UserService) rehashPassword(ctx context.Context, user *entity.User, password string) {
+	log := logger.FromContext(ctx, s.log)
+
+	hash, err := s.hasher.Hash(password)
+	if err != nil {
+		log.Errorf("failed to rehash password: id=%s: %v", user.ID, err)
+		return
+	}
+
+	if err := s.repo.UpdatePassword(ctx, user.ID, hash); err != nil {
+		log.Errorf("failed to store rehashed password: id=%s: %v", user.ID, err)
+		return
+	}
+
+	log.Infof("password hash upgraded: id=%s", user.ID)
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/jmoiron/sqlx"
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/bcrypt"
 
 	"github.com/senyabanana/pvz-service/internal/entity"
 	"github.com/senyabanana/pvz-service/internal/infrastructure/security"
@@ -24,6 +25,20 @@ const (
 	testJWTSecret  = "secret"
 )
 
+var (
+	testPasswordPolicy = security.PasswordPolicy{MinLength: 8, RequireDigit: true, RejectCommon: true}
+	testPasswordHasher = newTestPasswordHasher(security.AlgorithmBcrypt)
+)
+
+func newTestPasswordHasher(algorithm string) *security.PasswordHasher {
+	hasher, err := security.NewPasswordHasher(algorithm, bcrypt.MinCost, 1024, 1, 1)
+	if err != nil {
+		panic(err)
+	}
+
+	return hasher
+}
+
 func TestUserService_RegisterUser(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -34,7 +49,7 @@ func TestUserService_RegisterUser(t *testing.T) {
 	mockTrManager := manager.Must(trmsqlx.NewDefaultFactory(mockDB))
 	mockLog := logrus.New()
 
-	svc := NewUserService(mockRepo, mockTrManager, testJWTSecret, mockLog)
+	svc := NewUserService(mockRepo, testPasswordHasher, testPasswordPolicy, mockTrManager, testJWTSecret, mockLog)
 
 	tests := []struct {
 		name      string
@@ -46,7 +61,7 @@ func TestUserService_RegisterUser(t *testing.T) {
 			name: "success",
 			inputUser: &entity.User{
 				Email:    "test@example.com",
-				Password: "password",
+				Password: "s3cure-pass",
 				Role:     entity.RoleClient,
 			},
 			setup: func() {
@@ -57,11 +72,29 @@ func TestUserService_RegisterUser(t *testing.T) {
 			},
 			wantErr: nil,
 		},
+		{
+			name: "mixed case email is stored lowercased",
+			inputUser: &entity.User{
+				Email:    " Test@Example.COM ",
+				Password: "s3cure-pass",
+				Role:     entity.RoleClient,
+			},
+			setup: func() {
+				mock.ExpectBegin()
+				mockRepo.EXPECT().IsEmailExists(gomock.Any(), "test@example.com").Return(false, nil)
+				mockRepo.EXPECT().CreateUser(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, user *entity.User) error {
+					assert.Equal(t, "test@example.com", user.Email)
+					return nil
+				})
+				mock.ExpectCommit()
+			},
+			wantErr: nil,
+		},
 		{
 			name: "email exists",
 			inputUser: &entity.User{
 				Email:    "exists@example.com",
-				Password: "password",
+				Password: "s3cure-pass",
 				Role:     entity.RoleClient,
 			},
 			setup: func() {
@@ -75,12 +108,22 @@ func TestUserService_RegisterUser(t *testing.T) {
 			name: "invalid role",
 			inputUser: &entity.User{
 				Email:    "bad@example.com",
-				Password: "password",
+				Password: "s3cure-pass",
 				Role:     "invalid",
 			},
 			setup:   func() {},
 			wantErr: entity.ErrInvalidUserRole,
 		},
+		{
+			name: "weak password",
+			inputUser: &entity.User{
+				Email:    "weak@example.com",
+				Password: "password1",
+				Role:     entity.RoleClient,
+			},
+			setup:   func() {},
+			wantErr: entity.ErrWeakPassword,
+		},
 	}
 
 	for _, tt := range tests {
@@ -106,9 +149,9 @@ func TestUserService_LoginUser(t *testing.T) {
 	mockRepo := mocks.NewMockUserRepository(ctrl)
 	mockLog := logrus.New()
 
-	svc := NewUserService(mockRepo, nil, testJWTSecret, mockLog)
+	svc := NewUserService(mockRepo, testPasswordHasher, testPasswordPolicy, nil, testJWTSecret, mockLog)
 
-	hashedPassword, _ := security.GeneratePasswordHash("correct-password")
+	hashedPassword, _ := testPasswordHasher.Hash("correct-password")
 	user := &entity.User{
 		ID:       uuid.New(),
 		Email:    "test@example.com",
@@ -116,6 +159,14 @@ func TestUserService_LoginUser(t *testing.T) {
 		Role:     entity.RoleClient,
 	}
 
+	outdatedHash, _ := bcrypt.GenerateFromPassword([]byte("correct-password"), bcrypt.MinCost+1)
+	outdatedUser := &entity.User{
+		ID:       uuid.New(),
+		Email:    "outdated@example.com",
+		Password: string(outdatedHash),
+		Role:     entity.RoleClient,
+	}
+
 	tests := []struct {
 		name      string
 		email     string
@@ -134,6 +185,43 @@ func TestUserService_LoginUser(t *testing.T) {
 			wantErr:   nil,
 			expectJWT: true,
 		},
+		{
+			name:     "outdated hash is upgraded",
+			email:    "outdated@example.com",
+			password: "correct-password",
+			setup: func() {
+				mockRepo.EXPECT().GetUserByEmail(gomock.Any(), "outdated@example.com").Return(outdatedUser, nil)
+				mockRepo.EXPECT().UpdatePassword(gomock.Any(), outdatedUser.ID, gomock.Any()).DoAndReturn(
+					func(ctx context.Context, userID uuid.UUID, hash string) error {
+						assert.False(t, testPasswordHasher.NeedsRehash(hash))
+						assert.NoError(t, testPasswordHasher.Compare("correct-password", hash))
+						return nil
+					})
+			},
+			wantErr:   nil,
+			expectJWT: true,
+		},
+		{
+			name:     "failed hash upgrade does not block login",
+			email:    "outdated@example.com",
+			password: "correct-password",
+			setup: func() {
+				mockRepo.EXPECT().GetUserByEmail(gomock.Any(), "outdated@example.com").Return(outdatedUser, nil)
+				mockRepo.EXPECT().UpdatePassword(gomock.Any(), outdatedUser.ID, gomock.Any()).Return(errors.New("db error"))
+			},
+			wantErr:   nil,
+			expectJWT: true,
+		},
+		{
+			name:     "success - email case ignored",
+			email:    "Test@Example.com",
+			password: "correct-password",
+			setup: func() {
+				mockRepo.EXPECT().GetUserByEmail(gomock.Any(), "test@example.com").Return(user, nil)
+			},
+			wantErr:   nil,
+			expectJWT: true,
+		},
 		{
 			name:     "invalid credentials - wrong password",
 			email:    "test@example.com",
@@ -0,0 +1,53 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/senyabanana/pvz-service/internal/entity"
+	"github.com/senyabanana/pvz-service/internal/infrastructure/logger"
+	"github.com/senyabanana/pvz-service/internal/repository"
+)
+
+type VersionService struct {
+	schemaRepo          repository.SchemaRepository
+	version             string
+	latestSchemaVersion int64
+	log                 *logrus.Logger
+}
+
+func NewVersionService(
+	schemaRepo repository.SchemaRepository, version string, latestSchemaVersion int64, log *logrus.Logger,
+) *VersionService {
+	return &VersionService{
+		schemaRepo:          schemaRepo,
+		version:             version,
+		latestSchemaVersion: latestSchemaVersion,
+		log:                 log,
+	}
+}
+
+// GetVersion reports the build version together with the applied and the expected schema versions;
+// they differ when migrations are pending or the binary is older than the database.
+func (s *VersionService) GetVersion(ctx context.Context) (*entity.VersionInfo, error) {
+	ctx, span := tracer.Start(ctx, "VersionService.GetVersion")
+	defer span.End()
+	log := logger.FromContext(ctx, s.log)
+
+	info := &entity.VersionInfo{
+		Version:             s.version,
+		LatestSchemaVersion: s.latestSchemaVersion,
+	}
+
+	schema, err := s.schemaRepo.GetSchemaVersion(ctx)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		log.Errorf("failed to get schema version: %v", err)
+		return nil, err
+	}
+	info.Schema = schema
+
+	return info, nil
+}
@@ -0,0 +1,73 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/senyabanana/pvz-service/internal/entity"
+	mocks "github.com/senyabanana/pvz-service/internal/repository/mocks"
+)
+
+func TestVersionService_GetVersion(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockSchemaRepository(ctrl)
+	mockLog := logrus.New()
+
+	svc := NewVersionService(mockRepo, "v1.2.0", 18, mockLog)
+
+	dbErr := errors.New("db error")
+
+	tests := []struct {
+		name       string
+		setup      func()
+		wantSchema *entity.SchemaVersion
+		wantErr    error
+	}{
+		{
+			name: "migrated schema",
+			setup: func() {
+				mockRepo.EXPECT().GetSchemaVersion(gomock.Any()).Return(&entity.SchemaVersion{Version: 17}, nil)
+			},
+			wantSchema: &entity.SchemaVersion{Version: 17},
+		},
+		{
+			name: "schema never migrated",
+			setup: func() {
+				mockRepo.EXPECT().GetSchemaVersion(gomock.Any()).Return(nil, sql.ErrNoRows)
+			},
+		},
+		{
+			name: "repository error",
+			setup: func() {
+				mockRepo.EXPECT().GetSchemaVersion(gomock.Any()).Return(nil, dbErr)
+			},
+			wantErr: dbErr,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+
+			info, err := svc.GetVersion(context.Background())
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, info)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, "v1.2.0", info.Version)
+			assert.Equal(t, int64(18), info.LatestSchemaVersion)
+			assert.Equal(t, tt.wantSchema, info.Schema)
+		})
+	}
+}
@@ -2,9 +2,13 @@ package grpc
 
 import (
 	"context"
+	"errors"
 
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
+	"github.com/senyabanana/pvz-service/internal/entity"
 	"github.com/senyabanana/pvz-service/internal/service"
 	pbv1 "github.com/senyabanana/pvz-service/pkg/pb/pvz_v1"
 )
@@ -21,18 +25,79 @@ func NewPVZGRPCHandler(service service.PVZOperations) *PVZGRPCHandler {
 }
 
 func (h *PVZGRPCHandler) GetPVZList(ctx context.Context, req *pbv1.GetPVZListRequest) (*pbv1.GetPVZListResponse, error) {
-	pvzList, err := h.service.GetAllPVZ(ctx)
+	pvzList, err := h.service.GetAllPVZ(ctx, req.GetIncludeClosed())
 	if err != nil {
 		return nil, err
 	}
 
 	var resp pbv1.GetPVZListResponse
 	for _, pvz := range pvzList {
-		resp.Pvzs = append(resp.Pvzs, &pbv1.PVZ{
-			Id:               pvz.ID.String(),
-			City:             string(pvz.City),
-			RegistrationDate: timestamppb.New(pvz.RegistrationDate),
+		resp.Pvzs = append(resp.Pvzs, toProtoPVZ(pvz))
+	}
+	return &resp, nil
+}
+
+func (h *PVZGRPCHandler) GetNearbyPVZ(ctx context.Context, req *pbv1.GetNearbyPVZRequest) (*pbv1.GetNearbyPVZResponse, error) {
+	nearby, err := h.service.FindNearbyPVZ(ctx, entity.NearbyFilter{
+		Latitude:     req.GetLatitude(),
+		Longitude:    req.GetLongitude(),
+		RadiusMeters: req.GetRadiusMeters(),
+		Limit:        int(req.GetLimit()),
+	})
+	if err != nil {
+		if errors.Is(err, entity.ErrInvalidCoordinates) || errors.Is(err, entity.ErrInvalidSearchRadius) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, err
+	}
+
+	var resp pbv1.GetNearbyPVZResponse
+	for _, n := range nearby {
+		resp.Pvzs = append(resp.Pvzs, &pbv1.NearbyPVZ{
+			Pvz:            toProtoPVZ(n.PVZ),
+			DistanceMeters: n.DistanceMeters,
 		})
 	}
 	return &resp, nil
 }
+
+func toProtoPVZ(pvz entity.PVZ) *pbv1.PVZ {
+	result := &pbv1.PVZ{
+		Id:               pvz.ID.String(),
+		City:             string(pvz.City),
+		RegistrationDate: timestamppb.New(pvz.RegistrationDate),
+		Address:          pvz.Address,
+		Latitude:         pvz.Latitude,
+		Longitude:        pvz.Longitude,
+		Phone:            pvz.Phone,
+		Status:           string(pvz.Status),
+		StatusReason:     pvz.StatusReason,
+	}
+
+	if pvz.StatusChangedAt != nil {
+		result.StatusChangedAt = timestamppb.New(*pvz.StatusChangedAt)
+	}
+
+	if pvz.WorkingHours != nil {
+		hours := &pbv1.WorkingHours{}
+		for _, day := range pvz.WorkingHours.Weekly {
+			hours.Weekly = append(hours.Weekly, &pbv1.DailyHours{
+				Weekday: day.Weekday,
+				Open:    day.Open,
+				Close:   day.Close,
+			})
+		}
+		for _, exception := range pvz.WorkingHours.Exceptions {
+			hours.Exceptions = append(hours.Exceptions, &pbv1.HoursException{
+				Date:   exception.Date,
+				Closed: exception.Closed,
+				Open:   exception.Open,
+				Close:  exception.Close,
+				Note:   exception.Note,
+			})
+		}
+		result.WorkingHours = hours
+	}
+
+	return result
+}
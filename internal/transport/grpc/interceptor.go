@@ -0,0 +1,44 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/senyabanana/pvz-service/internal/infrastructure/logger"
+)
+
+const requestIDMetadata = "x-request-id"
+
+// RequestIDInterceptor is the gRPC counterpart of middleware.RequestID: it takes x-request-id from
+// the incoming metadata or generates one, returns it in the response header and binds a logger with
+// request_id and grpc_method to the call context.
+func RequestIDInterceptor(log *logrus.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		var incoming string
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if values := md.Get(requestIDMetadata); len(values) > 0 {
+				incoming = values[0]
+			}
+		}
+		requestID := logger.RequestIDOrNew(incoming)
+
+		if err := grpc.SetHeader(ctx, metadata.Pairs(requestIDMetadata, requestID)); err != nil {
+			log.Warnf("failed to set request id header: %v", err)
+		}
+
+		ctx = logger.WithEntry(ctx, log.WithFields(logrus.Fields{
+			logger.FieldRequestID:  requestID,
+			logger.FieldGRPCMethod: info.FullMethod,
+		}))
+
+		resp, err := handler(ctx, req)
+		if err != nil {
+			logger.FromContext(ctx, log).Warnf("grpc call failed: %v", err)
+		}
+
+		return resp, err
+	}
+}
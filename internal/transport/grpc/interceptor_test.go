@@ -0,0 +1,64 @@
+package grpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/senyabanana/pvz-service/internal/infrastructure/logger"
+)
+
+func TestRequestIDInterceptor(t *testing.T) {
+	var buf bytes.Buffer
+	log := logrus.New()
+	log.SetFormatter(&logrus.JSONFormatter{})
+	log.SetOutput(&buf)
+
+	interceptor := RequestIDInterceptor(log)
+	info := &grpc.UnaryServerInfo{FullMethod: "/pvz.v1.PVZService/GetPVZList"}
+
+	tests := []struct {
+		name       string
+		ctx        context.Context
+		handlerErr error
+		wantID     string
+	}{
+		{
+			name:   "incoming request id",
+			ctx:    metadata.NewIncomingContext(context.Background(), metadata.Pairs(requestIDMetadata, "req-42")),
+			wantID: "req-42",
+		},
+		{
+			name:       "failed call is logged with the request id",
+			ctx:        metadata.NewIncomingContext(context.Background(), metadata.Pairs(requestIDMetadata, "req-43")),
+			handlerErr: errors.New("db down"),
+			wantID:     "req-43",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf.Reset()
+
+			_, err := interceptor(tt.ctx, nil, info, func(ctx context.Context, req any) (any, error) {
+				logger.FromContext(ctx, log).Info("handled")
+				return nil, tt.handlerErr
+			})
+			assert.ErrorIs(t, err, tt.handlerErr)
+
+			lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+			var entry map[string]any
+			require.NoError(t, json.Unmarshal(lines[len(lines)-1], &entry))
+			assert.Equal(t, tt.wantID, entry[logger.FieldRequestID])
+			assert.Equal(t, info.FullMethod, entry[logger.FieldGRPCMethod])
+		})
+	}
+}
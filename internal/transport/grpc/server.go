@@ -5,7 +5,11 @@ import (
 	"net"
 
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc/filters"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 
 	"github.com/senyabanana/pvz-service/internal/service"
 	pbv1 "github.com/senyabanana/pvz-service/pkg/pb/pvz_v1"
@@ -15,6 +19,7 @@ const tcpNetwork = "tcp"
 
 type GRPCServer struct {
 	server   *grpc.Server
+	health   *health.Server
 	listener net.Listener
 	log      *logrus.Logger
 }
@@ -26,14 +31,36 @@ func NewGRPCServer(port string, pvzService service.PVZOperations, log *logrus.Lo
 		return nil, err
 	}
 
-	grpcServer := grpc.NewServer()
+	grpcServer := grpc.NewServer(
+		grpc.StatsHandler(otelgrpc.NewServerHandler(otelgrpc.WithFilter(filters.Not(filters.HealthCheck())))),
+		grpc.UnaryInterceptor(RequestIDInterceptor(log)),
+	)
 	pbv1.RegisterPVZServiceServer(grpcServer, NewPVZGRPCHandler(pvzService))
 
-	return &GRPCServer{
+	healthServer := health.NewServer()
+	healthpb.RegisterHealthServer(grpcServer, healthServer)
+
+	s := &GRPCServer{
 		server:   grpcServer,
+		health:   healthServer,
 		listener: listener,
 		log:      log,
-	}, nil
+	}
+	s.SetServing(false)
+
+	return s, nil
+}
+
+// SetServing reports the readiness of the service through grpc.health.v1, both for the whole server
+// and for the PVZ service. It starts as not serving until the first readiness check passes.
+func (s *GRPCServer) SetServing(serving bool) {
+	status := healthpb.HealthCheckResponse_NOT_SERVING
+	if serving {
+		status = healthpb.HealthCheckResponse_SERVING
+	}
+
+	s.health.SetServingStatus("", status)
+	s.health.SetServingStatus(pbv1.PVZService_ServiceDesc.ServiceName, status)
 }
 
 func (s *GRPCServer) Run() error {
@@ -43,5 +70,6 @@ func (s *GRPCServer) Run() error {
 
 func (s *GRPCServer) Shutdown(ctx context.Context) {
 	s.log.Info("Gracefully stopping gRPC server...")
+	s.health.Shutdown()
 	s.server.GracefulStop()
 }
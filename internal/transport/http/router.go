@@ -1,55 +1,148 @@
 package http
 
 import (
+	"net/http"
+	"strings"
+
 	"github.com/gin-gonic/gin"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/sirupsen/logrus"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
 
+	"github.com/senyabanana/pvz-service/internal/entity"
 	"github.com/senyabanana/pvz-service/internal/handler"
 	"github.com/senyabanana/pvz-service/internal/infrastructure/monitoring"
+	"github.com/senyabanana/pvz-service/internal/infrastructure/tracing"
 	"github.com/senyabanana/pvz-service/internal/middleware"
 )
 
 const (
 	moderatorRole = "moderator"
 	employeeRole  = "employee"
+	clientRole    = "client"
 )
 
-func SetupRouter(handlers *handler.Handler, secretKey string, log *logrus.Logger) *gin.Engine {
+func SetupRouter(
+	handlers *handler.Handler, secretKey string, apiKeys middleware.APIKeyAuthenticator, log *logrus.Logger,
+) *gin.Engine {
 	monitoring.RegisterMetrics()
 	router := gin.Default()
 	router.Use(middleware.PrometheusMiddleware())
+	router.Use(otelgin.Middleware(tracing.ServiceName, otelgin.WithFilter(isTracedRequest)))
+	router.Use(middleware.RequestID(log))
 
 	router.GET("/metrics", gin.WrapH(promhttp.Handler()))
 
 	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+	router.GET("/version", handlers.VersionOperations.GetVersion)
+	router.GET("/healthz", handlers.HealthOperations.Healthz)
+	router.GET("/readyz", handlers.HealthOperations.Readyz)
 
 	router.POST("/dummyLogin", handlers.Authorization.DummyLogin)
 	router.POST("/register", handlers.Authorization.Register)
 	router.POST("/login", handlers.Authorization.Login)
+	router.GET("/auth/oidc/login", handlers.OIDCAuthorization.OIDCLogin)
+	router.GET("/auth/oidc/callback", handlers.OIDCAuthorization.OIDCCallback)
+	router.POST("/password/reset/request", handlers.PasswordOperations.RequestPasswordReset)
+	router.POST("/password/reset", handlers.PasswordOperations.ResetPassword)
+
+	authenticated := router.Group("/")
+	authenticated.Use(middleware.RequireRole(secretKey, log, moderatorRole, employeeRole, clientRole))
+	{
+		authenticated.GET("/me", handlers.AccountOperations.GetMe)
+		authenticated.POST("/me/password", handlers.PasswordOperations.ChangePassword)
+	}
+
+	client := router.Group("/")
+	client.Use(middleware.RequireRole(secretKey, log, clientRole))
+	{
+		client.GET("/me/parcels", handlers.IssuanceOperations.GetMyParcels)
+		client.POST("/me/parcels/:productId/pickup-code", handlers.IssuanceOperations.RenewPickupCode)
+	}
+
+	introspection := router.Group("/")
+	introspection.Use(middleware.RequireAccess(secretKey, apiKeys, log, entity.ScopeTokensIntrospect, moderatorRole))
+	{
+		introspection.POST("/token/introspect", handlers.AccountOperations.IntrospectToken)
+	}
+
+	admin := router.Group("/")
+	admin.Use(middleware.RequireRole(secretKey, log, moderatorRole))
+	{
+		admin.POST("/api-keys", handlers.APIKeyOperations.CreateAPIKey)
+		admin.GET("/api-keys", handlers.APIKeyOperations.GetAllAPIKeys)
+		admin.DELETE("/api-keys/:keyId", handlers.APIKeyOperations.RevokeAPIKey)
+		admin.PUT("/users/:userId/pvz", handlers.AccountOperations.AssignUserPVZ)
+		admin.GET("/analytics/receptions", handlers.AnalyticsOperations.GetReceptionThroughput)
+		admin.GET("/analytics/products", handlers.AnalyticsOperations.GetProductMix)
+		admin.POST("/import", handlers.ImportOperations.ImportData)
+		admin.GET("/log-level", handlers.LogLevelOperations.GetLogLevel)
+		admin.PUT("/log-level", handlers.LogLevelOperations.SetLogLevel)
+	}
 
 	moderator := router.Group("/")
-	moderator.Use(middleware.RequireRole(secretKey, log, moderatorRole))
+	moderator.Use(middleware.RequireAccess(secretKey, apiKeys, log, entity.ScopePVZManage, moderatorRole))
 	{
 		moderator.POST("/pvz", handlers.PVZOperations.CreatePVZ)
+		moderator.PATCH("/pvz/:pvzId", handlers.PVZOperations.UpdatePVZ)
+		moderator.DELETE("/pvz/:pvzId", handlers.PVZOperations.ClosePVZ)
+		moderator.POST("/pvz/:pvzId/status", handlers.PVZOperations.ChangePVZStatus)
+		moderator.PUT("/pvz/:pvzId/capacity", handlers.CapacityOperations.SetPVZCapacity)
 	}
 
 	employee := router.Group("/")
-	employee.Use(middleware.RequireRole(secretKey, log, employeeRole))
+	employee.Use(middleware.RequireAccess(secretKey, apiKeys, log, entity.ScopeReceptionsManage, employeeRole))
 	{
 		employee.POST("/pvz/:pvzId/close_last_reception", handlers.ReceptionOperations.CloseLastReception)
 		employee.POST("/pvz/:pvzId/delete_last_product", handlers.ProductOperations.DeleteLastProduct)
 		employee.POST("/receptions", handlers.ReceptionOperations.CreateReception)
 		employee.POST("/products", handlers.ProductOperations.AddProduct)
+		employee.POST("/pvz/:pvzId/products/:productId/owner", handlers.IssuanceOperations.AssignProductOwner)
+		employee.POST("/pvz/:pvzId/products/:productId/issue", handlers.IssuanceOperations.IssueProduct)
+		employee.POST("/pvz/:pvzId/returns", handlers.ReturnOperations.AcceptReturn)
+		employee.POST("/pvz/:pvzId/returns/:returnId/status", handlers.ReturnOperations.ChangeReturnStatus)
+		employee.POST("/pvz/:pvzId/overdue/ship", handlers.StorageOperations.ShipOverdueProducts)
+		employee.POST("/pvz/:pvzId/transfers", handlers.TransferOperations.CreateTransfer)
+		employee.POST("/pvz/:pvzId/transfers/:transferId/receive", handlers.TransferOperations.ReceiveTransfer)
+		employee.POST("/pvz/:pvzId/stocktakes", handlers.StocktakeOperations.StartStocktake)
+		employee.POST("/pvz/:pvzId/stocktakes/:stocktakeId/scans", handlers.StocktakeOperations.ScanProducts)
+		employee.POST("/pvz/:pvzId/stocktakes/:stocktakeId/complete", handlers.StocktakeOperations.CompleteStocktake)
 	}
 
 	staff := router.Group("/")
-	staff.Use(middleware.RequireRole(secretKey, log, moderatorRole, employeeRole))
+	staff.Use(middleware.RequireAccess(secretKey, apiKeys, log, entity.ScopePVZRead, moderatorRole, employeeRole))
 	{
 		staff.GET("/pvz", handlers.PVZOperations.GetFullInfoPVZ)
+		staff.GET("/pvz/:pvzId/capacity", handlers.CapacityOperations.GetPVZCapacity)
+		staff.GET("/pvz/:pvzId/overdue", handlers.StorageOperations.GetOverdueProducts)
+		staff.GET("/pvz/:pvzId/stocktakes/:stocktakeId", handlers.StocktakeOperations.GetStocktake)
+		staff.GET("/export/pvz", handlers.ExportOperations.ExportPVZ)
+		staff.GET("/export/receptions", handlers.ExportOperations.ExportReceptions)
+		staff.GET("/export/products", handlers.ExportOperations.ExportProducts)
+		staff.POST("/jobs", handlers.ReportJobOperations.CreateReportJob)
+		staff.GET("/jobs/:jobId", handlers.ReportJobOperations.GetReportJob)
+		staff.GET("/jobs/:jobId/artifact", handlers.ReportJobOperations.GetReportArtifact)
+		staff.GET("/products/:productId/custody", handlers.TransferOperations.GetProductCustody)
+		staff.GET("/products/:productId/history", handlers.ProductOperations.GetProductHistory)
+	}
+
+	search := router.Group("/")
+	search.Use(middleware.RequireAccess(secretKey, apiKeys, log, entity.ScopePVZRead, moderatorRole, employeeRole, clientRole))
+	{
+		search.GET("/pvz/nearby", handlers.PVZOperations.GetNearbyPVZ)
 	}
 
 	return router
 }
+
+// isTracedRequest keeps probes, metrics scraping and swagger assets out of traces.
+func isTracedRequest(r *http.Request) bool {
+	switch r.URL.Path {
+	case "/metrics", "/healthz", "/readyz":
+		return false
+	}
+
+	return !strings.HasPrefix(r.URL.Path, "/swagger/")
+}
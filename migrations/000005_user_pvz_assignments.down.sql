@@ -0,0 +1,11 @@
+DO $$
+BEGIN
+    IF to_regclass('api_keys') IS NOT NULL THEN
+        UPDATE api_keys SET scopes = array_remove(scopes, 'tokens:introspect');
+        ALTER TABLE api_keys DROP CONSTRAINT IF EXISTS api_keys_scopes_check;
+        ALTER TABLE api_keys ADD CONSTRAINT api_keys_scopes_check
+            CHECK (scopes <@ ARRAY['pvz:manage', 'receptions:manage', 'pvz:read']);
+    END IF;
+END $$;
+
+DROP TABLE IF EXISTS user_pvz_assignments;
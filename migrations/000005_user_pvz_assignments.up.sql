@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS user_pvz_assignments
+(
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    pvz_id UUID NOT NULL REFERENCES pvz(id),
+    assigned_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, pvz_id)
+);
+
+ALTER TABLE api_keys DROP CONSTRAINT IF EXISTS api_keys_scopes_check;
+ALTER TABLE api_keys ADD CONSTRAINT api_keys_scopes_check
+    CHECK (scopes <@ ARRAY['pvz:manage', 'receptions:manage', 'pvz:read', 'tokens:introspect']);
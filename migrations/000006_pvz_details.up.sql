@@ -0,0 +1,9 @@
+ALTER TABLE pvz
+    ADD COLUMN IF NOT EXISTS address TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS latitude DOUBLE PRECISION CHECK (latitude BETWEEN -90 AND 90),
+    ADD COLUMN IF NOT EXISTS longitude DOUBLE PRECISION CHECK (longitude BETWEEN -180 AND 180),
+    ADD COLUMN IF NOT EXISTS phone TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS working_hours JSONB;
+
+ALTER TABLE pvz DROP CONSTRAINT IF EXISTS pvz_coordinates_check;
+ALTER TABLE pvz ADD CONSTRAINT pvz_coordinates_check CHECK ((latitude IS NULL) = (longitude IS NULL));
@@ -0,0 +1 @@
+CREATE INDEX IF NOT EXISTS idx_pvz_latitude ON pvz(latitude) WHERE latitude IS NOT NULL;
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_pvz_status;
+
+ALTER TABLE pvz
+    DROP COLUMN IF EXISTS status_changed_at,
+    DROP COLUMN IF EXISTS status_reason,
+    DROP COLUMN IF EXISTS status;
@@ -0,0 +1,6 @@
+ALTER TABLE pvz
+    ADD COLUMN IF NOT EXISTS status TEXT NOT NULL DEFAULT 'active' CHECK (status IN ('active', 'suspended', 'closed')),
+    ADD COLUMN IF NOT EXISTS status_reason TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS status_changed_at TIMESTAMP;
+
+CREATE INDEX IF NOT EXISTS idx_pvz_status ON pvz(status);
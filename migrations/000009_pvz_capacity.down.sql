@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_products_not_issued;
+
+ALTER TABLE products DROP COLUMN IF EXISTS issued_at;
+
+DROP TABLE IF EXISTS pvz_type_capacity;
+
+ALTER TABLE pvz DROP COLUMN IF EXISTS capacity;
@@ -0,0 +1,13 @@
+ALTER TABLE pvz ADD COLUMN IF NOT EXISTS capacity INTEGER CHECK (capacity >= 0);
+
+CREATE TABLE IF NOT EXISTS pvz_type_capacity
+(
+    pvz_id UUID NOT NULL REFERENCES pvz(id) ON DELETE CASCADE,
+    product_type TEXT NOT NULL CHECK (product_type IN ('электроника', 'одежда', 'обувь')),
+    capacity INTEGER NOT NULL CHECK (capacity >= 0),
+    PRIMARY KEY (pvz_id, product_type)
+);
+
+ALTER TABLE products ADD COLUMN IF NOT EXISTS issued_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_products_not_issued ON products(reception_id) WHERE issued_at IS NULL;
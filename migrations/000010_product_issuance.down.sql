@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_products_owner_id;
+
+ALTER TABLE products
+    DROP COLUMN IF EXISTS issued_by,
+    DROP COLUMN IF EXISTS pickup_code,
+    DROP COLUMN IF EXISTS owner_id,
+    DROP COLUMN IF EXISTS status;
@@ -0,0 +1,7 @@
+ALTER TABLE products
+    ADD COLUMN IF NOT EXISTS status TEXT NOT NULL DEFAULT 'received' CHECK (status IN ('received', 'issued')),
+    ADD COLUMN IF NOT EXISTS owner_id UUID REFERENCES users(id),
+    ADD COLUMN IF NOT EXISTS pickup_code TEXT,
+    ADD COLUMN IF NOT EXISTS issued_by UUID;
+
+CREATE INDEX IF NOT EXISTS idx_products_owner_id ON products(owner_id) WHERE owner_id IS NOT NULL;
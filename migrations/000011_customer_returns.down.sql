@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS customer_returns;
+
+UPDATE products SET status = 'issued' WHERE status = 'returned';
+ALTER TABLE products DROP CONSTRAINT IF EXISTS products_status_check;
+ALTER TABLE products
+    ADD CONSTRAINT products_status_check CHECK (status IN ('received', 'issued'));
@@ -0,0 +1,23 @@
+ALTER TABLE products DROP CONSTRAINT IF EXISTS products_status_check;
+ALTER TABLE products
+    ADD CONSTRAINT products_status_check CHECK (status IN ('received', 'issued', 'returned'));
+
+CREATE TABLE IF NOT EXISTS customer_returns
+(
+    id                UUID PRIMARY KEY,
+    pvz_id            UUID        NOT NULL REFERENCES pvz (id),
+    product_id        UUID        NOT NULL UNIQUE REFERENCES products (id),
+    client_id         UUID,
+    reason            TEXT        NOT NULL CHECK (reason IN ('defective', 'wrong_item', 'not_as_described',
+                                                             'damaged_in_transit', 'changed_mind', 'other')),
+    condition         TEXT        NOT NULL CHECK (condition IN ('new', 'opened', 'used', 'damaged')),
+    comment           TEXT        NOT NULL DEFAULT '',
+    status            TEXT        NOT NULL DEFAULT 'accepted'
+        CHECK (status IN ('accepted', 'ready_to_ship', 'shipped_to_warehouse')),
+    accepted_at       TIMESTAMPTZ NOT NULL DEFAULT now(),
+    accepted_by       UUID,
+    status_changed_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    shipped_at        TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_customer_returns_pvz_id ON customer_returns (pvz_id);
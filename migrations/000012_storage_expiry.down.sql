@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_products_received_type;
+DROP TABLE IF EXISTS return_to_sender_queue;
+
+UPDATE products SET status = 'received' WHERE status = 'return_to_sender';
+ALTER TABLE products DROP CONSTRAINT IF EXISTS products_status_check;
+ALTER TABLE products
+    ADD CONSTRAINT products_status_check CHECK (status IN ('received', 'issued', 'returned'));
@@ -0,0 +1,16 @@
+ALTER TABLE products DROP CONSTRAINT IF EXISTS products_status_check;
+ALTER TABLE products
+    ADD CONSTRAINT products_status_check CHECK (status IN ('received', 'issued', 'returned', 'return_to_sender'));
+
+CREATE TABLE IF NOT EXISTS return_to_sender_queue
+(
+    product_id UUID PRIMARY KEY REFERENCES products (id),
+    pvz_id     UUID        NOT NULL REFERENCES pvz (id),
+    ready_at   TIMESTAMPTZ NOT NULL,
+    deadline   TIMESTAMPTZ NOT NULL,
+    queued_at  TIMESTAMPTZ NOT NULL DEFAULT now(),
+    shipped_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_return_to_sender_queue_pvz_id ON return_to_sender_queue (pvz_id) WHERE shipped_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_products_received_type ON products (type) WHERE status = 'received';
@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS idx_transfer_orders_destination;
+DROP INDEX IF EXISTS idx_transfer_items_product_id;
+DROP TABLE IF EXISTS transfer_items;
+DROP TABLE IF EXISTS transfer_orders;
+
+UPDATE products SET status = 'received' WHERE status = 'in_transit';
+ALTER TABLE products DROP CONSTRAINT IF EXISTS products_status_check;
+ALTER TABLE products
+    ADD CONSTRAINT products_status_check CHECK (status IN ('received', 'issued', 'returned', 'return_to_sender'));
@@ -0,0 +1,29 @@
+ALTER TABLE products DROP CONSTRAINT IF EXISTS products_status_check;
+ALTER TABLE products
+    ADD CONSTRAINT products_status_check
+        CHECK (status IN ('received', 'issued', 'returned', 'return_to_sender', 'in_transit'));
+
+CREATE TABLE IF NOT EXISTS transfer_orders
+(
+    id                       UUID PRIMARY KEY,
+    source_pvz_id            UUID        NOT NULL REFERENCES pvz (id),
+    destination_pvz_id       UUID        NOT NULL REFERENCES pvz (id),
+    status                   TEXT        NOT NULL DEFAULT 'in_transit' CHECK (status IN ('in_transit', 'received')),
+    created_at               TIMESTAMPTZ NOT NULL DEFAULT now(),
+    created_by               UUID,
+    received_at              TIMESTAMPTZ,
+    received_by              UUID,
+    destination_reception_id UUID REFERENCES receptions (id),
+    CHECK (source_pvz_id <> destination_pvz_id)
+);
+
+CREATE TABLE IF NOT EXISTS transfer_items
+(
+    transfer_id         UUID NOT NULL REFERENCES transfer_orders (id),
+    product_id          UUID NOT NULL REFERENCES products (id),
+    source_reception_id UUID NOT NULL REFERENCES receptions (id),
+    PRIMARY KEY (transfer_id, product_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_transfer_items_product_id ON transfer_items (product_id);
+CREATE INDEX IF NOT EXISTS idx_transfer_orders_destination ON transfer_orders (destination_pvz_id) WHERE status = 'in_transit';
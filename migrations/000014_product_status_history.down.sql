@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS product_status_history;
+DROP FUNCTION IF EXISTS forbid_product_status_history_update();
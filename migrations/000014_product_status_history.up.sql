@@ -0,0 +1,65 @@
+CREATE TABLE IF NOT EXISTS product_status_history
+(
+    id          BIGSERIAL PRIMARY KEY,
+    product_id  UUID        NOT NULL REFERENCES products (id) ON DELETE CASCADE,
+    from_status TEXT,
+    to_status   TEXT        NOT NULL,
+    pvz_id      UUID REFERENCES pvz (id),
+    changed_at  TIMESTAMPTZ NOT NULL DEFAULT now(),
+    changed_by  UUID,
+    comment     TEXT        NOT NULL DEFAULT ''
+);
+
+CREATE INDEX IF NOT EXISTS idx_product_status_history_product_id ON product_status_history (product_id, changed_at, id);
+
+-- History is append-only: entries disappear only together with their product.
+CREATE OR REPLACE FUNCTION forbid_product_status_history_update() RETURNS trigger AS
+$$
+BEGIN
+    RAISE EXCEPTION 'product_status_history is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS product_status_history_append_only ON product_status_history;
+CREATE TRIGGER product_status_history_append_only
+    BEFORE UPDATE
+    ON product_status_history
+    FOR EACH ROW
+EXECUTE FUNCTION forbid_product_status_history_update();
+
+-- Rebuild the history of existing products from the columns and tables that recorded their moves.
+INSERT INTO product_status_history (product_id, from_status, to_status, pvz_id, changed_at)
+SELECT p.id, NULL, 'received', r.pvz_id, p.date_time
+FROM products p
+JOIN receptions r ON r.id = COALESCE(
+        (SELECT ti.source_reception_id
+         FROM transfer_items ti
+         JOIN transfer_orders t ON t.id = ti.transfer_id
+         WHERE ti.product_id = p.id
+         ORDER BY t.created_at
+         LIMIT 1),
+        p.reception_id);
+
+INSERT INTO product_status_history (product_id, from_status, to_status, pvz_id, changed_at, changed_by, comment)
+SELECT ti.product_id, 'received', 'in_transit', t.source_pvz_id, t.created_at, t.created_by, 'transfer ' || t.id
+FROM transfer_items ti
+JOIN transfer_orders t ON t.id = ti.transfer_id
+UNION ALL
+SELECT ti.product_id, 'in_transit', 'received', t.destination_pvz_id, t.received_at, t.received_by, 'transfer ' || t.id
+FROM transfer_items ti
+JOIN transfer_orders t ON t.id = ti.transfer_id
+WHERE t.status = 'received';
+
+INSERT INTO product_status_history (product_id, from_status, to_status, pvz_id, changed_at, changed_by)
+SELECT p.id, 'received', 'issued', r.pvz_id, p.issued_at, p.issued_by
+FROM products p
+JOIN receptions r ON r.id = p.reception_id
+WHERE p.issued_at IS NOT NULL;
+
+INSERT INTO product_status_history (product_id, from_status, to_status, pvz_id, changed_at, changed_by)
+SELECT product_id, 'issued', 'returned', pvz_id, accepted_at, accepted_by
+FROM customer_returns;
+
+INSERT INTO product_status_history (product_id, from_status, to_status, pvz_id, changed_at, comment)
+SELECT product_id, 'received', 'return_to_sender', pvz_id, queued_at, 'storage period expired'
+FROM return_to_sender_queue;
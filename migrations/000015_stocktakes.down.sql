@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS stocktake_items;
+DROP TABLE IF EXISTS stocktake_scans;
+DROP INDEX IF EXISTS idx_stocktakes_pvz_id;
+DROP INDEX IF EXISTS idx_stocktakes_open_pvz;
+DROP TABLE IF EXISTS stocktakes;
@@ -0,0 +1,37 @@
+CREATE TABLE IF NOT EXISTS stocktakes
+(
+    id               UUID PRIMARY KEY,
+    pvz_id           UUID        NOT NULL REFERENCES pvz (id),
+    status           TEXT        NOT NULL DEFAULT 'open' CHECK (status IN ('open', 'completed')),
+    started_at       TIMESTAMPTZ NOT NULL DEFAULT now(),
+    started_by       UUID,
+    completed_at     TIMESTAMPTZ,
+    completed_by     UUID,
+    comment          TEXT        NOT NULL DEFAULT '',
+    expected_count   INT         NOT NULL DEFAULT 0,
+    matched_count    INT         NOT NULL DEFAULT 0,
+    missing_count    INT         NOT NULL DEFAULT 0,
+    unexpected_count INT         NOT NULL DEFAULT 0,
+    CHECK (status = 'open' OR (completed_at IS NOT NULL AND completed_by IS NOT NULL))
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_stocktakes_open_pvz ON stocktakes (pvz_id) WHERE status = 'open';
+CREATE INDEX IF NOT EXISTS idx_stocktakes_pvz_id ON stocktakes (pvz_id, started_at DESC);
+
+-- Scanned ids are not foreign keys: an unexpected item may be unknown to the service altogether.
+CREATE TABLE IF NOT EXISTS stocktake_scans
+(
+    stocktake_id UUID        NOT NULL REFERENCES stocktakes (id),
+    product_id   UUID        NOT NULL,
+    scanned_at   TIMESTAMPTZ NOT NULL DEFAULT now(),
+    scanned_by   UUID,
+    PRIMARY KEY (stocktake_id, product_id)
+);
+
+CREATE TABLE IF NOT EXISTS stocktake_items
+(
+    stocktake_id UUID NOT NULL REFERENCES stocktakes (id),
+    product_id   UUID NOT NULL,
+    result       TEXT NOT NULL CHECK (result IN ('matched', 'missing', 'unexpected')),
+    PRIMARY KEY (stocktake_id, product_id)
+);
@@ -0,0 +1 @@
+CREATE INDEX IF NOT EXISTS idx_receptions_created_at ON receptions(created_at);
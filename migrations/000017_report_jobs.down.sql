@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_report_jobs_running;
+DROP INDEX IF EXISTS idx_report_jobs_queued;
+DROP TABLE IF EXISTS report_jobs;
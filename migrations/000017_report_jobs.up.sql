@@ -0,0 +1,25 @@
+CREATE TABLE IF NOT EXISTS report_jobs
+(
+    id                    UUID PRIMARY KEY,
+    kind                  TEXT        NOT NULL CHECK (kind IN ('export_pvz', 'export_receptions', 'export_products',
+                                                           'analytics_receptions', 'analytics_products')),
+    params                JSONB       NOT NULL,
+    status                TEXT        NOT NULL DEFAULT 'queued'
+        CHECK (status IN ('queued', 'running', 'succeeded', 'failed')),
+    progress              INTEGER     NOT NULL DEFAULT 0,
+    attempts              INTEGER     NOT NULL DEFAULT 0,
+    error                 TEXT        NOT NULL DEFAULT '',
+    created_by            UUID REFERENCES users (id) ON DELETE SET NULL,
+    api_key_id            UUID REFERENCES api_keys (id) ON DELETE SET NULL,
+    created_at            TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    started_at            TIMESTAMPTZ,
+    heartbeat_at          TIMESTAMPTZ,
+    finished_at           TIMESTAMPTZ,
+    artifact              BYTEA,
+    artifact_name         TEXT        NOT NULL DEFAULT '',
+    artifact_content_type TEXT        NOT NULL DEFAULT ''
+);
+
+-- Workers take the oldest queued job with FOR UPDATE SKIP LOCKED.
+CREATE INDEX IF NOT EXISTS idx_report_jobs_queued ON report_jobs (created_at) WHERE status = 'queued';
+CREATE INDEX IF NOT EXISTS idx_report_jobs_running ON report_jobs (heartbeat_at) WHERE status = 'running';
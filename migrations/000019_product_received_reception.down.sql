@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_products_received_reception_id;
+
+ALTER TABLE products
+    DROP COLUMN IF EXISTS received_reception_id;
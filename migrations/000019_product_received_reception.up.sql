@@ -0,0 +1,17 @@
+-- reception_id follows a product through transfers; received_reception_id keeps the reception it arrived with.
+ALTER TABLE products
+    ADD COLUMN IF NOT EXISTS received_reception_id UUID REFERENCES receptions (id);
+
+UPDATE products p
+SET received_reception_id = coalesce((SELECT i.source_reception_id
+                                      FROM transfer_items i
+                                               JOIN transfer_orders t ON t.id = i.transfer_id
+                                      WHERE i.product_id = p.id
+                                      ORDER BY t.created_at
+                                      LIMIT 1), p.reception_id)
+WHERE received_reception_id IS NULL;
+
+ALTER TABLE products
+    ALTER COLUMN received_reception_id SET NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_products_received_reception_id ON products (received_reception_id);
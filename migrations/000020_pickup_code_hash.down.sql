@@ -0,0 +1,7 @@
+-- Plaintext codes cannot be restored: owners have to be assigned again to get new codes.
+ALTER TABLE products
+    ADD COLUMN IF NOT EXISTS pickup_code TEXT;
+
+ALTER TABLE products
+    DROP COLUMN IF EXISTS pickup_code_attempts,
+    DROP COLUMN IF EXISTS pickup_code_hash;
@@ -0,0 +1,11 @@
+-- Pickup codes are kept as SHA-256 of "<product id>:<code>"; a code is locked after too many failed attempts.
+ALTER TABLE products
+    ADD COLUMN IF NOT EXISTS pickup_code_hash     TEXT,
+    ADD COLUMN IF NOT EXISTS pickup_code_attempts INT NOT NULL DEFAULT 0;
+
+UPDATE products
+SET pickup_code_hash = encode(sha256(convert_to(id::text || ':' || pickup_code, 'UTF8')), 'hex')
+WHERE pickup_code IS NOT NULL;
+
+ALTER TABLE products
+    DROP COLUMN IF EXISTS pickup_code;
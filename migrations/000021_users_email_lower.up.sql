@@ -0,0 +1,7 @@
+-- Emails are case-insensitive: lookups compare lower(email), and the index keeps
+-- "Ivan@Mail.ru" and "ivan@mail.ru" from becoming two accounts.
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_email_lower ON users (lower(email));
+
+UPDATE users
+SET email = lower(email)
+WHERE email <> lower(email);
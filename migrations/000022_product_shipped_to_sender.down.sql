@@ -0,0 +1,9 @@
+-- Shipped products go back to the queue status; return_to_sender_queue.shipped_at still records the shipment.
+UPDATE products
+SET status = 'return_to_sender'
+WHERE status = 'shipped_to_sender';
+
+ALTER TABLE products DROP CONSTRAINT IF EXISTS products_status_check;
+ALTER TABLE products
+    ADD CONSTRAINT products_status_check
+        CHECK (status IN ('received', 'issued', 'returned', 'return_to_sender', 'in_transit'));
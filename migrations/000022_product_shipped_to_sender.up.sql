@@ -0,0 +1,4 @@
+ALTER TABLE products DROP CONSTRAINT IF EXISTS products_status_check;
+ALTER TABLE products
+    ADD CONSTRAINT products_status_check
+        CHECK (status IN ('received', 'issued', 'returned', 'return_to_sender', 'shipped_to_sender', 'in_transit'));
@@ -0,0 +1,8 @@
+// Package migrations embeds the SQL migrations so that the service and pvzctl can apply them
+// without the files being deployed next to the binary.
+package migrations
+
+import "embed"
+
+//go:embed *.sql
+var FS embed.FS